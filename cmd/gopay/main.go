@@ -1,8 +1,10 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"net/http"
+	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -14,12 +16,33 @@ import (
 	_ "github.com/lib/pq"
 )
 
+const (
+	scheduleInterval = time.Minute
+	payoutInterval   = time.Minute
+	interestInterval = time.Hour
+)
+
 func main() {
 	config, err := utils.LoadConfig(".")
 	if err != nil {
 		log.Fatal().Msgf("could not loadconfig: %v", err)
 	}
 
+	limits, err := config.Limits()
+	if err != nil {
+		log.Fatal().Msgf("could not load limits: %v", err)
+	}
+
+	fees, err := config.FeeSchedule()
+	if err != nil {
+		log.Fatal().Msgf("could not load fee schedule: %v", err)
+	}
+
+	interestRate, err := config.InterestRate()
+	if err != nil {
+		log.Fatal().Msgf("could not load interest rate: %v", err)
+	}
+
 	db, err := sql.Open(config.DbDriver, config.DbSource)
 	if err != nil {
 		log.Fatal().Msgf("Could not connect to database: %v", err)
@@ -36,12 +59,37 @@ func main() {
 
 	transactionRepo := repository.NewTransactionRepoPsql(db)
 	accountRepo := repository.NewAccountRepoPsql(db)
-	transactionSvc := service.NewTransactionService(transactionRepo, accountRepo)
-	accountSvc := service.NewAccountService(accountRepo)
+	holdRepo := repository.NewHoldRepoPsql(db)
+	contactRepo := repository.NewContactRepoPsql(db)
+	payoutRepo := repository.NewPayoutRepoPsql(db)
+	credentialRepo := repository.NewCredentialRepoPsql(db)
+	uow := repository.NewUnitOfWorkPsql(db)
+	ledgerSvc := service.NewLedgerService(repository.NewLedgerRepoPsql(db), accountRepo)
+	limitSvc := service.NewLimitService(limits, repository.NewLimitRepoPsql(db), accountRepo, transactionRepo, payoutRepo)
+	feeSvc := service.NewFeeService(fees, repository.NewFeeWaiverRepoPsql(db), accountRepo)
+	transactionSvc := service.NewTransactionService(transactionRepo, accountRepo, holdRepo, uow, ledgerSvc, limitSvc, feeSvc)
+	accountSvc := service.NewAccountService(accountRepo, transactionRepo, credentialRepo, payoutRepo, transactionSvc, uow)
+	idempotencySvc := service.NewIdempotencyService(repository.NewIdempotencyRepoPsql(db))
+	paymentRequestSvc := service.NewPaymentRequestService(repository.NewPaymentRequestRepoPsql(db), accountRepo, transactionSvc, uow)
+	scheduleSvc := service.NewScheduleService(repository.NewScheduledPaymentRepoPsql(db), accountRepo, transactionSvc, uow)
+	payoutSvc := service.NewPayoutService(payoutRepo, accountRepo, transactionRepo, transactionSvc, uow)
+	holdSvc := service.NewHoldService(holdRepo, accountRepo, transactionRepo, transactionSvc, uow)
+	contactSvc := service.NewContactService(contactRepo, accountRepo, transactionRepo)
+	feedSvc := service.NewFeedService(transactionRepo, accountRepo, contactRepo)
+	interestSvc := service.NewInterestService(interestRate, repository.NewInterestRepoPsql(db), accountRepo, ledgerSvc, transactionSvc, uow)
+	authSvc := service.NewAuthService(config.AuthAdminToken, config.SessionTTL(), credentialRepo, repository.NewSessionRepoPsql(db), accountRepo, uow)
+
+	if config.AuthAdminToken == "" {
+		log.Warn().Msg("AUTH_ADMIN_TOKEN is not set, the admin routes are closed")
+	}
+
+	go scheduleSvc.Run(context.Background(), scheduleInterval)
+	go payoutSvc.Run(context.Background(), payoutInterval)
+	go interestSvc.Run(context.Background(), interestInterval)
 
-	apiHandler := internal.NewAPIHandler(transactionSvc, accountSvc)
+	apiHandler := internal.NewAPIHandler(transactionSvc, accountSvc, idempotencySvc, ledgerSvc, paymentRequestSvc, scheduleSvc, payoutSvc, holdSvc, contactSvc, feedSvc, limitSvc, feeSvc, interestSvc, authSvc)
 
-	router := internal.Router(apiHandler)
+	router := internal.Router(authSvc, apiHandler)
 
 	zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
 
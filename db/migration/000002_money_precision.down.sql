@@ -0,0 +1 @@
+ALTER TABLE transactions ALTER COLUMN amount TYPE NUMERIC(9, 2);
@@ -0,0 +1 @@
+ALTER TABLE transactions ALTER COLUMN amount TYPE NUMERIC(19, 2);
@@ -0,0 +1,20 @@
+CREATE TABLE journal_entries (
+    entry_id UUID NOT NULL DEFAULT (uuid_generate_v4()),
+    description VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (entry_id)
+);
+
+-- account_id is not a foreign key: system ledger accounts such as cash-in
+-- (00000000-0000-0000-0000-000000000001) and cash-out (...0002) have no row in accounts.
+CREATE TABLE postings (
+    posting_id UUID NOT NULL DEFAULT (uuid_generate_v4()),
+    entry_id UUID NOT NULL,
+    account_id UUID NOT NULL,
+    amount NUMERIC(19, 2) NOT NULL CHECK (amount <> 0),
+    PRIMARY KEY (posting_id),
+    FOREIGN KEY (entry_id) REFERENCES journal_entries(entry_id)
+);
+
+CREATE INDEX postings_account_id_idx ON postings (account_id);
+CREATE INDEX postings_entry_id_idx ON postings (entry_id);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS transactions_owner_created_at_idx;
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS transactions_owner_created_at_idx ON transactions (owner, created_at, transaction_id);
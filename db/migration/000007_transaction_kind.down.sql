@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS transactions_owner_kind_idx;
+ALTER TABLE transactions DROP COLUMN IF EXISTS kind;
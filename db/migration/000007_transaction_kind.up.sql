@@ -0,0 +1,32 @@
+ALTER TABLE transactions ADD COLUMN kind VARCHAR(32);
+
+-- Rows written before this migration only tell their kind apart through owner, sender,
+-- receiver and the sign of the amount.
+UPDATE transactions SET kind = 'withdrawal'
+WHERE amount < 0 AND sender = owner AND receiver = owner;
+
+UPDATE transactions SET kind = 'payment_out'
+WHERE amount < 0 AND sender = owner AND receiver <> owner;
+
+UPDATE transactions SET kind = 'payment_in'
+WHERE amount > 0 AND receiver = owner AND sender <> owner;
+
+-- A debit writes its change just before the withdrawal or payment that caused it, and
+-- both look like a deposit otherwise.
+UPDATE transactions t SET kind = 'change'
+WHERE t.kind IS NULL
+AND t.amount > 0
+AND EXISTS (
+    SELECT 1 FROM transactions d
+    WHERE d.owner = t.owner
+    AND d.amount < 0
+    AND d.created_at BETWEEN t.created_at AND t.created_at + INTERVAL '1 second'
+);
+
+UPDATE transactions SET kind = 'deposit' WHERE kind IS NULL;
+
+ALTER TABLE transactions ALTER COLUMN kind SET NOT NULL;
+ALTER TABLE transactions ADD CONSTRAINT transactions_kind_check
+CHECK (kind IN ('deposit', 'withdrawal', 'payment_out', 'payment_in', 'change', 'refund', 'fee', 'adjustment'));
+
+CREATE INDEX transactions_owner_kind_idx ON transactions (owner, kind);
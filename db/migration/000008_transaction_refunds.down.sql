@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS transactions_related_id_idx;
+ALTER TABLE transactions DROP COLUMN IF EXISTS related_id;
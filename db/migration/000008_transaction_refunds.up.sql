@@ -0,0 +1,3 @@
+ALTER TABLE transactions ADD COLUMN related_id UUID REFERENCES transactions(transaction_id);
+
+CREATE INDEX transactions_related_id_idx ON transactions (related_id) WHERE related_id IS NOT NULL;
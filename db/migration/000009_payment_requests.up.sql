@@ -0,0 +1,17 @@
+CREATE TABLE payment_requests (
+    request_id UUID NOT NULL DEFAULT (uuid_generate_v4()),
+    requester UUID NOT NULL,
+    payer UUID NOT NULL,
+    amount NUMERIC(19, 2) NOT NULL CHECK (amount > 0),
+    note VARCHAR(140) NOT NULL DEFAULT '',
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (request_id),
+    FOREIGN KEY (requester) REFERENCES accounts(account_id),
+    FOREIGN KEY (payer) REFERENCES accounts(account_id)
+);
+
+CREATE INDEX payment_requests_payer_idx ON payment_requests (payer, created_at);
+CREATE INDEX payment_requests_requester_idx ON payment_requests (requester, created_at);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS scheduled_payment_runs;
+DROP TABLE IF EXISTS scheduled_payments;
@@ -0,0 +1,34 @@
+CREATE TABLE scheduled_payments (
+    schedule_id UUID NOT NULL DEFAULT (uuid_generate_v4()),
+    owner UUID NOT NULL,
+    receiver UUID NOT NULL,
+    amount NUMERIC(19, 2) NOT NULL CHECK (amount > 0),
+    frequency VARCHAR(16) NOT NULL,
+    cron VARCHAR(255) NOT NULL DEFAULT '',
+    start_at TIMESTAMP NOT NULL,
+    next_run_at TIMESTAMP NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'active',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (schedule_id),
+    FOREIGN KEY (owner) REFERENCES accounts(account_id),
+    FOREIGN KEY (receiver) REFERENCES accounts(account_id)
+);
+
+CREATE INDEX scheduled_payments_due_idx ON scheduled_payments (next_run_at) WHERE status = 'active';
+CREATE INDEX scheduled_payments_owner_idx ON scheduled_payments (owner);
+
+CREATE TABLE scheduled_payment_runs (
+    run_id UUID NOT NULL DEFAULT (uuid_generate_v4()),
+    schedule_id UUID NOT NULL,
+    scheduled_for TIMESTAMP NOT NULL,
+    ran_at TIMESTAMP NOT NULL,
+    attempt INTEGER NOT NULL,
+    outcome VARCHAR(16) NOT NULL,
+    error TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (run_id),
+    FOREIGN KEY (schedule_id) REFERENCES scheduled_payments(schedule_id)
+);
+
+CREATE INDEX scheduled_payment_runs_schedule_idx ON scheduled_payment_runs (schedule_id, ran_at);
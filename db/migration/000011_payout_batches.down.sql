@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS payout_items;
+DROP TABLE IF EXISTS payout_batches;
@@ -0,0 +1,29 @@
+CREATE TABLE payout_batches (
+    batch_id UUID NOT NULL DEFAULT (uuid_generate_v4()),
+    owner UUID NOT NULL,
+    total NUMERIC(19, 2) NOT NULL CHECK (total > 0),
+    reserved NUMERIC(19, 2) NOT NULL CHECK (reserved >= 0 AND reserved <= total),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (batch_id),
+    FOREIGN KEY (owner) REFERENCES accounts(account_id)
+);
+
+CREATE INDEX payout_batches_owner_idx ON payout_batches (owner);
+
+-- receiver is free text on purpose: unknown receivers are a per-item result, not a
+-- reason to reject the whole batch.
+CREATE TABLE payout_items (
+    item_id UUID NOT NULL DEFAULT (uuid_generate_v4()),
+    batch_id UUID NOT NULL,
+    position INTEGER NOT NULL,
+    receiver VARCHAR(255) NOT NULL,
+    amount NUMERIC(19, 2) NOT NULL CHECK (amount > 0),
+    result VARCHAR(32) NOT NULL DEFAULT 'pending',
+    error TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (item_id),
+    UNIQUE (batch_id, position),
+    FOREIGN KEY (batch_id) REFERENCES payout_batches(batch_id)
+);
+
+CREATE INDEX payout_items_pending_idx ON payout_items (batch_id, position) WHERE result = 'pending';
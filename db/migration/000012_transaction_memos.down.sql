@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS transactions_metadata_idx;
+DROP INDEX IF EXISTS transactions_memo_trgm_idx;
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS metadata;
+ALTER TABLE transactions DROP COLUMN IF EXISTS memo;
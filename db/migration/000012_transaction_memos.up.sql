@@ -0,0 +1,8 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE transactions ADD COLUMN memo VARCHAR(140) NOT NULL DEFAULT '';
+ALTER TABLE transactions ADD COLUMN metadata JSONB NOT NULL DEFAULT '{}';
+
+-- Memo search is a case-insensitive substring match, metadata search a containment match.
+CREATE INDEX transactions_memo_trgm_idx ON transactions USING GIN (memo gin_trgm_ops);
+CREATE INDEX transactions_metadata_idx ON transactions USING GIN (metadata jsonb_path_ops);
@@ -0,0 +1,18 @@
+CREATE TABLE holds (
+    hold_id UUID NOT NULL DEFAULT (uuid_generate_v4()),
+    owner UUID NOT NULL,
+    merchant UUID NOT NULL,
+    amount NUMERIC(19, 2) NOT NULL CHECK (amount > 0),
+    captured NUMERIC(19, 2) NOT NULL DEFAULT 0 CHECK (captured >= 0 AND captured <= amount),
+    status VARCHAR(16) NOT NULL DEFAULT 'active',
+    memo VARCHAR(140) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (hold_id),
+    FOREIGN KEY (owner) REFERENCES accounts(account_id),
+    FOREIGN KEY (merchant) REFERENCES accounts(account_id)
+);
+
+CREATE INDEX holds_owner_idx ON holds (owner, created_at);
+CREATE INDEX holds_active_idx ON holds (owner, expires_at) WHERE status = 'active';
@@ -0,0 +1,16 @@
+ALTER TABLE accounts ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'active';
+
+CREATE TABLE account_status_changes (
+    change_id UUID NOT NULL DEFAULT (uuid_generate_v4()),
+    account_id UUID NOT NULL,
+    from_status VARCHAR(16) NOT NULL,
+    to_status VARCHAR(16) NOT NULL,
+    reason VARCHAR(255) NOT NULL,
+    payout_to VARCHAR(64) NOT NULL DEFAULT '',
+    amount NUMERIC(19, 2) NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (change_id),
+    FOREIGN KEY (account_id) REFERENCES accounts(account_id)
+);
+
+CREATE INDEX account_status_changes_account_idx ON account_status_changes (account_id, created_at);
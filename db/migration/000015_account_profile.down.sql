@@ -0,0 +1,16 @@
+DROP INDEX IF EXISTS accounts_phone_key;
+DROP INDEX IF EXISTS accounts_email_key;
+
+ALTER TABLE accounts
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS created_at,
+    DROP COLUMN IF EXISTS country,
+    DROP COLUMN IF EXISTS postal_code,
+    DROP COLUMN IF EXISTS region,
+    DROP COLUMN IF EXISTS city,
+    DROP COLUMN IF EXISTS address_line2,
+    DROP COLUMN IF EXISTS address_line1,
+    DROP COLUMN IF EXISTS date_of_birth,
+    DROP COLUMN IF EXISTS display_name,
+    DROP COLUMN IF EXISTS phone,
+    DROP COLUMN IF EXISTS email;
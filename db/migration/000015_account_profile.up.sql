@@ -0,0 +1,16 @@
+ALTER TABLE accounts
+    ADD COLUMN email VARCHAR(254) NOT NULL DEFAULT '',
+    ADD COLUMN phone VARCHAR(16) NOT NULL DEFAULT '',
+    ADD COLUMN display_name VARCHAR(64) NOT NULL DEFAULT '',
+    ADD COLUMN date_of_birth DATE,
+    ADD COLUMN address_line1 VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN address_line2 VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN city VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN region VARCHAR(100) NOT NULL DEFAULT '',
+    ADD COLUMN postal_code VARCHAR(16) NOT NULL DEFAULT '',
+    ADD COLUMN country CHAR(2) NOT NULL DEFAULT '',
+    ADD COLUMN created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ADD COLUMN updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+CREATE UNIQUE INDEX accounts_email_key ON accounts (lower(email)) WHERE email <> '';
+CREATE UNIQUE INDEX accounts_phone_key ON accounts (phone) WHERE phone <> '';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS accounts_created_at_idx;
+DROP INDEX IF EXISTS accounts_last_name_lower_idx;
+DROP INDEX IF EXISTS accounts_name_lower_idx;
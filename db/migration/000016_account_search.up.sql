@@ -0,0 +1,5 @@
+-- Lowercased names in byte order serve both the case-insensitive prefix search and the
+-- keyset ordering of GET /accounts.
+CREATE INDEX accounts_name_lower_idx ON accounts ((lower(name)) COLLATE "C", account_id);
+CREATE INDEX accounts_last_name_lower_idx ON accounts ((lower(last_name)) COLLATE "C", account_id);
+CREATE INDEX accounts_created_at_idx ON accounts (created_at, account_id);
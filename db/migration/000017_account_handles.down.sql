@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS accounts_handle_key;
+ALTER TABLE accounts DROP COLUMN IF EXISTS handle;
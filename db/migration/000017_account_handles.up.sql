@@ -0,0 +1,4 @@
+ALTER TABLE accounts ADD COLUMN handle VARCHAR(30) NOT NULL DEFAULT '';
+
+-- Serves the case-insensitive uniqueness, the lookups by handle and the prefix search.
+CREATE UNIQUE INDEX accounts_handle_key ON accounts ((lower(handle)) COLLATE "C") WHERE handle <> '';
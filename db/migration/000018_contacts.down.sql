@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS transactions_owner_payments_idx;
+DROP TABLE IF EXISTS contacts;
@@ -0,0 +1,14 @@
+CREATE TABLE contacts (
+    owner UUID NOT NULL,
+    account_id UUID NOT NULL,
+    favorite BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (owner, account_id),
+    FOREIGN KEY (owner) REFERENCES accounts(account_id),
+    FOREIGN KEY (account_id) REFERENCES accounts(account_id),
+    CHECK (owner <> account_id)
+);
+
+-- Recent counterparties are read from an account's payments over the last months.
+CREATE INDEX transactions_owner_payments_idx ON transactions (owner, created_at) WHERE kind IN ('payment_out', 'payment_in');
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS contacts_account_idx;
+DROP INDEX IF EXISTS transactions_feed_receiver_idx;
+DROP INDEX IF EXISTS transactions_feed_sender_idx;
+DROP INDEX IF EXISTS transactions_feed_idx;
+ALTER TABLE transactions DROP COLUMN IF EXISTS privacy;
+ALTER TABLE accounts DROP COLUMN IF EXISTS default_privacy;
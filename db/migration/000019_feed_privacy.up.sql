@@ -0,0 +1,14 @@
+ALTER TABLE accounts ADD COLUMN default_privacy VARCHAR(10) NOT NULL DEFAULT 'private'
+    CHECK (default_privacy IN ('public', 'friends', 'private'));
+
+-- Only payments made with Pay have a privacy, every other transaction keeps it empty.
+ALTER TABLE transactions ADD COLUMN privacy VARCHAR(10) NOT NULL DEFAULT ''
+    CHECK (privacy IN ('', 'public', 'friends', 'private'));
+
+-- The feed reads payment_out legs newest first, all of them or those of one account.
+CREATE INDEX transactions_feed_idx ON transactions (created_at DESC, transaction_id DESC) WHERE kind = 'payment_out' AND privacy <> '';
+CREATE INDEX transactions_feed_sender_idx ON transactions (sender, created_at DESC, transaction_id DESC) WHERE kind = 'payment_out' AND privacy <> '';
+CREATE INDEX transactions_feed_receiver_idx ON transactions (receiver, created_at DESC, transaction_id DESC) WHERE kind = 'payment_out' AND privacy <> '';
+
+-- Friends-only payments are shown to the accounts that saved their sender or receiver.
+CREATE INDEX contacts_account_idx ON contacts (account_id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS transactions_owner_outgoing_idx;
+DROP TABLE IF EXISTS account_limits;
@@ -0,0 +1,15 @@
+-- A NULL limit keeps the default from the configuration, a zero one lifts the limit.
+CREATE TABLE account_limits (
+    account_id UUID PRIMARY KEY,
+    max_deposit NUMERIC(9, 2) CHECK (max_deposit >= 0),
+    max_withdrawal NUMERIC(9, 2) CHECK (max_withdrawal >= 0),
+    max_payment NUMERIC(9, 2) CHECK (max_payment >= 0),
+    daily NUMERIC(9, 2) CHECK (daily >= 0),
+    weekly NUMERIC(9, 2) CHECK (weekly >= 0),
+    monthly NUMERIC(9, 2) CHECK (monthly >= 0),
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (account_id) REFERENCES accounts(account_id)
+);
+
+-- Rolling-window limits add up what an account withdrew and paid over the last days.
+CREATE INDEX transactions_owner_outgoing_idx ON transactions (owner, created_at) WHERE kind IN ('withdrawal', 'payment_out');
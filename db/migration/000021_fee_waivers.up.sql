@@ -0,0 +1,11 @@
+-- Fees themselves are transactions of kind 'fee'; their ledger postings credit the
+-- revenue account (00000000-0000-0000-0000-000000000004).
+CREATE TABLE fee_waivers (
+    account_id UUID NOT NULL,
+    operation VARCHAR(32) NOT NULL CHECK (operation IN ('withdrawal', 'payment', 'all')),
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    expires_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (account_id, operation),
+    FOREIGN KEY (account_id) REFERENCES accounts(account_id)
+);
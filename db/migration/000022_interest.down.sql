@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS interest_accruals;
+
+UPDATE transactions SET kind = 'adjustment' WHERE kind = 'interest';
+
+ALTER TABLE transactions DROP CONSTRAINT transactions_kind_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_kind_check
+CHECK (kind IN ('deposit', 'withdrawal', 'payment_out', 'payment_in', 'change', 'refund', 'fee', 'adjustment'));
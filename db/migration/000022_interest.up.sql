@@ -0,0 +1,14 @@
+-- Interest is paid out as transactions of kind 'interest'; their ledger postings debit
+-- the interest account (00000000-0000-0000-0000-000000000005).
+ALTER TABLE transactions DROP CONSTRAINT transactions_kind_check;
+ALTER TABLE transactions ADD CONSTRAINT transactions_kind_check
+CHECK (kind IN ('deposit', 'withdrawal', 'payment_out', 'payment_in', 'change', 'refund', 'fee', 'adjustment', 'interest'));
+
+-- accrued keeps fractions of a cent until they add up to a payout.
+CREATE TABLE interest_accruals (
+    account_id UUID PRIMARY KEY,
+    accrued NUMERIC(27, 8) NOT NULL DEFAULT 0 CHECK (accrued >= 0),
+    accrued_through DATE NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (account_id) REFERENCES accounts(account_id)
+);
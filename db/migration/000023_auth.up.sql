@@ -0,0 +1,18 @@
+-- Passwords are stored as salted PBKDF2-HMAC-SHA256 hashes and session tokens as their
+-- SHA-256 hashes, so neither can be read back from the database.
+CREATE TABLE credentials (
+    account_id UUID PRIMARY KEY,
+    password_hash VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (account_id) REFERENCES accounts(account_id)
+);
+
+CREATE TABLE sessions (
+    token_hash CHAR(64) PRIMARY KEY,
+    account_id UUID NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+    FOREIGN KEY (account_id) REFERENCES accounts(account_id)
+);
+
+CREATE INDEX sessions_account_idx ON sessions (account_id);
@@ -0,0 +1,7 @@
+ALTER TABLE account_limits
+    ALTER COLUMN max_deposit TYPE NUMERIC(19, 2),
+    ALTER COLUMN max_withdrawal TYPE NUMERIC(19, 2),
+    ALTER COLUMN max_payment TYPE NUMERIC(19, 2),
+    ALTER COLUMN daily TYPE NUMERIC(19, 2),
+    ALTER COLUMN weekly TYPE NUMERIC(19, 2),
+    ALTER COLUMN monthly TYPE NUMERIC(19, 2);
@@ -0,0 +1,3 @@
+-- A key in progress belongs to its request until leased_until, which the request keeps
+-- moving forward while it runs.
+ALTER TABLE idempotency_keys ADD COLUMN leased_until TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
@@ -0,0 +1,115 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gopay/internal/models"
+	"github.com/gopay/internal/repository"
+	"github.com/gopay/internal/service"
+	"github.com/gopay/internal/utils"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/julienschmidt/httprouter"
+	"github.com/rs/zerolog/log"
+)
+
+func (h *apiHandler) FreezeAccount(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	h.changeAccountStatus(w, r, params, "Handler::FreezeAccount", h.accountSvc.Freeze)
+}
+
+func (h *apiHandler) UnfreezeAccount(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	h.changeAccountStatus(w, r, params, "Handler::UnfreezeAccount", h.accountSvc.Unfreeze)
+}
+
+func (h *apiHandler) CloseAccount(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	h.changeAccountStatus(w, r, params, "Handler::CloseAccount", h.accountSvc.Close)
+}
+
+func (h *apiHandler) changeAccountStatus(w http.ResponseWriter, r *http.Request, params httprouter.Params, name string, change func(ctx context.Context, id string, req models.AccountStatusReq) (models.AccountStatusChange, error)) {
+	accountId := params.ByName(AccountIdParam)
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, OneMegabyte))
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	req := models.AccountStatusReq{}
+	err = jsoniter.Unmarshal(body, &req)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		utils.ErrorWithMessage(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	record, err := change(r.Context(), accountId, req)
+	if errors.Is(err, service.ErrInvalidStatusChange) || err == service.ErrInvalidPaymentOp {
+		log.Error().Err(err).Msg(name)
+		utils.ErrorWithMessage(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err == repository.ErrAccountNotFound {
+		log.Error().Err(err).Msg(name)
+		utils.ErrorWithMessage(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	if err == service.ErrStatusTransition || err == repository.ErrAccountStatusChanged || err == repository.ErrAccountLocked {
+		log.Error().Err(err).Msg(name)
+		utils.ErrorWithMessage(w, http.StatusConflict, err.Error())
+		return
+	}
+
+	if err == service.ErrCloseWithBalance || err == service.ErrCloseWithHolds || err == service.ErrCloseWithPayouts || errors.Is(err, service.ErrAccountInactive) {
+		log.Error().Err(err).Msg(name)
+		utils.ErrorWithMessage(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg(name)
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	res, err := jsoniter.Marshal(&record)
+	if err != nil {
+		log.Error().Err(err).Msg(name)
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.WithPayload(w, http.StatusOK, res)
+}
+
+// GetAccountStatusChanges returns the audit trail of the account's status, oldest first.
+func (h *apiHandler) GetAccountStatusChanges(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	accountId := params.ByName(AccountIdParam)
+
+	changes, err := h.accountSvc.GetStatusChanges(r.Context(), accountId)
+	if err == repository.ErrAccountNotFound {
+		log.Error().Err(err).Msg("Handler::GetAccountStatusChanges")
+		utils.ErrorWithMessage(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::GetAccountStatusChanges")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	res, err := jsoniter.Marshal(&changes)
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::GetAccountStatusChanges")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.WithPayload(w, http.StatusOK, res)
+}
@@ -0,0 +1,259 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gopay/internal/models"
+	"github.com/gopay/internal/repository"
+	"github.com/gopay/internal/service"
+	"github.com/gopay/internal/utils"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/julienschmidt/httprouter"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	AuthorizationHeader   = "Authorization"
+	WWWAuthenticateHeader = "WWW-Authenticate"
+	bearerScheme          = "Bearer"
+)
+
+var (
+	ErrUnauthenticated        = errors.New("authentication required")
+	ErrMalformedAuthorization = errors.New("authorization header must be 'Bearer <token>'")
+	ErrAccountForbidden       = errors.New("not allowed to act on this account")
+	ErrTransactionForbidden   = errors.New("not allowed to see this transaction")
+	ErrAdminRequired          = errors.New("admin access required")
+)
+
+type principalKey struct{}
+
+// principalFrom returns who made the request, if anyone logged in.
+func principalFrom(ctx context.Context) (models.Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(models.Principal)
+	return principal, ok
+}
+
+// bearerToken reads the token of the Authorization header. found is false without one.
+func bearerToken(r *http.Request) (token string, found bool, err error) {
+	header := r.Header.Get(AuthorizationHeader)
+	if header == "" {
+		return "", false, nil
+	}
+
+	scheme, token, _ := strings.Cut(header, " ")
+	token = strings.TrimSpace(token)
+	if !strings.EqualFold(scheme, bearerScheme) || token == "" {
+		return "", true, ErrMalformedAuthorization
+	}
+
+	return token, true, nil
+}
+
+func unauthorized(w http.ResponseWriter, err error) {
+	w.Header().Set(WWWAuthenticateHeader, bearerScheme)
+	utils.ErrorWithMessage(w, http.StatusUnauthorized, err.Error())
+}
+
+// authenticate resolves the bearer token of each request to whoever it belongs to and
+// hands it to the routes through the request context. Requests without a token go on
+// anonymously, for the routes to turn away; bad or expired tokens are refused outright.
+func authenticate(authSvc service.AuthService, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, found, err := bearerToken(r)
+		if err != nil {
+			log.Error().Err(err).Msg("Handler::Authenticate")
+			unauthorized(w, err)
+			return
+		}
+
+		if !found {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		principal, err := authSvc.Authenticate(r.Context(), token)
+		if err == service.ErrInvalidToken {
+			log.Error().Err(err).Msg("Handler::Authenticate")
+			unauthorized(w, err)
+			return
+		}
+
+		if err != nil {
+			log.Error().Err(err).Msg("Handler::Authenticate")
+			utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), principalKey{}, principal)))
+	})
+}
+
+// authenticated lets next run for anyone logged in.
+func (h *apiHandler) authenticated(next httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+		if _, ok := principalFrom(r.Context()); !ok {
+			unauthorized(w, ErrUnauthenticated)
+			return
+		}
+
+		next(w, r, params)
+	}
+}
+
+// owner lets next run only for the owner of the account in the path. Operators act on
+// accounts through the /admin routes instead.
+func (h *apiHandler) owner(next httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+		principal, ok := principalFrom(r.Context())
+		if !ok {
+			unauthorized(w, ErrUnauthenticated)
+			return
+		}
+
+		if principal.Admin || principal.AccountId != params.ByName(AccountIdParam) {
+			log.Error().Err(ErrAccountForbidden).Msg("Handler::Owner")
+			utils.ErrorWithMessage(w, http.StatusForbidden, ErrAccountForbidden.Error())
+			return
+		}
+
+		next(w, r, params)
+	}
+}
+
+// admin lets next run only for operators.
+func (h *apiHandler) admin(next httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+		principal, ok := principalFrom(r.Context())
+		if !ok {
+			unauthorized(w, ErrUnauthenticated)
+			return
+		}
+
+		if !principal.Admin {
+			log.Error().Err(ErrAdminRequired).Msg("Handler::Admin")
+			utils.ErrorWithMessage(w, http.StatusForbidden, ErrAdminRequired.Error())
+			return
+		}
+
+		next(w, r, params)
+	}
+}
+
+// Login trades an account's credentials for a bearer token.
+func (h *apiHandler) Login(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, OneMegabyte))
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	req := models.LoginReq{}
+	err = jsoniter.Unmarshal(body, &req)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		utils.ErrorWithMessage(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	session, err := h.authSvc.Login(r.Context(), req)
+	if err == service.ErrInvalidCredentials {
+		log.Error().Err(err).Msg("Handler::Login")
+		unauthorized(w, err)
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::Login")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	res, err := jsoniter.Marshal(&session)
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::Login")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	utils.WithPayload(w, http.StatusCreated, res)
+}
+
+// Logout ends the session the request was made with.
+func (h *apiHandler) Logout(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	token, _, err := bearerToken(r)
+	if err == nil {
+		err = h.authSvc.Logout(r.Context(), token)
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::Logout")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.WithPayload(w, http.StatusNoContent, nil)
+}
+
+// ChangePassword lets the owner set a new password, logging the account out everywhere.
+func (h *apiHandler) ChangePassword(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	h.setPassword(w, r, params, "Handler::ChangePassword", h.authSvc.ChangePassword)
+}
+
+// ResetPassword lets an operator set a new password for an account that lost it.
+func (h *apiHandler) ResetPassword(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	h.setPassword(w, r, params, "Handler::ResetPassword", h.authSvc.ResetPassword)
+}
+
+func (h *apiHandler) setPassword(w http.ResponseWriter, r *http.Request, params httprouter.Params, name string, do func(ctx context.Context, accId string, req models.PasswordReq) error) {
+	accountId := params.ByName(AccountIdParam)
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, OneMegabyte))
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	req := models.PasswordReq{}
+	err = jsoniter.Unmarshal(body, &req)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		utils.ErrorWithMessage(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	err = do(r.Context(), accountId, req)
+	if errors.Is(err, service.ErrInvalidPassword) {
+		log.Error().Err(err).Msg(name)
+		utils.ErrorWithMessage(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err == service.ErrWrongPassword {
+		log.Error().Err(err).Msg(name)
+		utils.ErrorWithMessage(w, http.StatusForbidden, err.Error())
+		return
+	}
+
+	if err == repository.ErrAccountNotFound {
+		log.Error().Err(err).Msg(name)
+		utils.ErrorWithMessage(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg(name)
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.WithPayload(w, http.StatusNoContent, nil)
+}
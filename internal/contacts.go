@@ -0,0 +1,173 @@
+package internal
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gopay/internal/models"
+	"github.com/gopay/internal/repository"
+	"github.com/gopay/internal/service"
+	"github.com/gopay/internal/utils"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/julienschmidt/httprouter"
+	"github.com/rs/zerolog/log"
+)
+
+// CreateContact saves the account named in the body, by id or by handle, to the contacts.
+func (h *apiHandler) CreateContact(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	owner := params.ByName(AccountIdParam)
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, OneMegabyte))
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	req := models.ContactReq{}
+	err = jsoniter.Unmarshal(body, &req)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		utils.ErrorWithMessage(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	contact, err := h.contactSvc.Add(r.Context(), owner, req)
+	if err == service.ErrInvalidContact {
+		log.Error().Err(err).Msg("Handler::CreateContact")
+		utils.ErrorWithMessage(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err == repository.ErrAccountNotFound {
+		log.Error().Err(err).Msg("Handler::CreateContact")
+		utils.ErrorWithMessage(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	if err == repository.ErrContactExists {
+		log.Error().Err(err).Msg("Handler::CreateContact")
+		utils.ErrorWithMessage(w, http.StatusConflict, err.Error())
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::CreateContact")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	res, err := jsoniter.Marshal(&contact)
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::CreateContact")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.WithPayload(w, http.StatusCreated, res)
+}
+
+// GetContacts lists the saved contacts and recent counterparties. favorites=true keeps
+// the favorites only.
+func (h *apiHandler) GetContacts(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	owner := params.ByName(AccountIdParam)
+
+	filter, err := parseContactFilter(r.URL.Query())
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::GetContacts")
+		utils.ErrorWithMessage(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	contacts, err := h.contactSvc.GetAll(r.Context(), owner, filter)
+	if err == service.ErrInvalidContactLimit {
+		log.Error().Err(err).Msg("Handler::GetContacts")
+		utils.ErrorWithMessage(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err == repository.ErrAccountNotFound {
+		log.Error().Err(err).Msg("Handler::GetContacts")
+		utils.ErrorWithMessage(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::GetContacts")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	res, err := jsoniter.Marshal(&contacts)
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::GetContacts")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.WithPayload(w, http.StatusOK, res)
+}
+
+func (h *apiHandler) UpdateContact(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	owner := params.ByName(AccountIdParam)
+	accId := params.ByName(ContactIdParam)
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, OneMegabyte))
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	req := models.FavoriteReq{}
+	err = jsoniter.Unmarshal(body, &req)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		utils.ErrorWithMessage(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	contact, err := h.contactSvc.SetFavorite(r.Context(), owner, accId, req.Favorite)
+	if err == repository.ErrContactNotFound {
+		log.Error().Err(err).Msg("Handler::UpdateContact")
+		utils.ErrorWithMessage(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::UpdateContact")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	res, err := jsoniter.Marshal(&contact)
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::UpdateContact")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.WithPayload(w, http.StatusOK, res)
+}
+
+func (h *apiHandler) DeleteContact(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	owner := params.ByName(AccountIdParam)
+	accId := params.ByName(ContactIdParam)
+
+	err := h.contactSvc.Remove(r.Context(), owner, accId)
+	if err == repository.ErrContactNotFound {
+		log.Error().Err(err).Msg("Handler::DeleteContact")
+		utils.ErrorWithMessage(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::DeleteContact")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.WithPayload(w, http.StatusNoContent, nil)
+}
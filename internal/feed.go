@@ -0,0 +1,64 @@
+package internal
+
+import (
+	"net/http"
+
+	"github.com/gopay/internal/repository"
+	"github.com/gopay/internal/service"
+	"github.com/gopay/internal/utils"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/julienschmidt/httprouter"
+	"github.com/rs/zerolog/log"
+)
+
+// GetFeed pages through the payments the caller may see, newest first. Callers that are
+// not logged in only see public payments.
+func (h *apiHandler) GetFeed(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	h.getFeed(w, r, "", "Handler::GetFeed")
+}
+
+// GetAccountFeed is GetFeed narrowed to the payments the account sent or received.
+func (h *apiHandler) GetAccountFeed(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	h.getFeed(w, r, params.ByName(AccountIdParam), "Handler::GetAccountFeed")
+}
+
+func (h *apiHandler) getFeed(w http.ResponseWriter, r *http.Request, accountId string, name string) {
+	filter, err := parseFeedFilter(r.URL.Query())
+	if err != nil {
+		log.Error().Err(err).Msg(name)
+		utils.ErrorWithMessage(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	filter.Account = accountId
+	if principal, ok := principalFrom(r.Context()); ok {
+		filter.Viewer = principal.AccountId
+	}
+
+	page, err := h.feedSvc.GetFeed(r.Context(), filter)
+	if err == service.ErrInvalidFeedLimit || err == repository.ErrInvalidCursor {
+		log.Error().Err(err).Msg(name)
+		utils.ErrorWithMessage(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err == repository.ErrAccountNotFound {
+		log.Error().Err(err).Msg(name)
+		utils.ErrorWithMessage(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg(name)
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	res, err := jsoniter.Marshal(&page)
+	if err != nil {
+		log.Error().Err(err).Msg(name)
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.WithPayload(w, http.StatusOK, res)
+}
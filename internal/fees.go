@@ -0,0 +1,144 @@
+package internal
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gopay/internal/models"
+	"github.com/gopay/internal/repository"
+	"github.com/gopay/internal/service"
+	"github.com/gopay/internal/utils"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/julienschmidt/httprouter"
+	"github.com/rs/zerolog/log"
+)
+
+// GetFeeSchedule returns the fees charged on withdrawals and payments.
+func (h *apiHandler) GetFeeSchedule(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	schedule := h.feeSvc.GetSchedule(r.Context())
+
+	res, err := jsoniter.Marshal(&schedule)
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::GetFeeSchedule")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.WithPayload(w, http.StatusOK, res)
+}
+
+func (h *apiHandler) GetFeeWaivers(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	accountId := params.ByName(AccountIdParam)
+
+	waivers, err := h.feeSvc.GetWaivers(r.Context(), accountId)
+	if err == repository.ErrAccountNotFound {
+		log.Error().Err(err).Msg("Handler::GetFeeWaivers")
+		utils.ErrorWithMessage(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::GetFeeWaivers")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	res, err := jsoniter.Marshal(waivers)
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::GetFeeWaivers")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.WithPayload(w, http.StatusOK, res)
+}
+
+// CreateFeeWaiver waives the fees of an operation for the account, replacing the waiver
+// it may already have for it.
+func (h *apiHandler) CreateFeeWaiver(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	accountId := params.ByName(AccountIdParam)
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, OneMegabyte))
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	req := models.FeeWaiverReq{}
+	err = jsoniter.Unmarshal(body, &req)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		utils.ErrorWithMessage(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	waiver, err := h.feeSvc.AddWaiver(r.Context(), accountId, req)
+	if errors.Is(err, service.ErrInvalidFeeWaiver) {
+		log.Error().Err(err).Msg("Handler::CreateFeeWaiver")
+		utils.ErrorWithMessage(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err == repository.ErrAccountNotFound {
+		log.Error().Err(err).Msg("Handler::CreateFeeWaiver")
+		utils.ErrorWithMessage(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::CreateFeeWaiver")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	res, err := jsoniter.Marshal(&waiver)
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::CreateFeeWaiver")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.WithPayload(w, http.StatusCreated, res)
+}
+
+func (h *apiHandler) DeleteFeeWaiver(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	accountId := params.ByName(AccountIdParam)
+	operation := models.FeeOperation(params.ByName(OperationParam))
+
+	err := h.feeSvc.RemoveWaiver(r.Context(), accountId, operation)
+	if err == repository.ErrAccountNotFound || err == repository.ErrFeeWaiverNotFound {
+		log.Error().Err(err).Msg("Handler::DeleteFeeWaiver")
+		utils.ErrorWithMessage(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::DeleteFeeWaiver")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.WithPayload(w, http.StatusNoContent, nil)
+}
+
+// GetRevenue returns the fees collected in the revenue ledger account.
+func (h *apiHandler) GetRevenue(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	revenue, err := h.ledgerSvc.GetRevenue(r.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::GetRevenue")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	res, err := jsoniter.Marshal(&revenue)
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::GetRevenue")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.WithPayload(w, http.StatusOK, res)
+}
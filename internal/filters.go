@@ -0,0 +1,170 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gopay/internal/models"
+)
+
+var (
+	ErrInvalidQueryParam = errors.New("invalid query parameter")
+)
+
+// parseTransactionFilter reads the history filters from the query string. Dates are
+// RFC 3339 timestamps or YYYY-MM-DD days; a day given as "to" includes the whole day.
+// Metadata is searched with one metadata=key:value parameter per pair.
+func parseTransactionFilter(query url.Values) (models.TransactionFilter, error) {
+	filter := models.TransactionFilter{
+		Cursor:       query.Get("cursor"),
+		Direction:    models.TransactionDirection(query.Get("direction")),
+		Counterparty: query.Get("counterparty"),
+		Kind:         models.TransactionKind(query.Get("kind")),
+		Memo:         query.Get("memo"),
+		Order:        models.SortOrder(query.Get("order")),
+	}
+
+	var err error
+
+	if v := query.Get("limit"); v != "" {
+		filter.Limit, err = strconv.Atoi(v)
+		if err != nil {
+			return filter, invalidParam("limit")
+		}
+	}
+
+	if v := query.Get("from"); v != "" {
+		filter.From, _, err = parseDate(v)
+		if err != nil {
+			return filter, invalidParam("from")
+		}
+	}
+
+	if v := query.Get("to"); v != "" {
+		to, isDay, err := parseDate(v)
+		if err != nil {
+			return filter, invalidParam("to")
+		}
+		if isDay {
+			to = to.AddDate(0, 0, 1)
+		}
+		filter.To = to
+	}
+
+	if v := query.Get("min_amount"); v != "" {
+		amount, err := models.ParseMoney(v)
+		if err != nil {
+			return filter, invalidParam("min_amount")
+		}
+		filter.MinAmount = &amount
+	}
+
+	if v := query.Get("max_amount"); v != "" {
+		amount, err := models.ParseMoney(v)
+		if err != nil {
+			return filter, invalidParam("max_amount")
+		}
+		filter.MaxAmount = &amount
+	}
+
+	if v := query.Get("consumed"); v != "" {
+		consumed, err := strconv.ParseBool(v)
+		if err != nil {
+			return filter, invalidParam("consumed")
+		}
+		filter.Consumed = &consumed
+	}
+
+	for _, v := range query["metadata"] {
+		key, value, found := strings.Cut(v, ":")
+		if !found {
+			return filter, invalidParam("metadata")
+		}
+		if filter.Metadata == nil {
+			filter.Metadata = models.Metadata{}
+		}
+		filter.Metadata[key] = value
+	}
+
+	return filter, nil
+}
+
+// parseAccountFilter reads the account search from the query string: q, sort, order,
+// limit and cursor.
+func parseAccountFilter(query url.Values) (models.AccountFilter, error) {
+	filter := models.AccountFilter{
+		Cursor: query.Get("cursor"),
+		Query:  query.Get("q"),
+		Sort:   models.AccountSort(query.Get("sort")),
+		Order:  models.SortOrder(query.Get("order")),
+	}
+
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			return filter, invalidParam("limit")
+		}
+		filter.Limit = limit
+	}
+
+	return filter, nil
+}
+
+// parseContactFilter reads the contact list options from the query string: favorites
+// and limit.
+func parseContactFilter(query url.Values) (models.ContactFilter, error) {
+	filter := models.ContactFilter{}
+
+	if v := query.Get("favorites"); v != "" {
+		favorites, err := strconv.ParseBool(v)
+		if err != nil {
+			return filter, invalidParam("favorites")
+		}
+		filter.Favorites = favorites
+	}
+
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			return filter, invalidParam("limit")
+		}
+		filter.Limit = limit
+	}
+
+	return filter, nil
+}
+
+// parseFeedFilter reads the feed options from the query string: limit and cursor.
+func parseFeedFilter(query url.Values) (models.FeedFilter, error) {
+	filter := models.FeedFilter{
+		Cursor: query.Get("cursor"),
+	}
+
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			return filter, invalidParam("limit")
+		}
+		filter.Limit = limit
+	}
+
+	return filter, nil
+}
+
+func parseDate(v string) (time.Time, bool, error) {
+	day, err := time.Parse(time.DateOnly, v)
+	if err == nil {
+		return day, true, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, v)
+	return t, false, err
+}
+
+func invalidParam(name string) error {
+	return fmt.Errorf("%s: %w", name, ErrInvalidQueryParam)
+}
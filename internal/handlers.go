@@ -19,6 +19,13 @@ import (
 const (
 	AccountIdParam     = "account-id"
 	TransactionIdParam = "transaction-id"
+	RequestIdParam     = "request-id"
+	ScheduleIdParam    = "schedule-id"
+	BatchIdParam       = "batch-id"
+	HoldIdParam        = "hold-id"
+	HandleParam        = "handle"
+	ContactIdParam     = "contact-id"
+	OperationParam     = "operation"
 	OneMegabyte        = 1048576
 )
 
@@ -35,28 +42,98 @@ type HandlerRegister interface {
 }
 
 type apiHandler struct {
-	transactionSvc service.TransactionService
-	accountSvc     service.AccountService
+	transactionSvc    service.TransactionService
+	accountSvc        service.AccountService
+	idempotencySvc    service.IdempotencyService
+	ledgerSvc         service.LedgerService
+	paymentRequestSvc service.PaymentRequestService
+	scheduleSvc       service.ScheduleService
+	payoutSvc         service.PayoutService
+	holdSvc           service.HoldService
+	contactSvc        service.ContactService
+	feedSvc           service.FeedService
+	limitSvc          service.LimitService
+	feeSvc            service.FeeService
+	interestSvc       service.InterestService
+	authSvc           service.AuthService
 }
 
-func NewAPIHandler(transactionSvc service.TransactionService, accountSvc service.AccountService) *apiHandler {
+func NewAPIHandler(transactionSvc service.TransactionService, accountSvc service.AccountService, idempotencySvc service.IdempotencyService, ledgerSvc service.LedgerService, paymentRequestSvc service.PaymentRequestService, scheduleSvc service.ScheduleService, payoutSvc service.PayoutService, holdSvc service.HoldService, contactSvc service.ContactService, feedSvc service.FeedService, limitSvc service.LimitService, feeSvc service.FeeService, interestSvc service.InterestService, authSvc service.AuthService) *apiHandler {
 	return &apiHandler{
-		transactionSvc: transactionSvc,
-		accountSvc:     accountSvc,
+		transactionSvc:    transactionSvc,
+		accountSvc:        accountSvc,
+		idempotencySvc:    idempotencySvc,
+		ledgerSvc:         ledgerSvc,
+		paymentRequestSvc: paymentRequestSvc,
+		scheduleSvc:       scheduleSvc,
+		payoutSvc:         payoutSvc,
+		holdSvc:           holdSvc,
+		contactSvc:        contactSvc,
+		feedSvc:           feedSvc,
+		limitSvc:          limitSvc,
+		feeSvc:            feeSvc,
+		interestSvc:       interestSvc,
+		authSvc:           authSvc,
 	}
 }
 
 func (h *apiHandler) Register(router *httprouter.Router) {
 	router.Handle(http.MethodGet, "/", h.Index)
-	router.Handle(http.MethodGet, "/accounts", h.GetAllAccounts)
-	router.Handle(http.MethodGet, "/accounts/:account-id", h.GetAccount)
+	router.Handle(http.MethodGet, "/accounts", h.admin(h.GetAllAccounts))
+	router.Handle(http.MethodGet, "/accounts/:account-id", h.owner(h.GetAccount))
 	router.Handle(http.MethodPost, "/accounts", h.CreateAccount)
-	router.Handle(http.MethodGet, "/accounts/:account-id/transactions", h.GetAllTransactions)
-	router.Handle(http.MethodGet, "/transactions/:transaction-id", h.GetTransaction)
-	router.Handle(http.MethodPost, "/accounts/:account-id/deposit", h.Deposit)
-	router.Handle(http.MethodPost, "/accounts/:account-id/withdraw", h.Withdraw)
-	router.Handle(http.MethodPost, "/accounts/:account-id/pay", h.Pay)
-	router.Handle(http.MethodGet, "/accounts/:account-id/balance", h.GetBalance)
+	router.Handle(http.MethodPost, "/auth/login", h.Login)
+	router.Handle(http.MethodPost, "/auth/logout", h.authenticated(h.Logout))
+	router.Handle(http.MethodPatch, "/accounts/:account-id", h.owner(h.UpdateAccount))
+	router.Handle(http.MethodPut, "/accounts/:account-id/password", h.owner(h.ChangePassword))
+	router.Handle(http.MethodGet, "/handles/:handle", h.authenticated(h.LookupHandle))
+	router.Handle(http.MethodGet, "/accounts/:account-id/transactions", h.owner(h.GetAllTransactions))
+	router.Handle(http.MethodGet, "/transactions/:transaction-id", h.authenticated(h.GetTransaction))
+	router.Handle(http.MethodPost, "/transactions/:transaction-id/refund", h.authenticated(h.idempotent(h.Refund)))
+	router.Handle(http.MethodPost, "/accounts/:account-id/deposit", h.owner(h.idempotent(h.Deposit)))
+	router.Handle(http.MethodPost, "/accounts/:account-id/withdraw", h.owner(h.idempotent(h.Withdraw)))
+	router.Handle(http.MethodPost, "/accounts/:account-id/pay", h.owner(h.idempotent(h.Pay)))
+	router.Handle(http.MethodPost, "/accounts/:account-id/pay/split", h.owner(h.idempotent(h.SplitPay)))
+	router.Handle(http.MethodPost, "/accounts/:account-id/requests", h.owner(h.idempotent(h.CreatePaymentRequest)))
+	router.Handle(http.MethodGet, "/accounts/:account-id/requests", h.owner(h.GetPaymentRequests))
+	router.Handle(http.MethodPost, "/accounts/:account-id/requests/:request-id/accept", h.owner(h.idempotent(h.AcceptPaymentRequest)))
+	router.Handle(http.MethodPost, "/accounts/:account-id/requests/:request-id/decline", h.owner(h.DeclinePaymentRequest))
+	router.Handle(http.MethodPost, "/accounts/:account-id/requests/:request-id/cancel", h.owner(h.CancelPaymentRequest))
+	router.Handle(http.MethodPost, "/accounts/:account-id/scheduled-payments", h.owner(h.idempotent(h.CreateScheduledPayment)))
+	router.Handle(http.MethodGet, "/accounts/:account-id/scheduled-payments", h.owner(h.GetScheduledPayments))
+	router.Handle(http.MethodGet, "/accounts/:account-id/scheduled-payments/:schedule-id/runs", h.owner(h.GetScheduledRuns))
+	router.Handle(http.MethodPost, "/accounts/:account-id/scheduled-payments/:schedule-id/pause", h.owner(h.PauseScheduledPayment))
+	router.Handle(http.MethodPost, "/accounts/:account-id/scheduled-payments/:schedule-id/resume", h.owner(h.ResumeScheduledPayment))
+	router.Handle(http.MethodPost, "/accounts/:account-id/scheduled-payments/:schedule-id/cancel", h.owner(h.CancelScheduledPayment))
+	router.Handle(http.MethodPost, "/accounts/:account-id/payouts", h.owner(h.idempotent(h.CreatePayoutBatch)))
+	router.Handle(http.MethodGet, "/accounts/:account-id/payouts/:batch-id", h.owner(h.GetPayoutBatch))
+	router.Handle(http.MethodPost, "/accounts/:account-id/holds", h.owner(h.idempotent(h.CreateHold)))
+	router.Handle(http.MethodGet, "/accounts/:account-id/holds", h.owner(h.GetHolds))
+	router.Handle(http.MethodGet, "/accounts/:account-id/holds/:hold-id", h.owner(h.GetHold))
+	router.Handle(http.MethodPost, "/accounts/:account-id/holds/:hold-id/capture", h.owner(h.idempotent(h.CaptureHold)))
+	router.Handle(http.MethodPost, "/accounts/:account-id/holds/:hold-id/void", h.owner(h.VoidHold))
+	router.Handle(http.MethodPost, "/accounts/:account-id/contacts", h.owner(h.CreateContact))
+	router.Handle(http.MethodGet, "/accounts/:account-id/contacts", h.owner(h.GetContacts))
+	router.Handle(http.MethodPatch, "/accounts/:account-id/contacts/:contact-id", h.owner(h.UpdateContact))
+	router.Handle(http.MethodDelete, "/accounts/:account-id/contacts/:contact-id", h.owner(h.DeleteContact))
+	router.Handle(http.MethodGet, "/feed", h.GetFeed)
+	router.Handle(http.MethodGet, "/accounts/:account-id/feed", h.GetAccountFeed)
+	router.Handle(http.MethodGet, "/accounts/:account-id/balance", h.owner(h.GetBalance))
+	router.Handle(http.MethodGet, "/accounts/:account-id/limits", h.owner(h.GetLimits))
+	router.Handle(http.MethodGet, "/fees", h.GetFeeSchedule)
+	router.Handle(http.MethodGet, "/accounts/:account-id/fee-waivers", h.owner(h.GetFeeWaivers))
+	router.Handle(http.MethodGet, "/accounts/:account-id/interest", h.owner(h.GetInterest))
+	router.Handle(http.MethodGet, "/accounts/:account-id/ledger", h.owner(h.GetLedgerEntries))
+	router.Handle(http.MethodGet, "/admin/ledger/check", h.admin(h.CheckLedger))
+	router.Handle(http.MethodGet, "/admin/ledger/revenue", h.admin(h.GetRevenue))
+	router.Handle(http.MethodPost, "/admin/accounts/:account-id/freeze", h.admin(h.FreezeAccount))
+	router.Handle(http.MethodPost, "/admin/accounts/:account-id/unfreeze", h.admin(h.UnfreezeAccount))
+	router.Handle(http.MethodPost, "/admin/accounts/:account-id/close", h.admin(h.idempotent(h.CloseAccount)))
+	router.Handle(http.MethodGet, "/admin/accounts/:account-id/status-changes", h.admin(h.GetAccountStatusChanges))
+	router.Handle(http.MethodPut, "/admin/accounts/:account-id/password", h.admin(h.ResetPassword))
+	router.Handle(http.MethodPut, "/admin/accounts/:account-id/limits", h.admin(h.SetLimits))
+	router.Handle(http.MethodPost, "/admin/accounts/:account-id/fee-waivers", h.admin(h.CreateFeeWaiver))
+	router.Handle(http.MethodDelete, "/admin/accounts/:account-id/fee-waivers/:operation", h.admin(h.DeleteFeeWaiver))
 }
 
 func (h *apiHandler) Index(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
@@ -72,15 +149,30 @@ func (h *apiHandler) Index(w http.ResponseWriter, r *http.Request, _ httprouter.
 	utils.WithPayload(w, http.StatusOK, res)
 }
 
+// GetAllAccounts pages through the accounts. q searches them by the start of their
+// names, sort and order pick the ordering.
 func (h *apiHandler) GetAllAccounts(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
-	accounts, err := h.accountSvc.GetAllAccounts(r.Context())
+	filter, err := parseAccountFilter(r.URL.Query())
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::GetAllAccounts")
+		utils.ErrorWithMessage(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, err := h.accountSvc.GetAllAccounts(r.Context(), filter)
+	if errors.Is(err, service.ErrInvalidAccountFilter) || err == repository.ErrInvalidCursor {
+		log.Error().Err(err).Msg("Handler::GetAllAccounts")
+		utils.ErrorWithMessage(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	if err != nil {
 		log.Error().Err(err).Msg("Handler::GetAllAccounts")
 		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	res, err := jsoniter.Marshal(&accounts)
+	res, err := jsoniter.Marshal(&page)
 	if err != nil {
 		log.Error().Err(err).Msg("Handler::GetAllAccounts")
 		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
@@ -133,39 +225,172 @@ func (h *apiHandler) CreateAccount(w http.ResponseWriter, r *http.Request, _ htt
 		return
 	}
 
-	_, err = h.accountSvc.CreateAccount(r.Context(), account.Name, account.LastName)
+	id, err := h.accountSvc.CreateAccount(r.Context(), account)
 	if err == repository.ErrMissingParams {
 		log.Error().Err(err).Msg("Handler::PostAccount")
 		utils.ErrorWithMessage(w, http.StatusUnprocessableEntity, err.Error())
 		return
 	}
 
+	if errors.Is(err, service.ErrInvalidProfile) || errors.Is(err, service.ErrInvalidPassword) {
+		log.Error().Err(err).Msg("Handler::PostAccount")
+		utils.ErrorWithMessage(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err == repository.ErrHandleTaken {
+		log.Error().Err(err).Msg("Handler::PostAccount")
+		utils.ErrorWithMessage(w, http.StatusConflict, err.Error())
+		return
+	}
+
 	if err != nil {
 		log.Error().Err(err).Msg("Handler::PostAccount")
 		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	utils.WithPayload(w, http.StatusCreated, nil)
+	created, err := h.accountSvc.GetAccount(r.Context(), id)
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::PostAccount")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	res, err := jsoniter.Marshal(&created)
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::PostAccount")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.WithPayload(w, http.StatusCreated, res)
+}
+
+// UpdateAccount changes the profile fields present in the body and returns the account.
+func (h *apiHandler) UpdateAccount(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id := params.ByName(AccountIdParam)
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, OneMegabyte))
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	req := models.AccountPatchReq{}
+	err = jsoniter.Unmarshal(body, &req)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		utils.ErrorWithMessage(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	account, err := h.accountSvc.UpdateProfile(r.Context(), id, req)
+	if errors.Is(err, service.ErrInvalidProfile) || err == service.ErrInvalidPrivacy || err == repository.ErrMissingParams {
+		log.Error().Err(err).Msg("Handler::UpdateAccount")
+		utils.ErrorWithMessage(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err == repository.ErrAccountNotFound {
+		log.Error().Err(err).Msg("Handler::UpdateAccount")
+		utils.ErrorWithMessage(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	if errors.Is(err, service.ErrAccountInactive) {
+		log.Error().Err(err).Msg("Handler::UpdateAccount")
+		utils.ErrorWithMessage(w, http.StatusForbidden, err.Error())
+		return
+	}
+
+	if err == repository.ErrEmailTaken || err == repository.ErrPhoneTaken || err == repository.ErrHandleTaken {
+		log.Error().Err(err).Msg("Handler::UpdateAccount")
+		utils.ErrorWithMessage(w, http.StatusConflict, err.Error())
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::UpdateAccount")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	res, err := jsoniter.Marshal(&account)
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::UpdateAccount")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.WithPayload(w, http.StatusOK, res)
+}
+
+// LookupHandle tells who a handle belongs to, so that payers can check before paying it.
+func (h *apiHandler) LookupHandle(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	handle := params.ByName(HandleParam)
+
+	summary, err := h.accountSvc.LookupHandle(r.Context(), handle)
+	if errors.Is(err, service.ErrInvalidProfile) {
+		log.Error().Err(err).Msg("Handler::LookupHandle")
+		utils.ErrorWithMessage(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err == repository.ErrAccountNotFound {
+		log.Error().Err(err).Msg("Handler::LookupHandle")
+		utils.ErrorWithMessage(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::LookupHandle")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	res, err := jsoniter.Marshal(&summary)
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::LookupHandle")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.WithPayload(w, http.StatusOK, res)
 }
 
 func (h *apiHandler) GetAllTransactions(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
 	accountId := params.ByName(AccountIdParam)
 
-	transactions, err := h.transactionSvc.GetAllTransactions(r.Context(), accountId)
+	filter, err := parseTransactionFilter(r.URL.Query())
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::GetAllTransactions")
+		utils.ErrorWithMessage(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	page, err := h.transactionSvc.GetAllTransactions(r.Context(), accountId, filter)
 	if err == repository.ErrAccountNotFound {
 		log.Error().Err(err).Msg("Handler::GetAllTransactions")
 		utils.ErrorWithMessage(w, http.StatusNotFound, err.Error())
 		return
 	}
 
+	if errors.Is(err, service.ErrInvalidFilter) || err == repository.ErrInvalidCursor {
+		log.Error().Err(err).Msg("Handler::GetAllTransactions")
+		utils.ErrorWithMessage(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
 	if err != nil {
 		log.Error().Err(err).Msg("Handler::GetAllTransactions")
 		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	res, err := jsoniter.Marshal(&transactions)
+	res, err := jsoniter.Marshal(&page)
 	if err != nil {
 		log.Error().Err(err).Msg("Handler::GetAllTransactions")
 		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
@@ -192,6 +417,13 @@ func (h *apiHandler) GetTransaction(w http.ResponseWriter, r *http.Request, para
 		return
 	}
 
+	principal, _ := principalFrom(r.Context())
+	if !principal.Admin && principal.AccountId != transaction.Owner && principal.AccountId != transaction.Sender && principal.AccountId != transaction.Receiver {
+		log.Error().Err(ErrTransactionForbidden).Msg("Handler::GetTransaction")
+		utils.ErrorWithMessage(w, http.StatusForbidden, ErrTransactionForbidden.Error())
+		return
+	}
+
 	res, err := jsoniter.Marshal(&transaction)
 	if err != nil {
 		log.Error().Err(err).Msg("Handler::GetTransaction")
@@ -221,8 +453,8 @@ func (h *apiHandler) Deposit(w http.ResponseWriter, r *http.Request, params http
 		return
 	}
 
-	err = h.transactionSvc.Deposit(r.Context(), owner, amount.Amount)
-	if err == service.ErrInvalidAmount {
+	err = h.transactionSvc.Deposit(r.Context(), owner, amount.Amount, amount.Annotation())
+	if err == service.ErrInvalidAmount || errors.Is(err, service.ErrInvalidAnnotation) {
 		log.Error().Err(err).Msg("Handler::Deposit")
 		utils.ErrorWithMessage(w, http.StatusBadRequest, err.Error())
 		return
@@ -234,6 +466,19 @@ func (h *apiHandler) Deposit(w http.ResponseWriter, r *http.Request, params http
 		return
 	}
 
+	if errors.Is(err, service.ErrAccountInactive) {
+		log.Error().Err(err).Msg("Handler::Deposit")
+		utils.ErrorWithMessage(w, http.StatusForbidden, err.Error())
+		return
+	}
+
+	var limitErr *service.LimitError
+	if errors.As(err, &limitErr) {
+		log.Error().Err(err).Msg("Handler::Deposit")
+		utils.ErrorWithDetails(w, http.StatusUnprocessableEntity, err.Error(), limitErr.LimitExceeded)
+		return
+	}
+
 	if err != nil {
 		log.Error().Err(err).Msg("Handler::Deposit")
 		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
@@ -262,8 +507,8 @@ func (h *apiHandler) Withdraw(w http.ResponseWriter, r *http.Request, params htt
 		return
 	}
 
-	err = h.transactionSvc.Withdraw(r.Context(), owner, amount.Amount)
-	if err == service.ErrInvalidAmount {
+	receipt, err := h.transactionSvc.Withdraw(r.Context(), owner, amount.Amount, amount.Annotation())
+	if err == service.ErrInvalidAmount || errors.Is(err, service.ErrInvalidAnnotation) {
 		log.Error().Err(err).Msg("Handler::Withdraw")
 		utils.ErrorWithMessage(w, http.StatusBadRequest, err.Error())
 		return
@@ -275,19 +520,45 @@ func (h *apiHandler) Withdraw(w http.ResponseWriter, r *http.Request, params htt
 		return
 	}
 
+	if errors.Is(err, service.ErrAccountInactive) {
+		log.Error().Err(err).Msg("Handler::Withdraw")
+		utils.ErrorWithMessage(w, http.StatusForbidden, err.Error())
+		return
+	}
+
 	if err == service.ErrInsufficentBalance {
 		log.Error().Err(err).Msg("Handler::Withdraw")
 		utils.ErrorWithMessage(w, http.StatusUnprocessableEntity, err.Error())
 		return
 	}
 
+	if err == repository.ErrAccountLocked {
+		log.Error().Err(err).Msg("Handler::Withdraw")
+		utils.ErrorWithMessage(w, http.StatusConflict, err.Error())
+		return
+	}
+
+	var limitErr *service.LimitError
+	if errors.As(err, &limitErr) {
+		log.Error().Err(err).Msg("Handler::Withdraw")
+		utils.ErrorWithDetails(w, http.StatusUnprocessableEntity, err.Error(), limitErr.LimitExceeded)
+		return
+	}
+
 	if err != nil {
 		log.Error().Err(err).Msg("Handler::Withdraw")
 		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	utils.WithPayload(w, http.StatusCreated, nil)
+	res, err := jsoniter.Marshal(&receipt)
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::Withdraw")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.WithPayload(w, http.StatusCreated, res)
 }
 
 func (h *apiHandler) Pay(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
@@ -309,8 +580,8 @@ func (h *apiHandler) Pay(w http.ResponseWriter, r *http.Request, params httprout
 		return
 	}
 
-	err = h.transactionSvc.Pay(r.Context(), owner, payment.Receiver, payment.Amount)
-	if err == service.ErrInvalidPaymentOp || err == service.ErrInvalidAmount {
+	receipt, err := h.transactionSvc.Pay(r.Context(), owner, payment.Receiver, payment.Amount, payment.Annotation())
+	if err == service.ErrInvalidPaymentOp || err == service.ErrInvalidAmount || err == service.ErrInvalidPrivacy || errors.Is(err, service.ErrInvalidAnnotation) {
 		log.Error().Err(err).Msg("Handler::Pay")
 		utils.ErrorWithMessage(w, http.StatusBadRequest, err.Error())
 		return
@@ -322,18 +593,186 @@ func (h *apiHandler) Pay(w http.ResponseWriter, r *http.Request, params httprout
 		return
 	}
 
+	if errors.Is(err, service.ErrAccountInactive) {
+		log.Error().Err(err).Msg("Handler::Pay")
+		utils.ErrorWithMessage(w, http.StatusForbidden, err.Error())
+		return
+	}
+
 	if err == service.ErrInsufficentBalance {
 		log.Error().Err(err).Msg("Handler::Pay")
 		utils.ErrorWithMessage(w, http.StatusUnprocessableEntity, err.Error())
 		return
 	}
 
+	if err == repository.ErrAccountLocked {
+		log.Error().Err(err).Msg("Handler::Pay")
+		utils.ErrorWithMessage(w, http.StatusConflict, err.Error())
+		return
+	}
+
+	var limitErr *service.LimitError
+	if errors.As(err, &limitErr) {
+		log.Error().Err(err).Msg("Handler::Pay")
+		utils.ErrorWithDetails(w, http.StatusUnprocessableEntity, err.Error(), limitErr.LimitExceeded)
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::Pay")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	res, err := jsoniter.Marshal(&receipt)
 	if err != nil {
 		log.Error().Err(err).Msg("Handler::Pay")
 		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
+	utils.WithPayload(w, http.StatusCreated, res)
+}
+
+func (h *apiHandler) SplitPay(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	owner := params.ByName(AccountIdParam)
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, OneMegabyte))
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	split := models.SplitPayReq{}
+	err = jsoniter.Unmarshal(body, &split)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		utils.ErrorWithMessage(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	shares, err := h.transactionSvc.SplitPay(r.Context(), owner, split)
+	if errors.Is(err, service.ErrInvalidSplit) || err == service.ErrInvalidPaymentOp || err == service.ErrInvalidAmount {
+		log.Error().Err(err).Msg("Handler::SplitPay")
+		utils.ErrorWithMessage(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err == repository.ErrAccountNotFound {
+		log.Error().Err(err).Msg("Handler::SplitPay")
+		utils.ErrorWithMessage(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	if errors.Is(err, service.ErrAccountInactive) {
+		log.Error().Err(err).Msg("Handler::SplitPay")
+		utils.ErrorWithMessage(w, http.StatusForbidden, err.Error())
+		return
+	}
+
+	if err == service.ErrInsufficentBalance {
+		log.Error().Err(err).Msg("Handler::SplitPay")
+		utils.ErrorWithMessage(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	if err == repository.ErrAccountLocked {
+		log.Error().Err(err).Msg("Handler::SplitPay")
+		utils.ErrorWithMessage(w, http.StatusConflict, err.Error())
+		return
+	}
+
+	var limitErr *service.LimitError
+	if errors.As(err, &limitErr) {
+		log.Error().Err(err).Msg("Handler::SplitPay")
+		utils.ErrorWithDetails(w, http.StatusUnprocessableEntity, err.Error(), limitErr.LimitExceeded)
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::SplitPay")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	res, err := jsoniter.Marshal(shares)
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::SplitPay")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.WithPayload(w, http.StatusCreated, res)
+}
+
+// Refund sends a payment back to its sender. Only the receiver of the payment can
+// refund it, or an operator on the receiver's behalf.
+func (h *apiHandler) Refund(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	id := params.ByName(TransactionIdParam)
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, OneMegabyte))
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	req := models.RefundReq{}
+	if len(body) > 0 {
+		err = jsoniter.Unmarshal(body, &req)
+		if err != nil {
+			log.Error().Err(err).Msg(err.Error())
+			utils.ErrorWithMessage(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+	}
+
+	principal, _ := principalFrom(r.Context())
+	if principal.Admin {
+		err = h.transactionSvc.AdminRefund(r.Context(), id, req.Amount)
+	} else {
+		err = h.transactionSvc.Refund(r.Context(), id, principal.AccountId, req.Amount)
+	}
+
+	if err == service.ErrInvalidAmount {
+		log.Error().Err(err).Msg("Handler::Refund")
+		utils.ErrorWithMessage(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err == service.ErrRefundForbidden {
+		log.Error().Err(err).Msg("Handler::Refund")
+		utils.ErrorWithMessage(w, http.StatusForbidden, err.Error())
+		return
+	}
+
+	if err == repository.ErrTransactionNotFound {
+		log.Error().Err(err).Msg("Handler::Refund")
+		utils.ErrorWithMessage(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	if err == service.ErrNotRefundable || err == service.ErrRefundExceeded || err == service.ErrInsufficentBalance {
+		log.Error().Err(err).Msg("Handler::Refund")
+		utils.ErrorWithMessage(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	if err == repository.ErrAccountLocked {
+		log.Error().Err(err).Msg("Handler::Refund")
+		utils.ErrorWithMessage(w, http.StatusConflict, err.Error())
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::Refund")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
 	utils.WithPayload(w, http.StatusCreated, nil)
 }
 
@@ -362,3 +801,47 @@ func (h *apiHandler) GetBalance(w http.ResponseWriter, r *http.Request, params h
 
 	utils.WithPayload(w, http.StatusOK, res)
 }
+
+func (h *apiHandler) GetLedgerEntries(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	accountId := params.ByName(AccountIdParam)
+
+	entries, err := h.ledgerSvc.GetEntries(r.Context(), accountId)
+	if err == repository.ErrAccountNotFound {
+		log.Error().Err(err).Msg("Handler::GetLedgerEntries")
+		utils.ErrorWithMessage(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::GetLedgerEntries")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	res, err := jsoniter.Marshal(&entries)
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::GetLedgerEntries")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.WithPayload(w, http.StatusOK, res)
+}
+
+func (h *apiHandler) CheckLedger(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	check, err := h.ledgerSvc.Check(r.Context())
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::CheckLedger")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	res, err := jsoniter.Marshal(&check)
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::CheckLedger")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.WithPayload(w, http.StatusOK, res)
+}
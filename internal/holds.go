@@ -0,0 +1,212 @@
+package internal
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gopay/internal/models"
+	"github.com/gopay/internal/repository"
+	"github.com/gopay/internal/service"
+	"github.com/gopay/internal/utils"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/julienschmidt/httprouter"
+	"github.com/rs/zerolog/log"
+)
+
+func (h *apiHandler) CreateHold(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	owner := params.ByName(AccountIdParam)
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, OneMegabyte))
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	req := models.HoldReq{}
+	err = jsoniter.Unmarshal(body, &req)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		utils.ErrorWithMessage(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	hold, err := h.holdSvc.Authorize(r.Context(), owner, req)
+	if err == service.ErrInvalidAmount || err == service.ErrInvalidPaymentOp || err == service.ErrInvalidExpiry || errors.Is(err, service.ErrInvalidAnnotation) {
+		log.Error().Err(err).Msg("Handler::CreateHold")
+		utils.ErrorWithMessage(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err == repository.ErrAccountNotFound {
+		log.Error().Err(err).Msg("Handler::CreateHold")
+		utils.ErrorWithMessage(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	if errors.Is(err, service.ErrAccountInactive) {
+		log.Error().Err(err).Msg("Handler::CreateHold")
+		utils.ErrorWithMessage(w, http.StatusForbidden, err.Error())
+		return
+	}
+
+	if err == repository.ErrAccountLocked {
+		log.Error().Err(err).Msg("Handler::CreateHold")
+		utils.ErrorWithMessage(w, http.StatusConflict, err.Error())
+		return
+	}
+
+	if err == service.ErrInsufficentBalance {
+		log.Error().Err(err).Msg("Handler::CreateHold")
+		utils.ErrorWithMessage(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::CreateHold")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	res, err := jsoniter.Marshal(&hold)
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::CreateHold")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.WithPayload(w, http.StatusCreated, res)
+}
+
+// GetHolds lists the holds on the account. An optional status narrows the list.
+func (h *apiHandler) GetHolds(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	owner := params.ByName(AccountIdParam)
+	status := models.HoldStatus(r.URL.Query().Get("status"))
+
+	holds, err := h.holdSvc.GetAll(r.Context(), owner, status)
+	if err == repository.ErrAccountNotFound {
+		log.Error().Err(err).Msg("Handler::GetHolds")
+		utils.ErrorWithMessage(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::GetHolds")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	res, err := jsoniter.Marshal(&holds)
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::GetHolds")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.WithPayload(w, http.StatusOK, res)
+}
+
+func (h *apiHandler) GetHold(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	accountId := params.ByName(AccountIdParam)
+	id := params.ByName(HoldIdParam)
+
+	hold, err := h.holdSvc.Get(r.Context(), id, accountId)
+	h.writeHold(w, "Handler::GetHold", hold, err)
+}
+
+// CaptureHold captures the amount in the body, or the whole hold if the body is empty.
+func (h *apiHandler) CaptureHold(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	accountId := params.ByName(AccountIdParam)
+	id := params.ByName(HoldIdParam)
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, OneMegabyte))
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	req := models.CaptureReq{}
+	if len(body) > 0 {
+		err = jsoniter.Unmarshal(body, &req)
+		if err != nil {
+			log.Error().Err(err).Msg(err.Error())
+			utils.ErrorWithMessage(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+	}
+
+	hold, err := h.holdSvc.Capture(r.Context(), id, accountId, req.Amount)
+	h.writeHold(w, "Handler::CaptureHold", hold, err)
+}
+
+func (h *apiHandler) VoidHold(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	accountId := params.ByName(AccountIdParam)
+	id := params.ByName(HoldIdParam)
+
+	hold, err := h.holdSvc.Void(r.Context(), id, accountId)
+	h.writeHold(w, "Handler::VoidHold", hold, err)
+}
+
+func (h *apiHandler) writeHold(w http.ResponseWriter, name string, hold models.Hold, err error) {
+	if err == service.ErrInvalidAmount || err == service.ErrCaptureExceedsHold {
+		log.Error().Err(err).Msg(name)
+		utils.ErrorWithMessage(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err == repository.ErrHoldNotFound || err == repository.ErrAccountNotFound {
+		log.Error().Err(err).Msg(name)
+		utils.ErrorWithMessage(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	if errors.Is(err, service.ErrAccountInactive) {
+		log.Error().Err(err).Msg(name)
+		utils.ErrorWithMessage(w, http.StatusForbidden, err.Error())
+		return
+	}
+
+	if err == service.ErrHoldForbidden || err == service.ErrHoldMerchantOnly {
+		log.Error().Err(err).Msg(name)
+		utils.ErrorWithMessage(w, http.StatusForbidden, err.Error())
+		return
+	}
+
+	if err == repository.ErrHoldNotActive || err == service.ErrHoldExpired || err == repository.ErrAccountLocked {
+		log.Error().Err(err).Msg(name)
+		utils.ErrorWithMessage(w, http.StatusConflict, err.Error())
+		return
+	}
+
+	if err == service.ErrInsufficentBalance {
+		log.Error().Err(err).Msg(name)
+		utils.ErrorWithMessage(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	var limitErr *service.LimitError
+	if errors.As(err, &limitErr) {
+		log.Error().Err(err).Msg(name)
+		utils.ErrorWithDetails(w, http.StatusUnprocessableEntity, err.Error(), limitErr.LimitExceeded)
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg(name)
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	res, err := jsoniter.Marshal(&hold)
+	if err != nil {
+		log.Error().Err(err).Msg(name)
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.WithPayload(w, http.StatusOK, res)
+}
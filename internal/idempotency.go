@@ -0,0 +1,139 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gopay/internal/service"
+	"github.com/gopay/internal/utils"
+	"github.com/julienschmidt/httprouter"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+	maxIdempotencyKeyLength  = 255
+)
+
+var (
+	ErrIdempotencyKeyTooLong = fmt.Errorf("idempotency key must be at most %d characters", maxIdempotencyKeyLength)
+)
+
+// responseRecorder captures what a handler writes so it can be stored for replays.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *responseRecorder) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+// idempotent makes next safe to retry: requests carrying an Idempotency-Key header run
+// at most once per caller, account and key, and retries get the first response back
+// verbatim. Server errors are not stored so that the request can be retried.
+func (h *apiHandler) idempotent(next httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+		key := r.Header.Get(IdempotencyKeyHeader)
+		if key == "" {
+			next(w, r, params)
+			return
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			utils.ErrorWithMessage(w, http.StatusBadRequest, ErrIdempotencyKeyTooLong.Error())
+			return
+		}
+
+		body, err := io.ReadAll(io.LimitReader(r.Body, OneMegabyte))
+		if err != nil {
+			log.Error().Err(err).Msg(err.Error())
+			utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		r.Body.Close()
+
+		// Keys are scoped to whoever makes the request, then to the account when the route
+		// has one and to the resource otherwise, so that callers never see each other's
+		// responses.
+		target := params.ByName(AccountIdParam)
+		if target == "" {
+			target = r.URL.Path
+		}
+		scope := callerScope(r.Context()) + " " + target
+		hash := sha256.Sum256([]byte(r.Method + " " + r.URL.Path + "\n" + string(body)))
+
+		record, err := h.idempotencySvc.Begin(r.Context(), scope, key, hex.EncodeToString(hash[:]))
+		if err == service.ErrIdempotencyKeyReused {
+			log.Error().Err(err).Msg("Handler::Idempotent")
+			utils.ErrorWithMessage(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+
+		if err == service.ErrRequestInProgress {
+			log.Error().Err(err).Msg("Handler::Idempotent")
+			utils.ErrorWithMessage(w, http.StatusConflict, err.Error())
+			return
+		}
+
+		if err != nil {
+			log.Error().Err(err).Msg("Handler::Idempotent")
+			utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		if record.Completed {
+			w.Header().Set(IdempotentReplayedHeader, "true")
+			utils.WithPayload(w, record.StatusCode, record.Body)
+			return
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		recorder := &responseRecorder{ResponseWriter: w}
+
+		// The key stays ours while the handler runs, and the outcome must be recorded even
+		// if the client has already gone away.
+		ctx := context.WithoutCancel(r.Context())
+		leaseCtx, stopLease := context.WithCancel(ctx)
+		go h.idempotencySvc.KeepAlive(leaseCtx, record)
+
+		next(recorder, r, params)
+		stopLease()
+
+		if recorder.status >= http.StatusInternalServerError {
+			err = h.idempotencySvc.Release(ctx, record)
+		} else {
+			err = h.idempotencySvc.Complete(ctx, record, recorder.status, recorder.body.Bytes())
+		}
+		if err != nil {
+			log.Error().Err(err).Msg("Handler::Idempotent")
+		}
+	}
+}
+
+// callerScope names who made the request for idempotency scopes: "admin" for operators
+// and the account id for account holders.
+func callerScope(ctx context.Context) string {
+	principal, _ := principalFrom(ctx)
+	if principal.Admin {
+		return "admin"
+	}
+
+	return principal.AccountId
+}
@@ -0,0 +1,38 @@
+package internal
+
+import (
+	"net/http"
+
+	"github.com/gopay/internal/repository"
+	"github.com/gopay/internal/utils"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/julienschmidt/httprouter"
+	"github.com/rs/zerolog/log"
+)
+
+// GetInterest returns the interest the account accrued and was not paid yet.
+func (h *apiHandler) GetInterest(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	accountId := params.ByName(AccountIdParam)
+
+	status, err := h.interestSvc.GetAccrual(r.Context(), accountId)
+	if err == repository.ErrAccountNotFound {
+		log.Error().Err(err).Msg("Handler::GetInterest")
+		utils.ErrorWithMessage(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::GetInterest")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	res, err := jsoniter.Marshal(&status)
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::GetInterest")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.WithPayload(w, http.StatusOK, res)
+}
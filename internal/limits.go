@@ -0,0 +1,76 @@
+package internal
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gopay/internal/models"
+	"github.com/gopay/internal/repository"
+	"github.com/gopay/internal/service"
+	"github.com/gopay/internal/utils"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/julienschmidt/httprouter"
+	"github.com/rs/zerolog/log"
+)
+
+// GetLimits returns the limits of the account with how much of them it used.
+func (h *apiHandler) GetLimits(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	accountId := params.ByName(AccountIdParam)
+
+	status, err := h.limitSvc.GetLimits(r.Context(), accountId)
+	h.writeLimits(w, "Handler::GetLimits", status, err)
+}
+
+// SetLimits replaces the overrides of the account's limits. A null limit falls back to
+// the default one and a zero limit lifts it.
+func (h *apiHandler) SetLimits(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	accountId := params.ByName(AccountIdParam)
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, OneMegabyte))
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	overrides := models.LimitOverrides{}
+	err = jsoniter.Unmarshal(body, &overrides)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		utils.ErrorWithMessage(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	status, err := h.limitSvc.SetLimits(r.Context(), accountId, overrides)
+	h.writeLimits(w, "Handler::SetLimits", status, err)
+}
+
+func (h *apiHandler) writeLimits(w http.ResponseWriter, name string, status models.LimitStatus, err error) {
+	if err == service.ErrInvalidLimits {
+		log.Error().Err(err).Msg(name)
+		utils.ErrorWithMessage(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err == repository.ErrAccountNotFound {
+		log.Error().Err(err).Msg(name)
+		utils.ErrorWithMessage(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg(name)
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	res, err := jsoniter.Marshal(&status)
+	if err != nil {
+		log.Error().Err(err).Msg(name)
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.WithPayload(w, http.StatusOK, res)
+}
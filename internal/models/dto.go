@@ -1,15 +1,153 @@
 package models
 
+import "time"
+
 type AmountReq struct {
-	Amount float32 `json:"amount"`
+	Amount   Money    `json:"amount"`
+	Memo     string   `json:"memo"`
+	Metadata Metadata `json:"metadata"`
+}
+
+func (r AmountReq) Annotation() Annotation {
+	return Annotation{Memo: r.Memo, Metadata: r.Metadata}
 }
 
+// AccReq opens an account that logs in with Password. Handle is optional and can be
+// claimed later too.
 type AccReq struct {
 	Name     string `json:"name"`
 	LastName string `json:"lastname"`
+	Handle   string `json:"handle"`
+	Password string `json:"password"`
+}
+
+// LoginReq logs in with Login, an account id or a handle, and its password.
+type LoginReq struct {
+	Login    string `json:"login"`
+	Password string `json:"password"`
+}
+
+// LoginRes carries the bearer token of a new session. It is only ever shown here.
+type LoginRes struct {
+	Token     string    `json:"token"`
+	AccountId string    `json:"accountId"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// PasswordReq sets a new password. CurrentPassword is required when the account owner
+// changes it and ignored when an operator resets it.
+type PasswordReq struct {
+	CurrentPassword string `json:"currentPassword"`
+	Password        string `json:"password"`
+}
+
+// AccountPatchReq changes the fields of an account profile that are set. An empty
+// string clears an optional field and an empty address removes it.
+type AccountPatchReq struct {
+	Name           *string  `json:"name"`
+	LastName       *string  `json:"lastName"`
+	Handle         *string  `json:"handle"`
+	Email          *string  `json:"email"`
+	Phone          *string  `json:"phone"`
+	DisplayName    *string  `json:"displayName"`
+	DateOfBirth    *string  `json:"dateOfBirth"`
+	Address        *Address `json:"address"`
+	DefaultPrivacy *Privacy `json:"defaultPrivacy"`
+}
+
+// ContactReq saves Contact, an account id or a handle, to a contact list.
+type ContactReq struct {
+	Contact  string `json:"contact"`
+	Favorite bool   `json:"favorite"`
+}
+
+type FavoriteReq struct {
+	Favorite bool `json:"favorite"`
 }
 
+// AccountStatusReq gives the reason for changing an account's status. Closing an account
+// with money left on it pays the balance out to PayoutTo.
+type AccountStatusReq struct {
+	Reason   string `json:"reason"`
+	PayoutTo string `json:"payoutTo"`
+}
+
+// PayReq pays Receiver, an account id or a handle such as "@caio". An empty Privacy
+// means the sender's default privacy.
 type PayReq struct {
-	Receiver string  `json:"receiver"`
-	Amount   float32 `json:"amount"`
+	Receiver string   `json:"receiver"`
+	Amount   Money    `json:"amount"`
+	Memo     string   `json:"memo"`
+	Metadata Metadata `json:"metadata"`
+	Privacy  Privacy  `json:"privacy"`
+}
+
+func (r PayReq) Annotation() Annotation {
+	return Annotation{Memo: r.Memo, Metadata: r.Metadata, Privacy: r.Privacy}
+}
+
+// RefundReq asks for amount of a received payment to be sent back. A zero amount
+// refunds everything that has not been refunded yet.
+type RefundReq struct {
+	Amount Money `json:"amount"`
+}
+
+type PaymentRequestReq struct {
+	Payer  string `json:"payer"`
+	Amount Money  `json:"amount"`
+	Note   string `json:"note"`
+}
+
+// ScheduledPaymentReq sets up a standing order. A zero StartAt means right away.
+type ScheduledPaymentReq struct {
+	Receiver  string    `json:"receiver"`
+	Amount    Money     `json:"amount"`
+	StartAt   time.Time `json:"startAt"`
+	Frequency Frequency `json:"frequency"`
+	Cron      string    `json:"cron"`
+}
+
+// SplitShare is what one receiver of a split payment gets. Fee is what paying the share
+// cost the sender; it is only set on the shares SplitPay returns.
+type SplitShare struct {
+	Receiver string `json:"receiver"`
+	Amount   Money  `json:"amount"`
+	Fee      Money  `json:"fee,omitempty"`
+}
+
+// SplitPayReq pays several receivers at once. Either Shares lists what each one gets,
+// or Total is divided equally among Receivers.
+type SplitPayReq struct {
+	Shares    []SplitShare `json:"shares"`
+	Receivers []string     `json:"receivers"`
+	Total     Money        `json:"total"`
+}
+
+type PayoutReq struct {
+	Receiver string `json:"receiver"`
+	Amount   Money  `json:"amount"`
+}
+
+type PayoutBatchReq struct {
+	Items []PayoutReq `json:"items"`
+}
+
+// HoldReq authorizes a hold. A zero ExpiresAt means the default expiry.
+type HoldReq struct {
+	Merchant  string    `json:"merchant"`
+	Amount    Money     `json:"amount"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	Memo      string    `json:"memo"`
+}
+
+// CaptureReq captures amount of a hold. A zero amount captures all of it.
+type CaptureReq struct {
+	Amount Money `json:"amount"`
+}
+
+// FeeWaiverReq waives the fees of Operation for an account, until ExpiresAt when it is set.
+type FeeWaiverReq struct {
+	Operation FeeOperation `json:"operation"`
+	Reason    string       `json:"reason"`
+	ExpiresAt *time.Time   `json:"expiresAt"`
 }
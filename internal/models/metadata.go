@@ -0,0 +1,60 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrInvalidMetadata = errors.New("invalid metadata")
+)
+
+// Metadata holds free key/value pairs attached to a transaction. It is stored as a
+// JSON object.
+type Metadata map[string]string
+
+// Scan implements sql.Scanner for JSON and JSONB columns.
+func (m *Metadata) Scan(src any) error {
+	var data []byte
+
+	switch v := src.(type) {
+	case nil:
+		*m = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("%w: cannot scan %T", ErrInvalidMetadata, src)
+	}
+
+	values := Metadata{}
+	err := json.Unmarshal(data, &values)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidMetadata, err)
+	}
+
+	if len(values) == 0 {
+		values = nil
+	}
+
+	*m = values
+	return nil
+}
+
+// Value implements driver.Valuer. Empty metadata is stored as an empty object.
+func (m Metadata) Value() (driver.Value, error) {
+	if len(m) == 0 {
+		return "{}", nil
+	}
+
+	data, err := json.Marshal(map[string]string(m))
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetadata_Scan(t *testing.T) {
+	scenarios := map[string]struct {
+		given   any
+		want    Metadata
+		wantErr error
+	}{
+		"bytes":         {given: []byte(`{"order": "42"}`), want: Metadata{"order": "42"}},
+		"string":        {given: `{"a": "1", "b": "2"}`, want: Metadata{"a": "1", "b": "2"}},
+		"empty-object":  {given: []byte(`{}`), want: nil},
+		"null":          {given: nil, want: nil},
+		"not-an-object": {given: []byte(`[1]`), wantErr: ErrInvalidMetadata},
+		"wrong-type":    {given: int64(1), wantErr: ErrInvalidMetadata},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			var result Metadata
+			err := result.Scan(tcase.given)
+
+			if tcase.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tcase.wantErr)
+			}
+
+			assert.Equal(t, tcase.want, result)
+		})
+	}
+}
+
+func TestMetadata_Value(t *testing.T) {
+	value, err := Metadata(nil).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "{}", value)
+
+	value, err = Metadata{"order": "42"}.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, `{"order":"42"}`, value)
+}
@@ -1,28 +1,714 @@
 package models
 
 import (
+	"errors"
+	"fmt"
 	"time"
 )
 
+// Account is a wallet and its owner's profile. DefaultPrivacy applies to the payments
+// the account makes without choosing a privacy.
 type Account struct {
-	AccountId string `json:"accountId"`
-	Name      string `json:"name"`
-	LastName  string `json:"lastName"`
+	AccountId      string        `json:"accountId"`
+	Name           string        `json:"name"`
+	LastName       string        `json:"lastName"`
+	Status         AccountStatus `json:"status"`
+	Handle         string        `json:"handle,omitempty"`
+	Email          string        `json:"email,omitempty"`
+	Phone          string        `json:"phone,omitempty"`
+	DisplayName    string        `json:"displayName,omitempty"`
+	DateOfBirth    string        `json:"dateOfBirth,omitempty"`
+	Address        *Address      `json:"address,omitempty"`
+	DefaultPrivacy Privacy       `json:"defaultPrivacy"`
+	CreatedAt      time.Time     `json:"createdAt"`
+	UpdatedAt      time.Time     `json:"updatedAt"`
+}
+
+// AccountSummary is what anyone can see of an account, for instance to check who a
+// handle belongs to before paying it.
+type AccountSummary struct {
+	AccountId   string `json:"accountId"`
+	Handle      string `json:"handle,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+	Name        string `json:"name"`
+	LastName    string `json:"lastName"`
+}
+
+// Contact is an account saved to Owner's contact list.
+type Contact struct {
+	Owner     string    `json:"owner"`
+	AccountId string    `json:"accountId"`
+	Favorite  bool      `json:"favorite"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Counterparty is an account that another one paid or was paid by: how many payments
+// went either way and when the last one was made.
+type Counterparty struct {
+	AccountId string
+	Payments  int
+	LastAt    time.Time
+}
+
+// ContactEntry is one line of an account's contacts: a saved contact, someone it
+// recently paid or was paid by, or both.
+type ContactEntry struct {
+	Account       AccountSummary `json:"account"`
+	Saved         bool           `json:"saved"`
+	Favorite      bool           `json:"favorite"`
+	Payments      int            `json:"payments"`
+	LastPaymentAt *time.Time     `json:"lastPaymentAt,omitempty"`
+}
+
+// ContactFilter narrows an account's contacts. Zero values mean "no filter".
+type ContactFilter struct {
+	Limit     int
+	Favorites bool
+}
+
+// Address is a postal address. Country is an ISO 3166-1 alpha-2 code.
+type Address struct {
+	Line1      string `json:"line1"`
+	Line2      string `json:"line2,omitempty"`
+	City       string `json:"city"`
+	Region     string `json:"region,omitempty"`
+	PostalCode string `json:"postalCode"`
+	Country    string `json:"country"`
+}
+
+// AccountStatus says what an account may do: frozen accounts can receive money but not
+// send it, closed accounts can do neither.
+type AccountStatus string
+
+const (
+	AccountActive AccountStatus = "active"
+	AccountFrozen AccountStatus = "frozen"
+	AccountClosed AccountStatus = "closed"
+)
+
+// AccountStatusChange is one entry of an account's audit trail. PayoutTo and Amount are
+// set when closing the account paid its balance out.
+type AccountStatusChange struct {
+	ChangeId  string        `json:"changeId"`
+	AccountId string        `json:"accountId"`
+	From      AccountStatus `json:"from"`
+	To        AccountStatus `json:"to"`
+	Reason    string        `json:"reason"`
+	PayoutTo  string        `json:"payoutTo,omitempty"`
+	Amount    Money         `json:"amount,omitempty"`
+	CreatedAt time.Time     `json:"createdAt"`
 }
 
 type Transaction struct {
-	TransactionId string    `json:"transactionId"`
-	Owner         string    `json:"owner"`
-	Sender        string    `json:"sender"`
-	Receiver      string    `json:"receiver"`
-	CreatedAt     time.Time `json:"createdAt"`
-	Amount        float32   `json:"amount"`
-	IsConsumed    bool      `json:"isConsumed"`
+	TransactionId string          `json:"transactionId"`
+	Owner         string          `json:"owner"`
+	Sender        string          `json:"sender"`
+	Receiver      string          `json:"receiver"`
+	CreatedAt     time.Time       `json:"createdAt"`
+	Amount        Money           `json:"amount"`
+	IsConsumed    bool            `json:"isConsumed"`
+	Kind          TransactionKind `json:"kind"`
+	RelatedId     string          `json:"relatedId,omitempty"`
+	Memo          string          `json:"memo,omitempty"`
+	Metadata      Metadata        `json:"metadata,omitempty"`
+	Privacy       Privacy         `json:"privacy,omitempty"`
+}
+
+// Annotation is what a user says about a transaction: a short memo and free key/value
+// metadata, and for payments who may see them in the feed. Both legs of a payment carry
+// the same annotation.
+type Annotation struct {
+	Memo     string
+	Metadata Metadata
+	Privacy  Privacy
+}
+
+// Privacy says who sees a payment in the feed: everyone, the friends of its sender and
+// receiver, or only the two of them. Amounts are only ever shown to the two of them.
+// Transactions that are not payments made with Pay have no privacy and never show.
+type Privacy string
+
+const (
+	PrivacyPublic  Privacy = "public"
+	PrivacyFriends Privacy = "friends"
+	PrivacyPrivate Privacy = "private"
+)
+
+func (p Privacy) IsValid() bool {
+	switch p {
+	case PrivacyPublic, PrivacyFriends, PrivacyPrivate:
+		return true
+	}
+	return false
+}
+
+// TransactionKind tells what a transaction stands for. Change transactions hold what is
+// left of an unconsumed lot after a debit; they are not money moving in or out.
+type TransactionKind string
+
+const (
+	KindDeposit    TransactionKind = "deposit"
+	KindWithdrawal TransactionKind = "withdrawal"
+	KindPaymentOut TransactionKind = "payment_out"
+	KindPaymentIn  TransactionKind = "payment_in"
+	KindChange     TransactionKind = "change"
+	KindRefund     TransactionKind = "refund"
+	KindFee        TransactionKind = "fee"
+	KindAdjustment TransactionKind = "adjustment"
+	KindInterest   TransactionKind = "interest"
+)
+
+func (k TransactionKind) IsValid() bool {
+	switch k {
+	case KindDeposit, KindWithdrawal, KindPaymentOut, KindPaymentIn, KindChange, KindRefund, KindFee, KindAdjustment, KindInterest:
+		return true
+	}
+	return false
+}
+
+type TransactionDirection string
+
+const (
+	DirectionIn  TransactionDirection = "in"
+	DirectionOut TransactionDirection = "out"
+)
+
+type SortOrder string
+
+const (
+	SortAsc  SortOrder = "asc"
+	SortDesc SortOrder = "desc"
+)
+
+// TransactionFilter narrows an account's transaction history. Zero values mean "no filter".
+// Memo matches memos containing it regardless of case; Metadata matches transactions
+// carrying every one of its pairs.
+type TransactionFilter struct {
+	Limit        int
+	Cursor       string
+	From         time.Time
+	To           time.Time
+	Direction    TransactionDirection
+	Counterparty string
+	MinAmount    *Money
+	MaxAmount    *Money
+	Consumed     *bool
+	Kind         TransactionKind
+	Memo         string
+	Metadata     Metadata
+	Order        SortOrder
+}
+
+// AccountSort is the account field search results are ordered by.
+type AccountSort string
+
+const (
+	SortByName      AccountSort = "name"
+	SortByLastName  AccountSort = "lastName"
+	SortByCreatedAt AccountSort = "createdAt"
+)
+
+// AccountFilter pages through accounts. Query keeps the accounts where every word of it
+// starts the name, the last name or the handle, regardless of case.
+type AccountFilter struct {
+	Limit  int
+	Cursor string
+	Query  string
+	Sort   AccountSort
+	Order  SortOrder
+}
+
+type AccountPage struct {
+	Accounts   []Account `json:"accounts"`
+	NextCursor string    `json:"next_cursor"`
+}
+
+// Limits caps the money an account moves. The Max limits cap a single deposit,
+// withdrawal or payment; Daily, Weekly and Monthly cap the money sent out, withdrawals
+// and payments together, over the last 24 hours, 7 days and 30 days. Zero means no limit.
+type Limits struct {
+	MaxDeposit    Money `json:"maxDeposit,omitempty"`
+	MaxWithdrawal Money `json:"maxWithdrawal,omitempty"`
+	MaxPayment    Money `json:"maxPayment,omitempty"`
+	Daily         Money `json:"daily,omitempty"`
+	Weekly        Money `json:"weekly,omitempty"`
+	Monthly       Money `json:"monthly,omitempty"`
+}
+
+// LimitOverrides replaces the default limits of one account. A nil field keeps the
+// default, a zero one lifts the limit.
+type LimitOverrides struct {
+	MaxDeposit    *Money `json:"maxDeposit"`
+	MaxWithdrawal *Money `json:"maxWithdrawal"`
+	MaxPayment    *Money `json:"maxPayment"`
+	Daily         *Money `json:"daily"`
+	Weekly        *Money `json:"weekly"`
+	Monthly       *Money `json:"monthly"`
+}
+
+// Apply returns limits with the overrides that are set.
+func (o LimitOverrides) Apply(limits Limits) Limits {
+	if o.MaxDeposit != nil {
+		limits.MaxDeposit = *o.MaxDeposit
+	}
+	if o.MaxWithdrawal != nil {
+		limits.MaxWithdrawal = *o.MaxWithdrawal
+	}
+	if o.MaxPayment != nil {
+		limits.MaxPayment = *o.MaxPayment
+	}
+	if o.Daily != nil {
+		limits.Daily = *o.Daily
+	}
+	if o.Weekly != nil {
+		limits.Weekly = *o.Weekly
+	}
+	if o.Monthly != nil {
+		limits.Monthly = *o.Monthly
+	}
+	return limits
+}
+
+// LimitUsage is the money sent out over each rolling window.
+type LimitUsage struct {
+	Daily   Money `json:"daily"`
+	Weekly  Money `json:"weekly"`
+	Monthly Money `json:"monthly"`
+}
+
+// LimitRemaining is what is left of each rolling window. Windows without a limit are nil.
+type LimitRemaining struct {
+	Daily   *Money `json:"daily,omitempty"`
+	Weekly  *Money `json:"weekly,omitempty"`
+	Monthly *Money `json:"monthly,omitempty"`
+}
+
+// LimitStatus is where an account stands against its limits.
+type LimitStatus struct {
+	AccountId string         `json:"accountId"`
+	Limits    Limits         `json:"limits"`
+	Overrides LimitOverrides `json:"overrides"`
+	Used      LimitUsage     `json:"used"`
+	Remaining LimitRemaining `json:"remaining"`
+}
+
+// LimitExceeded tells which limit a transaction would go over: the limit, its maximum
+// and what is left of it.
+type LimitExceeded struct {
+	Limit     string `json:"limit"`
+	Max       Money  `json:"max"`
+	Remaining Money  `json:"remaining"`
+}
+
+// FeeOperation is what a fee is charged on. A waiver for FeeAll waives every fee.
+type FeeOperation string
+
+const (
+	FeeWithdrawal FeeOperation = "withdrawal"
+	FeePayment    FeeOperation = "payment"
+	FeeAll        FeeOperation = "all"
+)
+
+func (o FeeOperation) IsValid() bool {
+	switch o {
+	case FeeWithdrawal, FeePayment, FeeAll:
+		return true
+	}
+	return false
+}
+
+// FeeTier is the price of the amounts up to UpTo, or of any amount when UpTo is zero.
+type FeeTier struct {
+	UpTo Money `json:"upTo,omitempty"`
+	Flat Money `json:"flat,omitempty"`
+	Bps  int64 `json:"bps,omitempty"`
+}
+
+// FeeRule prices one operation: Flat plus Bps basis points of the amount, or the first
+// of Tiers the amount fits in when there are tiers. The fee is then raised to Min and
+// cut to Max; a zero Max means no cap.
+type FeeRule struct {
+	Flat  Money     `json:"flat,omitempty"`
+	Bps   int64     `json:"bps,omitempty"`
+	Tiers []FeeTier `json:"tiers,omitempty"`
+	Min   Money     `json:"min,omitempty"`
+	Max   Money     `json:"max,omitempty"`
+}
+
+// FeeSchedule prices each operation. Operations without a rule are free.
+type FeeSchedule map[FeeOperation]FeeRule
+
+var ErrInvalidFeeSchedule = errors.New("invalid fee schedule")
+
+// Validate checks that every rule prices a known operation with non-negative amounts,
+// rates of at most 100%, Min not above Max and tiers in increasing order ending with an
+// unbounded one.
+func (s FeeSchedule) Validate() error {
+	for op, rule := range s {
+		if !op.IsValid() || op == FeeAll {
+			return fmt.Errorf("%w: unknown operation %q", ErrInvalidFeeSchedule, op)
+		}
+		if rule.Flat < 0 || rule.Bps < 0 || rule.Bps > 10000 || rule.Min < 0 || rule.Max < 0 {
+			return fmt.Errorf("%w: %s has a negative amount or a rate over 100%%", ErrInvalidFeeSchedule, op)
+		}
+		if rule.Max > 0 && rule.Min > rule.Max {
+			return fmt.Errorf("%w: %s has min above max", ErrInvalidFeeSchedule, op)
+		}
+
+		for i, tier := range rule.Tiers {
+			last := i == len(rule.Tiers)-1
+			if tier.Flat < 0 || tier.Bps < 0 || tier.Bps > 10000 || tier.UpTo < 0 {
+				return fmt.Errorf("%w: %s has a negative amount or a rate over 100%%", ErrInvalidFeeSchedule, op)
+			}
+			if last != (tier.UpTo == 0) || (i > 0 && !last && tier.UpTo <= rule.Tiers[i-1].UpTo) {
+				return fmt.Errorf("%w: %s tiers must go up and end with one without upTo", ErrInvalidFeeSchedule, op)
+			}
+		}
+	}
+
+	return nil
+}
+
+// FeeWaiver spares an account the fees of Operation until ExpiresAt, or for good when
+// ExpiresAt is nil.
+type FeeWaiver struct {
+	AccountId string       `json:"accountId"`
+	Operation FeeOperation `json:"operation"`
+	Reason    string       `json:"reason,omitempty"`
+	ExpiresAt *time.Time   `json:"expiresAt,omitempty"`
+	CreatedAt time.Time    `json:"createdAt"`
+}
+
+// Receipt is what a withdrawal or a payment took out of the account: the amount and the
+// fee charged on it.
+type Receipt struct {
+	Amount Money `json:"amount"`
+	Fee    Money `json:"fee"`
+	Total  Money `json:"total"`
 }
 
+// DayCount is the day-count convention that turns the APR into a daily rate: the year
+// has 365, 360 or, with ACT/ACT, as many days as the calendar year. With 30/360 every
+// month counts 30 days, so the 31st earns nothing and the end of February makes up
+// for its missing days.
+type DayCount string
+
+const (
+	DayCountActual365    DayCount = "ACT/365"
+	DayCountActual360    DayCount = "ACT/360"
+	DayCountActualActual DayCount = "ACT/ACT"
+	DayCount30360        DayCount = "30/360"
+)
+
+func (d DayCount) IsValid() bool {
+	switch d {
+	case DayCountActual365, DayCountActual360, DayCountActualActual, DayCount30360:
+		return true
+	}
+	return false
+}
+
+// InterestRate is the yearly rate balances earn, in basis points.
+type InterestRate struct {
+	AprBps   int64    `json:"aprBps"`
+	DayCount DayCount `json:"dayCount"`
+}
+
+var ErrInvalidInterestRate = errors.New("invalid interest rate")
+
+func (r InterestRate) Validate() error {
+	if r.AprBps < 0 || r.AprBps > 10000 {
+		return fmt.Errorf("%w: apr must be between 0 and 10000 basis points", ErrInvalidInterestRate)
+	}
+	if !r.DayCount.IsValid() {
+		return fmt.Errorf("%w: unknown day count %q", ErrInvalidInterestRate, r.DayCount)
+	}
+
+	return nil
+}
+
+// InterestAccrual is the interest an account earned on the days up to AccruedThrough,
+// a UTC midnight, and was not paid yet.
+type InterestAccrual struct {
+	AccountId      string
+	Accrued        Accrual
+	AccruedThrough time.Time
+	UpdatedAt      time.Time
+}
+
+// InterestStatus is the accrued but unpaid interest of an account. Payable is the part
+// of it the next payout credits; the fraction of a cent left carries over.
+type InterestStatus struct {
+	AccountId      string       `json:"accountId"`
+	Rate           InterestRate `json:"rate"`
+	Accrued        Accrual      `json:"accrued"`
+	Payable        Money        `json:"payable"`
+	AccruedThrough *time.Time   `json:"accruedThrough,omitempty"`
+	NextPayoutAt   time.Time    `json:"nextPayoutAt"`
+}
+
+// FeedFilter pages through the payments Viewer may see, newest first. An empty Viewer
+// sees the public payments only; an Account keeps the payments it sent or received.
+// Friends are the accounts whose friends-only payments Viewer may see, the ones that
+// saved Viewer as a contact.
+type FeedFilter struct {
+	Viewer  string
+	Account string
+	Limit   int
+	Cursor  string
+	Friends []string
+}
+
+// FeedItem is a payment as shown in the feed. Amount is only set when the viewer sent or
+// received it.
+type FeedItem struct {
+	TransactionId string         `json:"transactionId"`
+	Sender        AccountSummary `json:"sender"`
+	Receiver      AccountSummary `json:"receiver"`
+	Amount        *Money         `json:"amount,omitempty"`
+	Memo          string         `json:"memo,omitempty"`
+	Privacy       Privacy        `json:"privacy"`
+	CreatedAt     time.Time      `json:"createdAt"`
+}
+
+type FeedPage struct {
+	Items      []FeedItem `json:"items"`
+	NextCursor string     `json:"next_cursor"`
+}
+
+type TransactionPage struct {
+	Transactions []Transaction `json:"transactions"`
+	NextCursor   string        `json:"next_cursor"`
+}
+
+// Balance is the ledger balance of an account. Held is the part of it reserved by active
+// holds and Available what is left to spend.
 type Balance struct {
-	AccountId string  `json:"accountId"`
-	Amount    float64 `json:"balance"`
+	AccountId string `json:"accountId"`
+	Amount    Money  `json:"balance"`
+	Held      Money  `json:"held"`
+	Available Money  `json:"available"`
+}
+
+type IdempotencyRecord struct {
+	Scope       string
+	Key         string
+	RequestHash string
+	Completed   bool
+	StatusCode  int
+	Body        []byte
+	CreatedAt   time.Time
+	LeasedUntil time.Time
+}
+
+type PaymentRequestStatus string
+
+const (
+	RequestPending   PaymentRequestStatus = "pending"
+	RequestAccepted  PaymentRequestStatus = "accepted"
+	RequestDeclined  PaymentRequestStatus = "declined"
+	RequestCancelled PaymentRequestStatus = "cancelled"
+	RequestExpired   PaymentRequestStatus = "expired"
+)
+
+// PaymentRequest is Requester asking Payer for Amount. Accepting it pays the requester.
+type PaymentRequest struct {
+	RequestId string               `json:"requestId"`
+	Requester string               `json:"requester"`
+	Payer     string               `json:"payer"`
+	Amount    Money                `json:"amount"`
+	Note      string               `json:"note"`
+	Status    PaymentRequestStatus `json:"status"`
+	CreatedAt time.Time            `json:"createdAt"`
+	ExpiresAt time.Time            `json:"expiresAt"`
+	UpdatedAt time.Time            `json:"updatedAt"`
+}
+
+type Frequency string
+
+const (
+	FrequencyOnce    Frequency = "once"
+	FrequencyDaily   Frequency = "daily"
+	FrequencyWeekly  Frequency = "weekly"
+	FrequencyMonthly Frequency = "monthly"
+	FrequencyCron    Frequency = "cron"
+)
+
+type ScheduleStatus string
+
+const (
+	ScheduleActive    ScheduleStatus = "active"
+	SchedulePaused    ScheduleStatus = "paused"
+	ScheduleCancelled ScheduleStatus = "cancelled"
+	ScheduleCompleted ScheduleStatus = "completed"
+)
+
+// ScheduledPayment pays Receiver from Owner at StartAt and then on every recurrence.
+// Cron holds a five field cron expression, in UTC, when Frequency is cron. Attempts
+// counts the failed tries of the run due at NextRunAt.
+type ScheduledPayment struct {
+	ScheduleId string         `json:"scheduleId"`
+	Owner      string         `json:"owner"`
+	Receiver   string         `json:"receiver"`
+	Amount     Money          `json:"amount"`
+	Frequency  Frequency      `json:"frequency"`
+	Cron       string         `json:"cron,omitempty"`
+	StartAt    time.Time      `json:"startAt"`
+	NextRunAt  time.Time      `json:"nextRunAt"`
+	Status     ScheduleStatus `json:"status"`
+	Attempts   int            `json:"attempts"`
+	CreatedAt  time.Time      `json:"createdAt"`
+	UpdatedAt  time.Time      `json:"updatedAt"`
+}
+
+type RunOutcome string
+
+const (
+	RunSucceeded RunOutcome = "succeeded"
+	RunRetrying  RunOutcome = "retrying"
+	RunFailed    RunOutcome = "failed"
+)
+
+// ScheduledRun records one attempt at paying a scheduled payment.
+type ScheduledRun struct {
+	RunId        string     `json:"runId"`
+	ScheduleId   string     `json:"scheduleId"`
+	ScheduledFor time.Time  `json:"scheduledFor"`
+	RanAt        time.Time  `json:"ranAt"`
+	Attempt      int        `json:"attempt"`
+	Outcome      RunOutcome `json:"outcome"`
+	Error        string     `json:"error,omitempty"`
+}
+
+type PayoutResult string
+
+const (
+	PayoutPending             PayoutResult = "pending"
+	PayoutSucceeded           PayoutResult = "success"
+	PayoutInsufficientBalance PayoutResult = "insufficient_balance"
+	PayoutUnknownReceiver     PayoutResult = "unknown_receiver"
+	PayoutFailed              PayoutResult = "failed"
+)
+
+type PayoutBatchStatus string
+
+const (
+	BatchProcessing PayoutBatchStatus = "processing"
+	BatchCompleted  PayoutBatchStatus = "completed"
+)
+
+// PayoutBatch pays many receivers out of Owner's account. Reserved is the part of Total
+// that was set aside when the batch was accepted; items it could not cover fail right away.
+type PayoutBatch struct {
+	BatchId   string            `json:"batchId"`
+	Owner     string            `json:"owner"`
+	Total     Money             `json:"total"`
+	Reserved  Money             `json:"reserved"`
+	Status    PayoutBatchStatus `json:"status"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+	Pending   int               `json:"pending"`
+	CreatedAt time.Time         `json:"createdAt"`
+	Items     []PayoutItem      `json:"items"`
+}
+
+type PayoutItem struct {
+	ItemId    string       `json:"itemId"`
+	BatchId   string       `json:"batchId"`
+	Owner     string       `json:"-"`
+	Position  int          `json:"position"`
+	Receiver  string       `json:"receiver"`
+	Amount    Money        `json:"amount"`
+	Result    PayoutResult `json:"result"`
+	Error     string       `json:"error,omitempty"`
+	UpdatedAt time.Time    `json:"updatedAt"`
+}
+
+type HoldStatus string
+
+const (
+	HoldActive   HoldStatus = "active"
+	HoldCaptured HoldStatus = "captured"
+	HoldVoided   HoldStatus = "voided"
+	HoldExpired  HoldStatus = "expired"
+)
+
+// Hold reserves Amount of Owner's balance for Merchant without moving it, until it is
+// captured, voided or expires. Captured is how much of it was paid to Merchant.
+type Hold struct {
+	HoldId    string     `json:"holdId"`
+	Owner     string     `json:"owner"`
+	Merchant  string     `json:"merchant"`
+	Amount    Money      `json:"amount"`
+	Captured  Money      `json:"captured"`
+	Status    HoldStatus `json:"status"`
+	Memo      string     `json:"memo,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+	ExpiresAt time.Time  `json:"expiresAt"`
+	UpdatedAt time.Time  `json:"updatedAt"`
+}
+
+// Ledger accounts that are not customer accounts: money enters the ledger through
+// cash-in and leaves it through cash-out. Payout clearing holds what payout batches have
+// reserved until each item is paid or released, revenue collects the fees and interest
+// pays the interest accounts earn.
+const (
+	SystemCashInAccount         = "00000000-0000-0000-0000-000000000001"
+	SystemCashOutAccount        = "00000000-0000-0000-0000-000000000002"
+	SystemPayoutClearingAccount = "00000000-0000-0000-0000-000000000003"
+	SystemRevenueAccount        = "00000000-0000-0000-0000-000000000004"
+	SystemInterestAccount       = "00000000-0000-0000-0000-000000000005"
+)
+
+// JournalEntry is one balanced double-entry record: the amounts of its postings sum to zero.
+type JournalEntry struct {
+	EntryId     string    `json:"entryId"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"createdAt"`
+	Postings    []Posting `json:"postings"`
+}
+
+// Posting moves Amount into AccountId; a negative Amount moves money out of it.
+type Posting struct {
+	PostingId string `json:"postingId"`
+	EntryId   string `json:"entryId"`
+	AccountId string `json:"accountId"`
+	Amount    Money  `json:"amount"`
+}
+
+type LedgerCheck struct {
+	Balanced          bool     `json:"balanced"`
+	UnbalancedEntries []string `json:"unbalancedEntries"`
+}
+
+// Revenue is what the fees brought into the revenue ledger account.
+type Revenue struct {
+	AccountId string `json:"accountId"`
+	Amount    Money  `json:"amount"`
+}
+
+// Credential is the password an account logs in with. Only a salted hash of it is kept.
+type Credential struct {
+	AccountId    string    `json:"accountId"`
+	PasswordHash string    `json:"-"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+// Session is a login of AccountId. Only the hash of its bearer token is kept, so the
+// token is shown to the client once and cannot be recovered from storage.
+type Session struct {
+	TokenHash string    `json:"-"`
+	AccountId string    `json:"accountId"`
+	CreatedAt time.Time `json:"createdAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Principal is who a request is made by: the account a session belongs to, or the
+// operator when Admin is set.
+type Principal struct {
+	AccountId string
+	Admin     bool
 }
 
 var Accounts = make(map[string]*Account)
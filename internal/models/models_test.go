@@ -0,0 +1,43 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeeSchedule_Validate(t *testing.T) {
+	scenarios := map[string]struct {
+		given   FeeSchedule
+		wantErr error
+	}{
+		"empty": {given: FeeSchedule{}},
+		"valid": {
+			given: FeeSchedule{
+				FeeWithdrawal: {Flat: 100, Bps: 150, Min: 100, Max: 1000},
+				FeePayment:    {Tiers: []FeeTier{{UpTo: 2000}, {UpTo: 10000, Flat: 25}, {Bps: 100}}},
+			},
+		},
+		"unknown-operation":   {given: FeeSchedule{"fx": {Flat: 100}}, wantErr: ErrInvalidFeeSchedule},
+		"all-operation":       {given: FeeSchedule{FeeAll: {Flat: 100}}, wantErr: ErrInvalidFeeSchedule},
+		"negative-flat":       {given: FeeSchedule{FeePayment: {Flat: -1}}, wantErr: ErrInvalidFeeSchedule},
+		"rate-over-100":       {given: FeeSchedule{FeePayment: {Bps: 10001}}, wantErr: ErrInvalidFeeSchedule},
+		"min-above-max":       {given: FeeSchedule{FeePayment: {Min: 200, Max: 100}}, wantErr: ErrInvalidFeeSchedule},
+		"tiers-not-unbounded": {given: FeeSchedule{FeePayment: {Tiers: []FeeTier{{UpTo: 2000}, {UpTo: 5000}}}}, wantErr: ErrInvalidFeeSchedule},
+		"tiers-going-down":    {given: FeeSchedule{FeePayment: {Tiers: []FeeTier{{UpTo: 5000}, {UpTo: 2000}, {}}}}, wantErr: ErrInvalidFeeSchedule},
+		"unbounded-not-last":  {given: FeeSchedule{FeePayment: {Tiers: []FeeTier{{}, {UpTo: 2000}}}}, wantErr: ErrInvalidFeeSchedule},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			err := tcase.given.Validate()
+
+			if tcase.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tcase.wantErr)
+			}
+		})
+	}
+}
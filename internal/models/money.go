@@ -0,0 +1,241 @@
+package models
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidMoney  = errors.New("invalid money amount")
+	ErrMoneyOverflow = errors.New("money amount out of range")
+)
+
+const (
+	minorUnitDigits = 2
+	minorUnitScale  = 100
+)
+
+// Money is an exact monetary amount stored as an integer number of minor units (cents).
+// It is encoded in JSON as a decimal number and accepts both numbers and strings on input.
+type Money int64
+
+// NewMoney builds a Money value from its major (units) and minor (cents) parts.
+func NewMoney(units int64, cents int64) Money {
+	return Money(units*minorUnitScale + cents)
+}
+
+// ParseMoney parses a decimal string such as "12", "-0.5" or "1234.56" without
+// going through floating point. At most two fractional digits are accepted.
+func ParseMoney(s string) (Money, error) {
+	amount, err := parseFixed(s, minorUnitDigits)
+	return Money(amount), err
+}
+
+// parseFixed parses a decimal string with at most digits fractional digits into an
+// integer number of 10^-digits units.
+func parseFixed(s string, digits int) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, ErrInvalidMoney
+	}
+
+	negative := false
+	switch s[0] {
+	case '-':
+		negative = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+
+	whole, frac, hasFrac := strings.Cut(s, ".")
+	if whole == "" && frac == "" {
+		return 0, ErrInvalidMoney
+	}
+	if hasFrac && (frac == "" || len(frac) > digits) {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidMoney, s)
+	}
+	if !isDigits(whole) || !isDigits(frac) {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidMoney, s)
+	}
+
+	var units int64
+	if whole != "" {
+		var err error
+		units, err = strconv.ParseInt(whole, 10, 64)
+		if err != nil {
+			return 0, ErrMoneyOverflow
+		}
+	}
+
+	frac = frac + strings.Repeat("0", digits-len(frac))
+	minor, _ := strconv.ParseInt(frac, 10, 64)
+
+	scale := int64(math.Pow10(digits))
+	if units > (math.MaxInt64-minor)/scale {
+		return 0, ErrMoneyOverflow
+	}
+
+	amount := units*scale + minor
+	if negative {
+		amount = -amount
+	}
+
+	return amount, nil
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func (m Money) Abs() Money {
+	if m < 0 {
+		return -m
+	}
+	return m
+}
+
+// String formats the amount as a decimal with two fractional digits, e.g. "-12.05".
+func (m Money) String() string {
+	return formatFixed(int64(m), minorUnitDigits)
+}
+
+// formatFixed formats an integer number of 10^-digits units as a decimal.
+func formatFixed(amount int64, digits int) string {
+	sign := ""
+	value := uint64(amount)
+	if amount < 0 {
+		sign = "-"
+		value = uint64(-amount)
+	}
+
+	scale := uint64(math.Pow10(digits))
+	return fmt.Sprintf("%s%d.%0*d", sign, value/scale, digits, value%scale)
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+
+	amount, err := ParseMoney(s)
+	if err != nil {
+		return err
+	}
+
+	*m = amount
+	return nil
+}
+
+// Scan implements sql.Scanner for NUMERIC columns.
+func (m *Money) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*m = 0
+		return nil
+	case int64:
+		*m = Money(v * minorUnitScale)
+		return nil
+	case []byte:
+		amount, err := ParseMoney(string(v))
+		if err != nil {
+			return err
+		}
+		*m = amount
+		return nil
+	case string:
+		amount, err := ParseMoney(v)
+		if err != nil {
+			return err
+		}
+		*m = amount
+		return nil
+	}
+
+	return fmt.Errorf("%w: cannot scan %T", ErrInvalidMoney, src)
+}
+
+// Value implements driver.Valuer, sending the amount as an exact decimal string.
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// AccrualUnitsPerCent is how many Accrual units make up one cent.
+const (
+	AccrualUnitsPerCent = 1000000
+	accrualDigits       = minorUnitDigits + 6
+)
+
+// Accrual is an amount of accrued interest kept to a millionth of a cent, so that daily
+// accruals add up without each of them being rounded to the cent. It is encoded in JSON
+// as a decimal number with eight fractional digits.
+type Accrual int64
+
+// AccrualOf converts m to accrual units.
+func AccrualOf(m Money) Accrual {
+	return Accrual(m) * AccrualUnitsPerCent
+}
+
+// Money returns the whole cents of a, leaving out the fraction of a cent.
+func (a Accrual) Money() Money {
+	return Money(a / AccrualUnitsPerCent)
+}
+
+func (a Accrual) String() string {
+	return formatFixed(int64(a), accrualDigits)
+}
+
+func (a Accrual) MarshalJSON() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// Scan implements sql.Scanner for NUMERIC columns.
+func (a *Accrual) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*a = 0
+		return nil
+	case int64:
+		*a = Accrual(v) * minorUnitScale * AccrualUnitsPerCent
+		return nil
+	case []byte:
+		amount, err := parseFixed(string(v), accrualDigits)
+		if err != nil {
+			return err
+		}
+		*a = Accrual(amount)
+		return nil
+	case string:
+		amount, err := parseFixed(v, accrualDigits)
+		if err != nil {
+			return err
+		}
+		*a = Accrual(amount)
+		return nil
+	}
+
+	return fmt.Errorf("%w: cannot scan %T", ErrInvalidMoney, src)
+}
+
+// Value implements driver.Valuer, sending the amount as an exact decimal string.
+func (a Accrual) Value() (driver.Value, error) {
+	return a.String(), nil
+}
@@ -0,0 +1,112 @@
+package models
+
+import (
+	"testing"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoney_Parse(t *testing.T) {
+	scenarios := map[string]struct {
+		given   string
+		want    Money
+		wantErr error
+	}{
+		"whole":            {given: "12", want: 1200},
+		"one-decimal":      {given: "0.5", want: 50},
+		"two-decimals":     {given: "1234.56", want: 123456},
+		"negative":         {given: "-0.01", want: -1},
+		"leading-dot":      {given: ".3", want: 30},
+		"too-many-digits":  {given: "1.234", wantErr: ErrInvalidMoney},
+		"trailing-dot":     {given: "1.", wantErr: ErrInvalidMoney},
+		"not-a-number":     {given: "abc", wantErr: ErrInvalidMoney},
+		"empty":            {given: "", wantErr: ErrInvalidMoney},
+		"exponent":         {given: "1e3", wantErr: ErrInvalidMoney},
+		"overflow":         {given: "92233720368547758.08", wantErr: ErrMoneyOverflow},
+		"max-without-fail": {given: "92233720368547758.07", want: Money(9223372036854775807)},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			result, err := ParseMoney(tcase.given)
+
+			if tcase.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tcase.wantErr)
+			}
+
+			assert.Equal(t, tcase.want, result)
+		})
+	}
+}
+
+func TestMoney_JSON(t *testing.T) {
+	scenarios := map[string]struct {
+		given   string
+		want    Money
+		wantErr error
+	}{
+		"number":        {given: `{"amount": 0.3}`, want: 30},
+		"string":        {given: `{"amount": "19.99"}`, want: 1999},
+		"negative":      {given: `{"amount": -5}`, want: -500},
+		"invalid":       {given: `{"amount": "1.999"}`, wantErr: ErrInvalidMoney},
+		"missing-field": {given: `{}`, want: 0},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			req := AmountReq{}
+			err := jsoniter.Unmarshal([]byte(tcase.given), &req)
+
+			if tcase.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tcase.wantErr.Error())
+			}
+
+			assert.Equal(t, tcase.want, req.Amount)
+		})
+	}
+
+	res, err := jsoniter.Marshal(Balance{AccountId: "0001", Amount: -1005, Held: 250, Available: -1255})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"accountId": "0001", "balance": -10.05, "held": 2.50, "available": -12.55}`, string(res))
+}
+
+func TestMoney_Sum(t *testing.T) {
+	var total Money
+	for i := 0; i < 10; i++ {
+		total += 10
+	}
+
+	assert.Equal(t, Money(100), total)
+	assert.Equal(t, "1.00", total.String())
+	assert.Equal(t, Money(0), NewMoney(0, 10)*3-NewMoney(0, 30))
+}
+
+func TestAccrual(t *testing.T) {
+	// A third of a cent a day adds up without being rounded to the cent.
+	var total Accrual
+	for i := 0; i < 45; i++ {
+		total += AccrualOf(1) / 3
+	}
+
+	assert.Equal(t, "0.14999985", total.String())
+	assert.Equal(t, Money(14), total.Money())
+	assert.Equal(t, "0.00999985", (total - AccrualOf(total.Money())).String())
+
+	var scanned Accrual
+	assert.NoError(t, scanned.Scan([]byte("12.34567891")))
+	assert.Equal(t, Accrual(1234567891), scanned)
+	assert.ErrorIs(t, scanned.Scan("0.000000001"), ErrInvalidMoney)
+
+	res, err := jsoniter.Marshal(struct {
+		Accrued Accrual `json:"accrued"`
+	}{Accrued: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"accrued":0.00000001}`, string(res))
+}
@@ -0,0 +1,173 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gopay/internal/models"
+	"github.com/gopay/internal/repository"
+	"github.com/gopay/internal/service"
+	"github.com/gopay/internal/utils"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/julienschmidt/httprouter"
+	"github.com/rs/zerolog/log"
+)
+
+func (h *apiHandler) CreatePaymentRequest(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	requester := params.ByName(AccountIdParam)
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, OneMegabyte))
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	req := models.PaymentRequestReq{}
+	err = jsoniter.Unmarshal(body, &req)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		utils.ErrorWithMessage(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	request, err := h.paymentRequestSvc.Create(r.Context(), requester, req.Payer, req.Amount, req.Note)
+	if err == service.ErrInvalidAmount || err == service.ErrInvalidPaymentOp || err == service.ErrNoteTooLong {
+		log.Error().Err(err).Msg("Handler::CreatePaymentRequest")
+		utils.ErrorWithMessage(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err == repository.ErrAccountNotFound {
+		log.Error().Err(err).Msg("Handler::CreatePaymentRequest")
+		utils.ErrorWithMessage(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::CreatePaymentRequest")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	res, err := jsoniter.Marshal(&request)
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::CreatePaymentRequest")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.WithPayload(w, http.StatusCreated, res)
+}
+
+// GetPaymentRequests lists the requests the account has to pay, or with direction=out the
+// ones it made. An optional status narrows the list.
+func (h *apiHandler) GetPaymentRequests(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	accountId := params.ByName(AccountIdParam)
+	query := r.URL.Query()
+	status := models.PaymentRequestStatus(query.Get("status"))
+
+	var (
+		requests []models.PaymentRequest
+		err      error
+	)
+
+	switch models.TransactionDirection(query.Get("direction")) {
+	case "", models.DirectionIn:
+		requests, err = h.paymentRequestSvc.GetIncoming(r.Context(), accountId, status)
+	case models.DirectionOut:
+		requests, err = h.paymentRequestSvc.GetOutgoing(r.Context(), accountId, status)
+	default:
+		err = invalidParam("direction")
+		log.Error().Err(err).Msg("Handler::GetPaymentRequests")
+		utils.ErrorWithMessage(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err == repository.ErrAccountNotFound {
+		log.Error().Err(err).Msg("Handler::GetPaymentRequests")
+		utils.ErrorWithMessage(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::GetPaymentRequests")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	res, err := jsoniter.Marshal(&requests)
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::GetPaymentRequests")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.WithPayload(w, http.StatusOK, res)
+}
+
+func (h *apiHandler) AcceptPaymentRequest(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	h.resolvePaymentRequest(w, r, params, "Handler::AcceptPaymentRequest", h.paymentRequestSvc.Accept)
+}
+
+func (h *apiHandler) DeclinePaymentRequest(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	h.resolvePaymentRequest(w, r, params, "Handler::DeclinePaymentRequest", h.paymentRequestSvc.Decline)
+}
+
+func (h *apiHandler) CancelPaymentRequest(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	h.resolvePaymentRequest(w, r, params, "Handler::CancelPaymentRequest", h.paymentRequestSvc.Cancel)
+}
+
+func (h *apiHandler) resolvePaymentRequest(w http.ResponseWriter, r *http.Request, params httprouter.Params, name string, resolve func(ctx context.Context, id string, accId string) error) {
+	accountId := params.ByName(AccountIdParam)
+	requestId := params.ByName(RequestIdParam)
+
+	err := resolve(r.Context(), requestId, accountId)
+	if err == repository.ErrPaymentRequestNotFound || err == repository.ErrAccountNotFound {
+		log.Error().Err(err).Msg(name)
+		utils.ErrorWithMessage(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	if errors.Is(err, service.ErrAccountInactive) {
+		log.Error().Err(err).Msg(name)
+		utils.ErrorWithMessage(w, http.StatusForbidden, err.Error())
+		return
+	}
+
+	if err == service.ErrPaymentRequestForbidden {
+		log.Error().Err(err).Msg(name)
+		utils.ErrorWithMessage(w, http.StatusForbidden, err.Error())
+		return
+	}
+
+	if err == repository.ErrPaymentRequestNotPending || err == service.ErrPaymentRequestExpired || err == repository.ErrAccountLocked {
+		log.Error().Err(err).Msg(name)
+		utils.ErrorWithMessage(w, http.StatusConflict, err.Error())
+		return
+	}
+
+	if err == service.ErrInsufficentBalance {
+		log.Error().Err(err).Msg(name)
+		utils.ErrorWithMessage(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	var limitErr *service.LimitError
+	if errors.As(err, &limitErr) {
+		log.Error().Err(err).Msg(name)
+		utils.ErrorWithDetails(w, http.StatusUnprocessableEntity, err.Error(), limitErr.LimitExceeded)
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg(name)
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.WithPayload(w, http.StatusOK, nil)
+}
@@ -0,0 +1,181 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/gopay/internal/models"
+	"github.com/gopay/internal/repository"
+	"github.com/gopay/internal/service"
+	"github.com/gopay/internal/utils"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/julienschmidt/httprouter"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	ErrInvalidPayoutCSV = errors.New("invalid payout csv")
+)
+
+func (h *apiHandler) CreatePayoutBatch(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	owner := params.ByName(AccountIdParam)
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, OneMegabyte))
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	items, err := parsePayouts(r.Header.Get("Content-Type"), body)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		utils.ErrorWithMessage(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	batch, err := h.payoutSvc.CreateBatch(r.Context(), owner, items)
+	if errors.Is(err, service.ErrInvalidPayoutBatch) {
+		log.Error().Err(err).Msg("Handler::CreatePayoutBatch")
+		utils.ErrorWithMessage(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err == repository.ErrAccountNotFound {
+		log.Error().Err(err).Msg("Handler::CreatePayoutBatch")
+		utils.ErrorWithMessage(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	if errors.Is(err, service.ErrAccountInactive) {
+		log.Error().Err(err).Msg("Handler::CreatePayoutBatch")
+		utils.ErrorWithMessage(w, http.StatusForbidden, err.Error())
+		return
+	}
+
+	if err == repository.ErrAccountLocked {
+		log.Error().Err(err).Msg("Handler::CreatePayoutBatch")
+		utils.ErrorWithMessage(w, http.StatusConflict, err.Error())
+		return
+	}
+
+	var limitErr *service.LimitError
+	if errors.As(err, &limitErr) {
+		log.Error().Err(err).Msg("Handler::CreatePayoutBatch")
+		utils.ErrorWithDetails(w, http.StatusUnprocessableEntity, err.Error(), limitErr.LimitExceeded)
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::CreatePayoutBatch")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	res, err := jsoniter.Marshal(&batch)
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::CreatePayoutBatch")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.WithPayload(w, http.StatusAccepted, res)
+}
+
+func (h *apiHandler) GetPayoutBatch(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	owner := params.ByName(AccountIdParam)
+	id := params.ByName(BatchIdParam)
+
+	batch, err := h.payoutSvc.GetBatch(r.Context(), id, owner)
+	if err == repository.ErrPayoutBatchNotFound {
+		log.Error().Err(err).Msg("Handler::GetPayoutBatch")
+		utils.ErrorWithMessage(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	if err == service.ErrPayoutNotOwned {
+		log.Error().Err(err).Msg("Handler::GetPayoutBatch")
+		utils.ErrorWithMessage(w, http.StatusForbidden, err.Error())
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::GetPayoutBatch")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	res, err := jsoniter.Marshal(&batch)
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::GetPayoutBatch")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.WithPayload(w, http.StatusOK, res)
+}
+
+// parsePayouts reads a batch either as JSON or, for text/csv bodies, as CSV with a
+// header row naming the receiver and amount columns.
+func parsePayouts(contentType string, body []byte) ([]models.PayoutReq, error) {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType != "text/csv" {
+		req := models.PayoutBatchReq{}
+		err := jsoniter.Unmarshal(body, &req)
+		return req.Items, err
+	}
+
+	reader := csv.NewReader(bytes.NewReader(body))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("missing header: %w", ErrInvalidPayoutCSV)
+	}
+
+	receiverCol, amountCol := -1, -1
+	for i, name := range header {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "receiver":
+			receiverCol = i
+		case "amount":
+			amountCol = i
+		}
+	}
+	if receiverCol < 0 || amountCol < 0 {
+		return nil, fmt.Errorf("header must name receiver and amount columns: %w", ErrInvalidPayoutCSV)
+	}
+
+	items := []models.PayoutReq{}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", err, ErrInvalidPayoutCSV)
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		amount, err := models.ParseMoney(strings.TrimSpace(record[amountCol]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s: %w", line, err, ErrInvalidPayoutCSV)
+		}
+
+		items = append(items, models.PayoutReq{
+			Receiver: strings.TrimSpace(record[receiverCol]),
+			Amount:   amount,
+		})
+	}
+
+	return items, nil
+}
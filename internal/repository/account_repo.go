@@ -3,37 +3,58 @@ package repository
 import (
 	"context"
 	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/gopay/internal/models"
 	"github.com/gopay/internal/utils"
 )
 
 var (
-	ErrAccountNotFound = errors.New("account not found")
-	ErrMissingParams   = errors.New("must provide name and last name")
+	ErrAccountNotFound      = errors.New("account not found")
+	ErrMissingParams        = errors.New("must provide name and last name")
+	ErrAccountStatusChanged = errors.New("account status changed in the meantime")
+	ErrEmailTaken           = errors.New("email is already used by another account")
+	ErrPhoneTaken           = errors.New("phone is already used by another account")
+	ErrHandleTaken          = errors.New("handle is already taken")
 )
 
 type AccountRepo interface {
 	FindAll(ctx context.Context) ([]models.Account, error)
+	FindPage(ctx context.Context, filter models.AccountFilter) (models.AccountPage, error)
 	FindOne(ctx context.Context, id string) (models.Account, error)
+	FindByHandle(ctx context.Context, handle string) (models.Account, error)
 	Create(ctx context.Context, name string, lastname string) (string, error)
+	Update(ctx context.Context, acc models.Account) error
+	SetStatus(ctx context.Context, change models.AccountStatusChange) (string, error)
+	FindStatusChanges(ctx context.Context, id string) ([]models.AccountStatusChange, error)
 }
 
 var _ AccountRepo = (*accountRepoImpl)(nil)
 
 type accountRepoImpl struct {
+	mu          sync.RWMutex
 	accounts    map[string]models.Account
+	changes     map[string][]models.AccountStatusChange
 	idGenerator func() string
+	now         func() time.Time
 }
 
 func NewAccountRepo() *accountRepoImpl {
 	return &accountRepoImpl{
 		accounts:    make(map[string]models.Account),
+		changes:     make(map[string][]models.AccountStatusChange),
 		idGenerator: utils.GetAccountUUID,
+		now:         time.Now,
 	}
 }
 
 func (r *accountRepoImpl) FindAll(_ context.Context) ([]models.Account, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	accs := []models.Account{}
 
 	for _, account := range r.accounts {
@@ -43,7 +64,108 @@ func (r *accountRepoImpl) FindAll(_ context.Context) ([]models.Account, error) {
 	return accs, nil
 }
 
+// FindPage returns up to filter.Limit accounts matching filter.Query, starting after
+// filter.Cursor. Names compare lowercased, byte by byte, and ties are broken by id.
+// NextCursor is empty on the last page.
+func (r *accountRepoImpl) FindPage(_ context.Context, filter models.AccountFilter) (models.AccountPage, error) {
+	descending := filter.Order == models.SortDesc
+	terms := strings.Fields(strings.ToLower(filter.Query))
+
+	var (
+		cursorKey string
+		cursorAt  time.Time
+		cursorId  string
+		err       error
+	)
+	if filter.Cursor != "" {
+		if filter.Sort == models.SortByCreatedAt {
+			cursorAt, cursorId, err = decodeCursor(filter.Cursor)
+		} else {
+			cursorKey, cursorId, err = decodeKeyCursor(filter.Cursor)
+		}
+		if err != nil {
+			return models.AccountPage{}, err
+		}
+	}
+
+	r.mu.RLock()
+	accs := []models.Account{}
+	for _, acc := range r.accounts {
+		if !matchesAccountQuery(acc, terms) {
+			continue
+		}
+		if filter.Cursor != "" {
+			if filter.Sort == models.SortByCreatedAt && !afterCursor(acc.CreatedAt, acc.AccountId, cursorAt, cursorId, descending) {
+				continue
+			}
+			if filter.Sort != models.SortByCreatedAt && !afterKeyCursor(accountSortKey(acc, filter.Sort), acc.AccountId, cursorKey, cursorId, descending) {
+				continue
+			}
+		}
+		accs = append(accs, acc)
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(accs, func(i, j int) bool {
+		a, b := accs[i], accs[j]
+		if filter.Sort == models.SortByCreatedAt && !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt) != descending
+		}
+		if ka, kb := accountSortKey(a, filter.Sort), accountSortKey(b, filter.Sort); ka != kb {
+			return (ka < kb) != descending
+		}
+		return (a.AccountId < b.AccountId) != descending
+	})
+
+	return newAccountPage(accs, filter), nil
+}
+
+// matchesAccountQuery reports whether every one of terms, lowercased, starts the
+// account's name, last name or handle.
+func matchesAccountQuery(acc models.Account, terms []string) bool {
+	name, lastName, handle := strings.ToLower(acc.Name), strings.ToLower(acc.LastName), strings.ToLower(acc.Handle)
+
+	for _, term := range terms {
+		if !strings.HasPrefix(name, term) && !strings.HasPrefix(lastName, term) && !strings.HasPrefix(handle, term) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func accountSortKey(acc models.Account, by models.AccountSort) string {
+	switch by {
+	case models.SortByName:
+		return strings.ToLower(acc.Name)
+	case models.SortByLastName:
+		return strings.ToLower(acc.LastName)
+	}
+	return ""
+}
+
+// newAccountPage cuts accs, already in page order, down to filter.Limit. Callers fetch
+// one extra row so that a full last page does not get a cursor.
+func newAccountPage(accs []models.Account, filter models.AccountFilter) models.AccountPage {
+	page := models.AccountPage{Accounts: accs}
+
+	if filter.Limit > 0 && len(accs) > filter.Limit {
+		page.Accounts = accs[:filter.Limit]
+		last := page.Accounts[filter.Limit-1]
+		if filter.Sort == models.SortByCreatedAt {
+			page.NextCursor = encodeCursor(last.CreatedAt, last.AccountId)
+		} else {
+			page.NextCursor = encodeKeyCursor(accountSortKey(last, filter.Sort), last.AccountId)
+		}
+	}
+
+	return page
+}
+
 func (r *accountRepoImpl) FindOne(_ context.Context, id string) (models.Account, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	account, found := r.accounts[id]
 
 	if !found {
@@ -53,19 +175,144 @@ func (r *accountRepoImpl) FindOne(_ context.Context, id string) (models.Account,
 	return account, nil
 }
 
-func (r *accountRepoImpl) Create(_ context.Context, name string, lastname string) (string, error) {
+// FindByHandle finds the account whose handle is handle, regardless of case.
+func (r *accountRepoImpl) FindByHandle(_ context.Context, handle string) (models.Account, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if handle == "" {
+		return models.Account{}, ErrAccountNotFound
+	}
+
+	for _, account := range r.accounts {
+		if strings.EqualFold(account.Handle, handle) {
+			return account, nil
+		}
+	}
+
+	return models.Account{}, ErrAccountNotFound
+}
+
+func (r *accountRepoImpl) Create(ctx context.Context, name string, lastname string) (string, error) {
 	if name == "" || lastname == "" {
 		return "", ErrMissingParams
 	}
 
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	id := r.idGenerator()
+	now := r.now()
 
 	acc := models.Account{
-		AccountId: id,
-		Name:      name,
-		LastName:  lastname,
+		AccountId:      id,
+		Name:           name,
+		LastName:       lastname,
+		Status:         models.AccountActive,
+		DefaultPrivacy: models.PrivacyPrivate,
+		CreatedAt:      now,
+		UpdatedAt:      now,
 	}
 	r.accounts[id] = acc
+	onRollback(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.accounts, id)
+	})
 
 	return id, nil
 }
+
+// Update writes the profile fields of acc and its UpdatedAt. Its status and CreatedAt
+// are left alone. Handles and emails are unique regardless of case, phones as stored.
+func (r *accountRepoImpl) Update(ctx context.Context, acc models.Account) error {
+	if acc.Name == "" || acc.LastName == "" {
+		return ErrMissingParams
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, found := r.accounts[acc.AccountId]
+	if !found {
+		return ErrAccountNotFound
+	}
+
+	for id, other := range r.accounts {
+		if id == acc.AccountId {
+			continue
+		}
+		if acc.Handle != "" && strings.EqualFold(other.Handle, acc.Handle) {
+			return ErrHandleTaken
+		}
+		if acc.Email != "" && strings.EqualFold(other.Email, acc.Email) {
+			return ErrEmailTaken
+		}
+		if acc.Phone != "" && other.Phone == acc.Phone {
+			return ErrPhoneTaken
+		}
+	}
+
+	acc.Status = previous.Status
+	acc.CreatedAt = previous.CreatedAt
+	r.accounts[acc.AccountId] = acc
+
+	onRollback(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.accounts[previous.AccountId] = previous
+	})
+
+	return nil
+}
+
+// SetStatus moves the account from change.From to change.To and appends change to its
+// audit trail. It fails with ErrAccountStatusChanged if the account is no longer in
+// change.From, so concurrent changes cannot both apply.
+func (r *accountRepoImpl) SetStatus(ctx context.Context, change models.AccountStatusChange) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	acc, found := r.accounts[change.AccountId]
+	if !found {
+		return "", ErrAccountNotFound
+	}
+
+	if acc.Status != change.From {
+		return "", ErrAccountStatusChanged
+	}
+
+	previous := acc
+	acc.Status = change.To
+	r.accounts[acc.AccountId] = acc
+
+	change.ChangeId = r.idGenerator()
+	r.changes[acc.AccountId] = append(r.changes[acc.AccountId], change)
+
+	onRollback(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.accounts[previous.AccountId] = previous
+
+		kept := []models.AccountStatusChange{}
+		for _, c := range r.changes[previous.AccountId] {
+			if c.ChangeId != change.ChangeId {
+				kept = append(kept, c)
+			}
+		}
+		r.changes[previous.AccountId] = kept
+	})
+
+	return change.ChangeId, nil
+}
+
+// FindStatusChanges returns the audit trail of the account, oldest first.
+func (r *accountRepoImpl) FindStatusChanges(_ context.Context, id string) ([]models.AccountStatusChange, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	changes := []models.AccountStatusChange{}
+	changes = append(changes, r.changes[id]...)
+
+	return changes, nil
+}
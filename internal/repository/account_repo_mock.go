@@ -138,6 +138,63 @@ func (_c *MockAccountRepo_FindAll_Call) RunAndReturn(run func(context.Context) (
 	return _c
 }
 
+// FindByHandle provides a mock function with given fields: ctx, handle
+func (_m *MockAccountRepo) FindByHandle(ctx context.Context, handle string) (models.Account, error) {
+	ret := _m.Called(ctx, handle)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByHandle")
+	}
+
+	var r0 models.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.Account, error)); ok {
+		return rf(ctx, handle)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.Account); ok {
+		r0 = rf(ctx, handle)
+	} else {
+		r0 = ret.Get(0).(models.Account)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, handle)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAccountRepo_FindByHandle_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByHandle'
+type MockAccountRepo_FindByHandle_Call struct {
+	*mock.Call
+}
+
+// FindByHandle is a helper method to define mock.On call
+//   - ctx context.Context
+//   - handle string
+func (_e *MockAccountRepo_Expecter) FindByHandle(ctx interface{}, handle interface{}) *MockAccountRepo_FindByHandle_Call {
+	return &MockAccountRepo_FindByHandle_Call{Call: _e.mock.On("FindByHandle", ctx, handle)}
+}
+
+func (_c *MockAccountRepo_FindByHandle_Call) Run(run func(ctx context.Context, handle string)) *MockAccountRepo_FindByHandle_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockAccountRepo_FindByHandle_Call) Return(_a0 models.Account, _a1 error) *MockAccountRepo_FindByHandle_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAccountRepo_FindByHandle_Call) RunAndReturn(run func(context.Context, string) (models.Account, error)) *MockAccountRepo_FindByHandle_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FindOne provides a mock function with given fields: ctx, id
 func (_m *MockAccountRepo) FindOne(ctx context.Context, id string) (models.Account, error) {
 	ret := _m.Called(ctx, id)
@@ -195,6 +252,226 @@ func (_c *MockAccountRepo_FindOne_Call) RunAndReturn(run func(context.Context, s
 	return _c
 }
 
+// FindPage provides a mock function with given fields: ctx, filter
+func (_m *MockAccountRepo) FindPage(ctx context.Context, filter models.AccountFilter) (models.AccountPage, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPage")
+	}
+
+	var r0 models.AccountPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.AccountFilter) (models.AccountPage, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.AccountFilter) models.AccountPage); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(models.AccountPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.AccountFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAccountRepo_FindPage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindPage'
+type MockAccountRepo_FindPage_Call struct {
+	*mock.Call
+}
+
+// FindPage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter models.AccountFilter
+func (_e *MockAccountRepo_Expecter) FindPage(ctx interface{}, filter interface{}) *MockAccountRepo_FindPage_Call {
+	return &MockAccountRepo_FindPage_Call{Call: _e.mock.On("FindPage", ctx, filter)}
+}
+
+func (_c *MockAccountRepo_FindPage_Call) Run(run func(ctx context.Context, filter models.AccountFilter)) *MockAccountRepo_FindPage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.AccountFilter))
+	})
+	return _c
+}
+
+func (_c *MockAccountRepo_FindPage_Call) Return(_a0 models.AccountPage, _a1 error) *MockAccountRepo_FindPage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAccountRepo_FindPage_Call) RunAndReturn(run func(context.Context, models.AccountFilter) (models.AccountPage, error)) *MockAccountRepo_FindPage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindStatusChanges provides a mock function with given fields: ctx, id
+func (_m *MockAccountRepo) FindStatusChanges(ctx context.Context, id string) ([]models.AccountStatusChange, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindStatusChanges")
+	}
+
+	var r0 []models.AccountStatusChange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.AccountStatusChange, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.AccountStatusChange); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AccountStatusChange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAccountRepo_FindStatusChanges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindStatusChanges'
+type MockAccountRepo_FindStatusChanges_Call struct {
+	*mock.Call
+}
+
+// FindStatusChanges is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockAccountRepo_Expecter) FindStatusChanges(ctx interface{}, id interface{}) *MockAccountRepo_FindStatusChanges_Call {
+	return &MockAccountRepo_FindStatusChanges_Call{Call: _e.mock.On("FindStatusChanges", ctx, id)}
+}
+
+func (_c *MockAccountRepo_FindStatusChanges_Call) Run(run func(ctx context.Context, id string)) *MockAccountRepo_FindStatusChanges_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockAccountRepo_FindStatusChanges_Call) Return(_a0 []models.AccountStatusChange, _a1 error) *MockAccountRepo_FindStatusChanges_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAccountRepo_FindStatusChanges_Call) RunAndReturn(run func(context.Context, string) ([]models.AccountStatusChange, error)) *MockAccountRepo_FindStatusChanges_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetStatus provides a mock function with given fields: ctx, change
+func (_m *MockAccountRepo) SetStatus(ctx context.Context, change models.AccountStatusChange) (string, error) {
+	ret := _m.Called(ctx, change)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetStatus")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.AccountStatusChange) (string, error)); ok {
+		return rf(ctx, change)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.AccountStatusChange) string); ok {
+		r0 = rf(ctx, change)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.AccountStatusChange) error); ok {
+		r1 = rf(ctx, change)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockAccountRepo_SetStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetStatus'
+type MockAccountRepo_SetStatus_Call struct {
+	*mock.Call
+}
+
+// SetStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - change models.AccountStatusChange
+func (_e *MockAccountRepo_Expecter) SetStatus(ctx interface{}, change interface{}) *MockAccountRepo_SetStatus_Call {
+	return &MockAccountRepo_SetStatus_Call{Call: _e.mock.On("SetStatus", ctx, change)}
+}
+
+func (_c *MockAccountRepo_SetStatus_Call) Run(run func(ctx context.Context, change models.AccountStatusChange)) *MockAccountRepo_SetStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.AccountStatusChange))
+	})
+	return _c
+}
+
+func (_c *MockAccountRepo_SetStatus_Call) Return(_a0 string, _a1 error) *MockAccountRepo_SetStatus_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockAccountRepo_SetStatus_Call) RunAndReturn(run func(context.Context, models.AccountStatusChange) (string, error)) *MockAccountRepo_SetStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function with given fields: ctx, acc
+func (_m *MockAccountRepo) Update(ctx context.Context, acc models.Account) error {
+	ret := _m.Called(ctx, acc)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Account) error); ok {
+		r0 = rf(ctx, acc)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockAccountRepo_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockAccountRepo_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - acc models.Account
+func (_e *MockAccountRepo_Expecter) Update(ctx interface{}, acc interface{}) *MockAccountRepo_Update_Call {
+	return &MockAccountRepo_Update_Call{Call: _e.mock.On("Update", ctx, acc)}
+}
+
+func (_c *MockAccountRepo_Update_Call) Run(run func(ctx context.Context, acc models.Account)) *MockAccountRepo_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.Account))
+	})
+	return _c
+}
+
+func (_c *MockAccountRepo_Update_Call) Return(_a0 error) *MockAccountRepo_Update_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockAccountRepo_Update_Call) RunAndReturn(run func(context.Context, models.Account) error) *MockAccountRepo_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockAccountRepo creates a new instance of MockAccountRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockAccountRepo(t interface {
@@ -3,8 +3,14 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/gopay/internal/models"
+	"github.com/lib/pq"
 )
 
 const (
@@ -16,21 +22,75 @@ const (
 	`
 
 	findAllAccsQ = `
-	SELECT account_id, name, last_name 
+	SELECT ` + accColumns + `
 	FROM accounts
 	`
 
+	findPageAccsQ = `
+	SELECT ` + accColumns + `
+	FROM accounts
+	WHERE TRUE
+	`
+
 	findOneAccQ = `
-	SELECT account_id, name, last_name
+	SELECT ` + accColumns + `
+	FROM accounts
+	WHERE account_id = $1
+	`
+
+	updateAccQ = `
+	UPDATE accounts
+	SET name = $2, last_name = $3, email = $4, phone = $5, display_name = $6, date_of_birth = $7,
+	address_line1 = $8, address_line2 = $9, city = $10, region = $11, postal_code = $12, country = $13,
+	updated_at = $14, handle = $15, default_privacy = $16
+	WHERE account_id = $1
+	`
+
+	findAccByHandleQ = `
+	SELECT ` + accColumns + `
 	FROM accounts
+	WHERE lower(handle) COLLATE "C" = lower($1)
+	AND handle <> ''
+	`
+
+	accColumns = `account_id, name, last_name, status, handle, email, phone, display_name, date_of_birth,
+	address_line1, address_line2, city, region, postal_code, country, default_privacy, created_at, updated_at`
+
+	accEmailKey  = "accounts_email_key"
+	accPhoneKey  = "accounts_phone_key"
+	accHandleKey = "accounts_handle_key"
+
+	setAccStatusQ = `
+	UPDATE accounts
+	SET status = $3
+	WHERE account_id = $1
+	AND status = $2
+	`
+
+	createAccStatusChangeQ = `
+	INSERT INTO account_status_changes
+	(account_id, from_status, to_status, reason, payout_to, amount, created_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	RETURNING change_id
+	`
+
+	findAccStatusChangesQ = `
+	SELECT change_id, account_id, from_status, to_status, reason, payout_to, amount, created_at
+	FROM account_status_changes
 	WHERE account_id = $1
+	ORDER BY created_at, change_id
 	`
 )
 
 type AccountRepoPsql interface {
 	FindAll(ctx context.Context) ([]models.Account, error)
+	FindPage(ctx context.Context, filter models.AccountFilter) (models.AccountPage, error)
 	FindOne(ctx context.Context, id string) (models.Account, error)
+	FindByHandle(ctx context.Context, handle string) (models.Account, error)
 	Create(ctx context.Context, name string, lastname string) (string, error)
+	Update(ctx context.Context, acc models.Account) error
+	SetStatus(ctx context.Context, change models.AccountStatusChange) (string, error)
+	FindStatusChanges(ctx context.Context, id string) ([]models.AccountStatusChange, error)
 }
 
 var _ AccountRepoPsql = (*accountRepoPsqlImpl)(nil)
@@ -45,31 +105,146 @@ func NewAccountRepoPsql(db *sql.DB) *accountRepoPsqlImpl {
 	}
 }
 
-func (r *accountRepoPsqlImpl) FindAll(_ context.Context) ([]models.Account, error) {
+func (r *accountRepoPsqlImpl) FindAll(ctx context.Context) ([]models.Account, error) {
 	accs := []models.Account{}
 
-	rows, err := r.psql.Query(findAllAccsQ)
+	rows, err := conn(ctx, r.psql).QueryContext(ctx, findAllAccsQ)
 	if err != nil {
 		return accs, err
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		acc := models.Account{}
-		rows.Scan(&acc.AccountId, &acc.Name, &acc.LastName)
+		acc, err := scanAccount(rows)
+		if err != nil {
+			return []models.Account{}, err
+		}
+		accs = append(accs, acc)
+	}
+
+	return accs, rows.Err()
+}
+
+// FindPage returns up to filter.Limit accounts matching filter.Query, starting after
+// filter.Cursor. Names compare lowercased, byte by byte, and ties are broken by id.
+// NextCursor is empty on the last page.
+func (r *accountRepoPsqlImpl) FindPage(ctx context.Context, filter models.AccountFilter) (models.AccountPage, error) {
+	query, args, err := buildFindAccountPageQuery(filter)
+	if err != nil {
+		return models.AccountPage{}, err
+	}
+
+	rows, err := conn(ctx, r.psql).QueryContext(ctx, query, args...)
+	if err != nil {
+		return models.AccountPage{}, err
+	}
+	defer rows.Close()
+
+	accs := []models.Account{}
+	for rows.Next() {
+		acc, err := scanAccount(rows)
+		if err != nil {
+			return models.AccountPage{}, err
+		}
 		accs = append(accs, acc)
 	}
+	if err = rows.Err(); err != nil {
+		return models.AccountPage{}, err
+	}
+
+	return newAccountPage(accs, filter), nil
+}
+
+// buildFindAccountPageQuery appends the search terms, the cursor and the ordering to
+// findPageAccsQ. Names are compared as lower(...) COLLATE "C" so that the prefix search
+// and the ordering both use the lower() indexes and agree with accountRepoImpl.
+func buildFindAccountPageQuery(filter models.AccountFilter) (string, []any, error) {
+	query := findPageAccsQ
+	args := []any{}
+
+	where := func(cond string, values ...any) {
+		for _, v := range values {
+			args = append(args, v)
+			cond = strings.Replace(cond, "?", fmt.Sprintf("$%d", len(args)), 1)
+		}
+		query += "\tAND " + cond + "\n"
+	}
+
+	for _, term := range strings.Fields(strings.ToLower(filter.Query)) {
+		prefix := likeEscaper.Replace(term) + "%"
+		where(`(lower(name) COLLATE "C" LIKE ? OR lower(last_name) COLLATE "C" LIKE ? OR (handle <> '' AND lower(handle) COLLATE "C" LIKE ?))`, prefix, prefix, prefix)
+	}
+
+	key := "created_at"
+	switch filter.Sort {
+	case models.SortByName:
+		key = `lower(name) COLLATE "C"`
+	case models.SortByLastName:
+		key = `lower(last_name) COLLATE "C"`
+	}
+
+	order := "ASC"
+	op := ">"
+	if filter.Order == models.SortDesc {
+		order = "DESC"
+		op = "<"
+	}
+
+	if filter.Cursor != "" {
+		var (
+			cursorKey any
+			cursorId  string
+			err       error
+		)
+		if filter.Sort == models.SortByCreatedAt {
+			cursorKey, cursorId, err = decodeCursor(filter.Cursor)
+		} else {
+			cursorKey, cursorId, err = decodeKeyCursor(filter.Cursor)
+		}
+		if err != nil {
+			return "", nil, err
+		}
+
+		if uuid.Validate(cursorId) != nil {
+			return "", nil, ErrInvalidCursor
+		}
+
+		where(fmt.Sprintf("(%s, account_id) %s (?, ?)", key, op), cursorKey, cursorId)
+	}
+
+	query += fmt.Sprintf("\tORDER BY %s %s, account_id %s\n", key, order, order)
+
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit+1)
+		query += fmt.Sprintf("\tLIMIT $%d\n", len(args))
+	}
 
-	return accs, nil
+	return query, args, nil
 }
 
 func (r *accountRepoPsqlImpl) FindOne(ctx context.Context, id string) (models.Account, error) {
-	acc := models.Account{}
+	// A malformed id cannot match any account, and querying with it would fail the
+	// surrounding transaction.
+	if uuid.Validate(id) != nil {
+		return models.Account{}, ErrAccountNotFound
+	}
+
+	acc, err := scanAccount(conn(ctx, r.psql).QueryRowContext(ctx, findOneAccQ, id))
+	if err == sql.ErrNoRows {
+		return models.Account{}, ErrAccountNotFound
+	}
+	if err != nil {
+		return models.Account{}, err
+	}
 
-	row := r.psql.QueryRow(findOneAccQ, id)
-	err := row.Scan(&acc.AccountId, &acc.Name, &acc.LastName)
+	return acc, nil
+}
+
+// FindByHandle finds the account whose handle is handle, regardless of case.
+func (r *accountRepoPsqlImpl) FindByHandle(ctx context.Context, handle string) (models.Account, error) {
+	acc, err := scanAccount(conn(ctx, r.psql).QueryRowContext(ctx, findAccByHandleQ, handle))
 	if err == sql.ErrNoRows {
-		return acc, ErrAccountNotFound
+		return models.Account{}, ErrAccountNotFound
 	}
 	if err != nil {
 		return models.Account{}, err
@@ -85,7 +260,7 @@ func (r *accountRepoPsqlImpl) Create(ctx context.Context, name string, lastname
 
 	var id string
 
-	row := r.psql.QueryRow(createAccQ, name, lastname)
+	row := conn(ctx, r.psql).QueryRowContext(ctx, createAccQ, name, lastname)
 	err := row.Scan(&id)
 	if err != nil {
 		return "", err
@@ -93,3 +268,136 @@ func (r *accountRepoPsqlImpl) Create(ctx context.Context, name string, lastname
 
 	return id, nil
 }
+
+// Update writes the profile fields of acc and its UpdatedAt. Its status and CreatedAt
+// are left alone. Handles and emails are unique regardless of case, phones as stored.
+func (r *accountRepoPsqlImpl) Update(ctx context.Context, acc models.Account) error {
+	if acc.Name == "" || acc.LastName == "" {
+		return ErrMissingParams
+	}
+
+	if uuid.Validate(acc.AccountId) != nil {
+		return ErrAccountNotFound
+	}
+
+	var dateOfBirth sql.NullString
+	if acc.DateOfBirth != "" {
+		dateOfBirth = sql.NullString{String: acc.DateOfBirth, Valid: true}
+	}
+
+	address := models.Address{}
+	if acc.Address != nil {
+		address = *acc.Address
+	}
+
+	res, err := conn(ctx, r.psql).ExecContext(ctx, updateAccQ, acc.AccountId, acc.Name, acc.LastName, acc.Email, acc.Phone, acc.DisplayName, dateOfBirth,
+		address.Line1, address.Line2, address.City, address.Region, address.PostalCode, address.Country, acc.UpdatedAt, acc.Handle, acc.DefaultPrivacy)
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" { // unique_violation
+		switch pqErr.Constraint {
+		case accEmailKey:
+			return ErrEmailTaken
+		case accPhoneKey:
+			return ErrPhoneTaken
+		case accHandleKey:
+			return ErrHandleTaken
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrAccountNotFound
+	}
+
+	return nil
+}
+
+// SetStatus moves the account from change.From to change.To and appends change to its
+// audit trail. It fails with ErrAccountStatusChanged if the account is no longer in
+// change.From, so concurrent changes cannot both apply. It must run inside a unit of
+// work so that the status and its audit entry are written together.
+func (r *accountRepoPsqlImpl) SetStatus(ctx context.Context, change models.AccountStatusChange) (string, error) {
+	if !inTx(ctx) {
+		return "", ErrNoUnitOfWork
+	}
+
+	res, err := conn(ctx, r.psql).ExecContext(ctx, setAccStatusQ, change.AccountId, change.From, change.To)
+	if err != nil {
+		return "", err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return "", err
+	}
+
+	if rowsAffected == 0 {
+		_, err = r.FindOne(ctx, change.AccountId)
+		if err != nil {
+			return "", err
+		}
+		return "", ErrAccountStatusChanged
+	}
+
+	var id string
+
+	row := conn(ctx, r.psql).QueryRowContext(ctx, createAccStatusChangeQ, change.AccountId, change.From, change.To, change.Reason, change.PayoutTo, change.Amount, change.CreatedAt)
+	err = row.Scan(&id)
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// FindStatusChanges returns the audit trail of the account, oldest first.
+func (r *accountRepoPsqlImpl) FindStatusChanges(ctx context.Context, id string) ([]models.AccountStatusChange, error) {
+	changes := []models.AccountStatusChange{}
+
+	rows, err := conn(ctx, r.psql).QueryContext(ctx, findAccStatusChangesQ, id)
+	if err != nil {
+		return changes, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		c := models.AccountStatusChange{}
+		err := rows.Scan(&c.ChangeId, &c.AccountId, &c.From, &c.To, &c.Reason, &c.PayoutTo, &c.Amount, &c.CreatedAt)
+		if err != nil {
+			return []models.AccountStatusChange{}, err
+		}
+		changes = append(changes, c)
+	}
+
+	return changes, rows.Err()
+}
+
+func scanAccount(row scanner) (models.Account, error) {
+	acc := models.Account{}
+	address := models.Address{}
+	var dateOfBirth sql.NullTime
+
+	err := row.Scan(&acc.AccountId, &acc.Name, &acc.LastName, &acc.Status, &acc.Handle, &acc.Email, &acc.Phone, &acc.DisplayName, &dateOfBirth,
+		&address.Line1, &address.Line2, &address.City, &address.Region, &address.PostalCode, &address.Country, &acc.DefaultPrivacy, &acc.CreatedAt, &acc.UpdatedAt)
+	if err != nil {
+		return models.Account{}, err
+	}
+
+	if dateOfBirth.Valid {
+		acc.DateOfBirth = dateOfBirth.Time.Format(time.DateOnly)
+	}
+
+	if address != (models.Address{}) {
+		acc.Address = &address
+	}
+
+	return acc, nil
+}
@@ -2,7 +2,9 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/gopay/internal/models"
 	"github.com/stretchr/testify/assert"
@@ -10,6 +12,7 @@ import (
 
 func TestAccount_Create(t *testing.T) {
 	id := "0003"
+	now := time.Now()
 	idGenerator := func() string {
 		return id
 	}
@@ -45,9 +48,13 @@ func TestAccount_Create(t *testing.T) {
 			},
 
 			want: models.Account{
-				AccountId: id,
-				Name:      "Caio",
-				LastName:  "Henrique",
+				AccountId:      id,
+				Name:           "Caio",
+				LastName:       "Henrique",
+				Status:         models.AccountActive,
+				DefaultPrivacy: models.PrivacyPrivate,
+				CreatedAt:      now,
+				UpdatedAt:      now,
 			},
 			wantErr: nil,
 		},
@@ -120,6 +127,7 @@ func TestAccount_Create(t *testing.T) {
 		tcase := tcase
 		t.Run(name, func(t *testing.T) {
 			repo := setup(t, tcase.given.data, idGenerator)
+			repo.now = func() time.Time { return now }
 
 			id, err := repo.Create(tcase.given.ctx, tcase.given.name, tcase.given.lastname)
 
@@ -206,6 +214,105 @@ func TestAccount_FindAll(t *testing.T) {
 	}
 }
 
+func TestAccount_FindPage(t *testing.T) {
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	data := map[string]models.Account{
+		"0001": {AccountId: "0001", Name: "Shankar", LastName: "Nakai", CreatedAt: start},
+		"0002": {AccountId: "0002", Name: "Jessica", LastName: "Lourenco", CreatedAt: start.Add(time.Hour)},
+		"0003": {AccountId: "0003", Name: "caio", LastName: "Henrique", CreatedAt: start.Add(2 * time.Hour)},
+		"0004": {AccountId: "0004", Name: "Caio", LastName: "Santos", CreatedAt: start.Add(2 * time.Hour)},
+		"0005": {AccountId: "0005", Name: "Henrique", LastName: "Nakamura", Handle: "rick", CreatedAt: start.Add(3 * time.Hour)},
+	}
+
+	ids := func(accs []models.Account) []string {
+		result := []string{}
+		for _, acc := range accs {
+			result = append(result, acc.AccountId)
+		}
+		return result
+	}
+
+	scenarios := map[string]struct {
+		filter models.AccountFilter
+		want   []string
+	}{
+		"oldest-first": {
+			filter: models.AccountFilter{Sort: models.SortByCreatedAt},
+			want:   []string{"0001", "0002", "0003", "0004", "0005"},
+		},
+		"by-name-ignores-case": {
+			filter: models.AccountFilter{Sort: models.SortByName},
+			want:   []string{"0003", "0004", "0005", "0002", "0001"},
+		},
+		"by-last-name-descending": {
+			filter: models.AccountFilter{Sort: models.SortByLastName, Order: models.SortDesc},
+			want:   []string{"0004", "0005", "0001", "0002", "0003"},
+		},
+		"prefix-of-name-or-last-name": {
+			filter: models.AccountFilter{Sort: models.SortByName, Query: "HEN"},
+			want:   []string{"0003", "0005"},
+		},
+		"every-term-must-match": {
+			filter: models.AccountFilter{Sort: models.SortByName, Query: "caio hen"},
+			want:   []string{"0003"},
+		},
+		"prefix-of-handle": {
+			filter: models.AccountFilter{Sort: models.SortByName, Query: "RI"},
+			want:   []string{"0005"},
+		},
+		"prefix-only": {
+			filter: models.AccountFilter{Sort: models.SortByName, Query: "kai"},
+			want:   []string{},
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			repo := setup(t, data, nil)
+
+			page, err := repo.FindPage(context.Background(), tcase.filter)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tcase.want, ids(page.Accounts))
+			assert.Empty(t, page.NextCursor)
+		})
+	}
+
+	for _, sortBy := range []models.AccountSort{models.SortByName, models.SortByCreatedAt} {
+		sortBy := sortBy
+		t.Run("walks-pages-with-cursor-by-"+string(sortBy), func(t *testing.T) {
+			repo := setup(t, data, nil)
+			filter := models.AccountFilter{Limit: 2, Sort: sortBy, Order: models.SortDesc}
+
+			all, err := repo.FindPage(context.Background(), models.AccountFilter{Sort: sortBy, Order: models.SortDesc})
+			assert.NoError(t, err)
+
+			walked := []string{}
+			for {
+				page, err := repo.FindPage(context.Background(), filter)
+				assert.NoError(t, err)
+				assert.LessOrEqual(t, len(page.Accounts), 2)
+				walked = append(walked, ids(page.Accounts)...)
+				if page.NextCursor == "" {
+					break
+				}
+				filter.Cursor = page.NextCursor
+			}
+
+			assert.Equal(t, ids(all.Accounts), walked)
+		})
+	}
+
+	t.Run("invalid-cursor", func(t *testing.T) {
+		repo := setup(t, data, nil)
+
+		_, err := repo.FindPage(context.Background(), models.AccountFilter{Sort: models.SortByName, Cursor: "not a cursor"})
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+	})
+}
+
 func TestAccount_FindOne(t *testing.T) {
 	type args struct {
 		ctx  context.Context
@@ -291,3 +398,174 @@ func setup(_ *testing.T, initialData map[string]models.Account, idGenerator func
 	repo.idGenerator = idGenerator
 	return repo
 }
+
+func TestAccount_SetStatus(t *testing.T) {
+	now := time.Now()
+	ctx := context.Background()
+	errBoom := errors.New("boom")
+
+	repo := setup(t, map[string]models.Account{
+		"0001": {AccountId: "0001", Name: "Shankar", LastName: "Nakai", Status: models.AccountActive},
+	}, func() string { return "c-1" })
+	repo.changes = map[string][]models.AccountStatusChange{}
+
+	freeze := models.AccountStatusChange{AccountId: "0001", From: models.AccountActive, To: models.AccountFrozen, Reason: "fraud", CreatedAt: now}
+
+	err := NewUnitOfWork().Do(ctx, func(ctx context.Context) error {
+		_, err := repo.SetStatus(ctx, freeze)
+		assert.NoError(t, err)
+		return errBoom
+	})
+	assert.ErrorIs(t, err, errBoom)
+
+	acc, err := repo.FindOne(ctx, "0001")
+	assert.NoError(t, err)
+	assert.Equal(t, models.AccountActive, acc.Status)
+
+	changes, err := repo.FindStatusChanges(ctx, "0001")
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+
+	id, err := repo.SetStatus(ctx, freeze)
+	assert.NoError(t, err)
+	assert.Equal(t, "c-1", id)
+
+	acc, err = repo.FindOne(ctx, "0001")
+	assert.NoError(t, err)
+	assert.Equal(t, models.AccountFrozen, acc.Status)
+
+	freeze.ChangeId = "c-1"
+	changes, err = repo.FindStatusChanges(ctx, "0001")
+	assert.NoError(t, err)
+	assert.Equal(t, []models.AccountStatusChange{freeze}, changes)
+
+	_, err = repo.SetStatus(ctx, freeze)
+	assert.ErrorIs(t, err, ErrAccountStatusChanged)
+
+	_, err = repo.SetStatus(ctx, models.AccountStatusChange{AccountId: "0002", From: models.AccountActive, To: models.AccountFrozen})
+	assert.ErrorIs(t, err, ErrAccountNotFound)
+}
+
+func TestAccount_Update(t *testing.T) {
+	created := time.Now().Add(-time.Hour)
+	now := time.Now()
+	ctx := context.Background()
+
+	data := func() map[string]models.Account {
+		return map[string]models.Account{
+			"0001": {AccountId: "0001", Name: "Shankar", LastName: "Nakai", Status: models.AccountFrozen, CreatedAt: created, UpdatedAt: created},
+			"0002": {AccountId: "0002", Name: "Jessica", LastName: "Lourenco", Handle: "Jess", Email: "jessica@example.com", Phone: "+5511987654321"},
+		}
+	}
+
+	var scenarios = map[string]struct {
+		given   models.Account
+		want    models.Account
+		wantErr error
+	}{
+		"happy-path": {
+			given: models.Account{
+				AccountId:   "0001",
+				Name:        "Shankar",
+				LastName:    "Nakai",
+				Email:       "shankar@example.com",
+				Phone:       "+5511912345678",
+				DisplayName: "shankar",
+				Address:     &models.Address{Line1: "Rua A, 1", City: "Sao Paulo", PostalCode: "01000-000", Country: "BR"},
+				UpdatedAt:   now,
+			},
+			want: models.Account{
+				AccountId:   "0001",
+				Name:        "Shankar",
+				LastName:    "Nakai",
+				Status:      models.AccountFrozen,
+				Email:       "shankar@example.com",
+				Phone:       "+5511912345678",
+				DisplayName: "shankar",
+				Address:     &models.Address{Line1: "Rua A, 1", City: "Sao Paulo", PostalCode: "01000-000", Country: "BR"},
+				CreatedAt:   created,
+				UpdatedAt:   now,
+			},
+			wantErr: nil,
+		},
+		"email-taken-regardless-of-case": {
+			given:   models.Account{AccountId: "0001", Name: "Shankar", LastName: "Nakai", Email: "Jessica@Example.com"},
+			wantErr: ErrEmailTaken,
+		},
+		"handle-taken-regardless-of-case": {
+			given:   models.Account{AccountId: "0001", Name: "Shankar", LastName: "Nakai", Handle: "jess"},
+			wantErr: ErrHandleTaken,
+		},
+		"phone-taken": {
+			given:   models.Account{AccountId: "0001", Name: "Shankar", LastName: "Nakai", Phone: "+5511987654321"},
+			wantErr: ErrPhoneTaken,
+		},
+		"own-email-is-not-taken": {
+			given: models.Account{AccountId: "0002", Name: "Jessica", LastName: "Lourenco", Handle: "JESS", Email: "jessica@example.com", Phone: "+5511987654321", UpdatedAt: now},
+			want:  models.Account{AccountId: "0002", Name: "Jessica", LastName: "Lourenco", Handle: "JESS", Email: "jessica@example.com", Phone: "+5511987654321", UpdatedAt: now},
+		},
+		"missing-name": {
+			given:   models.Account{AccountId: "0001", LastName: "Nakai"},
+			wantErr: ErrMissingParams,
+		},
+		"not-found": {
+			given:   models.Account{AccountId: "0003", Name: "Caio", LastName: "Henrique"},
+			wantErr: ErrAccountNotFound,
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			repo := setup(t, data(), nil)
+
+			err := repo.Update(ctx, tcase.given)
+
+			if tcase.wantErr == nil {
+				assert.NoError(t, err)
+				acc, err := repo.FindOne(ctx, tcase.given.AccountId)
+				assert.NoError(t, err)
+				assert.Equal(t, tcase.want, acc)
+			} else {
+				assert.ErrorIs(t, err, tcase.wantErr)
+			}
+		})
+	}
+}
+
+func TestAccount_FindByHandle(t *testing.T) {
+	ctx := context.Background()
+	repo := setup(t, map[string]models.Account{
+		"0001": {AccountId: "0001", Name: "Shankar", LastName: "Nakai"},
+		"0002": {AccountId: "0002", Name: "Jessica", LastName: "Lourenco", Handle: "Jess"},
+	}, nil)
+
+	acc, err := repo.FindByHandle(ctx, "JESS")
+	assert.NoError(t, err)
+	assert.Equal(t, "0002", acc.AccountId)
+
+	_, err = repo.FindByHandle(ctx, "shankar")
+	assert.ErrorIs(t, err, ErrAccountNotFound)
+
+	_, err = repo.FindByHandle(ctx, "")
+	assert.ErrorIs(t, err, ErrAccountNotFound)
+}
+
+func TestAccount_UpdateRollback(t *testing.T) {
+	ctx := context.Background()
+	errBoom := errors.New("boom")
+	original := models.Account{AccountId: "0001", Name: "Shankar", LastName: "Nakai"}
+
+	repo := setup(t, map[string]models.Account{"0001": original}, nil)
+
+	err := NewUnitOfWork().Do(ctx, func(ctx context.Context) error {
+		err := repo.Update(ctx, models.Account{AccountId: "0001", Name: "Shankar", LastName: "Nakai", Email: "shankar@example.com"})
+		assert.NoError(t, err)
+		return errBoom
+	})
+	assert.ErrorIs(t, err, errBoom)
+
+	acc, err := repo.FindOne(ctx, "0001")
+	assert.NoError(t, err)
+	assert.Equal(t, original, acc)
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gopay/internal/models"
+)
+
+var (
+	ErrContactNotFound = errors.New("contact not found")
+	ErrContactExists   = errors.New("contact is already saved")
+)
+
+type ContactRepo interface {
+	Add(ctx context.Context, contact models.Contact) error
+	Remove(ctx context.Context, owner string, accId string) error
+	SetFavorite(ctx context.Context, owner string, accId string, favorite bool, at time.Time) (models.Contact, error)
+	FindByOwner(ctx context.Context, owner string) ([]models.Contact, error)
+	FindOwners(ctx context.Context, accId string) ([]string, error)
+}
+
+var _ ContactRepo = (*contactRepoImpl)(nil)
+
+type contactKey struct {
+	owner string
+	accId string
+}
+
+type contactRepoImpl struct {
+	mu       sync.RWMutex
+	contacts map[contactKey]models.Contact
+}
+
+func NewContactRepo() *contactRepoImpl {
+	return &contactRepoImpl{
+		contacts: make(map[contactKey]models.Contact),
+	}
+}
+
+func (r *contactRepoImpl) Add(ctx context.Context, contact models.Contact) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := contactKey{contact.Owner, contact.AccountId}
+	if _, found := r.contacts[key]; found {
+		return ErrContactExists
+	}
+
+	contact.UpdatedAt = contact.CreatedAt
+	r.contacts[key] = contact
+	onRollback(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.contacts, key)
+	})
+
+	return nil
+}
+
+func (r *contactRepoImpl) Remove(ctx context.Context, owner string, accId string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := contactKey{owner, accId}
+	contact, found := r.contacts[key]
+	if !found {
+		return ErrContactNotFound
+	}
+
+	delete(r.contacts, key)
+	onRollback(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.contacts[key] = contact
+	})
+
+	return nil
+}
+
+func (r *contactRepoImpl) SetFavorite(ctx context.Context, owner string, accId string, favorite bool, at time.Time) (models.Contact, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := contactKey{owner, accId}
+	previous, found := r.contacts[key]
+	if !found {
+		return models.Contact{}, ErrContactNotFound
+	}
+
+	contact := previous
+	contact.Favorite = favorite
+	contact.UpdatedAt = at
+	r.contacts[key] = contact
+	onRollback(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.contacts[key] = previous
+	})
+
+	return contact, nil
+}
+
+// FindByOwner returns owner's saved contacts, oldest first.
+func (r *contactRepoImpl) FindByOwner(_ context.Context, owner string) ([]models.Contact, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	contacts := []models.Contact{}
+	for key, c := range r.contacts {
+		if key.owner == owner {
+			contacts = append(contacts, c)
+		}
+	}
+
+	sort.Slice(contacts, func(i, j int) bool {
+		if contacts[i].CreatedAt.Equal(contacts[j].CreatedAt) {
+			return contacts[i].AccountId < contacts[j].AccountId
+		}
+		return contacts[i].CreatedAt.Before(contacts[j].CreatedAt)
+	})
+
+	return contacts, nil
+}
+
+// FindOwners returns the accounts that saved accId as a contact.
+func (r *contactRepoImpl) FindOwners(_ context.Context, accId string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	owners := []string{}
+	for key := range r.contacts {
+		if key.accId == accId {
+			owners = append(owners, key.owner)
+		}
+	}
+	sort.Strings(owners)
+
+	return owners, nil
+}
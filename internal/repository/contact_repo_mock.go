@@ -0,0 +1,312 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package repository
+
+import (
+	context "context"
+
+	models "github.com/gopay/internal/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockContactRepo is an autogenerated mock type for the ContactRepo type
+type MockContactRepo struct {
+	mock.Mock
+}
+
+type MockContactRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockContactRepo) EXPECT() *MockContactRepo_Expecter {
+	return &MockContactRepo_Expecter{mock: &_m.Mock}
+}
+
+// Add provides a mock function with given fields: ctx, contact
+func (_m *MockContactRepo) Add(ctx context.Context, contact models.Contact) error {
+	ret := _m.Called(ctx, contact)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Add")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Contact) error); ok {
+		r0 = rf(ctx, contact)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockContactRepo_Add_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Add'
+type MockContactRepo_Add_Call struct {
+	*mock.Call
+}
+
+// Add is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contact models.Contact
+func (_e *MockContactRepo_Expecter) Add(ctx interface{}, contact interface{}) *MockContactRepo_Add_Call {
+	return &MockContactRepo_Add_Call{Call: _e.mock.On("Add", ctx, contact)}
+}
+
+func (_c *MockContactRepo_Add_Call) Run(run func(ctx context.Context, contact models.Contact)) *MockContactRepo_Add_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.Contact))
+	})
+	return _c
+}
+
+func (_c *MockContactRepo_Add_Call) Return(_a0 error) *MockContactRepo_Add_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockContactRepo_Add_Call) RunAndReturn(run func(context.Context, models.Contact) error) *MockContactRepo_Add_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByOwner provides a mock function with given fields: ctx, owner
+func (_m *MockContactRepo) FindByOwner(ctx context.Context, owner string) ([]models.Contact, error) {
+	ret := _m.Called(ctx, owner)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByOwner")
+	}
+
+	var r0 []models.Contact
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.Contact, error)); ok {
+		return rf(ctx, owner)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.Contact); ok {
+		r0 = rf(ctx, owner)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Contact)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, owner)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockContactRepo_FindByOwner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByOwner'
+type MockContactRepo_FindByOwner_Call struct {
+	*mock.Call
+}
+
+// FindByOwner is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+func (_e *MockContactRepo_Expecter) FindByOwner(ctx interface{}, owner interface{}) *MockContactRepo_FindByOwner_Call {
+	return &MockContactRepo_FindByOwner_Call{Call: _e.mock.On("FindByOwner", ctx, owner)}
+}
+
+func (_c *MockContactRepo_FindByOwner_Call) Run(run func(ctx context.Context, owner string)) *MockContactRepo_FindByOwner_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockContactRepo_FindByOwner_Call) Return(_a0 []models.Contact, _a1 error) *MockContactRepo_FindByOwner_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockContactRepo_FindByOwner_Call) RunAndReturn(run func(context.Context, string) ([]models.Contact, error)) *MockContactRepo_FindByOwner_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindOwners provides a mock function with given fields: ctx, accId
+func (_m *MockContactRepo) FindOwners(ctx context.Context, accId string) ([]string, error) {
+	ret := _m.Called(ctx, accId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindOwners")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, accId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, accId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, accId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockContactRepo_FindOwners_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindOwners'
+type MockContactRepo_FindOwners_Call struct {
+	*mock.Call
+}
+
+// FindOwners is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accId string
+func (_e *MockContactRepo_Expecter) FindOwners(ctx interface{}, accId interface{}) *MockContactRepo_FindOwners_Call {
+	return &MockContactRepo_FindOwners_Call{Call: _e.mock.On("FindOwners", ctx, accId)}
+}
+
+func (_c *MockContactRepo_FindOwners_Call) Run(run func(ctx context.Context, accId string)) *MockContactRepo_FindOwners_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockContactRepo_FindOwners_Call) Return(_a0 []string, _a1 error) *MockContactRepo_FindOwners_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockContactRepo_FindOwners_Call) RunAndReturn(run func(context.Context, string) ([]string, error)) *MockContactRepo_FindOwners_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Remove provides a mock function with given fields: ctx, owner, accId
+func (_m *MockContactRepo) Remove(ctx context.Context, owner string, accId string) error {
+	ret := _m.Called(ctx, owner, accId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Remove")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, owner, accId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockContactRepo_Remove_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Remove'
+type MockContactRepo_Remove_Call struct {
+	*mock.Call
+}
+
+// Remove is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - accId string
+func (_e *MockContactRepo_Expecter) Remove(ctx interface{}, owner interface{}, accId interface{}) *MockContactRepo_Remove_Call {
+	return &MockContactRepo_Remove_Call{Call: _e.mock.On("Remove", ctx, owner, accId)}
+}
+
+func (_c *MockContactRepo_Remove_Call) Run(run func(ctx context.Context, owner string, accId string)) *MockContactRepo_Remove_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockContactRepo_Remove_Call) Return(_a0 error) *MockContactRepo_Remove_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockContactRepo_Remove_Call) RunAndReturn(run func(context.Context, string, string) error) *MockContactRepo_Remove_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetFavorite provides a mock function with given fields: ctx, owner, accId, favorite, at
+func (_m *MockContactRepo) SetFavorite(ctx context.Context, owner string, accId string, favorite bool, at time.Time) (models.Contact, error) {
+	ret := _m.Called(ctx, owner, accId, favorite, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetFavorite")
+	}
+
+	var r0 models.Contact
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool, time.Time) (models.Contact, error)); ok {
+		return rf(ctx, owner, accId, favorite, at)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, bool, time.Time) models.Contact); ok {
+		r0 = rf(ctx, owner, accId, favorite, at)
+	} else {
+		r0 = ret.Get(0).(models.Contact)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, bool, time.Time) error); ok {
+		r1 = rf(ctx, owner, accId, favorite, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockContactRepo_SetFavorite_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetFavorite'
+type MockContactRepo_SetFavorite_Call struct {
+	*mock.Call
+}
+
+// SetFavorite is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - accId string
+//   - favorite bool
+//   - at time.Time
+func (_e *MockContactRepo_Expecter) SetFavorite(ctx interface{}, owner interface{}, accId interface{}, favorite interface{}, at interface{}) *MockContactRepo_SetFavorite_Call {
+	return &MockContactRepo_SetFavorite_Call{Call: _e.mock.On("SetFavorite", ctx, owner, accId, favorite, at)}
+}
+
+func (_c *MockContactRepo_SetFavorite_Call) Run(run func(ctx context.Context, owner string, accId string, favorite bool, at time.Time)) *MockContactRepo_SetFavorite_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(bool), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockContactRepo_SetFavorite_Call) Return(_a0 models.Contact, _a1 error) *MockContactRepo_SetFavorite_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockContactRepo_SetFavorite_Call) RunAndReturn(run func(context.Context, string, string, bool, time.Time) (models.Contact, error)) *MockContactRepo_SetFavorite_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockContactRepo creates a new instance of MockContactRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockContactRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockContactRepo {
+	mock := &MockContactRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gopay/internal/models"
+)
+
+const (
+	addContactQ = `
+	INSERT INTO contacts
+	(owner, account_id, favorite, created_at, updated_at)
+	VALUES ($1, $2, $3, $4, $4)
+	ON CONFLICT (owner, account_id) DO NOTHING
+	`
+
+	removeContactQ = `
+	DELETE FROM contacts
+	WHERE owner = $1
+	AND account_id = $2
+	`
+
+	setContactFavoriteQ = `
+	UPDATE contacts
+	SET favorite = $3, updated_at = $4
+	WHERE owner = $1
+	AND account_id = $2
+	RETURNING owner, account_id, favorite, created_at, updated_at
+	`
+
+	findContactsByOwnerQ = `
+	SELECT owner, account_id, favorite, created_at, updated_at
+	FROM contacts
+	WHERE owner = $1
+	ORDER BY created_at, account_id
+	`
+
+	findContactOwnersQ = `
+	SELECT owner
+	FROM contacts
+	WHERE account_id = $1
+	ORDER BY owner
+	`
+)
+
+type ContactRepoPsql interface {
+	Add(ctx context.Context, contact models.Contact) error
+	Remove(ctx context.Context, owner string, accId string) error
+	SetFavorite(ctx context.Context, owner string, accId string, favorite bool, at time.Time) (models.Contact, error)
+	FindByOwner(ctx context.Context, owner string) ([]models.Contact, error)
+	FindOwners(ctx context.Context, accId string) ([]string, error)
+}
+
+var _ ContactRepoPsql = (*contactRepoPsqlImpl)(nil)
+
+type contactRepoPsqlImpl struct {
+	psql *sql.DB
+}
+
+func NewContactRepoPsql(db *sql.DB) *contactRepoPsqlImpl {
+	return &contactRepoPsqlImpl{
+		psql: db,
+	}
+}
+
+func (r *contactRepoPsqlImpl) Add(ctx context.Context, contact models.Contact) error {
+	res, err := conn(ctx, r.psql).ExecContext(ctx, addContactQ, contact.Owner, contact.AccountId, contact.Favorite, contact.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrContactExists
+	}
+
+	return nil
+}
+
+func (r *contactRepoPsqlImpl) Remove(ctx context.Context, owner string, accId string) error {
+	if uuid.Validate(owner) != nil || uuid.Validate(accId) != nil {
+		return ErrContactNotFound
+	}
+
+	res, err := conn(ctx, r.psql).ExecContext(ctx, removeContactQ, owner, accId)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrContactNotFound
+	}
+
+	return nil
+}
+
+func (r *contactRepoPsqlImpl) SetFavorite(ctx context.Context, owner string, accId string, favorite bool, at time.Time) (models.Contact, error) {
+	if uuid.Validate(owner) != nil || uuid.Validate(accId) != nil {
+		return models.Contact{}, ErrContactNotFound
+	}
+
+	contact, err := scanContact(conn(ctx, r.psql).QueryRowContext(ctx, setContactFavoriteQ, owner, accId, favorite, at))
+	if err == sql.ErrNoRows {
+		return models.Contact{}, ErrContactNotFound
+	}
+	if err != nil {
+		return models.Contact{}, err
+	}
+
+	return contact, nil
+}
+
+// FindByOwner returns owner's saved contacts, oldest first.
+func (r *contactRepoPsqlImpl) FindByOwner(ctx context.Context, owner string) ([]models.Contact, error) {
+	contacts := []models.Contact{}
+
+	rows, err := conn(ctx, r.psql).QueryContext(ctx, findContactsByOwnerQ, owner)
+	if err != nil {
+		return contacts, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		c, err := scanContact(rows)
+		if err != nil {
+			return []models.Contact{}, err
+		}
+		contacts = append(contacts, c)
+	}
+
+	return contacts, rows.Err()
+}
+
+func (r *contactRepoPsqlImpl) FindOwners(ctx context.Context, accId string) ([]string, error) {
+	owners := []string{}
+
+	rows, err := conn(ctx, r.psql).QueryContext(ctx, findContactOwnersQ, accId)
+	if err != nil {
+		return owners, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var owner string
+		err = rows.Scan(&owner)
+		if err != nil {
+			return []string{}, err
+		}
+		owners = append(owners, owner)
+	}
+
+	return owners, rows.Err()
+}
+
+func scanContact(row scanner) (models.Contact, error) {
+	c := models.Contact{}
+
+	err := row.Scan(&c.Owner, &c.AccountId, &c.Favorite, &c.CreatedAt, &c.UpdatedAt)
+	if err != nil {
+		return models.Contact{}, err
+	}
+
+	return c, nil
+}
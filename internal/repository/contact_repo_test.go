@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gopay/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContact_Add(t *testing.T) {
+	now := time.Now()
+	ctx := context.Background()
+
+	repo := setupContacts(t, map[contactKey]models.Contact{})
+
+	assert.NoError(t, repo.Add(ctx, models.Contact{Owner: "0001", AccountId: "0003", CreatedAt: now.Add(time.Minute)}))
+	assert.NoError(t, repo.Add(ctx, models.Contact{Owner: "0001", AccountId: "0002", Favorite: true, CreatedAt: now}))
+	assert.NoError(t, repo.Add(ctx, models.Contact{Owner: "0002", AccountId: "0001", CreatedAt: now}))
+	assert.ErrorIs(t, repo.Add(ctx, models.Contact{Owner: "0001", AccountId: "0002", CreatedAt: now}), ErrContactExists)
+
+	contacts, err := repo.FindByOwner(ctx, "0001")
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Contact{
+		{Owner: "0001", AccountId: "0002", Favorite: true, CreatedAt: now, UpdatedAt: now},
+		{Owner: "0001", AccountId: "0003", CreatedAt: now.Add(time.Minute), UpdatedAt: now.Add(time.Minute)},
+	}, contacts)
+
+	contacts, err = repo.FindByOwner(ctx, "0004")
+	assert.NoError(t, err)
+	assert.Empty(t, contacts)
+}
+
+func TestContact_Remove(t *testing.T) {
+	ctx := context.Background()
+
+	repo := setupContacts(t, map[contactKey]models.Contact{
+		{"0001", "0002"}: {Owner: "0001", AccountId: "0002"},
+		{"0002", "0001"}: {Owner: "0002", AccountId: "0001"},
+	})
+
+	assert.NoError(t, repo.Remove(ctx, "0001", "0002"))
+	assert.ErrorIs(t, repo.Remove(ctx, "0001", "0002"), ErrContactNotFound)
+
+	contacts, err := repo.FindByOwner(ctx, "0002")
+	assert.NoError(t, err)
+	assert.Len(t, contacts, 1)
+}
+
+func TestContact_SetFavorite(t *testing.T) {
+	now := time.Now()
+	ctx := context.Background()
+
+	repo := setupContacts(t, map[contactKey]models.Contact{
+		{"0001", "0002"}: {Owner: "0001", AccountId: "0002", CreatedAt: now, UpdatedAt: now},
+	})
+
+	contact, err := repo.SetFavorite(ctx, "0001", "0002", true, now.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, models.Contact{Owner: "0001", AccountId: "0002", Favorite: true, CreatedAt: now, UpdatedAt: now.Add(time.Hour)}, contact)
+
+	_, err = repo.SetFavorite(ctx, "0002", "0001", true, now)
+	assert.ErrorIs(t, err, ErrContactNotFound)
+}
+
+func TestContact_FindOwners(t *testing.T) {
+	ctx := context.Background()
+
+	repo := setupContacts(t, map[contactKey]models.Contact{
+		{"0003", "0001"}: {Owner: "0003", AccountId: "0001"},
+		{"0002", "0001"}: {Owner: "0002", AccountId: "0001"},
+		{"0001", "0002"}: {Owner: "0001", AccountId: "0002"},
+	})
+
+	owners, err := repo.FindOwners(ctx, "0001")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0002", "0003"}, owners)
+
+	owners, err = repo.FindOwners(ctx, "0004")
+	assert.NoError(t, err)
+	assert.Empty(t, owners)
+}
+
+func TestContact_Rollback(t *testing.T) {
+	now := time.Now()
+	ctx := context.Background()
+	errBoom := errors.New("boom")
+
+	repo := setupContacts(t, map[contactKey]models.Contact{
+		{"0001", "0002"}: {Owner: "0001", AccountId: "0002", CreatedAt: now, UpdatedAt: now},
+		{"0001", "0003"}: {Owner: "0001", AccountId: "0003", CreatedAt: now.Add(time.Minute), UpdatedAt: now.Add(time.Minute)},
+	})
+
+	err := NewUnitOfWork().Do(ctx, func(ctx context.Context) error {
+		assert.NoError(t, repo.Add(ctx, models.Contact{Owner: "0001", AccountId: "0004", CreatedAt: now}))
+		assert.NoError(t, repo.Remove(ctx, "0001", "0003"))
+		_, err := repo.SetFavorite(ctx, "0001", "0002", true, now.Add(time.Hour))
+		assert.NoError(t, err)
+		return errBoom
+	})
+	assert.ErrorIs(t, err, errBoom)
+
+	contacts, err := repo.FindByOwner(ctx, "0001")
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Contact{
+		{Owner: "0001", AccountId: "0002", CreatedAt: now, UpdatedAt: now},
+		{Owner: "0001", AccountId: "0003", CreatedAt: now.Add(time.Minute), UpdatedAt: now.Add(time.Minute)},
+	}, contacts)
+}
+
+func setupContacts(_ *testing.T, initialData map[contactKey]models.Contact) *contactRepoImpl {
+	repo := NewContactRepo()
+	repo.contacts = initialData
+	return repo
+}
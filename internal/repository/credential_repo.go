@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/gopay/internal/models"
+)
+
+var (
+	ErrCredentialNotFound = errors.New("credential not found")
+)
+
+type CredentialRepo interface {
+	Save(ctx context.Context, credential models.Credential) error
+	FindOne(ctx context.Context, accId string) (models.Credential, error)
+}
+
+var _ CredentialRepo = (*credentialRepoImpl)(nil)
+
+type credentialRepoImpl struct {
+	mu          sync.RWMutex
+	credentials map[string]models.Credential
+}
+
+func NewCredentialRepo() *credentialRepoImpl {
+	return &credentialRepoImpl{
+		credentials: make(map[string]models.Credential),
+	}
+}
+
+// Save sets the credential of its account, replacing the one it may already have.
+func (r *credentialRepoImpl) Save(ctx context.Context, credential models.Credential) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, found := r.credentials[credential.AccountId]
+	r.credentials[credential.AccountId] = credential
+	onRollback(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if found {
+			r.credentials[credential.AccountId] = previous
+		} else {
+			delete(r.credentials, credential.AccountId)
+		}
+	})
+
+	return nil
+}
+
+func (r *credentialRepoImpl) FindOne(_ context.Context, accId string) (models.Credential, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	credential, found := r.credentials[accId]
+	if !found {
+		return models.Credential{}, ErrCredentialNotFound
+	}
+
+	return credential, nil
+}
@@ -0,0 +1,141 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package repository
+
+import (
+	context "context"
+
+	models "github.com/gopay/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockCredentialRepo is an autogenerated mock type for the CredentialRepo type
+type MockCredentialRepo struct {
+	mock.Mock
+}
+
+type MockCredentialRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockCredentialRepo) EXPECT() *MockCredentialRepo_Expecter {
+	return &MockCredentialRepo_Expecter{mock: &_m.Mock}
+}
+
+// FindOne provides a mock function with given fields: ctx, accId
+func (_m *MockCredentialRepo) FindOne(ctx context.Context, accId string) (models.Credential, error) {
+	ret := _m.Called(ctx, accId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindOne")
+	}
+
+	var r0 models.Credential
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.Credential, error)); ok {
+		return rf(ctx, accId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.Credential); ok {
+		r0 = rf(ctx, accId)
+	} else {
+		r0 = ret.Get(0).(models.Credential)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, accId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockCredentialRepo_FindOne_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindOne'
+type MockCredentialRepo_FindOne_Call struct {
+	*mock.Call
+}
+
+// FindOne is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accId string
+func (_e *MockCredentialRepo_Expecter) FindOne(ctx interface{}, accId interface{}) *MockCredentialRepo_FindOne_Call {
+	return &MockCredentialRepo_FindOne_Call{Call: _e.mock.On("FindOne", ctx, accId)}
+}
+
+func (_c *MockCredentialRepo_FindOne_Call) Run(run func(ctx context.Context, accId string)) *MockCredentialRepo_FindOne_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockCredentialRepo_FindOne_Call) Return(_a0 models.Credential, _a1 error) *MockCredentialRepo_FindOne_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockCredentialRepo_FindOne_Call) RunAndReturn(run func(context.Context, string) (models.Credential, error)) *MockCredentialRepo_FindOne_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function with given fields: ctx, credential
+func (_m *MockCredentialRepo) Save(ctx context.Context, credential models.Credential) error {
+	ret := _m.Called(ctx, credential)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Credential) error); ok {
+		r0 = rf(ctx, credential)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCredentialRepo_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type MockCredentialRepo_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - credential models.Credential
+func (_e *MockCredentialRepo_Expecter) Save(ctx interface{}, credential interface{}) *MockCredentialRepo_Save_Call {
+	return &MockCredentialRepo_Save_Call{Call: _e.mock.On("Save", ctx, credential)}
+}
+
+func (_c *MockCredentialRepo_Save_Call) Run(run func(ctx context.Context, credential models.Credential)) *MockCredentialRepo_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.Credential))
+	})
+	return _c
+}
+
+func (_c *MockCredentialRepo_Save_Call) Return(_a0 error) *MockCredentialRepo_Save_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCredentialRepo_Save_Call) RunAndReturn(run func(context.Context, models.Credential) error) *MockCredentialRepo_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockCredentialRepo creates a new instance of MockCredentialRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockCredentialRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockCredentialRepo {
+	mock := &MockCredentialRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/gopay/internal/models"
+)
+
+const (
+	saveCredentialQ = `
+	INSERT INTO credentials
+	(account_id, password_hash, updated_at)
+	VALUES ($1, $2, $3)
+	ON CONFLICT (account_id) DO UPDATE
+	SET password_hash = EXCLUDED.password_hash, updated_at = EXCLUDED.updated_at
+	`
+
+	findOneCredentialQ = `
+	SELECT account_id, password_hash, updated_at
+	FROM credentials
+	WHERE account_id = $1
+	`
+)
+
+type CredentialRepoPsql interface {
+	Save(ctx context.Context, credential models.Credential) error
+	FindOne(ctx context.Context, accId string) (models.Credential, error)
+}
+
+var _ CredentialRepoPsql = (*credentialRepoPsqlImpl)(nil)
+
+type credentialRepoPsqlImpl struct {
+	psql *sql.DB
+}
+
+func NewCredentialRepoPsql(db *sql.DB) *credentialRepoPsqlImpl {
+	return &credentialRepoPsqlImpl{
+		psql: db,
+	}
+}
+
+func (r *credentialRepoPsqlImpl) Save(ctx context.Context, credential models.Credential) error {
+	_, err := conn(ctx, r.psql).ExecContext(ctx, saveCredentialQ, credential.AccountId, credential.PasswordHash, credential.UpdatedAt)
+	return err
+}
+
+func (r *credentialRepoPsqlImpl) FindOne(ctx context.Context, accId string) (models.Credential, error) {
+	if uuid.Validate(accId) != nil {
+		return models.Credential{}, ErrCredentialNotFound
+	}
+
+	credential := models.Credential{}
+	row := conn(ctx, r.psql).QueryRowContext(ctx, findOneCredentialQ, accId)
+	err := row.Scan(&credential.AccountId, &credential.PasswordHash, &credential.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return models.Credential{}, ErrCredentialNotFound
+	}
+	if err != nil {
+		return models.Credential{}, err
+	}
+
+	return credential, nil
+}
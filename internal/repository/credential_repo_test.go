@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gopay/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCredential_Save(t *testing.T) {
+	now := time.Now()
+	ctx := context.Background()
+
+	repo := setupCredentials(t, map[string]models.Credential{})
+
+	_, err := repo.FindOne(ctx, "0001")
+	assert.ErrorIs(t, err, ErrCredentialNotFound)
+
+	assert.NoError(t, repo.Save(ctx, models.Credential{AccountId: "0001", PasswordHash: "first", UpdatedAt: now}))
+	assert.NoError(t, repo.Save(ctx, models.Credential{AccountId: "0001", PasswordHash: "second", UpdatedAt: now}))
+
+	credential, err := repo.FindOne(ctx, "0001")
+	assert.NoError(t, err)
+	assert.Equal(t, models.Credential{AccountId: "0001", PasswordHash: "second", UpdatedAt: now}, credential)
+}
+
+func TestCredential_Rollback(t *testing.T) {
+	ctx := context.Background()
+	errBoom := errors.New("boom")
+
+	repo := setupCredentials(t, map[string]models.Credential{
+		"0001": {AccountId: "0001", PasswordHash: "first"},
+	})
+
+	err := NewUnitOfWork().Do(ctx, func(ctx context.Context) error {
+		assert.NoError(t, repo.Save(ctx, models.Credential{AccountId: "0001", PasswordHash: "second"}))
+		assert.NoError(t, repo.Save(ctx, models.Credential{AccountId: "0002", PasswordHash: "other"}))
+		return errBoom
+	})
+	assert.ErrorIs(t, err, errBoom)
+
+	credential, err := repo.FindOne(ctx, "0001")
+	assert.NoError(t, err)
+	assert.Equal(t, "first", credential.PasswordHash)
+
+	_, err = repo.FindOne(ctx, "0002")
+	assert.ErrorIs(t, err, ErrCredentialNotFound)
+}
+
+func setupCredentials(_ *testing.T, initialData map[string]models.Credential) *credentialRepoImpl {
+	repo := NewCredentialRepo()
+	repo.credentials = initialData
+	return repo
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidCursor = errors.New("invalid pagination cursor")
+)
+
+// encodeCursor builds an opaque keyset cursor pointing right after the row (at, id).
+func encodeCursor(at time.Time, id string) string {
+	raw := strconv.FormatInt(at.UnixNano(), 10) + "|" + id
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCursor(cursor string) (time.Time, string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, "", ErrInvalidCursor
+	}
+
+	nanos, id, found := strings.Cut(string(raw), "|")
+	if !found || id == "" {
+		return time.Time{}, "", ErrInvalidCursor
+	}
+
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return time.Time{}, "", ErrInvalidCursor
+	}
+
+	return time.Unix(0, n).UTC(), id, nil
+}
+
+// afterCursor reports whether the row (at, id) comes after the cursor position in the given order.
+func afterCursor(at time.Time, id string, cursorAt time.Time, cursorId string, descending bool) bool {
+	if at.Equal(cursorAt) {
+		if descending {
+			return id < cursorId
+		}
+		return id > cursorId
+	}
+
+	if descending {
+		return at.Before(cursorAt)
+	}
+	return at.After(cursorAt)
+}
+
+// encodeKeyCursor builds an opaque keyset cursor pointing right after the row with sort
+// key key and id.
+func encodeKeyCursor(key string, id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(key + "|" + id))
+}
+
+func decodeKeyCursor(cursor string) (string, string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", "", ErrInvalidCursor
+	}
+
+	// Ids never contain the separator, keys might.
+	i := strings.LastIndexByte(string(raw), '|')
+	if i < 0 || i == len(raw)-1 {
+		return "", "", ErrInvalidCursor
+	}
+
+	return string(raw[:i]), string(raw[i+1:]), nil
+}
+
+// afterKeyCursor reports whether the row (key, id) comes after the cursor position in the given order.
+func afterKeyCursor(key string, id string, cursorKey string, cursorId string, descending bool) bool {
+	if key == cursorKey {
+		if descending {
+			return id < cursorId
+		}
+		return id > cursorId
+	}
+
+	if descending {
+		return key < cursorKey
+	}
+	return key > cursorKey
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/gopay/internal/models"
+)
+
+var (
+	ErrFeeWaiverNotFound = errors.New("fee waiver not found")
+)
+
+type FeeWaiverRepo interface {
+	Save(ctx context.Context, waiver models.FeeWaiver) error
+	Remove(ctx context.Context, accId string, operation models.FeeOperation) error
+	FindByAccount(ctx context.Context, accId string) ([]models.FeeWaiver, error)
+}
+
+var _ FeeWaiverRepo = (*feeWaiverRepoImpl)(nil)
+
+type feeWaiverKey struct {
+	accId     string
+	operation models.FeeOperation
+}
+
+type feeWaiverRepoImpl struct {
+	mu      sync.RWMutex
+	waivers map[feeWaiverKey]models.FeeWaiver
+}
+
+func NewFeeWaiverRepo() *feeWaiverRepoImpl {
+	return &feeWaiverRepoImpl{
+		waivers: make(map[feeWaiverKey]models.FeeWaiver),
+	}
+}
+
+// Save adds waiver, replacing the account's waiver for the same operation if any.
+func (r *feeWaiverRepoImpl) Save(ctx context.Context, waiver models.FeeWaiver) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := feeWaiverKey{waiver.AccountId, waiver.Operation}
+	previous, found := r.waivers[key]
+	r.waivers[key] = waiver
+	onRollback(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if found {
+			r.waivers[key] = previous
+		} else {
+			delete(r.waivers, key)
+		}
+	})
+
+	return nil
+}
+
+func (r *feeWaiverRepoImpl) Remove(ctx context.Context, accId string, operation models.FeeOperation) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := feeWaiverKey{accId, operation}
+	waiver, found := r.waivers[key]
+	if !found {
+		return ErrFeeWaiverNotFound
+	}
+
+	delete(r.waivers, key)
+	onRollback(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.waivers[key] = waiver
+	})
+
+	return nil
+}
+
+// FindByAccount returns the waivers of accId, expired ones included, by operation.
+func (r *feeWaiverRepoImpl) FindByAccount(_ context.Context, accId string) ([]models.FeeWaiver, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	waivers := []models.FeeWaiver{}
+	for key, w := range r.waivers {
+		if key.accId == accId {
+			waivers = append(waivers, w)
+		}
+	}
+
+	sort.Slice(waivers, func(i, j int) bool {
+		return waivers[i].Operation < waivers[j].Operation
+	})
+
+	return waivers, nil
+}
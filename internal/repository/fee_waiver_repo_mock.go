@@ -0,0 +1,191 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package repository
+
+import (
+	context "context"
+
+	models "github.com/gopay/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockFeeWaiverRepo is an autogenerated mock type for the FeeWaiverRepo type
+type MockFeeWaiverRepo struct {
+	mock.Mock
+}
+
+type MockFeeWaiverRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockFeeWaiverRepo) EXPECT() *MockFeeWaiverRepo_Expecter {
+	return &MockFeeWaiverRepo_Expecter{mock: &_m.Mock}
+}
+
+// FindByAccount provides a mock function with given fields: ctx, accId
+func (_m *MockFeeWaiverRepo) FindByAccount(ctx context.Context, accId string) ([]models.FeeWaiver, error) {
+	ret := _m.Called(ctx, accId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByAccount")
+	}
+
+	var r0 []models.FeeWaiver
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.FeeWaiver, error)); ok {
+		return rf(ctx, accId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.FeeWaiver); ok {
+		r0 = rf(ctx, accId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.FeeWaiver)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, accId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFeeWaiverRepo_FindByAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByAccount'
+type MockFeeWaiverRepo_FindByAccount_Call struct {
+	*mock.Call
+}
+
+// FindByAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accId string
+func (_e *MockFeeWaiverRepo_Expecter) FindByAccount(ctx interface{}, accId interface{}) *MockFeeWaiverRepo_FindByAccount_Call {
+	return &MockFeeWaiverRepo_FindByAccount_Call{Call: _e.mock.On("FindByAccount", ctx, accId)}
+}
+
+func (_c *MockFeeWaiverRepo_FindByAccount_Call) Run(run func(ctx context.Context, accId string)) *MockFeeWaiverRepo_FindByAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockFeeWaiverRepo_FindByAccount_Call) Return(_a0 []models.FeeWaiver, _a1 error) *MockFeeWaiverRepo_FindByAccount_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFeeWaiverRepo_FindByAccount_Call) RunAndReturn(run func(context.Context, string) ([]models.FeeWaiver, error)) *MockFeeWaiverRepo_FindByAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Remove provides a mock function with given fields: ctx, accId, operation
+func (_m *MockFeeWaiverRepo) Remove(ctx context.Context, accId string, operation models.FeeOperation) error {
+	ret := _m.Called(ctx, accId, operation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Remove")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.FeeOperation) error); ok {
+		r0 = rf(ctx, accId, operation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockFeeWaiverRepo_Remove_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Remove'
+type MockFeeWaiverRepo_Remove_Call struct {
+	*mock.Call
+}
+
+// Remove is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accId string
+//   - operation models.FeeOperation
+func (_e *MockFeeWaiverRepo_Expecter) Remove(ctx interface{}, accId interface{}, operation interface{}) *MockFeeWaiverRepo_Remove_Call {
+	return &MockFeeWaiverRepo_Remove_Call{Call: _e.mock.On("Remove", ctx, accId, operation)}
+}
+
+func (_c *MockFeeWaiverRepo_Remove_Call) Run(run func(ctx context.Context, accId string, operation models.FeeOperation)) *MockFeeWaiverRepo_Remove_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.FeeOperation))
+	})
+	return _c
+}
+
+func (_c *MockFeeWaiverRepo_Remove_Call) Return(_a0 error) *MockFeeWaiverRepo_Remove_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockFeeWaiverRepo_Remove_Call) RunAndReturn(run func(context.Context, string, models.FeeOperation) error) *MockFeeWaiverRepo_Remove_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function with given fields: ctx, waiver
+func (_m *MockFeeWaiverRepo) Save(ctx context.Context, waiver models.FeeWaiver) error {
+	ret := _m.Called(ctx, waiver)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.FeeWaiver) error); ok {
+		r0 = rf(ctx, waiver)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockFeeWaiverRepo_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type MockFeeWaiverRepo_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - waiver models.FeeWaiver
+func (_e *MockFeeWaiverRepo_Expecter) Save(ctx interface{}, waiver interface{}) *MockFeeWaiverRepo_Save_Call {
+	return &MockFeeWaiverRepo_Save_Call{Call: _e.mock.On("Save", ctx, waiver)}
+}
+
+func (_c *MockFeeWaiverRepo_Save_Call) Run(run func(ctx context.Context, waiver models.FeeWaiver)) *MockFeeWaiverRepo_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.FeeWaiver))
+	})
+	return _c
+}
+
+func (_c *MockFeeWaiverRepo_Save_Call) Return(_a0 error) *MockFeeWaiverRepo_Save_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockFeeWaiverRepo_Save_Call) RunAndReturn(run func(context.Context, models.FeeWaiver) error) *MockFeeWaiverRepo_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockFeeWaiverRepo creates a new instance of MockFeeWaiverRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockFeeWaiverRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockFeeWaiverRepo {
+	mock := &MockFeeWaiverRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/gopay/internal/models"
+)
+
+const (
+	saveFeeWaiverQ = `
+	INSERT INTO fee_waivers
+	(account_id, operation, reason, expires_at, created_at)
+	VALUES ($1, $2, $3, $4, $5)
+	ON CONFLICT (account_id, operation) DO UPDATE
+	SET reason = EXCLUDED.reason, expires_at = EXCLUDED.expires_at, created_at = EXCLUDED.created_at
+	`
+
+	removeFeeWaiverQ = `
+	DELETE FROM fee_waivers
+	WHERE account_id = $1
+	AND operation = $2
+	`
+
+	findFeeWaiversQ = `
+	SELECT account_id, operation, reason, expires_at, created_at
+	FROM fee_waivers
+	WHERE account_id = $1
+	ORDER BY operation
+	`
+)
+
+type FeeWaiverRepoPsql interface {
+	Save(ctx context.Context, waiver models.FeeWaiver) error
+	Remove(ctx context.Context, accId string, operation models.FeeOperation) error
+	FindByAccount(ctx context.Context, accId string) ([]models.FeeWaiver, error)
+}
+
+var _ FeeWaiverRepoPsql = (*feeWaiverRepoPsqlImpl)(nil)
+
+type feeWaiverRepoPsqlImpl struct {
+	psql *sql.DB
+}
+
+func NewFeeWaiverRepoPsql(db *sql.DB) *feeWaiverRepoPsqlImpl {
+	return &feeWaiverRepoPsqlImpl{
+		psql: db,
+	}
+}
+
+func (r *feeWaiverRepoPsqlImpl) Save(ctx context.Context, waiver models.FeeWaiver) error {
+	_, err := conn(ctx, r.psql).ExecContext(ctx, saveFeeWaiverQ, waiver.AccountId, waiver.Operation, waiver.Reason, waiver.ExpiresAt, waiver.CreatedAt)
+	return err
+}
+
+func (r *feeWaiverRepoPsqlImpl) Remove(ctx context.Context, accId string, operation models.FeeOperation) error {
+	if uuid.Validate(accId) != nil {
+		return ErrFeeWaiverNotFound
+	}
+
+	res, err := conn(ctx, r.psql).ExecContext(ctx, removeFeeWaiverQ, accId, operation)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrFeeWaiverNotFound
+	}
+
+	return nil
+}
+
+// FindByAccount returns the waivers of accId, expired ones included, by operation.
+func (r *feeWaiverRepoPsqlImpl) FindByAccount(ctx context.Context, accId string) ([]models.FeeWaiver, error) {
+	waivers := []models.FeeWaiver{}
+	if uuid.Validate(accId) != nil {
+		return waivers, nil
+	}
+
+	rows, err := conn(ctx, r.psql).QueryContext(ctx, findFeeWaiversQ, accId)
+	if err != nil {
+		return waivers, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		w := models.FeeWaiver{}
+		err = rows.Scan(&w.AccountId, &w.Operation, &w.Reason, &w.ExpiresAt, &w.CreatedAt)
+		if err != nil {
+			return []models.FeeWaiver{}, err
+		}
+		waivers = append(waivers, w)
+	}
+
+	return waivers, rows.Err()
+}
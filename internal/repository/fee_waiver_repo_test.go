@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gopay/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeeWaiver_Save(t *testing.T) {
+	now := time.Now()
+	ctx := context.Background()
+
+	repo := setupFeeWaivers(t, map[feeWaiverKey]models.FeeWaiver{})
+
+	assert.NoError(t, repo.Save(ctx, models.FeeWaiver{AccountId: "0001", Operation: models.FeePayment, Reason: "promo", CreatedAt: now}))
+	assert.NoError(t, repo.Save(ctx, models.FeeWaiver{AccountId: "0001", Operation: models.FeeAll, CreatedAt: now}))
+	assert.NoError(t, repo.Save(ctx, models.FeeWaiver{AccountId: "0001", Operation: models.FeePayment, Reason: "staff", CreatedAt: now}))
+	assert.NoError(t, repo.Save(ctx, models.FeeWaiver{AccountId: "0002", Operation: models.FeeWithdrawal, CreatedAt: now}))
+
+	waivers, err := repo.FindByAccount(ctx, "0001")
+	assert.NoError(t, err)
+	assert.Equal(t, []models.FeeWaiver{
+		{AccountId: "0001", Operation: models.FeeAll, CreatedAt: now},
+		{AccountId: "0001", Operation: models.FeePayment, Reason: "staff", CreatedAt: now},
+	}, waivers)
+
+	waivers, err = repo.FindByAccount(ctx, "0003")
+	assert.NoError(t, err)
+	assert.Empty(t, waivers)
+}
+
+func TestFeeWaiver_Remove(t *testing.T) {
+	ctx := context.Background()
+
+	repo := setupFeeWaivers(t, map[feeWaiverKey]models.FeeWaiver{
+		{"0001", models.FeePayment}: {AccountId: "0001", Operation: models.FeePayment},
+	})
+
+	assert.ErrorIs(t, repo.Remove(ctx, "0001", models.FeeWithdrawal), ErrFeeWaiverNotFound)
+	assert.NoError(t, repo.Remove(ctx, "0001", models.FeePayment))
+	assert.ErrorIs(t, repo.Remove(ctx, "0001", models.FeePayment), ErrFeeWaiverNotFound)
+}
+
+func TestFeeWaiver_Rollback(t *testing.T) {
+	ctx := context.Background()
+	errBoom := errors.New("boom")
+
+	repo := setupFeeWaivers(t, map[feeWaiverKey]models.FeeWaiver{
+		{"0001", models.FeePayment}: {AccountId: "0001", Operation: models.FeePayment, Reason: "promo"},
+		{"0001", models.FeeAll}:     {AccountId: "0001", Operation: models.FeeAll},
+	})
+
+	err := NewUnitOfWork().Do(ctx, func(ctx context.Context) error {
+		assert.NoError(t, repo.Save(ctx, models.FeeWaiver{AccountId: "0001", Operation: models.FeePayment, Reason: "staff"}))
+		assert.NoError(t, repo.Save(ctx, models.FeeWaiver{AccountId: "0001", Operation: models.FeeWithdrawal}))
+		assert.NoError(t, repo.Remove(ctx, "0001", models.FeeAll))
+		return errBoom
+	})
+	assert.ErrorIs(t, err, errBoom)
+
+	waivers, err := repo.FindByAccount(ctx, "0001")
+	assert.NoError(t, err)
+	assert.Equal(t, []models.FeeWaiver{
+		{AccountId: "0001", Operation: models.FeeAll},
+		{AccountId: "0001", Operation: models.FeePayment, Reason: "promo"},
+	}, waivers)
+}
+
+func setupFeeWaivers(_ *testing.T, initialData map[feeWaiverKey]models.FeeWaiver) *feeWaiverRepoImpl {
+	repo := NewFeeWaiverRepo()
+	repo.waivers = initialData
+	return repo
+}
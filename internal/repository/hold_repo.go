@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gopay/internal/models"
+)
+
+var (
+	ErrHoldNotFound  = errors.New("hold not found")
+	ErrHoldNotActive = errors.New("hold is no longer active")
+)
+
+type HoldRepo interface {
+	Create(ctx context.Context, hold models.Hold) (string, error)
+	FindOne(ctx context.Context, id string) (models.Hold, error)
+	FindByOwner(ctx context.Context, owner string, status models.HoldStatus) ([]models.Hold, error)
+	GetHeld(ctx context.Context, owner string, now time.Time) (models.Money, error)
+	Resolve(ctx context.Context, id string, status models.HoldStatus, captured models.Money, at time.Time) error
+}
+
+var _ HoldRepo = (*holdRepoImpl)(nil)
+
+type holdRepoImpl struct {
+	mu          sync.RWMutex
+	holds       map[string]models.Hold
+	idGenerator func() string
+}
+
+func NewHoldRepo() *holdRepoImpl {
+	return &holdRepoImpl{
+		holds:       make(map[string]models.Hold),
+		idGenerator: uuid.NewString,
+	}
+}
+
+func (r *holdRepoImpl) Create(ctx context.Context, hold models.Hold) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := r.idGenerator()
+	hold.HoldId = id
+	hold.Status = models.HoldActive
+	hold.UpdatedAt = hold.CreatedAt
+
+	r.holds[id] = hold
+	onRollback(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.holds, id)
+	})
+
+	return id, nil
+}
+
+func (r *holdRepoImpl) FindOne(_ context.Context, id string) (models.Hold, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	hold, found := r.holds[id]
+	if !found {
+		return models.Hold{}, ErrHoldNotFound
+	}
+
+	return hold, nil
+}
+
+// FindByOwner returns the holds on owner's account, newest first. An empty status matches any.
+func (r *holdRepoImpl) FindByOwner(_ context.Context, owner string, status models.HoldStatus) ([]models.Hold, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	holds := []models.Hold{}
+
+	for _, h := range r.holds {
+		if h.Owner == owner && (status == "" || h.Status == status) {
+			holds = append(holds, h)
+		}
+	}
+
+	sort.Slice(holds, func(i, j int) bool {
+		return holds[i].CreatedAt.After(holds[j].CreatedAt)
+	})
+
+	return holds, nil
+}
+
+// GetHeld adds up the holds on owner's account that are active and not yet expired at now.
+func (r *holdRepoImpl) GetHeld(_ context.Context, owner string, now time.Time) (models.Money, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var held models.Money
+
+	for _, h := range r.holds {
+		if h.Owner == owner && h.Status == models.HoldActive && now.Before(h.ExpiresAt) {
+			held += h.Amount
+		}
+	}
+
+	return held, nil
+}
+
+// Resolve moves an active hold to status. It fails with ErrHoldNotActive if the hold was
+// resolved in the meantime, so a hold is only ever captured or voided once.
+func (r *holdRepoImpl) Resolve(ctx context.Context, id string, status models.HoldStatus, captured models.Money, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	hold, found := r.holds[id]
+	if !found {
+		return ErrHoldNotFound
+	}
+
+	if hold.Status != models.HoldActive {
+		return ErrHoldNotActive
+	}
+
+	previous := hold
+	hold.Status = status
+	hold.Captured = captured
+	hold.UpdatedAt = at
+	r.holds[id] = hold
+	onRollback(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.holds[id] = previous
+	})
+
+	return nil
+}
@@ -0,0 +1,321 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package repository
+
+import (
+	context "context"
+
+	models "github.com/gopay/internal/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockHoldRepo is an autogenerated mock type for the HoldRepo type
+type MockHoldRepo struct {
+	mock.Mock
+}
+
+type MockHoldRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockHoldRepo) EXPECT() *MockHoldRepo_Expecter {
+	return &MockHoldRepo_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, hold
+func (_m *MockHoldRepo) Create(ctx context.Context, hold models.Hold) (string, error) {
+	ret := _m.Called(ctx, hold)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Hold) (string, error)); ok {
+		return rf(ctx, hold)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.Hold) string); ok {
+		r0 = rf(ctx, hold)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.Hold) error); ok {
+		r1 = rf(ctx, hold)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockHoldRepo_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockHoldRepo_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hold models.Hold
+func (_e *MockHoldRepo_Expecter) Create(ctx interface{}, hold interface{}) *MockHoldRepo_Create_Call {
+	return &MockHoldRepo_Create_Call{Call: _e.mock.On("Create", ctx, hold)}
+}
+
+func (_c *MockHoldRepo_Create_Call) Run(run func(ctx context.Context, hold models.Hold)) *MockHoldRepo_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.Hold))
+	})
+	return _c
+}
+
+func (_c *MockHoldRepo_Create_Call) Return(_a0 string, _a1 error) *MockHoldRepo_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockHoldRepo_Create_Call) RunAndReturn(run func(context.Context, models.Hold) (string, error)) *MockHoldRepo_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByOwner provides a mock function with given fields: ctx, owner, status
+func (_m *MockHoldRepo) FindByOwner(ctx context.Context, owner string, status models.HoldStatus) ([]models.Hold, error) {
+	ret := _m.Called(ctx, owner, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByOwner")
+	}
+
+	var r0 []models.Hold
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.HoldStatus) ([]models.Hold, error)); ok {
+		return rf(ctx, owner, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.HoldStatus) []models.Hold); ok {
+		r0 = rf(ctx, owner, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Hold)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.HoldStatus) error); ok {
+		r1 = rf(ctx, owner, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockHoldRepo_FindByOwner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByOwner'
+type MockHoldRepo_FindByOwner_Call struct {
+	*mock.Call
+}
+
+// FindByOwner is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - status models.HoldStatus
+func (_e *MockHoldRepo_Expecter) FindByOwner(ctx interface{}, owner interface{}, status interface{}) *MockHoldRepo_FindByOwner_Call {
+	return &MockHoldRepo_FindByOwner_Call{Call: _e.mock.On("FindByOwner", ctx, owner, status)}
+}
+
+func (_c *MockHoldRepo_FindByOwner_Call) Run(run func(ctx context.Context, owner string, status models.HoldStatus)) *MockHoldRepo_FindByOwner_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.HoldStatus))
+	})
+	return _c
+}
+
+func (_c *MockHoldRepo_FindByOwner_Call) Return(_a0 []models.Hold, _a1 error) *MockHoldRepo_FindByOwner_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockHoldRepo_FindByOwner_Call) RunAndReturn(run func(context.Context, string, models.HoldStatus) ([]models.Hold, error)) *MockHoldRepo_FindByOwner_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindOne provides a mock function with given fields: ctx, id
+func (_m *MockHoldRepo) FindOne(ctx context.Context, id string) (models.Hold, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindOne")
+	}
+
+	var r0 models.Hold
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.Hold, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.Hold); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(models.Hold)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockHoldRepo_FindOne_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindOne'
+type MockHoldRepo_FindOne_Call struct {
+	*mock.Call
+}
+
+// FindOne is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockHoldRepo_Expecter) FindOne(ctx interface{}, id interface{}) *MockHoldRepo_FindOne_Call {
+	return &MockHoldRepo_FindOne_Call{Call: _e.mock.On("FindOne", ctx, id)}
+}
+
+func (_c *MockHoldRepo_FindOne_Call) Run(run func(ctx context.Context, id string)) *MockHoldRepo_FindOne_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockHoldRepo_FindOne_Call) Return(_a0 models.Hold, _a1 error) *MockHoldRepo_FindOne_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockHoldRepo_FindOne_Call) RunAndReturn(run func(context.Context, string) (models.Hold, error)) *MockHoldRepo_FindOne_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetHeld provides a mock function with given fields: ctx, owner, now
+func (_m *MockHoldRepo) GetHeld(ctx context.Context, owner string, now time.Time) (models.Money, error) {
+	ret := _m.Called(ctx, owner, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHeld")
+	}
+
+	var r0 models.Money
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (models.Money, error)); ok {
+		return rf(ctx, owner, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) models.Money); ok {
+		r0 = rf(ctx, owner, now)
+	} else {
+		r0 = ret.Get(0).(models.Money)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, owner, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockHoldRepo_GetHeld_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetHeld'
+type MockHoldRepo_GetHeld_Call struct {
+	*mock.Call
+}
+
+// GetHeld is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - now time.Time
+func (_e *MockHoldRepo_Expecter) GetHeld(ctx interface{}, owner interface{}, now interface{}) *MockHoldRepo_GetHeld_Call {
+	return &MockHoldRepo_GetHeld_Call{Call: _e.mock.On("GetHeld", ctx, owner, now)}
+}
+
+func (_c *MockHoldRepo_GetHeld_Call) Run(run func(ctx context.Context, owner string, now time.Time)) *MockHoldRepo_GetHeld_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockHoldRepo_GetHeld_Call) Return(_a0 models.Money, _a1 error) *MockHoldRepo_GetHeld_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockHoldRepo_GetHeld_Call) RunAndReturn(run func(context.Context, string, time.Time) (models.Money, error)) *MockHoldRepo_GetHeld_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Resolve provides a mock function with given fields: ctx, id, status, captured, at
+func (_m *MockHoldRepo) Resolve(ctx context.Context, id string, status models.HoldStatus, captured models.Money, at time.Time) error {
+	ret := _m.Called(ctx, id, status, captured, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Resolve")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.HoldStatus, models.Money, time.Time) error); ok {
+		r0 = rf(ctx, id, status, captured, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockHoldRepo_Resolve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Resolve'
+type MockHoldRepo_Resolve_Call struct {
+	*mock.Call
+}
+
+// Resolve is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - status models.HoldStatus
+//   - captured models.Money
+//   - at time.Time
+func (_e *MockHoldRepo_Expecter) Resolve(ctx interface{}, id interface{}, status interface{}, captured interface{}, at interface{}) *MockHoldRepo_Resolve_Call {
+	return &MockHoldRepo_Resolve_Call{Call: _e.mock.On("Resolve", ctx, id, status, captured, at)}
+}
+
+func (_c *MockHoldRepo_Resolve_Call) Run(run func(ctx context.Context, id string, status models.HoldStatus, captured models.Money, at time.Time)) *MockHoldRepo_Resolve_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.HoldStatus), args[3].(models.Money), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockHoldRepo_Resolve_Call) Return(_a0 error) *MockHoldRepo_Resolve_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockHoldRepo_Resolve_Call) RunAndReturn(run func(context.Context, string, models.HoldStatus, models.Money, time.Time) error) *MockHoldRepo_Resolve_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockHoldRepo creates a new instance of MockHoldRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockHoldRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockHoldRepo {
+	mock := &MockHoldRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gopay/internal/models"
+)
+
+const (
+	createHoldQ = `
+	INSERT INTO holds
+	(owner, merchant, amount, status, memo, created_at, expires_at, updated_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $6)
+	RETURNING hold_id
+	`
+
+	findHoldQ = `
+	SELECT hold_id, owner, merchant, amount, captured, status, memo, created_at, expires_at, updated_at
+	FROM holds
+	WHERE hold_id = $1
+	`
+
+	findHoldsByOwnerQ = `
+	SELECT hold_id, owner, merchant, amount, captured, status, memo, created_at, expires_at, updated_at
+	FROM holds
+	WHERE owner = $1
+	AND ($2 = '' OR status = $2)
+	ORDER BY created_at DESC
+	`
+
+	getHeldQ = `
+	SELECT COALESCE(SUM(amount), 0)
+	FROM holds
+	WHERE owner = $1
+	AND status = 'active'
+	AND expires_at > $2
+	`
+
+	resolveHoldQ = `
+	UPDATE holds
+	SET status = $2, captured = $3, updated_at = $4
+	WHERE hold_id = $1
+	AND status = 'active'
+	`
+)
+
+type HoldRepoPsql interface {
+	Create(ctx context.Context, hold models.Hold) (string, error)
+	FindOne(ctx context.Context, id string) (models.Hold, error)
+	FindByOwner(ctx context.Context, owner string, status models.HoldStatus) ([]models.Hold, error)
+	GetHeld(ctx context.Context, owner string, now time.Time) (models.Money, error)
+	Resolve(ctx context.Context, id string, status models.HoldStatus, captured models.Money, at time.Time) error
+}
+
+var _ HoldRepoPsql = (*holdRepoPsqlImpl)(nil)
+
+type holdRepoPsqlImpl struct {
+	psql *sql.DB
+}
+
+func NewHoldRepoPsql(db *sql.DB) *holdRepoPsqlImpl {
+	return &holdRepoPsqlImpl{
+		psql: db,
+	}
+}
+
+func (r *holdRepoPsqlImpl) Create(ctx context.Context, hold models.Hold) (string, error) {
+	var id string
+
+	row := conn(ctx, r.psql).QueryRowContext(ctx, createHoldQ, hold.Owner, hold.Merchant, hold.Amount, models.HoldActive, hold.Memo, hold.CreatedAt, hold.ExpiresAt)
+	err := row.Scan(&id)
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (r *holdRepoPsqlImpl) FindOne(ctx context.Context, id string) (models.Hold, error) {
+	// A malformed id cannot match any hold, and querying with it would fail the
+	// surrounding transaction.
+	if uuid.Validate(id) != nil {
+		return models.Hold{}, ErrHoldNotFound
+	}
+
+	row := conn(ctx, r.psql).QueryRowContext(ctx, findHoldQ, id)
+	h, err := scanHold(row)
+	if err == sql.ErrNoRows {
+		return models.Hold{}, ErrHoldNotFound
+	}
+	if err != nil {
+		return models.Hold{}, err
+	}
+
+	return h, nil
+}
+
+// FindByOwner returns the holds on owner's account, newest first. An empty status matches any.
+func (r *holdRepoPsqlImpl) FindByOwner(ctx context.Context, owner string, status models.HoldStatus) ([]models.Hold, error) {
+	holds := []models.Hold{}
+
+	rows, err := conn(ctx, r.psql).QueryContext(ctx, findHoldsByOwnerQ, owner, string(status))
+	if err != nil {
+		return holds, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		h, err := scanHold(rows)
+		if err != nil {
+			return []models.Hold{}, err
+		}
+		holds = append(holds, h)
+	}
+
+	return holds, rows.Err()
+}
+
+// GetHeld adds up the holds on owner's account that are active and not yet expired at now.
+func (r *holdRepoPsqlImpl) GetHeld(ctx context.Context, owner string, now time.Time) (models.Money, error) {
+	var held models.Money
+
+	row := conn(ctx, r.psql).QueryRowContext(ctx, getHeldQ, owner, now)
+	err := row.Scan(&held)
+	if err != nil {
+		return 0, err
+	}
+
+	return held, nil
+}
+
+// Resolve moves an active hold to status. It fails with ErrHoldNotActive if the hold was
+// resolved in the meantime, so a hold is only ever captured or voided once.
+func (r *holdRepoPsqlImpl) Resolve(ctx context.Context, id string, status models.HoldStatus, captured models.Money, at time.Time) error {
+	res, err := conn(ctx, r.psql).ExecContext(ctx, resolveHoldQ, id, status, captured, at)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		_, err = r.FindOne(ctx, id)
+		if err != nil {
+			return err
+		}
+		return ErrHoldNotActive
+	}
+
+	return nil
+}
+
+func scanHold(row scanner) (models.Hold, error) {
+	h := models.Hold{}
+
+	err := row.Scan(&h.HoldId, &h.Owner, &h.Merchant, &h.Amount, &h.Captured, &h.Status, &h.Memo, &h.CreatedAt, &h.ExpiresAt, &h.UpdatedAt)
+	if err != nil {
+		return models.Hold{}, err
+	}
+
+	return h, nil
+}
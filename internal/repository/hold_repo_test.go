@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gopay/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHold_Create(t *testing.T) {
+	now := time.Now()
+	ctx := context.Background()
+
+	repo := setupHolds(t, map[string]models.Hold{}, func() string { return "h-1" })
+
+	id, err := repo.Create(ctx, models.Hold{
+		Owner:     "0001",
+		Merchant:  "0002",
+		Amount:    2500,
+		Memo:      "hotel",
+		CreatedAt: now,
+		ExpiresAt: now.Add(time.Hour),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "h-1", id)
+
+	hold, err := repo.FindOne(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, models.Hold{
+		HoldId:    "h-1",
+		Owner:     "0001",
+		Merchant:  "0002",
+		Amount:    2500,
+		Status:    models.HoldActive,
+		Memo:      "hotel",
+		CreatedAt: now,
+		ExpiresAt: now.Add(time.Hour),
+		UpdatedAt: now,
+	}, hold)
+
+	_, err = repo.FindOne(ctx, "h-2")
+	assert.ErrorIs(t, err, ErrHoldNotFound)
+}
+
+func TestHold_GetHeld(t *testing.T) {
+	now := time.Now()
+
+	repo := setupHolds(t, map[string]models.Hold{
+		"h-1": {HoldId: "h-1", Owner: "0001", Amount: 1000, Status: models.HoldActive, ExpiresAt: now.Add(time.Hour)},
+		"h-2": {HoldId: "h-2", Owner: "0001", Amount: 2000, Status: models.HoldActive, ExpiresAt: now.Add(time.Minute)},
+		"h-3": {HoldId: "h-3", Owner: "0001", Amount: 4000, Status: models.HoldActive, ExpiresAt: now},
+		"h-4": {HoldId: "h-4", Owner: "0001", Amount: 8000, Status: models.HoldCaptured, ExpiresAt: now.Add(time.Hour)},
+		"h-5": {HoldId: "h-5", Owner: "0002", Amount: 1600, Status: models.HoldActive, ExpiresAt: now.Add(time.Hour)},
+	}, nil)
+
+	held, err := repo.GetHeld(context.Background(), "0001", now)
+	assert.NoError(t, err)
+	assert.Equal(t, models.Money(3000), held)
+
+	held, err = repo.GetHeld(context.Background(), "0003", now)
+	assert.NoError(t, err)
+	assert.Equal(t, models.Money(0), held)
+}
+
+func TestHold_FindByOwner(t *testing.T) {
+	now := time.Now()
+
+	data := map[string]models.Hold{
+		"h-1": {HoldId: "h-1", Owner: "0001", Status: models.HoldActive, CreatedAt: now},
+		"h-2": {HoldId: "h-2", Owner: "0001", Status: models.HoldVoided, CreatedAt: now.Add(time.Minute)},
+		"h-3": {HoldId: "h-3", Owner: "0002", Status: models.HoldActive, CreatedAt: now},
+	}
+
+	scenarios := map[string]struct {
+		status models.HoldStatus
+		want   []string
+	}{
+		"any-status-newest-first": {
+			status: "",
+			want:   []string{"h-2", "h-1"},
+		},
+		"active": {
+			status: models.HoldActive,
+			want:   []string{"h-1"},
+		},
+		"none": {
+			status: models.HoldCaptured,
+			want:   []string{},
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			repo := setupHolds(t, data, nil)
+
+			holds, err := repo.FindByOwner(context.Background(), "0001", tcase.status)
+
+			assert.NoError(t, err)
+			ids := []string{}
+			for _, h := range holds {
+				ids = append(ids, h.HoldId)
+			}
+			assert.Equal(t, tcase.want, ids)
+		})
+	}
+}
+
+func TestHold_Resolve(t *testing.T) {
+	now := time.Now()
+	ctx := context.Background()
+	errBoom := errors.New("boom")
+
+	repo := setupHolds(t, map[string]models.Hold{
+		"h-1": {HoldId: "h-1", Owner: "0001", Amount: 2500, Status: models.HoldActive, CreatedAt: now},
+	}, nil)
+
+	err := NewUnitOfWork().Do(ctx, func(ctx context.Context) error {
+		assert.NoError(t, repo.Resolve(ctx, "h-1", models.HoldCaptured, 1000, now))
+		return errBoom
+	})
+	assert.ErrorIs(t, err, errBoom)
+
+	hold, err := repo.FindOne(ctx, "h-1")
+	assert.NoError(t, err)
+	assert.Equal(t, models.HoldActive, hold.Status)
+	assert.Equal(t, models.Money(0), hold.Captured)
+
+	assert.NoError(t, repo.Resolve(ctx, "h-1", models.HoldCaptured, 1000, now))
+	hold, err = repo.FindOne(ctx, "h-1")
+	assert.NoError(t, err)
+	assert.Equal(t, models.HoldCaptured, hold.Status)
+	assert.Equal(t, models.Money(1000), hold.Captured)
+
+	assert.ErrorIs(t, repo.Resolve(ctx, "h-1", models.HoldVoided, 0, now), ErrHoldNotActive)
+	assert.ErrorIs(t, repo.Resolve(ctx, "h-2", models.HoldVoided, 0, now), ErrHoldNotFound)
+}
+
+func setupHolds(_ *testing.T, initialData map[string]models.Hold, idGenerator func() string) *holdRepoImpl {
+	repo := NewHoldRepo()
+	repo.holds = initialData
+	repo.idGenerator = idGenerator
+	return repo
+}
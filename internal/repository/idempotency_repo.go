@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gopay/internal/models"
+)
+
+var (
+	ErrIdempotencyKeyNotFound = errors.New("idempotency key not found")
+	ErrIdempotencyKeyExists   = errors.New("idempotency key already exists")
+)
+
+// IdempotencyRepo stores idempotency keys. A request owns the key it claimed for as long
+// as the key keeps the CreatedAt it was claimed with, so Reclaim, Renew, Complete and
+// Delete all compare and swap on it.
+type IdempotencyRepo interface {
+	FindOne(ctx context.Context, scope string, key string) (models.IdempotencyRecord, error)
+	Create(ctx context.Context, record models.IdempotencyRecord) error
+	Reclaim(ctx context.Context, record models.IdempotencyRecord, staleBefore time.Time) error
+	Renew(ctx context.Context, record models.IdempotencyRecord) error
+	Complete(ctx context.Context, record models.IdempotencyRecord, statusCode int, body []byte) error
+	Delete(ctx context.Context, record models.IdempotencyRecord) error
+}
+
+var _ IdempotencyRepo = (*idempotencyRepoImpl)(nil)
+
+type idempotencyRepoImpl struct {
+	mu      sync.RWMutex
+	records map[string]models.IdempotencyRecord
+}
+
+func NewIdempotencyRepo() *idempotencyRepoImpl {
+	return &idempotencyRepoImpl{
+		records: make(map[string]models.IdempotencyRecord),
+	}
+}
+
+func idempotencyId(scope string, key string) string {
+	return scope + "/" + key
+}
+
+func (r *idempotencyRepoImpl) FindOne(_ context.Context, scope string, key string) (models.IdempotencyRecord, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	record, found := r.records[idempotencyId(scope, key)]
+	if !found {
+		return models.IdempotencyRecord{}, ErrIdempotencyKeyNotFound
+	}
+
+	return record, nil
+}
+
+func (r *idempotencyRepoImpl) Create(_ context.Context, record models.IdempotencyRecord) error {
+	if record.Scope == "" || record.Key == "" {
+		return ErrMissingFields
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := idempotencyId(record.Scope, record.Key)
+	if _, found := r.records[id]; found {
+		return ErrIdempotencyKeyExists
+	}
+
+	r.records[id] = record
+
+	return nil
+}
+
+// Reclaim hands a stale key over to record: one created before staleBefore, or one still
+// in progress whose lease ran out before record was created. It fails with
+// ErrIdempotencyKeyNotFound when there is no such key, e.g. because another request
+// reclaimed it first.
+func (r *idempotencyRepoImpl) Reclaim(_ context.Context, record models.IdempotencyRecord, staleBefore time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := idempotencyId(record.Scope, record.Key)
+	existing, found := r.records[id]
+	if !found {
+		return ErrIdempotencyKeyNotFound
+	}
+
+	stale := existing.CreatedAt.Before(staleBefore) || (!existing.Completed && existing.LeasedUntil.Before(record.CreatedAt))
+	if !stale {
+		return ErrIdempotencyKeyNotFound
+	}
+
+	r.records[id] = record
+
+	return nil
+}
+
+// Renew moves the lease of the key record claimed to record.LeasedUntil.
+func (r *idempotencyRepoImpl) Renew(_ context.Context, record models.IdempotencyRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := idempotencyId(record.Scope, record.Key)
+	existing, found := r.records[id]
+	if !found || existing.Completed || !existing.CreatedAt.Equal(record.CreatedAt) {
+		return ErrIdempotencyKeyNotFound
+	}
+
+	existing.LeasedUntil = record.LeasedUntil
+	r.records[id] = existing
+
+	return nil
+}
+
+func (r *idempotencyRepoImpl) Complete(_ context.Context, record models.IdempotencyRecord, statusCode int, body []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := idempotencyId(record.Scope, record.Key)
+	existing, found := r.records[id]
+	if !found || !existing.CreatedAt.Equal(record.CreatedAt) {
+		return ErrIdempotencyKeyNotFound
+	}
+
+	existing.Completed = true
+	existing.StatusCode = statusCode
+	existing.Body = body
+	r.records[id] = existing
+
+	return nil
+}
+
+func (r *idempotencyRepoImpl) Delete(_ context.Context, record models.IdempotencyRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := idempotencyId(record.Scope, record.Key)
+	existing, found := r.records[id]
+	if found && existing.CreatedAt.Equal(record.CreatedAt) {
+		delete(r.records, id)
+	}
+
+	return nil
+}
@@ -0,0 +1,335 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package repository
+
+import (
+	context "context"
+
+	models "github.com/gopay/internal/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockIdempotencyRepo is an autogenerated mock type for the IdempotencyRepo type
+type MockIdempotencyRepo struct {
+	mock.Mock
+}
+
+type MockIdempotencyRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockIdempotencyRepo) EXPECT() *MockIdempotencyRepo_Expecter {
+	return &MockIdempotencyRepo_Expecter{mock: &_m.Mock}
+}
+
+// Complete provides a mock function with given fields: ctx, record, statusCode, body
+func (_m *MockIdempotencyRepo) Complete(ctx context.Context, record models.IdempotencyRecord, statusCode int, body []byte) error {
+	ret := _m.Called(ctx, record, statusCode, body)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Complete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.IdempotencyRecord, int, []byte) error); ok {
+		r0 = rf(ctx, record, statusCode, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIdempotencyRepo_Complete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Complete'
+type MockIdempotencyRepo_Complete_Call struct {
+	*mock.Call
+}
+
+// Complete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - record models.IdempotencyRecord
+//   - statusCode int
+//   - body []byte
+func (_e *MockIdempotencyRepo_Expecter) Complete(ctx interface{}, record interface{}, statusCode interface{}, body interface{}) *MockIdempotencyRepo_Complete_Call {
+	return &MockIdempotencyRepo_Complete_Call{Call: _e.mock.On("Complete", ctx, record, statusCode, body)}
+}
+
+func (_c *MockIdempotencyRepo_Complete_Call) Run(run func(ctx context.Context, record models.IdempotencyRecord, statusCode int, body []byte)) *MockIdempotencyRepo_Complete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.IdempotencyRecord), args[2].(int), args[3].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockIdempotencyRepo_Complete_Call) Return(_a0 error) *MockIdempotencyRepo_Complete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIdempotencyRepo_Complete_Call) RunAndReturn(run func(context.Context, models.IdempotencyRecord, int, []byte) error) *MockIdempotencyRepo_Complete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: ctx, record
+func (_m *MockIdempotencyRepo) Create(ctx context.Context, record models.IdempotencyRecord) error {
+	ret := _m.Called(ctx, record)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.IdempotencyRecord) error); ok {
+		r0 = rf(ctx, record)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIdempotencyRepo_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockIdempotencyRepo_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - record models.IdempotencyRecord
+func (_e *MockIdempotencyRepo_Expecter) Create(ctx interface{}, record interface{}) *MockIdempotencyRepo_Create_Call {
+	return &MockIdempotencyRepo_Create_Call{Call: _e.mock.On("Create", ctx, record)}
+}
+
+func (_c *MockIdempotencyRepo_Create_Call) Run(run func(ctx context.Context, record models.IdempotencyRecord)) *MockIdempotencyRepo_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.IdempotencyRecord))
+	})
+	return _c
+}
+
+func (_c *MockIdempotencyRepo_Create_Call) Return(_a0 error) *MockIdempotencyRepo_Create_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIdempotencyRepo_Create_Call) RunAndReturn(run func(context.Context, models.IdempotencyRecord) error) *MockIdempotencyRepo_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function with given fields: ctx, record
+func (_m *MockIdempotencyRepo) Delete(ctx context.Context, record models.IdempotencyRecord) error {
+	ret := _m.Called(ctx, record)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.IdempotencyRecord) error); ok {
+		r0 = rf(ctx, record)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIdempotencyRepo_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockIdempotencyRepo_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - record models.IdempotencyRecord
+func (_e *MockIdempotencyRepo_Expecter) Delete(ctx interface{}, record interface{}) *MockIdempotencyRepo_Delete_Call {
+	return &MockIdempotencyRepo_Delete_Call{Call: _e.mock.On("Delete", ctx, record)}
+}
+
+func (_c *MockIdempotencyRepo_Delete_Call) Run(run func(ctx context.Context, record models.IdempotencyRecord)) *MockIdempotencyRepo_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.IdempotencyRecord))
+	})
+	return _c
+}
+
+func (_c *MockIdempotencyRepo_Delete_Call) Return(_a0 error) *MockIdempotencyRepo_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIdempotencyRepo_Delete_Call) RunAndReturn(run func(context.Context, models.IdempotencyRecord) error) *MockIdempotencyRepo_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindOne provides a mock function with given fields: ctx, scope, key
+func (_m *MockIdempotencyRepo) FindOne(ctx context.Context, scope string, key string) (models.IdempotencyRecord, error) {
+	ret := _m.Called(ctx, scope, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindOne")
+	}
+
+	var r0 models.IdempotencyRecord
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (models.IdempotencyRecord, error)); ok {
+		return rf(ctx, scope, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) models.IdempotencyRecord); ok {
+		r0 = rf(ctx, scope, key)
+	} else {
+		r0 = ret.Get(0).(models.IdempotencyRecord)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, scope, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIdempotencyRepo_FindOne_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindOne'
+type MockIdempotencyRepo_FindOne_Call struct {
+	*mock.Call
+}
+
+// FindOne is a helper method to define mock.On call
+//   - ctx context.Context
+//   - scope string
+//   - key string
+func (_e *MockIdempotencyRepo_Expecter) FindOne(ctx interface{}, scope interface{}, key interface{}) *MockIdempotencyRepo_FindOne_Call {
+	return &MockIdempotencyRepo_FindOne_Call{Call: _e.mock.On("FindOne", ctx, scope, key)}
+}
+
+func (_c *MockIdempotencyRepo_FindOne_Call) Run(run func(ctx context.Context, scope string, key string)) *MockIdempotencyRepo_FindOne_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockIdempotencyRepo_FindOne_Call) Return(_a0 models.IdempotencyRecord, _a1 error) *MockIdempotencyRepo_FindOne_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIdempotencyRepo_FindOne_Call) RunAndReturn(run func(context.Context, string, string) (models.IdempotencyRecord, error)) *MockIdempotencyRepo_FindOne_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reclaim provides a mock function with given fields: ctx, record, staleBefore
+func (_m *MockIdempotencyRepo) Reclaim(ctx context.Context, record models.IdempotencyRecord, staleBefore time.Time) error {
+	ret := _m.Called(ctx, record, staleBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reclaim")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.IdempotencyRecord, time.Time) error); ok {
+		r0 = rf(ctx, record, staleBefore)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIdempotencyRepo_Reclaim_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reclaim'
+type MockIdempotencyRepo_Reclaim_Call struct {
+	*mock.Call
+}
+
+// Reclaim is a helper method to define mock.On call
+//   - ctx context.Context
+//   - record models.IdempotencyRecord
+//   - staleBefore time.Time
+func (_e *MockIdempotencyRepo_Expecter) Reclaim(ctx interface{}, record interface{}, staleBefore interface{}) *MockIdempotencyRepo_Reclaim_Call {
+	return &MockIdempotencyRepo_Reclaim_Call{Call: _e.mock.On("Reclaim", ctx, record, staleBefore)}
+}
+
+func (_c *MockIdempotencyRepo_Reclaim_Call) Run(run func(ctx context.Context, record models.IdempotencyRecord, staleBefore time.Time)) *MockIdempotencyRepo_Reclaim_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.IdempotencyRecord), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockIdempotencyRepo_Reclaim_Call) Return(_a0 error) *MockIdempotencyRepo_Reclaim_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIdempotencyRepo_Reclaim_Call) RunAndReturn(run func(context.Context, models.IdempotencyRecord, time.Time) error) *MockIdempotencyRepo_Reclaim_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Renew provides a mock function with given fields: ctx, record
+func (_m *MockIdempotencyRepo) Renew(ctx context.Context, record models.IdempotencyRecord) error {
+	ret := _m.Called(ctx, record)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Renew")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.IdempotencyRecord) error); ok {
+		r0 = rf(ctx, record)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIdempotencyRepo_Renew_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Renew'
+type MockIdempotencyRepo_Renew_Call struct {
+	*mock.Call
+}
+
+// Renew is a helper method to define mock.On call
+//   - ctx context.Context
+//   - record models.IdempotencyRecord
+func (_e *MockIdempotencyRepo_Expecter) Renew(ctx interface{}, record interface{}) *MockIdempotencyRepo_Renew_Call {
+	return &MockIdempotencyRepo_Renew_Call{Call: _e.mock.On("Renew", ctx, record)}
+}
+
+func (_c *MockIdempotencyRepo_Renew_Call) Run(run func(ctx context.Context, record models.IdempotencyRecord)) *MockIdempotencyRepo_Renew_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.IdempotencyRecord))
+	})
+	return _c
+}
+
+func (_c *MockIdempotencyRepo_Renew_Call) Return(_a0 error) *MockIdempotencyRepo_Renew_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIdempotencyRepo_Renew_Call) RunAndReturn(run func(context.Context, models.IdempotencyRecord) error) *MockIdempotencyRepo_Renew_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockIdempotencyRepo creates a new instance of MockIdempotencyRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockIdempotencyRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockIdempotencyRepo {
+	mock := &MockIdempotencyRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/gopay/internal/models"
+)
+
+const (
+	createIdempotencyQ = `
+	INSERT INTO idempotency_keys
+	(scope, key, request_hash, created_at, leased_until)
+	VALUES ($1, $2, $3, $4, $5)
+	ON CONFLICT (scope, key) DO NOTHING
+	`
+
+	findOneIdempotencyQ = `
+	SELECT scope, key, request_hash, completed, status_code, response_body, created_at, leased_until
+	FROM idempotency_keys
+	WHERE scope = $1
+	AND key = $2
+	`
+
+	reclaimIdempotencyQ = `
+	UPDATE idempotency_keys
+	SET request_hash = $3, completed = false, status_code = NULL, response_body = NULL, created_at = $4, leased_until = $5
+	WHERE scope = $1
+	AND key = $2
+	AND (created_at < $6 OR (NOT completed AND leased_until < $4))
+	`
+
+	renewIdempotencyQ = `
+	UPDATE idempotency_keys
+	SET leased_until = $4
+	WHERE scope = $1
+	AND key = $2
+	AND created_at = $3
+	AND NOT completed
+	`
+
+	completeIdempotencyQ = `
+	UPDATE idempotency_keys
+	SET completed = true, status_code = $4, response_body = $5
+	WHERE scope = $1
+	AND key = $2
+	AND created_at = $3
+	`
+
+	deleteIdempotencyQ = `
+	DELETE FROM idempotency_keys
+	WHERE scope = $1
+	AND key = $2
+	AND created_at = $3
+	`
+)
+
+type IdempotencyRepoPsql interface {
+	FindOne(ctx context.Context, scope string, key string) (models.IdempotencyRecord, error)
+	Create(ctx context.Context, record models.IdempotencyRecord) error
+	Reclaim(ctx context.Context, record models.IdempotencyRecord, staleBefore time.Time) error
+	Renew(ctx context.Context, record models.IdempotencyRecord) error
+	Complete(ctx context.Context, record models.IdempotencyRecord, statusCode int, body []byte) error
+	Delete(ctx context.Context, record models.IdempotencyRecord) error
+}
+
+var _ IdempotencyRepoPsql = (*idempotencyRepoPsqlImpl)(nil)
+
+type idempotencyRepoPsqlImpl struct {
+	psql *sql.DB
+}
+
+func NewIdempotencyRepoPsql(db *sql.DB) *idempotencyRepoPsqlImpl {
+	return &idempotencyRepoPsqlImpl{
+		psql: db,
+	}
+}
+
+func (r *idempotencyRepoPsqlImpl) FindOne(ctx context.Context, scope string, key string) (models.IdempotencyRecord, error) {
+	record := models.IdempotencyRecord{}
+	var statusCode sql.NullInt64
+
+	row := conn(ctx, r.psql).QueryRowContext(ctx, findOneIdempotencyQ, scope, key)
+	err := row.Scan(&record.Scope, &record.Key, &record.RequestHash, &record.Completed, &statusCode, &record.Body, &record.CreatedAt, &record.LeasedUntil)
+	if err == sql.ErrNoRows {
+		return models.IdempotencyRecord{}, ErrIdempotencyKeyNotFound
+	}
+	if err != nil {
+		return models.IdempotencyRecord{}, err
+	}
+
+	record.StatusCode = int(statusCode.Int64)
+
+	return record, nil
+}
+
+func (r *idempotencyRepoPsqlImpl) Create(ctx context.Context, record models.IdempotencyRecord) error {
+	if record.Scope == "" || record.Key == "" {
+		return ErrMissingFields
+	}
+
+	res, err := conn(ctx, r.psql).ExecContext(ctx, createIdempotencyQ, record.Scope, record.Key, record.RequestHash, record.CreatedAt, record.LeasedUntil)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrIdempotencyKeyExists
+	}
+
+	return nil
+}
+
+func (r *idempotencyRepoPsqlImpl) Reclaim(ctx context.Context, record models.IdempotencyRecord, staleBefore time.Time) error {
+	res, err := conn(ctx, r.psql).ExecContext(ctx, reclaimIdempotencyQ, record.Scope, record.Key, record.RequestHash, record.CreatedAt, record.LeasedUntil, staleBefore)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrIdempotencyKeyNotFound
+	}
+
+	return nil
+}
+
+func (r *idempotencyRepoPsqlImpl) Renew(ctx context.Context, record models.IdempotencyRecord) error {
+	res, err := conn(ctx, r.psql).ExecContext(ctx, renewIdempotencyQ, record.Scope, record.Key, record.CreatedAt, record.LeasedUntil)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrIdempotencyKeyNotFound
+	}
+
+	return nil
+}
+
+func (r *idempotencyRepoPsqlImpl) Complete(ctx context.Context, record models.IdempotencyRecord, statusCode int, body []byte) error {
+	res, err := conn(ctx, r.psql).ExecContext(ctx, completeIdempotencyQ, record.Scope, record.Key, record.CreatedAt, statusCode, body)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrIdempotencyKeyNotFound
+	}
+
+	return nil
+}
+
+func (r *idempotencyRepoPsqlImpl) Delete(ctx context.Context, record models.IdempotencyRecord) error {
+	_, err := conn(ctx, r.psql).ExecContext(ctx, deleteIdempotencyQ, record.Scope, record.Key, record.CreatedAt)
+	return err
+}
+
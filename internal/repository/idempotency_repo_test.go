@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gopay/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotency_Create(t *testing.T) {
+	now := time.Now()
+
+	type args struct {
+		ctx    context.Context
+		data   map[string]models.IdempotencyRecord
+		record models.IdempotencyRecord
+	}
+
+	scenarios := map[string]struct {
+		given   args
+		wantErr error
+	}{
+		"happy-path": {
+			given: args{
+				ctx:  context.Background(),
+				data: map[string]models.IdempotencyRecord{},
+				record: models.IdempotencyRecord{
+					Scope:       "0001",
+					Key:         "key-1",
+					RequestHash: "hash",
+					CreatedAt:   now,
+				},
+			},
+			wantErr: nil,
+		},
+		"same-key-other-account": {
+			given: args{
+				ctx: context.Background(),
+				data: map[string]models.IdempotencyRecord{
+					"0002/key-1": {Scope: "0002", Key: "key-1", RequestHash: "hash", CreatedAt: now},
+				},
+				record: models.IdempotencyRecord{
+					Scope:       "0001",
+					Key:         "key-1",
+					RequestHash: "hash",
+					CreatedAt:   now,
+				},
+			},
+			wantErr: nil,
+		},
+		"duplicate-key": {
+			given: args{
+				ctx: context.Background(),
+				data: map[string]models.IdempotencyRecord{
+					"0001/key-1": {Scope: "0001", Key: "key-1", RequestHash: "hash", CreatedAt: now},
+				},
+				record: models.IdempotencyRecord{
+					Scope:       "0001",
+					Key:         "key-1",
+					RequestHash: "other",
+					CreatedAt:   now,
+				},
+			},
+			wantErr: ErrIdempotencyKeyExists,
+		},
+		"missing-key": {
+			given: args{
+				ctx:    context.Background(),
+				data:   map[string]models.IdempotencyRecord{},
+				record: models.IdempotencyRecord{Scope: "0001"},
+			},
+			wantErr: ErrMissingFields,
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			repo := setupIdempotency(t, tcase.given.data)
+
+			err := repo.Create(tcase.given.ctx, tcase.given.record)
+
+			if tcase.wantErr == nil {
+				assert.NoError(t, err)
+				record, err := repo.FindOne(tcase.given.ctx, tcase.given.record.Scope, tcase.given.record.Key)
+				assert.NoError(t, err)
+				assert.Equal(t, tcase.given.record, record)
+			} else {
+				assert.ErrorIs(t, err, tcase.wantErr)
+			}
+		})
+	}
+}
+
+func TestIdempotency_Reclaim(t *testing.T) {
+	now := time.Now()
+	staleBefore := now.Add(-24 * time.Hour)
+	claim := models.IdempotencyRecord{
+		Scope:       "0001",
+		Key:         "key-1",
+		RequestHash: "other",
+		CreatedAt:   now,
+		LeasedUntil: now.Add(time.Minute),
+	}
+
+	scenarios := map[string]struct {
+		given   models.IdempotencyRecord
+		wantErr error
+	}{
+		"expired": {
+			given: models.IdempotencyRecord{
+				Scope:       "0001",
+				Key:         "key-1",
+				RequestHash: "hash",
+				Completed:   true,
+				StatusCode:  201,
+				CreatedAt:   now.Add(-48 * time.Hour),
+				LeasedUntil: now.Add(-48 * time.Hour),
+			},
+			wantErr: nil,
+		},
+		"lease-ran-out": {
+			given: models.IdempotencyRecord{
+				Scope:       "0001",
+				Key:         "key-1",
+				RequestHash: "hash",
+				CreatedAt:   now.Add(-2 * time.Minute),
+				LeasedUntil: now.Add(-time.Minute),
+			},
+			wantErr: nil,
+		},
+		"lease-held": {
+			given: models.IdempotencyRecord{
+				Scope:       "0001",
+				Key:         "key-1",
+				RequestHash: "hash",
+				CreatedAt:   now.Add(-2 * time.Minute),
+				LeasedUntil: now.Add(time.Second),
+			},
+			wantErr: ErrIdempotencyKeyNotFound,
+		},
+		"completed": {
+			given: models.IdempotencyRecord{
+				Scope:       "0001",
+				Key:         "key-1",
+				RequestHash: "hash",
+				Completed:   true,
+				StatusCode:  201,
+				CreatedAt:   now.Add(-2 * time.Minute),
+				LeasedUntil: now.Add(-time.Minute),
+			},
+			wantErr: ErrIdempotencyKeyNotFound,
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := setupIdempotency(t, map[string]models.IdempotencyRecord{"0001/key-1": tcase.given})
+
+			err := repo.Reclaim(ctx, claim, staleBefore)
+
+			record, _ := repo.FindOne(ctx, "0001", "key-1")
+			if tcase.wantErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, claim, record)
+
+				// Whoever comes second finds the key taken.
+				assert.ErrorIs(t, repo.Reclaim(ctx, claim, staleBefore), ErrIdempotencyKeyNotFound)
+			} else {
+				assert.ErrorIs(t, err, tcase.wantErr)
+				assert.Equal(t, tcase.given, record)
+			}
+		})
+	}
+}
+
+func TestIdempotency_Complete(t *testing.T) {
+	now := time.Now()
+	ctx := context.Background()
+	claim := models.IdempotencyRecord{
+		Scope:       "0001",
+		Key:         "key-1",
+		RequestHash: "hash",
+		CreatedAt:   now,
+		LeasedUntil: now.Add(time.Minute),
+	}
+
+	repo := setupIdempotency(t, map[string]models.IdempotencyRecord{
+		"0001/key-1": claim,
+	})
+
+	renewed := claim
+	renewed.LeasedUntil = now.Add(2 * time.Minute)
+	assert.NoError(t, repo.Renew(ctx, renewed))
+
+	// A request that lost the key to a reclaim cannot touch it any more.
+	stale := claim
+	stale.CreatedAt = now.Add(-time.Hour)
+	assert.ErrorIs(t, repo.Renew(ctx, stale), ErrIdempotencyKeyNotFound)
+	assert.ErrorIs(t, repo.Complete(ctx, stale, 201, nil), ErrIdempotencyKeyNotFound)
+	assert.NoError(t, repo.Delete(ctx, stale))
+
+	err := repo.Complete(ctx, claim, 201, []byte(`{}`))
+	assert.NoError(t, err)
+
+	record, err := repo.FindOne(ctx, "0001", "key-1")
+	assert.NoError(t, err)
+	assert.Equal(t, models.IdempotencyRecord{
+		Scope:       "0001",
+		Key:         "key-1",
+		RequestHash: "hash",
+		Completed:   true,
+		StatusCode:  201,
+		Body:        []byte(`{}`),
+		CreatedAt:   now,
+		LeasedUntil: now.Add(2 * time.Minute),
+	}, record)
+
+	assert.ErrorIs(t, repo.Renew(ctx, renewed), ErrIdempotencyKeyNotFound)
+
+	other := claim
+	other.Key = "key-2"
+	err = repo.Complete(ctx, other, 201, nil)
+	assert.ErrorIs(t, err, ErrIdempotencyKeyNotFound)
+
+	assert.NoError(t, repo.Delete(ctx, claim))
+	_, err = repo.FindOne(ctx, "0001", "key-1")
+	assert.ErrorIs(t, err, ErrIdempotencyKeyNotFound)
+}
+
+func setupIdempotency(_ *testing.T, initialData map[string]models.IdempotencyRecord) *idempotencyRepoImpl {
+	repo := NewIdempotencyRepo()
+	repo.records = initialData
+	return repo
+}
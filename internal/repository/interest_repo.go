@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/gopay/internal/models"
+)
+
+var (
+	ErrInterestAccrualNotFound = errors.New("interest accrual not found")
+)
+
+type InterestRepo interface {
+	Open(ctx context.Context, accrual models.InterestAccrual) error
+	FindOne(ctx context.Context, accId string) (models.InterestAccrual, error)
+	Claim(ctx context.Context, accId string) (models.InterestAccrual, error)
+	Save(ctx context.Context, accrual models.InterestAccrual) error
+}
+
+var _ InterestRepo = (*interestRepoImpl)(nil)
+
+type interestRepoImpl struct {
+	mu       sync.RWMutex
+	accruals map[string]models.InterestAccrual
+	claims   *keyedLock
+}
+
+func NewInterestRepo() *interestRepoImpl {
+	return &interestRepoImpl{
+		accruals: make(map[string]models.InterestAccrual),
+		claims:   newKeyedLock(0, ErrInterestAccrualNotFound),
+	}
+}
+
+// Open starts accrual for its account unless the account already has one.
+func (r *interestRepoImpl) Open(ctx context.Context, accrual models.InterestAccrual) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, found := r.accruals[accrual.AccountId]; found {
+		return nil
+	}
+
+	r.accruals[accrual.AccountId] = accrual
+	onRollback(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.accruals, accrual.AccountId)
+	})
+
+	return nil
+}
+
+func (r *interestRepoImpl) FindOne(_ context.Context, accId string) (models.InterestAccrual, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	accrual, found := r.accruals[accId]
+	if !found {
+		return models.InterestAccrual{}, ErrInterestAccrualNotFound
+	}
+
+	return accrual, nil
+}
+
+// Claim returns the accrual of accId and keeps other workers off it until the unit of
+// work bound to ctx ends. Accruals already claimed are not found.
+func (r *interestRepoImpl) Claim(ctx context.Context, accId string) (models.InterestAccrual, error) {
+	err := r.claims.lockInTx(ctx, accId)
+	if err != nil {
+		return models.InterestAccrual{}, err
+	}
+
+	return r.FindOne(ctx, accId)
+}
+
+func (r *interestRepoImpl) Save(ctx context.Context, accrual models.InterestAccrual) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, found := r.accruals[accrual.AccountId]
+	if !found {
+		return ErrInterestAccrualNotFound
+	}
+
+	r.accruals[accrual.AccountId] = accrual
+	onRollback(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.accruals[accrual.AccountId] = previous
+	})
+
+	return nil
+}
@@ -0,0 +1,245 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package repository
+
+import (
+	context "context"
+
+	models "github.com/gopay/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockInterestRepo is an autogenerated mock type for the InterestRepo type
+type MockInterestRepo struct {
+	mock.Mock
+}
+
+type MockInterestRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockInterestRepo) EXPECT() *MockInterestRepo_Expecter {
+	return &MockInterestRepo_Expecter{mock: &_m.Mock}
+}
+
+// Claim provides a mock function with given fields: ctx, accId
+func (_m *MockInterestRepo) Claim(ctx context.Context, accId string) (models.InterestAccrual, error) {
+	ret := _m.Called(ctx, accId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Claim")
+	}
+
+	var r0 models.InterestAccrual
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.InterestAccrual, error)); ok {
+		return rf(ctx, accId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.InterestAccrual); ok {
+		r0 = rf(ctx, accId)
+	} else {
+		r0 = ret.Get(0).(models.InterestAccrual)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, accId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockInterestRepo_Claim_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Claim'
+type MockInterestRepo_Claim_Call struct {
+	*mock.Call
+}
+
+// Claim is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accId string
+func (_e *MockInterestRepo_Expecter) Claim(ctx interface{}, accId interface{}) *MockInterestRepo_Claim_Call {
+	return &MockInterestRepo_Claim_Call{Call: _e.mock.On("Claim", ctx, accId)}
+}
+
+func (_c *MockInterestRepo_Claim_Call) Run(run func(ctx context.Context, accId string)) *MockInterestRepo_Claim_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockInterestRepo_Claim_Call) Return(_a0 models.InterestAccrual, _a1 error) *MockInterestRepo_Claim_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockInterestRepo_Claim_Call) RunAndReturn(run func(context.Context, string) (models.InterestAccrual, error)) *MockInterestRepo_Claim_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindOne provides a mock function with given fields: ctx, accId
+func (_m *MockInterestRepo) FindOne(ctx context.Context, accId string) (models.InterestAccrual, error) {
+	ret := _m.Called(ctx, accId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindOne")
+	}
+
+	var r0 models.InterestAccrual
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.InterestAccrual, error)); ok {
+		return rf(ctx, accId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.InterestAccrual); ok {
+		r0 = rf(ctx, accId)
+	} else {
+		r0 = ret.Get(0).(models.InterestAccrual)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, accId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockInterestRepo_FindOne_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindOne'
+type MockInterestRepo_FindOne_Call struct {
+	*mock.Call
+}
+
+// FindOne is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accId string
+func (_e *MockInterestRepo_Expecter) FindOne(ctx interface{}, accId interface{}) *MockInterestRepo_FindOne_Call {
+	return &MockInterestRepo_FindOne_Call{Call: _e.mock.On("FindOne", ctx, accId)}
+}
+
+func (_c *MockInterestRepo_FindOne_Call) Run(run func(ctx context.Context, accId string)) *MockInterestRepo_FindOne_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockInterestRepo_FindOne_Call) Return(_a0 models.InterestAccrual, _a1 error) *MockInterestRepo_FindOne_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockInterestRepo_FindOne_Call) RunAndReturn(run func(context.Context, string) (models.InterestAccrual, error)) *MockInterestRepo_FindOne_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Open provides a mock function with given fields: ctx, accrual
+func (_m *MockInterestRepo) Open(ctx context.Context, accrual models.InterestAccrual) error {
+	ret := _m.Called(ctx, accrual)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Open")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.InterestAccrual) error); ok {
+		r0 = rf(ctx, accrual)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockInterestRepo_Open_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Open'
+type MockInterestRepo_Open_Call struct {
+	*mock.Call
+}
+
+// Open is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accrual models.InterestAccrual
+func (_e *MockInterestRepo_Expecter) Open(ctx interface{}, accrual interface{}) *MockInterestRepo_Open_Call {
+	return &MockInterestRepo_Open_Call{Call: _e.mock.On("Open", ctx, accrual)}
+}
+
+func (_c *MockInterestRepo_Open_Call) Run(run func(ctx context.Context, accrual models.InterestAccrual)) *MockInterestRepo_Open_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.InterestAccrual))
+	})
+	return _c
+}
+
+func (_c *MockInterestRepo_Open_Call) Return(_a0 error) *MockInterestRepo_Open_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockInterestRepo_Open_Call) RunAndReturn(run func(context.Context, models.InterestAccrual) error) *MockInterestRepo_Open_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function with given fields: ctx, accrual
+func (_m *MockInterestRepo) Save(ctx context.Context, accrual models.InterestAccrual) error {
+	ret := _m.Called(ctx, accrual)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.InterestAccrual) error); ok {
+		r0 = rf(ctx, accrual)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockInterestRepo_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type MockInterestRepo_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accrual models.InterestAccrual
+func (_e *MockInterestRepo_Expecter) Save(ctx interface{}, accrual interface{}) *MockInterestRepo_Save_Call {
+	return &MockInterestRepo_Save_Call{Call: _e.mock.On("Save", ctx, accrual)}
+}
+
+func (_c *MockInterestRepo_Save_Call) Run(run func(ctx context.Context, accrual models.InterestAccrual)) *MockInterestRepo_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.InterestAccrual))
+	})
+	return _c
+}
+
+func (_c *MockInterestRepo_Save_Call) Return(_a0 error) *MockInterestRepo_Save_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockInterestRepo_Save_Call) RunAndReturn(run func(context.Context, models.InterestAccrual) error) *MockInterestRepo_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockInterestRepo creates a new instance of MockInterestRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockInterestRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockInterestRepo {
+	mock := &MockInterestRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/gopay/internal/models"
+)
+
+const (
+	openInterestAccrualQ = `
+	INSERT INTO interest_accruals
+	(account_id, accrued, accrued_through, updated_at)
+	VALUES ($1, $2, $3, $4)
+	ON CONFLICT (account_id) DO NOTHING
+	`
+
+	findInterestAccrualQ = `
+	SELECT account_id, accrued, accrued_through, updated_at
+	FROM interest_accruals
+	WHERE account_id = $1
+	`
+
+	claimInterestAccrualQ = `
+	SELECT account_id, accrued, accrued_through, updated_at
+	FROM interest_accruals
+	WHERE account_id = $1
+	FOR UPDATE SKIP LOCKED
+	`
+
+	saveInterestAccrualQ = `
+	UPDATE interest_accruals
+	SET accrued = $2, accrued_through = $3, updated_at = $4
+	WHERE account_id = $1
+	`
+)
+
+type InterestRepoPsql interface {
+	Open(ctx context.Context, accrual models.InterestAccrual) error
+	FindOne(ctx context.Context, accId string) (models.InterestAccrual, error)
+	Claim(ctx context.Context, accId string) (models.InterestAccrual, error)
+	Save(ctx context.Context, accrual models.InterestAccrual) error
+}
+
+var _ InterestRepoPsql = (*interestRepoPsqlImpl)(nil)
+
+type interestRepoPsqlImpl struct {
+	psql *sql.DB
+}
+
+func NewInterestRepoPsql(db *sql.DB) *interestRepoPsqlImpl {
+	return &interestRepoPsqlImpl{
+		psql: db,
+	}
+}
+
+// Open starts accrual for its account unless the account already has one.
+func (r *interestRepoPsqlImpl) Open(ctx context.Context, accrual models.InterestAccrual) error {
+	_, err := conn(ctx, r.psql).ExecContext(ctx, openInterestAccrualQ, accrual.AccountId, accrual.Accrued, accrual.AccruedThrough, accrual.UpdatedAt)
+	return err
+}
+
+func (r *interestRepoPsqlImpl) FindOne(ctx context.Context, accId string) (models.InterestAccrual, error) {
+	if uuid.Validate(accId) != nil {
+		return models.InterestAccrual{}, ErrInterestAccrualNotFound
+	}
+
+	return r.scan(conn(ctx, r.psql).QueryRowContext(ctx, findInterestAccrualQ, accId))
+}
+
+// Claim locks the accrual of accId for the surrounding unit of work. Accruals already
+// claimed by another replica are skipped and reported as ErrInterestAccrualNotFound.
+func (r *interestRepoPsqlImpl) Claim(ctx context.Context, accId string) (models.InterestAccrual, error) {
+	if !inTx(ctx) {
+		return models.InterestAccrual{}, ErrNoUnitOfWork
+	}
+
+	return r.scan(conn(ctx, r.psql).QueryRowContext(ctx, claimInterestAccrualQ, accId))
+}
+
+func (r *interestRepoPsqlImpl) Save(ctx context.Context, accrual models.InterestAccrual) error {
+	res, err := conn(ctx, r.psql).ExecContext(ctx, saveInterestAccrualQ, accrual.AccountId, accrual.Accrued, accrual.AccruedThrough, accrual.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrInterestAccrualNotFound
+	}
+
+	return nil
+}
+
+func (r *interestRepoPsqlImpl) scan(row *sql.Row) (models.InterestAccrual, error) {
+	a := models.InterestAccrual{}
+
+	err := row.Scan(&a.AccountId, &a.Accrued, &a.AccruedThrough, &a.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return models.InterestAccrual{}, ErrInterestAccrualNotFound
+	}
+	if err != nil {
+		return models.InterestAccrual{}, err
+	}
+
+	return a, nil
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gopay/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterest_Open(t *testing.T) {
+	ctx := context.Background()
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	repo := setupInterestAccruals(t, map[string]models.InterestAccrual{
+		"0001": {AccountId: "0001", Accrued: 1500, AccruedThrough: day},
+	})
+
+	assert.NoError(t, repo.Open(ctx, models.InterestAccrual{AccountId: "0001", AccruedThrough: day.AddDate(0, 0, 5)}))
+	assert.NoError(t, repo.Open(ctx, models.InterestAccrual{AccountId: "0002", AccruedThrough: day}))
+
+	accrual, err := repo.FindOne(ctx, "0001")
+	assert.NoError(t, err)
+	assert.Equal(t, models.InterestAccrual{AccountId: "0001", Accrued: 1500, AccruedThrough: day}, accrual)
+
+	accrual, err = repo.FindOne(ctx, "0002")
+	assert.NoError(t, err)
+	assert.Equal(t, models.InterestAccrual{AccountId: "0002", AccruedThrough: day}, accrual)
+
+	_, err = repo.FindOne(ctx, "0003")
+	assert.ErrorIs(t, err, ErrInterestAccrualNotFound)
+}
+
+func TestInterest_Claim(t *testing.T) {
+	ctx := context.Background()
+	errBoom := errors.New("boom")
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	repo := setupInterestAccruals(t, map[string]models.InterestAccrual{
+		"0001": {AccountId: "0001", Accrued: 1500, AccruedThrough: day},
+	})
+
+	_, err := repo.Claim(ctx, "0001")
+	assert.ErrorIs(t, err, ErrNoUnitOfWork)
+
+	err = NewUnitOfWork().Do(ctx, func(ctx context.Context) error {
+		accrual, err := repo.Claim(ctx, "0001")
+		assert.NoError(t, err)
+
+		// Another worker skips the claimed accrual.
+		err = NewUnitOfWork().Do(context.Background(), func(ctx context.Context) error {
+			_, err := repo.Claim(ctx, "0001")
+			return err
+		})
+		assert.ErrorIs(t, err, ErrInterestAccrualNotFound)
+
+		accrual.Accrued = 2500
+		accrual.AccruedThrough = day.AddDate(0, 0, 1)
+		assert.NoError(t, repo.Save(ctx, accrual))
+		assert.NoError(t, repo.Open(ctx, models.InterestAccrual{AccountId: "0002", AccruedThrough: day}))
+		return errBoom
+	})
+	assert.ErrorIs(t, err, errBoom)
+
+	accrual, err := repo.FindOne(ctx, "0001")
+	assert.NoError(t, err)
+	assert.Equal(t, models.InterestAccrual{AccountId: "0001", Accrued: 1500, AccruedThrough: day}, accrual)
+
+	_, err = repo.FindOne(ctx, "0002")
+	assert.ErrorIs(t, err, ErrInterestAccrualNotFound)
+
+	assert.ErrorIs(t, repo.Save(ctx, models.InterestAccrual{AccountId: "0003"}), ErrInterestAccrualNotFound)
+}
+
+func setupInterestAccruals(_ *testing.T, initialData map[string]models.InterestAccrual) *interestRepoImpl {
+	repo := NewInterestRepo()
+	repo.accruals = initialData
+	return repo
+}
@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gopay/internal/models"
+)
+
+var (
+	ErrUnbalancedEntry = errors.New("journal entry postings do not sum to zero")
+	ErrIncompleteEntry = errors.New("journal entry needs at least two postings")
+)
+
+type LedgerRepo interface {
+	CreateEntry(ctx context.Context, entry models.JournalEntry) (string, error)
+	FindEntries(ctx context.Context, accId string) ([]models.JournalEntry, error)
+	GetBalance(ctx context.Context, accId string) (models.Money, error)
+	GetBalanceAt(ctx context.Context, accId string, at time.Time) (models.Money, error)
+	FindUnbalanced(ctx context.Context) ([]string, error)
+}
+
+var _ LedgerRepo = (*ledgerRepoImpl)(nil)
+
+type ledgerRepoImpl struct {
+	mu          sync.RWMutex
+	entries     map[string]models.JournalEntry
+	idGenerator func() string
+}
+
+func NewLedgerRepo() *ledgerRepoImpl {
+	return &ledgerRepoImpl{
+		entries:     make(map[string]models.JournalEntry),
+		idGenerator: uuid.NewString,
+	}
+}
+
+// validateEntry enforces the double-entry invariant before anything is written.
+func validateEntry(entry models.JournalEntry) error {
+	if len(entry.Postings) < 2 {
+		return ErrIncompleteEntry
+	}
+
+	var sum models.Money
+	for _, p := range entry.Postings {
+		if p.AccountId == "" {
+			return ErrAccountMissing
+		}
+		if p.Amount == 0 {
+			return ErrZeroAmount
+		}
+		sum += p.Amount
+	}
+
+	if sum != 0 {
+		return ErrUnbalancedEntry
+	}
+
+	return nil
+}
+
+func (r *ledgerRepoImpl) CreateEntry(ctx context.Context, entry models.JournalEntry) (string, error) {
+	err := validateEntry(entry)
+	if err != nil {
+		return "", err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := r.idGenerator()
+	entry.EntryId = id
+
+	postings := make([]models.Posting, 0, len(entry.Postings))
+	for _, p := range entry.Postings {
+		p.PostingId = r.idGenerator()
+		p.EntryId = id
+		postings = append(postings, p)
+	}
+	entry.Postings = postings
+
+	r.entries[id] = entry
+	onRollback(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.entries, id)
+	})
+
+	return id, nil
+}
+
+func (r *ledgerRepoImpl) FindEntries(_ context.Context, accId string) ([]models.JournalEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entries := []models.JournalEntry{}
+
+	for _, e := range r.entries {
+		for _, p := range e.Postings {
+			if p.AccountId == accId {
+				entries = append(entries, e)
+				break
+			}
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].CreatedAt.Before(entries[j].CreatedAt)
+	})
+
+	return entries, nil
+}
+
+func (r *ledgerRepoImpl) GetBalance(_ context.Context, accId string) (models.Money, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var balance models.Money
+
+	for _, e := range r.entries {
+		for _, p := range e.Postings {
+			if p.AccountId == accId {
+				balance += p.Amount
+			}
+		}
+	}
+
+	return balance, nil
+}
+
+// GetBalanceAt returns the balance of accId made of the entries created before at.
+func (r *ledgerRepoImpl) GetBalanceAt(_ context.Context, accId string, at time.Time) (models.Money, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var balance models.Money
+
+	for _, e := range r.entries {
+		if !e.CreatedAt.Before(at) {
+			continue
+		}
+		for _, p := range e.Postings {
+			if p.AccountId == accId {
+				balance += p.Amount
+			}
+		}
+	}
+
+	return balance, nil
+}
+
+func (r *ledgerRepoImpl) FindUnbalanced(_ context.Context) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	unbalanced := []string{}
+
+	for id, e := range r.entries {
+		var sum models.Money
+		for _, p := range e.Postings {
+			sum += p.Amount
+		}
+		if sum != 0 {
+			unbalanced = append(unbalanced, id)
+		}
+	}
+
+	sort.Strings(unbalanced)
+
+	return unbalanced, nil
+}
@@ -0,0 +1,328 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package repository
+
+import (
+	context "context"
+
+	models "github.com/gopay/internal/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockLedgerRepo is an autogenerated mock type for the LedgerRepo type
+type MockLedgerRepo struct {
+	mock.Mock
+}
+
+type MockLedgerRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockLedgerRepo) EXPECT() *MockLedgerRepo_Expecter {
+	return &MockLedgerRepo_Expecter{mock: &_m.Mock}
+}
+
+// CreateEntry provides a mock function with given fields: ctx, entry
+func (_m *MockLedgerRepo) CreateEntry(ctx context.Context, entry models.JournalEntry) (string, error) {
+	ret := _m.Called(ctx, entry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateEntry")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.JournalEntry) (string, error)); ok {
+		return rf(ctx, entry)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.JournalEntry) string); ok {
+		r0 = rf(ctx, entry)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.JournalEntry) error); ok {
+		r1 = rf(ctx, entry)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLedgerRepo_CreateEntry_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateEntry'
+type MockLedgerRepo_CreateEntry_Call struct {
+	*mock.Call
+}
+
+// CreateEntry is a helper method to define mock.On call
+//   - ctx context.Context
+//   - entry models.JournalEntry
+func (_e *MockLedgerRepo_Expecter) CreateEntry(ctx interface{}, entry interface{}) *MockLedgerRepo_CreateEntry_Call {
+	return &MockLedgerRepo_CreateEntry_Call{Call: _e.mock.On("CreateEntry", ctx, entry)}
+}
+
+func (_c *MockLedgerRepo_CreateEntry_Call) Run(run func(ctx context.Context, entry models.JournalEntry)) *MockLedgerRepo_CreateEntry_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.JournalEntry))
+	})
+	return _c
+}
+
+func (_c *MockLedgerRepo_CreateEntry_Call) Return(_a0 string, _a1 error) *MockLedgerRepo_CreateEntry_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLedgerRepo_CreateEntry_Call) RunAndReturn(run func(context.Context, models.JournalEntry) (string, error)) *MockLedgerRepo_CreateEntry_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindEntries provides a mock function with given fields: ctx, accId
+func (_m *MockLedgerRepo) FindEntries(ctx context.Context, accId string) ([]models.JournalEntry, error) {
+	ret := _m.Called(ctx, accId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindEntries")
+	}
+
+	var r0 []models.JournalEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.JournalEntry, error)); ok {
+		return rf(ctx, accId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.JournalEntry); ok {
+		r0 = rf(ctx, accId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.JournalEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, accId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLedgerRepo_FindEntries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindEntries'
+type MockLedgerRepo_FindEntries_Call struct {
+	*mock.Call
+}
+
+// FindEntries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accId string
+func (_e *MockLedgerRepo_Expecter) FindEntries(ctx interface{}, accId interface{}) *MockLedgerRepo_FindEntries_Call {
+	return &MockLedgerRepo_FindEntries_Call{Call: _e.mock.On("FindEntries", ctx, accId)}
+}
+
+func (_c *MockLedgerRepo_FindEntries_Call) Run(run func(ctx context.Context, accId string)) *MockLedgerRepo_FindEntries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockLedgerRepo_FindEntries_Call) Return(_a0 []models.JournalEntry, _a1 error) *MockLedgerRepo_FindEntries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLedgerRepo_FindEntries_Call) RunAndReturn(run func(context.Context, string) ([]models.JournalEntry, error)) *MockLedgerRepo_FindEntries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindUnbalanced provides a mock function with given fields: ctx
+func (_m *MockLedgerRepo) FindUnbalanced(ctx context.Context) ([]string, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindUnbalanced")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]string, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []string); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLedgerRepo_FindUnbalanced_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindUnbalanced'
+type MockLedgerRepo_FindUnbalanced_Call struct {
+	*mock.Call
+}
+
+// FindUnbalanced is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockLedgerRepo_Expecter) FindUnbalanced(ctx interface{}) *MockLedgerRepo_FindUnbalanced_Call {
+	return &MockLedgerRepo_FindUnbalanced_Call{Call: _e.mock.On("FindUnbalanced", ctx)}
+}
+
+func (_c *MockLedgerRepo_FindUnbalanced_Call) Run(run func(ctx context.Context)) *MockLedgerRepo_FindUnbalanced_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockLedgerRepo_FindUnbalanced_Call) Return(_a0 []string, _a1 error) *MockLedgerRepo_FindUnbalanced_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLedgerRepo_FindUnbalanced_Call) RunAndReturn(run func(context.Context) ([]string, error)) *MockLedgerRepo_FindUnbalanced_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBalance provides a mock function with given fields: ctx, accId
+func (_m *MockLedgerRepo) GetBalance(ctx context.Context, accId string) (models.Money, error) {
+	ret := _m.Called(ctx, accId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBalance")
+	}
+
+	var r0 models.Money
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.Money, error)); ok {
+		return rf(ctx, accId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.Money); ok {
+		r0 = rf(ctx, accId)
+	} else {
+		r0 = ret.Get(0).(models.Money)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, accId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLedgerRepo_GetBalance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBalance'
+type MockLedgerRepo_GetBalance_Call struct {
+	*mock.Call
+}
+
+// GetBalance is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accId string
+func (_e *MockLedgerRepo_Expecter) GetBalance(ctx interface{}, accId interface{}) *MockLedgerRepo_GetBalance_Call {
+	return &MockLedgerRepo_GetBalance_Call{Call: _e.mock.On("GetBalance", ctx, accId)}
+}
+
+func (_c *MockLedgerRepo_GetBalance_Call) Run(run func(ctx context.Context, accId string)) *MockLedgerRepo_GetBalance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockLedgerRepo_GetBalance_Call) Return(_a0 models.Money, _a1 error) *MockLedgerRepo_GetBalance_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLedgerRepo_GetBalance_Call) RunAndReturn(run func(context.Context, string) (models.Money, error)) *MockLedgerRepo_GetBalance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBalanceAt provides a mock function with given fields: ctx, accId, at
+func (_m *MockLedgerRepo) GetBalanceAt(ctx context.Context, accId string, at time.Time) (models.Money, error) {
+	ret := _m.Called(ctx, accId, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBalanceAt")
+	}
+
+	var r0 models.Money
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (models.Money, error)); ok {
+		return rf(ctx, accId, at)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) models.Money); ok {
+		r0 = rf(ctx, accId, at)
+	} else {
+		r0 = ret.Get(0).(models.Money)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, accId, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLedgerRepo_GetBalanceAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBalanceAt'
+type MockLedgerRepo_GetBalanceAt_Call struct {
+	*mock.Call
+}
+
+// GetBalanceAt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accId string
+//   - at time.Time
+func (_e *MockLedgerRepo_Expecter) GetBalanceAt(ctx interface{}, accId interface{}, at interface{}) *MockLedgerRepo_GetBalanceAt_Call {
+	return &MockLedgerRepo_GetBalanceAt_Call{Call: _e.mock.On("GetBalanceAt", ctx, accId, at)}
+}
+
+func (_c *MockLedgerRepo_GetBalanceAt_Call) Run(run func(ctx context.Context, accId string, at time.Time)) *MockLedgerRepo_GetBalanceAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockLedgerRepo_GetBalanceAt_Call) Return(_a0 models.Money, _a1 error) *MockLedgerRepo_GetBalanceAt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLedgerRepo_GetBalanceAt_Call) RunAndReturn(run func(context.Context, string, time.Time) (models.Money, error)) *MockLedgerRepo_GetBalanceAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockLedgerRepo creates a new instance of MockLedgerRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockLedgerRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockLedgerRepo {
+	mock := &MockLedgerRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
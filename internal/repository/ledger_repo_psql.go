@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/gopay/internal/models"
+)
+
+const (
+	createEntryQ = `
+	INSERT INTO journal_entries
+	(description, created_at)
+	VALUES ($1, $2)
+	RETURNING entry_id
+	`
+
+	createPostingQ = `
+	INSERT INTO postings
+	(entry_id, account_id, amount)
+	VALUES ($1, $2, $3)
+	`
+
+	findEntriesQ = `
+	SELECT e.entry_id, e.description, e.created_at, p.posting_id, p.account_id, p.amount
+	FROM journal_entries e
+	JOIN postings p ON p.entry_id = e.entry_id
+	WHERE e.entry_id IN (SELECT entry_id FROM postings WHERE account_id = $1)
+	ORDER BY e.created_at ASC, e.entry_id, p.posting_id
+	`
+
+	getLedgerBalanceQ = `
+	SELECT COALESCE(SUM(amount), 0)
+	FROM postings
+	WHERE account_id = $1
+	`
+
+	getLedgerBalanceAtQ = `
+	SELECT COALESCE(SUM(p.amount), 0)
+	FROM postings p
+	JOIN journal_entries e ON e.entry_id = p.entry_id
+	WHERE p.account_id = $1
+	AND e.created_at < $2
+	`
+
+	findUnbalancedQ = `
+	SELECT entry_id
+	FROM postings
+	GROUP BY entry_id
+	HAVING SUM(amount) <> 0
+	ORDER BY entry_id
+	`
+)
+
+type LedgerRepoPsql interface {
+	CreateEntry(ctx context.Context, entry models.JournalEntry) (string, error)
+	FindEntries(ctx context.Context, accId string) ([]models.JournalEntry, error)
+	GetBalance(ctx context.Context, accId string) (models.Money, error)
+	GetBalanceAt(ctx context.Context, accId string, at time.Time) (models.Money, error)
+	FindUnbalanced(ctx context.Context) ([]string, error)
+}
+
+var _ LedgerRepoPsql = (*ledgerRepoPsqlImpl)(nil)
+
+type ledgerRepoPsqlImpl struct {
+	psql *sql.DB
+}
+
+func NewLedgerRepoPsql(db *sql.DB) *ledgerRepoPsqlImpl {
+	return &ledgerRepoPsqlImpl{
+		psql: db,
+	}
+}
+
+// CreateEntry writes the entry and its postings atomically, joining the caller's unit of work if any.
+func (r *ledgerRepoPsqlImpl) CreateEntry(ctx context.Context, entry models.JournalEntry) (string, error) {
+	err := validateEntry(entry)
+	if err != nil {
+		return "", err
+	}
+
+	var id string
+
+	err = NewUnitOfWorkPsql(r.psql).Do(ctx, func(ctx context.Context) error {
+		row := conn(ctx, r.psql).QueryRowContext(ctx, createEntryQ, entry.Description, entry.CreatedAt)
+		err := row.Scan(&id)
+		if err != nil {
+			return err
+		}
+
+		for _, p := range entry.Postings {
+			_, err = conn(ctx, r.psql).ExecContext(ctx, createPostingQ, id, p.AccountId, p.Amount)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (r *ledgerRepoPsqlImpl) FindEntries(ctx context.Context, accId string) ([]models.JournalEntry, error) {
+	entries := []models.JournalEntry{}
+
+	rows, err := conn(ctx, r.psql).QueryContext(ctx, findEntriesQ, accId)
+	if err != nil {
+		return entries, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		e := models.JournalEntry{}
+		p := models.Posting{}
+
+		err = rows.Scan(&e.EntryId, &e.Description, &e.CreatedAt, &p.PostingId, &p.AccountId, &p.Amount)
+		if err != nil {
+			return []models.JournalEntry{}, err
+		}
+		p.EntryId = e.EntryId
+
+		last := len(entries) - 1
+		if last < 0 || entries[last].EntryId != e.EntryId {
+			entries = append(entries, e)
+			last++
+		}
+		entries[last].Postings = append(entries[last].Postings, p)
+	}
+
+	return entries, rows.Err()
+}
+
+func (r *ledgerRepoPsqlImpl) GetBalance(ctx context.Context, accId string) (models.Money, error) {
+	var balance models.Money
+
+	row := conn(ctx, r.psql).QueryRowContext(ctx, getLedgerBalanceQ, accId)
+	err := row.Scan(&balance)
+	if err != nil {
+		return 0, err
+	}
+
+	return balance, nil
+}
+
+// GetBalanceAt returns the balance of accId made of the entries created before at.
+func (r *ledgerRepoPsqlImpl) GetBalanceAt(ctx context.Context, accId string, at time.Time) (models.Money, error) {
+	var balance models.Money
+
+	row := conn(ctx, r.psql).QueryRowContext(ctx, getLedgerBalanceAtQ, accId, at)
+	err := row.Scan(&balance)
+	if err != nil {
+		return 0, err
+	}
+
+	return balance, nil
+}
+
+func (r *ledgerRepoPsqlImpl) FindUnbalanced(ctx context.Context) ([]string, error) {
+	unbalanced := []string{}
+
+	rows, err := conn(ctx, r.psql).QueryContext(ctx, findUnbalancedQ)
+	if err != nil {
+		return unbalanced, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		err = rows.Scan(&id)
+		if err != nil {
+			return []string{}, err
+		}
+		unbalanced = append(unbalanced, id)
+	}
+
+	return unbalanced, rows.Err()
+}
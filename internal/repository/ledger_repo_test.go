@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gopay/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLedger_CreateEntry(t *testing.T) {
+	now := time.Now()
+
+	type args struct {
+		ctx   context.Context
+		entry models.JournalEntry
+	}
+
+	scenarios := map[string]struct {
+		given   args
+		wantErr error
+	}{
+		"happy-path": {
+			given: args{
+				ctx: context.Background(),
+				entry: models.JournalEntry{
+					Description: "payment",
+					CreatedAt:   now,
+					Postings: []models.Posting{
+						{AccountId: "0001", Amount: -500},
+						{AccountId: "0002", Amount: 500},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		"unbalanced": {
+			given: args{
+				ctx: context.Background(),
+				entry: models.JournalEntry{
+					Description: "payment",
+					CreatedAt:   now,
+					Postings: []models.Posting{
+						{AccountId: "0001", Amount: -500},
+						{AccountId: "0002", Amount: 499},
+					},
+				},
+			},
+			wantErr: ErrUnbalancedEntry,
+		},
+		"single-posting": {
+			given: args{
+				ctx: context.Background(),
+				entry: models.JournalEntry{
+					Description: "deposit",
+					CreatedAt:   now,
+					Postings: []models.Posting{
+						{AccountId: "0001", Amount: 500},
+					},
+				},
+			},
+			wantErr: ErrIncompleteEntry,
+		},
+		"zero-posting": {
+			given: args{
+				ctx: context.Background(),
+				entry: models.JournalEntry{
+					Description: "deposit",
+					CreatedAt:   now,
+					Postings: []models.Posting{
+						{AccountId: "0001", Amount: 0},
+						{AccountId: "0002", Amount: 0},
+					},
+				},
+			},
+			wantErr: ErrZeroAmount,
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			repo := NewLedgerRepo()
+
+			_, err := repo.CreateEntry(tcase.given.ctx, tcase.given.entry)
+
+			if tcase.wantErr == nil {
+				assert.NoError(t, err)
+				for _, p := range tcase.given.entry.Postings {
+					balance, err := repo.GetBalance(tcase.given.ctx, p.AccountId)
+					assert.NoError(t, err)
+					assert.Equal(t, p.Amount, balance)
+				}
+			} else {
+				assert.ErrorIs(t, err, tcase.wantErr)
+				assert.Empty(t, repo.entries)
+			}
+		})
+	}
+}
+
+func TestLedger_FindUnbalanced(t *testing.T) {
+	now := time.Now()
+	ctx := context.Background()
+
+	repo := NewLedgerRepo()
+	repo.entries = map[string]models.JournalEntry{
+		"e-1": {
+			EntryId:   "e-1",
+			CreatedAt: now,
+			Postings: []models.Posting{
+				{AccountId: "0001", Amount: -500},
+				{AccountId: "0002", Amount: 500},
+			},
+		},
+		"e-2": {
+			EntryId:   "e-2",
+			CreatedAt: now.Add(time.Second),
+			Postings: []models.Posting{
+				{AccountId: "0001", Amount: -500},
+				{AccountId: "0002", Amount: 400},
+			},
+		},
+	}
+
+	unbalanced, err := repo.FindUnbalanced(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"e-2"}, unbalanced)
+
+	entries, err := repo.FindEntries(ctx, "0002")
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "e-1", entries[0].EntryId)
+}
+
+func TestLedger_GetBalanceAt(t *testing.T) {
+	midnight := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+
+	repo := NewLedgerRepo()
+	repo.entries = map[string]models.JournalEntry{
+		"e-1": {
+			EntryId:   "e-1",
+			CreatedAt: midnight.Add(-time.Hour),
+			Postings: []models.Posting{
+				{AccountId: models.SystemCashInAccount, Amount: -1000},
+				{AccountId: "0001", Amount: 1000},
+			},
+		},
+		"e-2": {
+			EntryId:   "e-2",
+			CreatedAt: midnight,
+			Postings: []models.Posting{
+				{AccountId: "0001", Amount: -300},
+				{AccountId: "0002", Amount: 300},
+			},
+		},
+	}
+
+	balance, err := repo.GetBalanceAt(ctx, "0001", midnight)
+	assert.NoError(t, err)
+	assert.Equal(t, models.Money(1000), balance)
+
+	balance, err = repo.GetBalanceAt(ctx, "0001", midnight.Add(time.Nanosecond))
+	assert.NoError(t, err)
+	assert.Equal(t, models.Money(700), balance)
+
+	balance, err = repo.GetBalanceAt(ctx, "0002", midnight)
+	assert.NoError(t, err)
+	assert.Equal(t, models.Money(0), balance)
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gopay/internal/models"
+)
+
+type LimitRepo interface {
+	FindOne(ctx context.Context, accId string) (models.LimitOverrides, error)
+	Save(ctx context.Context, accId string, overrides models.LimitOverrides) error
+}
+
+var _ LimitRepo = (*limitRepoImpl)(nil)
+
+type limitRepoImpl struct {
+	mu        sync.RWMutex
+	overrides map[string]models.LimitOverrides
+}
+
+func NewLimitRepo() *limitRepoImpl {
+	return &limitRepoImpl{
+		overrides: make(map[string]models.LimitOverrides),
+	}
+}
+
+// FindOne returns the limit overrides of accId, none when it has not got any.
+func (r *limitRepoImpl) FindOne(_ context.Context, accId string) (models.LimitOverrides, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.overrides[accId], nil
+}
+
+// Save replaces the limit overrides of accId.
+func (r *limitRepoImpl) Save(ctx context.Context, accId string, overrides models.LimitOverrides) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous, found := r.overrides[accId]
+	r.overrides[accId] = overrides
+	onRollback(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if found {
+			r.overrides[accId] = previous
+		} else {
+			delete(r.overrides, accId)
+		}
+	})
+
+	return nil
+}
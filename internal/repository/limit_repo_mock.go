@@ -0,0 +1,142 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package repository
+
+import (
+	context "context"
+
+	models "github.com/gopay/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockLimitRepo is an autogenerated mock type for the LimitRepo type
+type MockLimitRepo struct {
+	mock.Mock
+}
+
+type MockLimitRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockLimitRepo) EXPECT() *MockLimitRepo_Expecter {
+	return &MockLimitRepo_Expecter{mock: &_m.Mock}
+}
+
+// FindOne provides a mock function with given fields: ctx, accId
+func (_m *MockLimitRepo) FindOne(ctx context.Context, accId string) (models.LimitOverrides, error) {
+	ret := _m.Called(ctx, accId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindOne")
+	}
+
+	var r0 models.LimitOverrides
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.LimitOverrides, error)); ok {
+		return rf(ctx, accId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.LimitOverrides); ok {
+		r0 = rf(ctx, accId)
+	} else {
+		r0 = ret.Get(0).(models.LimitOverrides)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, accId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLimitRepo_FindOne_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindOne'
+type MockLimitRepo_FindOne_Call struct {
+	*mock.Call
+}
+
+// FindOne is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accId string
+func (_e *MockLimitRepo_Expecter) FindOne(ctx interface{}, accId interface{}) *MockLimitRepo_FindOne_Call {
+	return &MockLimitRepo_FindOne_Call{Call: _e.mock.On("FindOne", ctx, accId)}
+}
+
+func (_c *MockLimitRepo_FindOne_Call) Run(run func(ctx context.Context, accId string)) *MockLimitRepo_FindOne_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockLimitRepo_FindOne_Call) Return(_a0 models.LimitOverrides, _a1 error) *MockLimitRepo_FindOne_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLimitRepo_FindOne_Call) RunAndReturn(run func(context.Context, string) (models.LimitOverrides, error)) *MockLimitRepo_FindOne_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function with given fields: ctx, accId, overrides
+func (_m *MockLimitRepo) Save(ctx context.Context, accId string, overrides models.LimitOverrides) error {
+	ret := _m.Called(ctx, accId, overrides)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.LimitOverrides) error); ok {
+		r0 = rf(ctx, accId, overrides)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockLimitRepo_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type MockLimitRepo_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accId string
+//   - overrides models.LimitOverrides
+func (_e *MockLimitRepo_Expecter) Save(ctx interface{}, accId interface{}, overrides interface{}) *MockLimitRepo_Save_Call {
+	return &MockLimitRepo_Save_Call{Call: _e.mock.On("Save", ctx, accId, overrides)}
+}
+
+func (_c *MockLimitRepo_Save_Call) Run(run func(ctx context.Context, accId string, overrides models.LimitOverrides)) *MockLimitRepo_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.LimitOverrides))
+	})
+	return _c
+}
+
+func (_c *MockLimitRepo_Save_Call) Return(_a0 error) *MockLimitRepo_Save_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLimitRepo_Save_Call) RunAndReturn(run func(context.Context, string, models.LimitOverrides) error) *MockLimitRepo_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockLimitRepo creates a new instance of MockLimitRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockLimitRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockLimitRepo {
+	mock := &MockLimitRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/gopay/internal/models"
+)
+
+const (
+	findLimitsQ = `
+	SELECT max_deposit, max_withdrawal, max_payment, daily, weekly, monthly
+	FROM account_limits
+	WHERE account_id = $1
+	`
+
+	saveLimitsQ = `
+	INSERT INTO account_limits
+	(account_id, max_deposit, max_withdrawal, max_payment, daily, weekly, monthly)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	ON CONFLICT (account_id) DO UPDATE
+	SET max_deposit = $2, max_withdrawal = $3, max_payment = $4, daily = $5, weekly = $6, monthly = $7,
+	updated_at = CURRENT_TIMESTAMP
+	`
+)
+
+type LimitRepoPsql interface {
+	FindOne(ctx context.Context, accId string) (models.LimitOverrides, error)
+	Save(ctx context.Context, accId string, overrides models.LimitOverrides) error
+}
+
+var _ LimitRepoPsql = (*limitRepoPsqlImpl)(nil)
+
+type limitRepoPsqlImpl struct {
+	psql *sql.DB
+}
+
+func NewLimitRepoPsql(db *sql.DB) *limitRepoPsqlImpl {
+	return &limitRepoPsqlImpl{
+		psql: db,
+	}
+}
+
+// FindOne returns the limit overrides of accId, none when it has not got any. A NULL
+// column keeps the default limit.
+func (r *limitRepoPsqlImpl) FindOne(ctx context.Context, accId string) (models.LimitOverrides, error) {
+	if uuid.Validate(accId) != nil {
+		return models.LimitOverrides{}, nil
+	}
+
+	o := models.LimitOverrides{}
+	err := conn(ctx, r.psql).QueryRowContext(ctx, findLimitsQ, accId).
+		Scan(&o.MaxDeposit, &o.MaxWithdrawal, &o.MaxPayment, &o.Daily, &o.Weekly, &o.Monthly)
+	if err == sql.ErrNoRows {
+		return models.LimitOverrides{}, nil
+	}
+	if err != nil {
+		return models.LimitOverrides{}, err
+	}
+
+	return o, nil
+}
+
+func (r *limitRepoPsqlImpl) Save(ctx context.Context, accId string, o models.LimitOverrides) error {
+	_, err := conn(ctx, r.psql).ExecContext(ctx, saveLimitsQ, accId, o.MaxDeposit, o.MaxWithdrawal, o.MaxPayment, o.Daily, o.Weekly, o.Monthly)
+	return err
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gopay/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimit_Save(t *testing.T) {
+	ctx := context.Background()
+	daily := models.Money(10000)
+
+	repo := setupLimits(t, map[string]models.LimitOverrides{})
+
+	overrides, err := repo.FindOne(ctx, "0001")
+	assert.NoError(t, err)
+	assert.Equal(t, models.LimitOverrides{}, overrides)
+
+	assert.NoError(t, repo.Save(ctx, "0001", models.LimitOverrides{Daily: &daily}))
+
+	overrides, err = repo.FindOne(ctx, "0001")
+	assert.NoError(t, err)
+	assert.Equal(t, models.LimitOverrides{Daily: &daily}, overrides)
+}
+
+func TestLimit_Rollback(t *testing.T) {
+	ctx := context.Background()
+	errBoom := errors.New("boom")
+	daily, weekly := models.Money(10000), models.Money(50000)
+
+	repo := setupLimits(t, map[string]models.LimitOverrides{
+		"0001": {Daily: &daily},
+	})
+
+	err := NewUnitOfWork().Do(ctx, func(ctx context.Context) error {
+		assert.NoError(t, repo.Save(ctx, "0001", models.LimitOverrides{Weekly: &weekly}))
+		assert.NoError(t, repo.Save(ctx, "0002", models.LimitOverrides{Weekly: &weekly}))
+		return errBoom
+	})
+	assert.ErrorIs(t, err, errBoom)
+
+	overrides, err := repo.FindOne(ctx, "0001")
+	assert.NoError(t, err)
+	assert.Equal(t, models.LimitOverrides{Daily: &daily}, overrides)
+
+	overrides, err = repo.FindOne(ctx, "0002")
+	assert.NoError(t, err)
+	assert.Equal(t, models.LimitOverrides{}, overrides)
+}
+
+func setupLimits(_ *testing.T, initialData map[string]models.LimitOverrides) *limitRepoImpl {
+	repo := NewLimitRepo()
+	repo.overrides = initialData
+	return repo
+}
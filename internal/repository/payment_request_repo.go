@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gopay/internal/models"
+)
+
+var (
+	ErrPaymentRequestNotFound   = errors.New("payment request not found")
+	ErrPaymentRequestNotPending = errors.New("payment request is no longer pending")
+)
+
+type PaymentRequestRepo interface {
+	Create(ctx context.Context, request models.PaymentRequest) (string, error)
+	FindOne(ctx context.Context, id string) (models.PaymentRequest, error)
+	FindByPayer(ctx context.Context, payer string, status models.PaymentRequestStatus) ([]models.PaymentRequest, error)
+	FindByRequester(ctx context.Context, requester string, status models.PaymentRequestStatus) ([]models.PaymentRequest, error)
+	Resolve(ctx context.Context, id string, status models.PaymentRequestStatus, at time.Time) error
+}
+
+var _ PaymentRequestRepo = (*paymentRequestRepoImpl)(nil)
+
+type paymentRequestRepoImpl struct {
+	mu          sync.RWMutex
+	requests    map[string]models.PaymentRequest
+	idGenerator func() string
+}
+
+func NewPaymentRequestRepo() *paymentRequestRepoImpl {
+	return &paymentRequestRepoImpl{
+		requests:    make(map[string]models.PaymentRequest),
+		idGenerator: uuid.NewString,
+	}
+}
+
+func (r *paymentRequestRepoImpl) Create(ctx context.Context, request models.PaymentRequest) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := r.idGenerator()
+	request.RequestId = id
+	request.Status = models.RequestPending
+	request.UpdatedAt = request.CreatedAt
+
+	r.requests[id] = request
+	onRollback(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.requests, id)
+	})
+
+	return id, nil
+}
+
+func (r *paymentRequestRepoImpl) FindOne(_ context.Context, id string) (models.PaymentRequest, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	request, found := r.requests[id]
+	if !found {
+		return models.PaymentRequest{}, ErrPaymentRequestNotFound
+	}
+
+	return request, nil
+}
+
+// FindByPayer returns the requests addressed to payer, newest first. An empty status matches any.
+func (r *paymentRequestRepoImpl) FindByPayer(_ context.Context, payer string, status models.PaymentRequestStatus) ([]models.PaymentRequest, error) {
+	return r.filter(func(p models.PaymentRequest) bool {
+		return p.Payer == payer && (status == "" || p.Status == status)
+	}), nil
+}
+
+// FindByRequester returns the requests made by requester, newest first. An empty status matches any.
+func (r *paymentRequestRepoImpl) FindByRequester(_ context.Context, requester string, status models.PaymentRequestStatus) ([]models.PaymentRequest, error) {
+	return r.filter(func(p models.PaymentRequest) bool {
+		return p.Requester == requester && (status == "" || p.Status == status)
+	}), nil
+}
+
+func (r *paymentRequestRepoImpl) filter(keep func(p models.PaymentRequest) bool) []models.PaymentRequest {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	requests := []models.PaymentRequest{}
+
+	for _, p := range r.requests {
+		if keep(p) {
+			requests = append(requests, p)
+		}
+	}
+
+	sort.Slice(requests, func(i, j int) bool {
+		return requests[i].CreatedAt.After(requests[j].CreatedAt)
+	})
+
+	return requests
+}
+
+// Resolve moves a pending request to status. It fails with ErrPaymentRequestNotPending
+// if the request was resolved in the meantime, so a request is only ever resolved once.
+func (r *paymentRequestRepoImpl) Resolve(ctx context.Context, id string, status models.PaymentRequestStatus, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	request, found := r.requests[id]
+	if !found {
+		return ErrPaymentRequestNotFound
+	}
+
+	if request.Status != models.RequestPending {
+		return ErrPaymentRequestNotPending
+	}
+
+	previous := request
+	request.Status = status
+	request.UpdatedAt = at
+	r.requests[id] = request
+	onRollback(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.requests[id] = previous
+	})
+
+	return nil
+}
@@ -0,0 +1,322 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package repository
+
+import (
+	context "context"
+
+	models "github.com/gopay/internal/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockPaymentRequestRepo is an autogenerated mock type for the PaymentRequestRepo type
+type MockPaymentRequestRepo struct {
+	mock.Mock
+}
+
+type MockPaymentRequestRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPaymentRequestRepo) EXPECT() *MockPaymentRequestRepo_Expecter {
+	return &MockPaymentRequestRepo_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function with given fields: ctx, request
+func (_m *MockPaymentRequestRepo) Create(ctx context.Context, request models.PaymentRequest) (string, error) {
+	ret := _m.Called(ctx, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.PaymentRequest) (string, error)); ok {
+		return rf(ctx, request)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.PaymentRequest) string); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.PaymentRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPaymentRequestRepo_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockPaymentRequestRepo_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - request models.PaymentRequest
+func (_e *MockPaymentRequestRepo_Expecter) Create(ctx interface{}, request interface{}) *MockPaymentRequestRepo_Create_Call {
+	return &MockPaymentRequestRepo_Create_Call{Call: _e.mock.On("Create", ctx, request)}
+}
+
+func (_c *MockPaymentRequestRepo_Create_Call) Run(run func(ctx context.Context, request models.PaymentRequest)) *MockPaymentRequestRepo_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.PaymentRequest))
+	})
+	return _c
+}
+
+func (_c *MockPaymentRequestRepo_Create_Call) Return(_a0 string, _a1 error) *MockPaymentRequestRepo_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPaymentRequestRepo_Create_Call) RunAndReturn(run func(context.Context, models.PaymentRequest) (string, error)) *MockPaymentRequestRepo_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByPayer provides a mock function with given fields: ctx, payer, status
+func (_m *MockPaymentRequestRepo) FindByPayer(ctx context.Context, payer string, status models.PaymentRequestStatus) ([]models.PaymentRequest, error) {
+	ret := _m.Called(ctx, payer, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByPayer")
+	}
+
+	var r0 []models.PaymentRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.PaymentRequestStatus) ([]models.PaymentRequest, error)); ok {
+		return rf(ctx, payer, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.PaymentRequestStatus) []models.PaymentRequest); ok {
+		r0 = rf(ctx, payer, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PaymentRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.PaymentRequestStatus) error); ok {
+		r1 = rf(ctx, payer, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPaymentRequestRepo_FindByPayer_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByPayer'
+type MockPaymentRequestRepo_FindByPayer_Call struct {
+	*mock.Call
+}
+
+// FindByPayer is a helper method to define mock.On call
+//   - ctx context.Context
+//   - payer string
+//   - status models.PaymentRequestStatus
+func (_e *MockPaymentRequestRepo_Expecter) FindByPayer(ctx interface{}, payer interface{}, status interface{}) *MockPaymentRequestRepo_FindByPayer_Call {
+	return &MockPaymentRequestRepo_FindByPayer_Call{Call: _e.mock.On("FindByPayer", ctx, payer, status)}
+}
+
+func (_c *MockPaymentRequestRepo_FindByPayer_Call) Run(run func(ctx context.Context, payer string, status models.PaymentRequestStatus)) *MockPaymentRequestRepo_FindByPayer_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.PaymentRequestStatus))
+	})
+	return _c
+}
+
+func (_c *MockPaymentRequestRepo_FindByPayer_Call) Return(_a0 []models.PaymentRequest, _a1 error) *MockPaymentRequestRepo_FindByPayer_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPaymentRequestRepo_FindByPayer_Call) RunAndReturn(run func(context.Context, string, models.PaymentRequestStatus) ([]models.PaymentRequest, error)) *MockPaymentRequestRepo_FindByPayer_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByRequester provides a mock function with given fields: ctx, requester, status
+func (_m *MockPaymentRequestRepo) FindByRequester(ctx context.Context, requester string, status models.PaymentRequestStatus) ([]models.PaymentRequest, error) {
+	ret := _m.Called(ctx, requester, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByRequester")
+	}
+
+	var r0 []models.PaymentRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.PaymentRequestStatus) ([]models.PaymentRequest, error)); ok {
+		return rf(ctx, requester, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.PaymentRequestStatus) []models.PaymentRequest); ok {
+		r0 = rf(ctx, requester, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PaymentRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.PaymentRequestStatus) error); ok {
+		r1 = rf(ctx, requester, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPaymentRequestRepo_FindByRequester_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByRequester'
+type MockPaymentRequestRepo_FindByRequester_Call struct {
+	*mock.Call
+}
+
+// FindByRequester is a helper method to define mock.On call
+//   - ctx context.Context
+//   - requester string
+//   - status models.PaymentRequestStatus
+func (_e *MockPaymentRequestRepo_Expecter) FindByRequester(ctx interface{}, requester interface{}, status interface{}) *MockPaymentRequestRepo_FindByRequester_Call {
+	return &MockPaymentRequestRepo_FindByRequester_Call{Call: _e.mock.On("FindByRequester", ctx, requester, status)}
+}
+
+func (_c *MockPaymentRequestRepo_FindByRequester_Call) Run(run func(ctx context.Context, requester string, status models.PaymentRequestStatus)) *MockPaymentRequestRepo_FindByRequester_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.PaymentRequestStatus))
+	})
+	return _c
+}
+
+func (_c *MockPaymentRequestRepo_FindByRequester_Call) Return(_a0 []models.PaymentRequest, _a1 error) *MockPaymentRequestRepo_FindByRequester_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPaymentRequestRepo_FindByRequester_Call) RunAndReturn(run func(context.Context, string, models.PaymentRequestStatus) ([]models.PaymentRequest, error)) *MockPaymentRequestRepo_FindByRequester_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindOne provides a mock function with given fields: ctx, id
+func (_m *MockPaymentRequestRepo) FindOne(ctx context.Context, id string) (models.PaymentRequest, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindOne")
+	}
+
+	var r0 models.PaymentRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.PaymentRequest, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.PaymentRequest); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(models.PaymentRequest)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPaymentRequestRepo_FindOne_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindOne'
+type MockPaymentRequestRepo_FindOne_Call struct {
+	*mock.Call
+}
+
+// FindOne is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockPaymentRequestRepo_Expecter) FindOne(ctx interface{}, id interface{}) *MockPaymentRequestRepo_FindOne_Call {
+	return &MockPaymentRequestRepo_FindOne_Call{Call: _e.mock.On("FindOne", ctx, id)}
+}
+
+func (_c *MockPaymentRequestRepo_FindOne_Call) Run(run func(ctx context.Context, id string)) *MockPaymentRequestRepo_FindOne_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockPaymentRequestRepo_FindOne_Call) Return(_a0 models.PaymentRequest, _a1 error) *MockPaymentRequestRepo_FindOne_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPaymentRequestRepo_FindOne_Call) RunAndReturn(run func(context.Context, string) (models.PaymentRequest, error)) *MockPaymentRequestRepo_FindOne_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Resolve provides a mock function with given fields: ctx, id, status, at
+func (_m *MockPaymentRequestRepo) Resolve(ctx context.Context, id string, status models.PaymentRequestStatus, at time.Time) error {
+	ret := _m.Called(ctx, id, status, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Resolve")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.PaymentRequestStatus, time.Time) error); ok {
+		r0 = rf(ctx, id, status, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPaymentRequestRepo_Resolve_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Resolve'
+type MockPaymentRequestRepo_Resolve_Call struct {
+	*mock.Call
+}
+
+// Resolve is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - status models.PaymentRequestStatus
+//   - at time.Time
+func (_e *MockPaymentRequestRepo_Expecter) Resolve(ctx interface{}, id interface{}, status interface{}, at interface{}) *MockPaymentRequestRepo_Resolve_Call {
+	return &MockPaymentRequestRepo_Resolve_Call{Call: _e.mock.On("Resolve", ctx, id, status, at)}
+}
+
+func (_c *MockPaymentRequestRepo_Resolve_Call) Run(run func(ctx context.Context, id string, status models.PaymentRequestStatus, at time.Time)) *MockPaymentRequestRepo_Resolve_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.PaymentRequestStatus), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockPaymentRequestRepo_Resolve_Call) Return(_a0 error) *MockPaymentRequestRepo_Resolve_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPaymentRequestRepo_Resolve_Call) RunAndReturn(run func(context.Context, string, models.PaymentRequestStatus, time.Time) error) *MockPaymentRequestRepo_Resolve_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPaymentRequestRepo creates a new instance of MockPaymentRequestRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPaymentRequestRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPaymentRequestRepo {
+	mock := &MockPaymentRequestRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
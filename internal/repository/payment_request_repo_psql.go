@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gopay/internal/models"
+)
+
+const (
+	createPaymentRequestQ = `
+	INSERT INTO payment_requests
+	(requester, payer, amount, note, status, created_at, expires_at, updated_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $6)
+	RETURNING request_id
+	`
+
+	findPaymentRequestQ = `
+	SELECT request_id, requester, payer, amount, note, status, created_at, expires_at, updated_at
+	FROM payment_requests
+	WHERE request_id = $1
+	`
+
+	findPaymentRequestsByPayerQ = `
+	SELECT request_id, requester, payer, amount, note, status, created_at, expires_at, updated_at
+	FROM payment_requests
+	WHERE payer = $1
+	AND ($2 = '' OR status = $2)
+	ORDER BY created_at DESC
+	`
+
+	findPaymentRequestsByRequesterQ = `
+	SELECT request_id, requester, payer, amount, note, status, created_at, expires_at, updated_at
+	FROM payment_requests
+	WHERE requester = $1
+	AND ($2 = '' OR status = $2)
+	ORDER BY created_at DESC
+	`
+
+	resolvePaymentRequestQ = `
+	UPDATE payment_requests
+	SET status = $2, updated_at = $3
+	WHERE request_id = $1
+	AND status = 'pending'
+	`
+)
+
+type PaymentRequestRepoPsql interface {
+	Create(ctx context.Context, request models.PaymentRequest) (string, error)
+	FindOne(ctx context.Context, id string) (models.PaymentRequest, error)
+	FindByPayer(ctx context.Context, payer string, status models.PaymentRequestStatus) ([]models.PaymentRequest, error)
+	FindByRequester(ctx context.Context, requester string, status models.PaymentRequestStatus) ([]models.PaymentRequest, error)
+	Resolve(ctx context.Context, id string, status models.PaymentRequestStatus, at time.Time) error
+}
+
+var _ PaymentRequestRepoPsql = (*paymentRequestRepoPsqlImpl)(nil)
+
+type paymentRequestRepoPsqlImpl struct {
+	psql *sql.DB
+}
+
+func NewPaymentRequestRepoPsql(db *sql.DB) *paymentRequestRepoPsqlImpl {
+	return &paymentRequestRepoPsqlImpl{
+		psql: db,
+	}
+}
+
+func (r *paymentRequestRepoPsqlImpl) Create(ctx context.Context, request models.PaymentRequest) (string, error) {
+	var id string
+
+	row := conn(ctx, r.psql).QueryRowContext(ctx, createPaymentRequestQ, request.Requester, request.Payer, request.Amount, request.Note, models.RequestPending, request.CreatedAt, request.ExpiresAt)
+	err := row.Scan(&id)
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (r *paymentRequestRepoPsqlImpl) FindOne(ctx context.Context, id string) (models.PaymentRequest, error) {
+	// A malformed id cannot match any payment request, and querying with it would fail the
+	// surrounding transaction.
+	if uuid.Validate(id) != nil {
+		return models.PaymentRequest{}, ErrPaymentRequestNotFound
+	}
+
+	row := conn(ctx, r.psql).QueryRowContext(ctx, findPaymentRequestQ, id)
+	p, err := scanPaymentRequest(row)
+	if err == sql.ErrNoRows {
+		return models.PaymentRequest{}, ErrPaymentRequestNotFound
+	}
+	if err != nil {
+		return models.PaymentRequest{}, err
+	}
+
+	return p, nil
+}
+
+// FindByPayer returns the requests addressed to payer, newest first. An empty status matches any.
+func (r *paymentRequestRepoPsqlImpl) FindByPayer(ctx context.Context, payer string, status models.PaymentRequestStatus) ([]models.PaymentRequest, error) {
+	return r.find(ctx, findPaymentRequestsByPayerQ, payer, status)
+}
+
+// FindByRequester returns the requests made by requester, newest first. An empty status matches any.
+func (r *paymentRequestRepoPsqlImpl) FindByRequester(ctx context.Context, requester string, status models.PaymentRequestStatus) ([]models.PaymentRequest, error) {
+	return r.find(ctx, findPaymentRequestsByRequesterQ, requester, status)
+}
+
+func (r *paymentRequestRepoPsqlImpl) find(ctx context.Context, query string, accId string, status models.PaymentRequestStatus) ([]models.PaymentRequest, error) {
+	requests := []models.PaymentRequest{}
+
+	rows, err := conn(ctx, r.psql).QueryContext(ctx, query, accId, string(status))
+	if err != nil {
+		return requests, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		p, err := scanPaymentRequest(rows)
+		if err != nil {
+			return []models.PaymentRequest{}, err
+		}
+		requests = append(requests, p)
+	}
+
+	return requests, rows.Err()
+}
+
+// Resolve moves a pending request to status. It fails with ErrPaymentRequestNotPending
+// if the request was resolved in the meantime, so a request is only ever resolved once.
+func (r *paymentRequestRepoPsqlImpl) Resolve(ctx context.Context, id string, status models.PaymentRequestStatus, at time.Time) error {
+	res, err := conn(ctx, r.psql).ExecContext(ctx, resolvePaymentRequestQ, id, status, at)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		_, err = r.FindOne(ctx, id)
+		if err != nil {
+			return err
+		}
+		return ErrPaymentRequestNotPending
+	}
+
+	return nil
+}
+
+func scanPaymentRequest(row scanner) (models.PaymentRequest, error) {
+	p := models.PaymentRequest{}
+
+	err := row.Scan(&p.RequestId, &p.Requester, &p.Payer, &p.Amount, &p.Note, &p.Status, &p.CreatedAt, &p.ExpiresAt, &p.UpdatedAt)
+	if err != nil {
+		return models.PaymentRequest{}, err
+	}
+
+	return p, nil
+}
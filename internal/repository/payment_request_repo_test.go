@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gopay/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaymentRequest_Create(t *testing.T) {
+	now := time.Now()
+	ctx := context.Background()
+
+	repo := setupPaymentRequests(t, map[string]models.PaymentRequest{}, func() string { return "r-1" })
+
+	id, err := repo.Create(ctx, models.PaymentRequest{
+		Requester: "0001",
+		Payer:     "0002",
+		Amount:    1500,
+		Note:      "pizza",
+		CreatedAt: now,
+		ExpiresAt: now.Add(time.Hour),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "r-1", id)
+
+	request, err := repo.FindOne(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, models.PaymentRequest{
+		RequestId: "r-1",
+		Requester: "0001",
+		Payer:     "0002",
+		Amount:    1500,
+		Note:      "pizza",
+		Status:    models.RequestPending,
+		CreatedAt: now,
+		ExpiresAt: now.Add(time.Hour),
+		UpdatedAt: now,
+	}, request)
+
+	_, err = repo.FindOne(ctx, "r-2")
+	assert.ErrorIs(t, err, ErrPaymentRequestNotFound)
+}
+
+func TestPaymentRequest_FindByPayer(t *testing.T) {
+	now := time.Now()
+
+	data := map[string]models.PaymentRequest{
+		"r-1": {RequestId: "r-1", Requester: "0001", Payer: "0002", Status: models.RequestPending, CreatedAt: now},
+		"r-2": {RequestId: "r-2", Requester: "0003", Payer: "0002", Status: models.RequestDeclined, CreatedAt: now.Add(time.Minute)},
+		"r-3": {RequestId: "r-3", Requester: "0002", Payer: "0001", Status: models.RequestPending, CreatedAt: now},
+	}
+
+	scenarios := map[string]struct {
+		status models.PaymentRequestStatus
+		want   []string
+	}{
+		"any-status-newest-first": {
+			status: "",
+			want:   []string{"r-2", "r-1"},
+		},
+		"pending": {
+			status: models.RequestPending,
+			want:   []string{"r-1"},
+		},
+		"none": {
+			status: models.RequestAccepted,
+			want:   []string{},
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			repo := setupPaymentRequests(t, data, nil)
+
+			requests, err := repo.FindByPayer(context.Background(), "0002", tcase.status)
+
+			assert.NoError(t, err)
+			ids := []string{}
+			for _, p := range requests {
+				ids = append(ids, p.RequestId)
+			}
+			assert.Equal(t, tcase.want, ids)
+		})
+	}
+}
+
+func TestPaymentRequest_Resolve(t *testing.T) {
+	now := time.Now()
+	ctx := context.Background()
+	errBoom := errors.New("boom")
+
+	repo := setupPaymentRequests(t, map[string]models.PaymentRequest{
+		"r-1": {RequestId: "r-1", Requester: "0001", Payer: "0002", Status: models.RequestPending, CreatedAt: now},
+	}, nil)
+
+	err := NewUnitOfWork().Do(ctx, func(ctx context.Context) error {
+		assert.NoError(t, repo.Resolve(ctx, "r-1", models.RequestAccepted, now))
+		return errBoom
+	})
+	assert.ErrorIs(t, err, errBoom)
+
+	request, err := repo.FindOne(ctx, "r-1")
+	assert.NoError(t, err)
+	assert.Equal(t, models.RequestPending, request.Status)
+
+	assert.NoError(t, repo.Resolve(ctx, "r-1", models.RequestDeclined, now))
+	assert.ErrorIs(t, repo.Resolve(ctx, "r-1", models.RequestAccepted, now), ErrPaymentRequestNotPending)
+	assert.ErrorIs(t, repo.Resolve(ctx, "r-2", models.RequestAccepted, now), ErrPaymentRequestNotFound)
+}
+
+func setupPaymentRequests(_ *testing.T, initialData map[string]models.PaymentRequest, idGenerator func() string) *paymentRequestRepoImpl {
+	repo := NewPaymentRequestRepo()
+	repo.requests = initialData
+	repo.idGenerator = idGenerator
+	return repo
+}
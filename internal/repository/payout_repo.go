@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gopay/internal/models"
+)
+
+var (
+	ErrPayoutBatchNotFound = errors.New("payout batch not found")
+	ErrPayoutItemNotFound  = errors.New("payout item not found")
+)
+
+type PayoutRepo interface {
+	CreateBatch(ctx context.Context, batch models.PayoutBatch) (string, error)
+	FindBatch(ctx context.Context, id string) (models.PayoutBatch, error)
+	FindPendingItems(ctx context.Context) ([]models.PayoutItem, error)
+	GetReserved(ctx context.Context, owner string) (models.Money, error)
+	ClaimItem(ctx context.Context, id string) (models.PayoutItem, error)
+	SetItemResult(ctx context.Context, id string, result models.PayoutResult, message string, at time.Time) error
+}
+
+var _ PayoutRepo = (*payoutRepoImpl)(nil)
+
+type payoutRepoImpl struct {
+	mu          sync.RWMutex
+	batches     map[string]models.PayoutBatch
+	items       map[string]models.PayoutItem
+	idGenerator func() string
+	claims      *keyedLock
+}
+
+func NewPayoutRepo() *payoutRepoImpl {
+	return &payoutRepoImpl{
+		batches:     make(map[string]models.PayoutBatch),
+		items:       make(map[string]models.PayoutItem),
+		idGenerator: uuid.NewString,
+		claims:      newKeyedLock(0, ErrPayoutItemNotFound),
+	}
+}
+
+// CreateBatch stores batch together with its items.
+func (r *payoutRepoImpl) CreateBatch(ctx context.Context, batch models.PayoutBatch) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := r.idGenerator()
+	items := batch.Items
+	itemIds := make([]string, 0, len(items))
+
+	batch.BatchId = id
+	batch.Items = nil
+	r.batches[id] = batch
+
+	for _, item := range items {
+		item.ItemId = r.idGenerator()
+		item.BatchId = id
+		item.Owner = batch.Owner
+		item.UpdatedAt = batch.CreatedAt
+
+		r.items[item.ItemId] = item
+		itemIds = append(itemIds, item.ItemId)
+	}
+
+	onRollback(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.batches, id)
+		for _, itemId := range itemIds {
+			delete(r.items, itemId)
+		}
+	})
+
+	return id, nil
+}
+
+// FindBatch returns the batch with its items in the order they were submitted.
+func (r *payoutRepoImpl) FindBatch(_ context.Context, id string) (models.PayoutBatch, error) {
+	r.mu.RLock()
+	batch, found := r.batches[id]
+	r.mu.RUnlock()
+
+	if !found {
+		return models.PayoutBatch{}, ErrPayoutBatchNotFound
+	}
+
+	batch.Items = r.filter(func(item models.PayoutItem) bool {
+		return item.BatchId == id
+	})
+
+	return batch, nil
+}
+
+// FindPendingItems returns the items still waiting to be paid, oldest batch first.
+func (r *payoutRepoImpl) FindPendingItems(_ context.Context) ([]models.PayoutItem, error) {
+	return r.filter(func(item models.PayoutItem) bool {
+		return item.Result == models.PayoutPending
+	}), nil
+}
+
+// GetReserved returns what the pending items of owner's batches still hold in reserve.
+func (r *payoutRepoImpl) GetReserved(_ context.Context, owner string) (models.Money, error) {
+	pending := r.filter(func(item models.PayoutItem) bool {
+		return item.Owner == owner && item.Result == models.PayoutPending
+	})
+
+	var total models.Money
+	for _, item := range pending {
+		total += item.Amount
+	}
+
+	return total, nil
+}
+
+func (r *payoutRepoImpl) filter(keep func(item models.PayoutItem) bool) []models.PayoutItem {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	items := []models.PayoutItem{}
+
+	for _, item := range r.items {
+		if keep(item) {
+			items = append(items, item)
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		a, b := r.batches[items[i].BatchId], r.batches[items[j].BatchId]
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		if a.BatchId != b.BatchId {
+			return a.BatchId < b.BatchId
+		}
+		return items[i].Position < items[j].Position
+	})
+
+	return items
+}
+
+// ClaimItem returns a pending item and keeps other workers off it until the unit of
+// work bound to ctx ends. Items already paid, failed or claimed are not found.
+func (r *payoutRepoImpl) ClaimItem(ctx context.Context, id string) (models.PayoutItem, error) {
+	err := r.claims.lockInTx(ctx, id)
+	if err != nil {
+		return models.PayoutItem{}, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	item, found := r.items[id]
+	if !found || item.Result != models.PayoutPending {
+		return models.PayoutItem{}, ErrPayoutItemNotFound
+	}
+
+	return item, nil
+}
+
+func (r *payoutRepoImpl) SetItemResult(ctx context.Context, id string, result models.PayoutResult, message string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	item, found := r.items[id]
+	if !found {
+		return ErrPayoutItemNotFound
+	}
+
+	previous := item
+	item.Result = result
+	item.Error = message
+	item.UpdatedAt = at
+	r.items[id] = item
+
+	onRollback(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.items[id] = previous
+	})
+
+	return nil
+}
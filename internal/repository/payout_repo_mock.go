@@ -0,0 +1,375 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package repository
+
+import (
+	context "context"
+
+	models "github.com/gopay/internal/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockPayoutRepo is an autogenerated mock type for the PayoutRepo type
+type MockPayoutRepo struct {
+	mock.Mock
+}
+
+type MockPayoutRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockPayoutRepo) EXPECT() *MockPayoutRepo_Expecter {
+	return &MockPayoutRepo_Expecter{mock: &_m.Mock}
+}
+
+// ClaimItem provides a mock function with given fields: ctx, id
+func (_m *MockPayoutRepo) ClaimItem(ctx context.Context, id string) (models.PayoutItem, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimItem")
+	}
+
+	var r0 models.PayoutItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.PayoutItem, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.PayoutItem); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(models.PayoutItem)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPayoutRepo_ClaimItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimItem'
+type MockPayoutRepo_ClaimItem_Call struct {
+	*mock.Call
+}
+
+// ClaimItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockPayoutRepo_Expecter) ClaimItem(ctx interface{}, id interface{}) *MockPayoutRepo_ClaimItem_Call {
+	return &MockPayoutRepo_ClaimItem_Call{Call: _e.mock.On("ClaimItem", ctx, id)}
+}
+
+func (_c *MockPayoutRepo_ClaimItem_Call) Run(run func(ctx context.Context, id string)) *MockPayoutRepo_ClaimItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockPayoutRepo_ClaimItem_Call) Return(_a0 models.PayoutItem, _a1 error) *MockPayoutRepo_ClaimItem_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPayoutRepo_ClaimItem_Call) RunAndReturn(run func(context.Context, string) (models.PayoutItem, error)) *MockPayoutRepo_ClaimItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateBatch provides a mock function with given fields: ctx, batch
+func (_m *MockPayoutRepo) CreateBatch(ctx context.Context, batch models.PayoutBatch) (string, error) {
+	ret := _m.Called(ctx, batch)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateBatch")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.PayoutBatch) (string, error)); ok {
+		return rf(ctx, batch)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.PayoutBatch) string); ok {
+		r0 = rf(ctx, batch)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.PayoutBatch) error); ok {
+		r1 = rf(ctx, batch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPayoutRepo_CreateBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateBatch'
+type MockPayoutRepo_CreateBatch_Call struct {
+	*mock.Call
+}
+
+// CreateBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - batch models.PayoutBatch
+func (_e *MockPayoutRepo_Expecter) CreateBatch(ctx interface{}, batch interface{}) *MockPayoutRepo_CreateBatch_Call {
+	return &MockPayoutRepo_CreateBatch_Call{Call: _e.mock.On("CreateBatch", ctx, batch)}
+}
+
+func (_c *MockPayoutRepo_CreateBatch_Call) Run(run func(ctx context.Context, batch models.PayoutBatch)) *MockPayoutRepo_CreateBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.PayoutBatch))
+	})
+	return _c
+}
+
+func (_c *MockPayoutRepo_CreateBatch_Call) Return(_a0 string, _a1 error) *MockPayoutRepo_CreateBatch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPayoutRepo_CreateBatch_Call) RunAndReturn(run func(context.Context, models.PayoutBatch) (string, error)) *MockPayoutRepo_CreateBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindBatch provides a mock function with given fields: ctx, id
+func (_m *MockPayoutRepo) FindBatch(ctx context.Context, id string) (models.PayoutBatch, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindBatch")
+	}
+
+	var r0 models.PayoutBatch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.PayoutBatch, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.PayoutBatch); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(models.PayoutBatch)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPayoutRepo_FindBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindBatch'
+type MockPayoutRepo_FindBatch_Call struct {
+	*mock.Call
+}
+
+// FindBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockPayoutRepo_Expecter) FindBatch(ctx interface{}, id interface{}) *MockPayoutRepo_FindBatch_Call {
+	return &MockPayoutRepo_FindBatch_Call{Call: _e.mock.On("FindBatch", ctx, id)}
+}
+
+func (_c *MockPayoutRepo_FindBatch_Call) Run(run func(ctx context.Context, id string)) *MockPayoutRepo_FindBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockPayoutRepo_FindBatch_Call) Return(_a0 models.PayoutBatch, _a1 error) *MockPayoutRepo_FindBatch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPayoutRepo_FindBatch_Call) RunAndReturn(run func(context.Context, string) (models.PayoutBatch, error)) *MockPayoutRepo_FindBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindPendingItems provides a mock function with given fields: ctx
+func (_m *MockPayoutRepo) FindPendingItems(ctx context.Context) ([]models.PayoutItem, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPendingItems")
+	}
+
+	var r0 []models.PayoutItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]models.PayoutItem, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []models.PayoutItem); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PayoutItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPayoutRepo_FindPendingItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindPendingItems'
+type MockPayoutRepo_FindPendingItems_Call struct {
+	*mock.Call
+}
+
+// FindPendingItems is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockPayoutRepo_Expecter) FindPendingItems(ctx interface{}) *MockPayoutRepo_FindPendingItems_Call {
+	return &MockPayoutRepo_FindPendingItems_Call{Call: _e.mock.On("FindPendingItems", ctx)}
+}
+
+func (_c *MockPayoutRepo_FindPendingItems_Call) Run(run func(ctx context.Context)) *MockPayoutRepo_FindPendingItems_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockPayoutRepo_FindPendingItems_Call) Return(_a0 []models.PayoutItem, _a1 error) *MockPayoutRepo_FindPendingItems_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPayoutRepo_FindPendingItems_Call) RunAndReturn(run func(context.Context) ([]models.PayoutItem, error)) *MockPayoutRepo_FindPendingItems_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReserved provides a mock function with given fields: ctx, owner
+func (_m *MockPayoutRepo) GetReserved(ctx context.Context, owner string) (models.Money, error) {
+	ret := _m.Called(ctx, owner)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReserved")
+	}
+
+	var r0 models.Money
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.Money, error)); ok {
+		return rf(ctx, owner)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.Money); ok {
+		r0 = rf(ctx, owner)
+	} else {
+		r0 = ret.Get(0).(models.Money)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, owner)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockPayoutRepo_GetReserved_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReserved'
+type MockPayoutRepo_GetReserved_Call struct {
+	*mock.Call
+}
+
+// GetReserved is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+func (_e *MockPayoutRepo_Expecter) GetReserved(ctx interface{}, owner interface{}) *MockPayoutRepo_GetReserved_Call {
+	return &MockPayoutRepo_GetReserved_Call{Call: _e.mock.On("GetReserved", ctx, owner)}
+}
+
+func (_c *MockPayoutRepo_GetReserved_Call) Run(run func(ctx context.Context, owner string)) *MockPayoutRepo_GetReserved_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockPayoutRepo_GetReserved_Call) Return(_a0 models.Money, _a1 error) *MockPayoutRepo_GetReserved_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockPayoutRepo_GetReserved_Call) RunAndReturn(run func(context.Context, string) (models.Money, error)) *MockPayoutRepo_GetReserved_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetItemResult provides a mock function with given fields: ctx, id, result, message, at
+func (_m *MockPayoutRepo) SetItemResult(ctx context.Context, id string, result models.PayoutResult, message string, at time.Time) error {
+	ret := _m.Called(ctx, id, result, message, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetItemResult")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.PayoutResult, string, time.Time) error); ok {
+		r0 = rf(ctx, id, result, message, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockPayoutRepo_SetItemResult_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetItemResult'
+type MockPayoutRepo_SetItemResult_Call struct {
+	*mock.Call
+}
+
+// SetItemResult is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - result models.PayoutResult
+//   - message string
+//   - at time.Time
+func (_e *MockPayoutRepo_Expecter) SetItemResult(ctx interface{}, id interface{}, result interface{}, message interface{}, at interface{}) *MockPayoutRepo_SetItemResult_Call {
+	return &MockPayoutRepo_SetItemResult_Call{Call: _e.mock.On("SetItemResult", ctx, id, result, message, at)}
+}
+
+func (_c *MockPayoutRepo_SetItemResult_Call) Run(run func(ctx context.Context, id string, result models.PayoutResult, message string, at time.Time)) *MockPayoutRepo_SetItemResult_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.PayoutResult), args[3].(string), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockPayoutRepo_SetItemResult_Call) Return(_a0 error) *MockPayoutRepo_SetItemResult_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockPayoutRepo_SetItemResult_Call) RunAndReturn(run func(context.Context, string, models.PayoutResult, string, time.Time) error) *MockPayoutRepo_SetItemResult_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockPayoutRepo creates a new instance of MockPayoutRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPayoutRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPayoutRepo {
+	mock := &MockPayoutRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,228 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gopay/internal/models"
+)
+
+const (
+	createPayoutBatchQ = `
+	INSERT INTO payout_batches
+	(owner, total, reserved, created_at)
+	VALUES ($1, $2, $3, $4)
+	RETURNING batch_id
+	`
+
+	createPayoutItemQ = `
+	INSERT INTO payout_items
+	(batch_id, position, receiver, amount, result, error, updated_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	findPayoutBatchQ = `
+	SELECT batch_id, owner, total, reserved, created_at
+	FROM payout_batches
+	WHERE batch_id = $1
+	`
+
+	findPayoutItemsQ = `
+	SELECT i.item_id, i.batch_id, b.owner, i.position, i.receiver, i.amount, i.result, i.error, i.updated_at
+	FROM payout_items i
+	JOIN payout_batches b ON b.batch_id = i.batch_id
+	WHERE i.batch_id = $1
+	ORDER BY i.position ASC
+	`
+
+	findPendingPayoutItemsQ = `
+	SELECT i.item_id, i.batch_id, b.owner, i.position, i.receiver, i.amount, i.result, i.error, i.updated_at
+	FROM payout_items i
+	JOIN payout_batches b ON b.batch_id = i.batch_id
+	WHERE i.result = 'pending'
+	ORDER BY b.created_at ASC, i.batch_id ASC, i.position ASC
+	`
+
+	getReservedPayoutQ = `
+	SELECT COALESCE(SUM(i.amount), 0)
+	FROM payout_items i
+	JOIN payout_batches b ON b.batch_id = i.batch_id
+	WHERE b.owner = $1
+	AND i.result = 'pending'
+	`
+
+	claimPayoutItemQ = `
+	SELECT i.item_id, i.batch_id, b.owner, i.position, i.receiver, i.amount, i.result, i.error, i.updated_at
+	FROM payout_items i
+	JOIN payout_batches b ON b.batch_id = i.batch_id
+	WHERE i.item_id = $1
+	AND i.result = 'pending'
+	FOR UPDATE OF i SKIP LOCKED
+	`
+
+	setPayoutItemResultQ = `
+	UPDATE payout_items
+	SET result = $2, error = $3, updated_at = $4
+	WHERE item_id = $1
+	`
+)
+
+type PayoutRepoPsql interface {
+	CreateBatch(ctx context.Context, batch models.PayoutBatch) (string, error)
+	FindBatch(ctx context.Context, id string) (models.PayoutBatch, error)
+	FindPendingItems(ctx context.Context) ([]models.PayoutItem, error)
+	GetReserved(ctx context.Context, owner string) (models.Money, error)
+	ClaimItem(ctx context.Context, id string) (models.PayoutItem, error)
+	SetItemResult(ctx context.Context, id string, result models.PayoutResult, message string, at time.Time) error
+}
+
+var _ PayoutRepoPsql = (*payoutRepoPsqlImpl)(nil)
+
+type payoutRepoPsqlImpl struct {
+	psql *sql.DB
+}
+
+func NewPayoutRepoPsql(db *sql.DB) *payoutRepoPsqlImpl {
+	return &payoutRepoPsqlImpl{
+		psql: db,
+	}
+}
+
+// CreateBatch stores batch together with its items. It must run inside a unit of work
+// so that a batch is never stored without all of its items.
+func (r *payoutRepoPsqlImpl) CreateBatch(ctx context.Context, batch models.PayoutBatch) (string, error) {
+	if !inTx(ctx) {
+		return "", ErrNoUnitOfWork
+	}
+
+	var id string
+
+	row := conn(ctx, r.psql).QueryRowContext(ctx, createPayoutBatchQ, batch.Owner, batch.Total, batch.Reserved, batch.CreatedAt)
+	err := row.Scan(&id)
+	if err != nil {
+		return "", err
+	}
+
+	for _, item := range batch.Items {
+		_, err = conn(ctx, r.psql).ExecContext(ctx, createPayoutItemQ, id, item.Position, item.Receiver, item.Amount, item.Result, item.Error, batch.CreatedAt)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return id, nil
+}
+
+// FindBatch returns the batch with its items in the order they were submitted.
+func (r *payoutRepoPsqlImpl) FindBatch(ctx context.Context, id string) (models.PayoutBatch, error) {
+	// A malformed id cannot match any batch, and querying with it would fail the
+	// surrounding transaction.
+	if uuid.Validate(id) != nil {
+		return models.PayoutBatch{}, ErrPayoutBatchNotFound
+	}
+
+	batch := models.PayoutBatch{}
+
+	row := conn(ctx, r.psql).QueryRowContext(ctx, findPayoutBatchQ, id)
+	err := row.Scan(&batch.BatchId, &batch.Owner, &batch.Total, &batch.Reserved, &batch.CreatedAt)
+	if err == sql.ErrNoRows {
+		return models.PayoutBatch{}, ErrPayoutBatchNotFound
+	}
+	if err != nil {
+		return models.PayoutBatch{}, err
+	}
+
+	batch.Items, err = r.findItems(ctx, findPayoutItemsQ, id)
+	if err != nil {
+		return models.PayoutBatch{}, err
+	}
+
+	return batch, nil
+}
+
+// FindPendingItems returns the items still waiting to be paid, oldest batch first.
+func (r *payoutRepoPsqlImpl) FindPendingItems(ctx context.Context) ([]models.PayoutItem, error) {
+	return r.findItems(ctx, findPendingPayoutItemsQ)
+}
+
+// GetReserved returns what the pending items of owner's batches still hold in reserve.
+func (r *payoutRepoPsqlImpl) GetReserved(ctx context.Context, owner string) (models.Money, error) {
+	var total models.Money
+
+	err := conn(ctx, r.psql).QueryRowContext(ctx, getReservedPayoutQ, owner).Scan(&total)
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func (r *payoutRepoPsqlImpl) findItems(ctx context.Context, query string, args ...any) ([]models.PayoutItem, error) {
+	items := []models.PayoutItem{}
+
+	rows, err := conn(ctx, r.psql).QueryContext(ctx, query, args...)
+	if err != nil {
+		return items, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item, err := scanPayoutItem(rows)
+		if err != nil {
+			return []models.PayoutItem{}, err
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
+
+// ClaimItem locks a pending item for the surrounding unit of work. Rows already claimed
+// by another replica are skipped and reported as ErrPayoutItemNotFound.
+func (r *payoutRepoPsqlImpl) ClaimItem(ctx context.Context, id string) (models.PayoutItem, error) {
+	if !inTx(ctx) {
+		return models.PayoutItem{}, ErrNoUnitOfWork
+	}
+
+	row := conn(ctx, r.psql).QueryRowContext(ctx, claimPayoutItemQ, id)
+	item, err := scanPayoutItem(row)
+	if err == sql.ErrNoRows {
+		return models.PayoutItem{}, ErrPayoutItemNotFound
+	}
+	if err != nil {
+		return models.PayoutItem{}, err
+	}
+
+	return item, nil
+}
+
+func (r *payoutRepoPsqlImpl) SetItemResult(ctx context.Context, id string, result models.PayoutResult, message string, at time.Time) error {
+	res, err := conn(ctx, r.psql).ExecContext(ctx, setPayoutItemResultQ, id, result, message, at)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrPayoutItemNotFound
+	}
+
+	return nil
+}
+
+func scanPayoutItem(row scanner) (models.PayoutItem, error) {
+	item := models.PayoutItem{}
+
+	err := row.Scan(&item.ItemId, &item.BatchId, &item.Owner, &item.Position, &item.Receiver, &item.Amount, &item.Result, &item.Error, &item.UpdatedAt)
+	if err != nil {
+		return models.PayoutItem{}, err
+	}
+
+	return item, nil
+}
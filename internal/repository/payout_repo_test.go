@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gopay/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayout_CreateBatch(t *testing.T) {
+	now := time.Now()
+	ctx := context.Background()
+
+	ids := []string{"b-1", "i-1", "i-2"}
+	repo := setupPayouts(t, func() string {
+		id := ids[0]
+		ids = ids[1:]
+		return id
+	})
+
+	id, err := repo.CreateBatch(ctx, models.PayoutBatch{
+		Owner:     "0001",
+		Total:     4000,
+		Reserved:  1000,
+		CreatedAt: now,
+		Items: []models.PayoutItem{
+			{Position: 1, Receiver: "0002", Amount: 1000, Result: models.PayoutPending},
+			{Position: 2, Receiver: "0003", Amount: 3000, Result: models.PayoutInsufficientBalance},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "b-1", id)
+
+	batch, err := repo.FindBatch(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, models.PayoutBatch{
+		BatchId:   "b-1",
+		Owner:     "0001",
+		Total:     4000,
+		Reserved:  1000,
+		CreatedAt: now,
+		Items: []models.PayoutItem{
+			{ItemId: "i-1", BatchId: "b-1", Owner: "0001", Position: 1, Receiver: "0002", Amount: 1000, Result: models.PayoutPending, UpdatedAt: now},
+			{ItemId: "i-2", BatchId: "b-1", Owner: "0001", Position: 2, Receiver: "0003", Amount: 3000, Result: models.PayoutInsufficientBalance, UpdatedAt: now},
+		},
+	}, batch)
+
+	pending, err := repo.FindPendingItems(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, pending, 1)
+	assert.Equal(t, "i-1", pending[0].ItemId)
+
+	reserved, err := repo.GetReserved(ctx, "0001")
+	assert.NoError(t, err)
+	assert.Equal(t, models.Money(1000), reserved)
+
+	reserved, err = repo.GetReserved(ctx, "0002")
+	assert.NoError(t, err)
+	assert.Equal(t, models.Money(0), reserved)
+
+	_, err = repo.FindBatch(ctx, "b-2")
+	assert.ErrorIs(t, err, ErrPayoutBatchNotFound)
+}
+
+func TestPayout_CreateBatchRollback(t *testing.T) {
+	ctx := context.Background()
+	errBoom := errors.New("boom")
+
+	repo := setupPayouts(t, nil)
+
+	var id string
+	err := NewUnitOfWork().Do(ctx, func(ctx context.Context) error {
+		var err error
+		id, err = repo.CreateBatch(ctx, models.PayoutBatch{
+			Owner: "0001",
+			Items: []models.PayoutItem{{Position: 1, Result: models.PayoutPending}},
+		})
+		assert.NoError(t, err)
+		return errBoom
+	})
+	assert.ErrorIs(t, err, errBoom)
+
+	_, err = repo.FindBatch(ctx, id)
+	assert.ErrorIs(t, err, ErrPayoutBatchNotFound)
+
+	pending, err := repo.FindPendingItems(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+func TestPayout_ClaimItem(t *testing.T) {
+	now := time.Now()
+	ctx := context.Background()
+	uow := NewUnitOfWork()
+
+	repo := setupPayouts(t, nil)
+	repo.items = map[string]models.PayoutItem{
+		"i-1": {ItemId: "i-1", BatchId: "b-1", Result: models.PayoutPending},
+		"i-2": {ItemId: "i-2", BatchId: "b-1", Result: models.PayoutSucceeded},
+	}
+
+	err := uow.Do(ctx, func(ctx context.Context) error {
+		_, err := repo.ClaimItem(ctx, "i-1")
+		assert.NoError(t, err)
+
+		_, err = repo.ClaimItem(ctx, "i-2")
+		assert.ErrorIs(t, err, ErrPayoutItemNotFound)
+
+		err = NewUnitOfWork().Do(context.Background(), func(other context.Context) error {
+			_, err := repo.ClaimItem(other, "i-1")
+			assert.ErrorIs(t, err, ErrPayoutItemNotFound)
+			return nil
+		})
+		assert.NoError(t, err)
+
+		return repo.SetItemResult(ctx, "i-1", models.PayoutFailed, "boom", now)
+	})
+	assert.NoError(t, err)
+
+	err = uow.Do(ctx, func(ctx context.Context) error {
+		_, err := repo.ClaimItem(ctx, "i-1")
+		return err
+	})
+	assert.ErrorIs(t, err, ErrPayoutItemNotFound)
+	assert.Equal(t, models.PayoutItem{ItemId: "i-1", BatchId: "b-1", Result: models.PayoutFailed, Error: "boom", UpdatedAt: now}, repo.items["i-1"])
+
+	assert.ErrorIs(t, repo.SetItemResult(ctx, "i-3", models.PayoutFailed, "", now), ErrPayoutItemNotFound)
+}
+
+func setupPayouts(_ *testing.T, idGenerator func() string) *payoutRepoImpl {
+	repo := NewPayoutRepo()
+	if idGenerator != nil {
+		repo.idGenerator = idGenerator
+	}
+	return repo
+}
@@ -0,0 +1,207 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gopay/internal/models"
+)
+
+var (
+	ErrScheduledPaymentNotFound = errors.New("scheduled payment not found")
+)
+
+type ScheduledPaymentRepo interface {
+	Create(ctx context.Context, schedule models.ScheduledPayment) (string, error)
+	FindOne(ctx context.Context, id string) (models.ScheduledPayment, error)
+	FindByOwner(ctx context.Context, owner string) ([]models.ScheduledPayment, error)
+	FindDue(ctx context.Context, now time.Time) ([]models.ScheduledPayment, error)
+	Claim(ctx context.Context, id string, now time.Time) (models.ScheduledPayment, error)
+	Reschedule(ctx context.Context, id string, nextRunAt time.Time, attempts int, at time.Time) error
+	SetStatus(ctx context.Context, id string, status models.ScheduleStatus, at time.Time) error
+	CreateRun(ctx context.Context, run models.ScheduledRun) (string, error)
+	FindRuns(ctx context.Context, scheduleId string) ([]models.ScheduledRun, error)
+}
+
+var _ ScheduledPaymentRepo = (*scheduledPaymentRepoImpl)(nil)
+
+type scheduledPaymentRepoImpl struct {
+	mu          sync.RWMutex
+	schedules   map[string]models.ScheduledPayment
+	runs        map[string]models.ScheduledRun
+	idGenerator func() string
+	claims      *keyedLock
+}
+
+func NewScheduledPaymentRepo() *scheduledPaymentRepoImpl {
+	return &scheduledPaymentRepoImpl{
+		schedules:   make(map[string]models.ScheduledPayment),
+		runs:        make(map[string]models.ScheduledRun),
+		idGenerator: uuid.NewString,
+		claims:      newKeyedLock(0, ErrScheduledPaymentNotFound),
+	}
+}
+
+func (r *scheduledPaymentRepoImpl) Create(ctx context.Context, schedule models.ScheduledPayment) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := r.idGenerator()
+	schedule.ScheduleId = id
+	schedule.Status = models.ScheduleActive
+	schedule.UpdatedAt = schedule.CreatedAt
+
+	r.schedules[id] = schedule
+	onRollback(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.schedules, id)
+	})
+
+	return id, nil
+}
+
+func (r *scheduledPaymentRepoImpl) FindOne(_ context.Context, id string) (models.ScheduledPayment, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	schedule, found := r.schedules[id]
+	if !found {
+		return models.ScheduledPayment{}, ErrScheduledPaymentNotFound
+	}
+
+	return schedule, nil
+}
+
+func (r *scheduledPaymentRepoImpl) FindByOwner(_ context.Context, owner string) ([]models.ScheduledPayment, error) {
+	return r.filter(func(s models.ScheduledPayment) bool {
+		return s.Owner == owner
+	}), nil
+}
+
+// FindDue returns the active schedules whose next run is at or before now.
+func (r *scheduledPaymentRepoImpl) FindDue(_ context.Context, now time.Time) ([]models.ScheduledPayment, error) {
+	return r.filter(func(s models.ScheduledPayment) bool {
+		return isDue(s, now)
+	}), nil
+}
+
+// filter returns the schedules matching keep, earliest next run first.
+func (r *scheduledPaymentRepoImpl) filter(keep func(s models.ScheduledPayment) bool) []models.ScheduledPayment {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	schedules := []models.ScheduledPayment{}
+
+	for _, s := range r.schedules {
+		if keep(s) {
+			schedules = append(schedules, s)
+		}
+	}
+
+	sort.Slice(schedules, func(i, j int) bool {
+		return schedules[i].NextRunAt.Before(schedules[j].NextRunAt)
+	})
+
+	return schedules
+}
+
+func isDue(s models.ScheduledPayment, now time.Time) bool {
+	return s.Status == models.ScheduleActive && !s.NextRunAt.After(now)
+}
+
+// Claim returns a due schedule and keeps other workers off it until the unit of work
+// bound to ctx ends. Schedules that are not due or already claimed are not found.
+func (r *scheduledPaymentRepoImpl) Claim(ctx context.Context, id string, now time.Time) (models.ScheduledPayment, error) {
+	err := r.claims.lockInTx(ctx, id)
+	if err != nil {
+		return models.ScheduledPayment{}, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	schedule, found := r.schedules[id]
+	if !found || !isDue(schedule, now) {
+		return models.ScheduledPayment{}, ErrScheduledPaymentNotFound
+	}
+
+	return schedule, nil
+}
+
+func (r *scheduledPaymentRepoImpl) Reschedule(ctx context.Context, id string, nextRunAt time.Time, attempts int, at time.Time) error {
+	return r.update(ctx, id, func(s *models.ScheduledPayment) {
+		s.NextRunAt = nextRunAt
+		s.Attempts = attempts
+		s.UpdatedAt = at
+	})
+}
+
+func (r *scheduledPaymentRepoImpl) SetStatus(ctx context.Context, id string, status models.ScheduleStatus, at time.Time) error {
+	return r.update(ctx, id, func(s *models.ScheduledPayment) {
+		s.Status = status
+		s.UpdatedAt = at
+	})
+}
+
+func (r *scheduledPaymentRepoImpl) update(ctx context.Context, id string, change func(s *models.ScheduledPayment)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	schedule, found := r.schedules[id]
+	if !found {
+		return ErrScheduledPaymentNotFound
+	}
+
+	previous := schedule
+	change(&schedule)
+	r.schedules[id] = schedule
+	onRollback(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.schedules[id] = previous
+	})
+
+	return nil
+}
+
+func (r *scheduledPaymentRepoImpl) CreateRun(ctx context.Context, run models.ScheduledRun) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := r.idGenerator()
+	run.RunId = id
+
+	r.runs[id] = run
+	onRollback(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.runs, id)
+	})
+
+	return id, nil
+}
+
+// FindRuns returns the runs of a schedule, latest first.
+func (r *scheduledPaymentRepoImpl) FindRuns(_ context.Context, scheduleId string) ([]models.ScheduledRun, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	runs := []models.ScheduledRun{}
+
+	for _, run := range r.runs {
+		if run.ScheduleId == scheduleId {
+			runs = append(runs, run)
+		}
+	}
+
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].RanAt.After(runs[j].RanAt)
+	})
+
+	return runs, nil
+}
@@ -0,0 +1,544 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package repository
+
+import (
+	context "context"
+
+	models "github.com/gopay/internal/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockScheduledPaymentRepo is an autogenerated mock type for the ScheduledPaymentRepo type
+type MockScheduledPaymentRepo struct {
+	mock.Mock
+}
+
+type MockScheduledPaymentRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockScheduledPaymentRepo) EXPECT() *MockScheduledPaymentRepo_Expecter {
+	return &MockScheduledPaymentRepo_Expecter{mock: &_m.Mock}
+}
+
+// Claim provides a mock function with given fields: ctx, id, now
+func (_m *MockScheduledPaymentRepo) Claim(ctx context.Context, id string, now time.Time) (models.ScheduledPayment, error) {
+	ret := _m.Called(ctx, id, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Claim")
+	}
+
+	var r0 models.ScheduledPayment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (models.ScheduledPayment, error)); ok {
+		return rf(ctx, id, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) models.ScheduledPayment); ok {
+		r0 = rf(ctx, id, now)
+	} else {
+		r0 = ret.Get(0).(models.ScheduledPayment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, id, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockScheduledPaymentRepo_Claim_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Claim'
+type MockScheduledPaymentRepo_Claim_Call struct {
+	*mock.Call
+}
+
+// Claim is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - now time.Time
+func (_e *MockScheduledPaymentRepo_Expecter) Claim(ctx interface{}, id interface{}, now interface{}) *MockScheduledPaymentRepo_Claim_Call {
+	return &MockScheduledPaymentRepo_Claim_Call{Call: _e.mock.On("Claim", ctx, id, now)}
+}
+
+func (_c *MockScheduledPaymentRepo_Claim_Call) Run(run func(ctx context.Context, id string, now time.Time)) *MockScheduledPaymentRepo_Claim_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockScheduledPaymentRepo_Claim_Call) Return(_a0 models.ScheduledPayment, _a1 error) *MockScheduledPaymentRepo_Claim_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockScheduledPaymentRepo_Claim_Call) RunAndReturn(run func(context.Context, string, time.Time) (models.ScheduledPayment, error)) *MockScheduledPaymentRepo_Claim_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function with given fields: ctx, schedule
+func (_m *MockScheduledPaymentRepo) Create(ctx context.Context, schedule models.ScheduledPayment) (string, error) {
+	ret := _m.Called(ctx, schedule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.ScheduledPayment) (string, error)); ok {
+		return rf(ctx, schedule)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.ScheduledPayment) string); ok {
+		r0 = rf(ctx, schedule)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.ScheduledPayment) error); ok {
+		r1 = rf(ctx, schedule)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockScheduledPaymentRepo_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockScheduledPaymentRepo_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx context.Context
+//   - schedule models.ScheduledPayment
+func (_e *MockScheduledPaymentRepo_Expecter) Create(ctx interface{}, schedule interface{}) *MockScheduledPaymentRepo_Create_Call {
+	return &MockScheduledPaymentRepo_Create_Call{Call: _e.mock.On("Create", ctx, schedule)}
+}
+
+func (_c *MockScheduledPaymentRepo_Create_Call) Run(run func(ctx context.Context, schedule models.ScheduledPayment)) *MockScheduledPaymentRepo_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.ScheduledPayment))
+	})
+	return _c
+}
+
+func (_c *MockScheduledPaymentRepo_Create_Call) Return(_a0 string, _a1 error) *MockScheduledPaymentRepo_Create_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockScheduledPaymentRepo_Create_Call) RunAndReturn(run func(context.Context, models.ScheduledPayment) (string, error)) *MockScheduledPaymentRepo_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateRun provides a mock function with given fields: ctx, run
+func (_m *MockScheduledPaymentRepo) CreateRun(ctx context.Context, run models.ScheduledRun) (string, error) {
+	ret := _m.Called(ctx, run)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRun")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.ScheduledRun) (string, error)); ok {
+		return rf(ctx, run)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.ScheduledRun) string); ok {
+		r0 = rf(ctx, run)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.ScheduledRun) error); ok {
+		r1 = rf(ctx, run)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockScheduledPaymentRepo_CreateRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRun'
+type MockScheduledPaymentRepo_CreateRun_Call struct {
+	*mock.Call
+}
+
+// CreateRun is a helper method to define mock.On call
+//   - ctx context.Context
+//   - run models.ScheduledRun
+func (_e *MockScheduledPaymentRepo_Expecter) CreateRun(ctx interface{}, run interface{}) *MockScheduledPaymentRepo_CreateRun_Call {
+	return &MockScheduledPaymentRepo_CreateRun_Call{Call: _e.mock.On("CreateRun", ctx, run)}
+}
+
+func (_c *MockScheduledPaymentRepo_CreateRun_Call) Run(run func(ctx context.Context, run models.ScheduledRun)) *MockScheduledPaymentRepo_CreateRun_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.ScheduledRun))
+	})
+	return _c
+}
+
+func (_c *MockScheduledPaymentRepo_CreateRun_Call) Return(_a0 string, _a1 error) *MockScheduledPaymentRepo_CreateRun_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockScheduledPaymentRepo_CreateRun_Call) RunAndReturn(run func(context.Context, models.ScheduledRun) (string, error)) *MockScheduledPaymentRepo_CreateRun_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByOwner provides a mock function with given fields: ctx, owner
+func (_m *MockScheduledPaymentRepo) FindByOwner(ctx context.Context, owner string) ([]models.ScheduledPayment, error) {
+	ret := _m.Called(ctx, owner)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByOwner")
+	}
+
+	var r0 []models.ScheduledPayment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.ScheduledPayment, error)); ok {
+		return rf(ctx, owner)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.ScheduledPayment); ok {
+		r0 = rf(ctx, owner)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ScheduledPayment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, owner)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockScheduledPaymentRepo_FindByOwner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByOwner'
+type MockScheduledPaymentRepo_FindByOwner_Call struct {
+	*mock.Call
+}
+
+// FindByOwner is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+func (_e *MockScheduledPaymentRepo_Expecter) FindByOwner(ctx interface{}, owner interface{}) *MockScheduledPaymentRepo_FindByOwner_Call {
+	return &MockScheduledPaymentRepo_FindByOwner_Call{Call: _e.mock.On("FindByOwner", ctx, owner)}
+}
+
+func (_c *MockScheduledPaymentRepo_FindByOwner_Call) Run(run func(ctx context.Context, owner string)) *MockScheduledPaymentRepo_FindByOwner_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockScheduledPaymentRepo_FindByOwner_Call) Return(_a0 []models.ScheduledPayment, _a1 error) *MockScheduledPaymentRepo_FindByOwner_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockScheduledPaymentRepo_FindByOwner_Call) RunAndReturn(run func(context.Context, string) ([]models.ScheduledPayment, error)) *MockScheduledPaymentRepo_FindByOwner_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindDue provides a mock function with given fields: ctx, now
+func (_m *MockScheduledPaymentRepo) FindDue(ctx context.Context, now time.Time) ([]models.ScheduledPayment, error) {
+	ret := _m.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDue")
+	}
+
+	var r0 []models.ScheduledPayment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]models.ScheduledPayment, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []models.ScheduledPayment); ok {
+		r0 = rf(ctx, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ScheduledPayment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockScheduledPaymentRepo_FindDue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindDue'
+type MockScheduledPaymentRepo_FindDue_Call struct {
+	*mock.Call
+}
+
+// FindDue is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+func (_e *MockScheduledPaymentRepo_Expecter) FindDue(ctx interface{}, now interface{}) *MockScheduledPaymentRepo_FindDue_Call {
+	return &MockScheduledPaymentRepo_FindDue_Call{Call: _e.mock.On("FindDue", ctx, now)}
+}
+
+func (_c *MockScheduledPaymentRepo_FindDue_Call) Run(run func(ctx context.Context, now time.Time)) *MockScheduledPaymentRepo_FindDue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockScheduledPaymentRepo_FindDue_Call) Return(_a0 []models.ScheduledPayment, _a1 error) *MockScheduledPaymentRepo_FindDue_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockScheduledPaymentRepo_FindDue_Call) RunAndReturn(run func(context.Context, time.Time) ([]models.ScheduledPayment, error)) *MockScheduledPaymentRepo_FindDue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindOne provides a mock function with given fields: ctx, id
+func (_m *MockScheduledPaymentRepo) FindOne(ctx context.Context, id string) (models.ScheduledPayment, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindOne")
+	}
+
+	var r0 models.ScheduledPayment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.ScheduledPayment, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.ScheduledPayment); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(models.ScheduledPayment)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockScheduledPaymentRepo_FindOne_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindOne'
+type MockScheduledPaymentRepo_FindOne_Call struct {
+	*mock.Call
+}
+
+// FindOne is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockScheduledPaymentRepo_Expecter) FindOne(ctx interface{}, id interface{}) *MockScheduledPaymentRepo_FindOne_Call {
+	return &MockScheduledPaymentRepo_FindOne_Call{Call: _e.mock.On("FindOne", ctx, id)}
+}
+
+func (_c *MockScheduledPaymentRepo_FindOne_Call) Run(run func(ctx context.Context, id string)) *MockScheduledPaymentRepo_FindOne_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockScheduledPaymentRepo_FindOne_Call) Return(_a0 models.ScheduledPayment, _a1 error) *MockScheduledPaymentRepo_FindOne_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockScheduledPaymentRepo_FindOne_Call) RunAndReturn(run func(context.Context, string) (models.ScheduledPayment, error)) *MockScheduledPaymentRepo_FindOne_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindRuns provides a mock function with given fields: ctx, scheduleId
+func (_m *MockScheduledPaymentRepo) FindRuns(ctx context.Context, scheduleId string) ([]models.ScheduledRun, error) {
+	ret := _m.Called(ctx, scheduleId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindRuns")
+	}
+
+	var r0 []models.ScheduledRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.ScheduledRun, error)); ok {
+		return rf(ctx, scheduleId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.ScheduledRun); ok {
+		r0 = rf(ctx, scheduleId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.ScheduledRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, scheduleId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockScheduledPaymentRepo_FindRuns_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindRuns'
+type MockScheduledPaymentRepo_FindRuns_Call struct {
+	*mock.Call
+}
+
+// FindRuns is a helper method to define mock.On call
+//   - ctx context.Context
+//   - scheduleId string
+func (_e *MockScheduledPaymentRepo_Expecter) FindRuns(ctx interface{}, scheduleId interface{}) *MockScheduledPaymentRepo_FindRuns_Call {
+	return &MockScheduledPaymentRepo_FindRuns_Call{Call: _e.mock.On("FindRuns", ctx, scheduleId)}
+}
+
+func (_c *MockScheduledPaymentRepo_FindRuns_Call) Run(run func(ctx context.Context, scheduleId string)) *MockScheduledPaymentRepo_FindRuns_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockScheduledPaymentRepo_FindRuns_Call) Return(_a0 []models.ScheduledRun, _a1 error) *MockScheduledPaymentRepo_FindRuns_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockScheduledPaymentRepo_FindRuns_Call) RunAndReturn(run func(context.Context, string) ([]models.ScheduledRun, error)) *MockScheduledPaymentRepo_FindRuns_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Reschedule provides a mock function with given fields: ctx, id, nextRunAt, attempts, at
+func (_m *MockScheduledPaymentRepo) Reschedule(ctx context.Context, id string, nextRunAt time.Time, attempts int, at time.Time) error {
+	ret := _m.Called(ctx, id, nextRunAt, attempts, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reschedule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, int, time.Time) error); ok {
+		r0 = rf(ctx, id, nextRunAt, attempts, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockScheduledPaymentRepo_Reschedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reschedule'
+type MockScheduledPaymentRepo_Reschedule_Call struct {
+	*mock.Call
+}
+
+// Reschedule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - nextRunAt time.Time
+//   - attempts int
+//   - at time.Time
+func (_e *MockScheduledPaymentRepo_Expecter) Reschedule(ctx interface{}, id interface{}, nextRunAt interface{}, attempts interface{}, at interface{}) *MockScheduledPaymentRepo_Reschedule_Call {
+	return &MockScheduledPaymentRepo_Reschedule_Call{Call: _e.mock.On("Reschedule", ctx, id, nextRunAt, attempts, at)}
+}
+
+func (_c *MockScheduledPaymentRepo_Reschedule_Call) Run(run func(ctx context.Context, id string, nextRunAt time.Time, attempts int, at time.Time)) *MockScheduledPaymentRepo_Reschedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time), args[3].(int), args[4].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockScheduledPaymentRepo_Reschedule_Call) Return(_a0 error) *MockScheduledPaymentRepo_Reschedule_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockScheduledPaymentRepo_Reschedule_Call) RunAndReturn(run func(context.Context, string, time.Time, int, time.Time) error) *MockScheduledPaymentRepo_Reschedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetStatus provides a mock function with given fields: ctx, id, status, at
+func (_m *MockScheduledPaymentRepo) SetStatus(ctx context.Context, id string, status models.ScheduleStatus, at time.Time) error {
+	ret := _m.Called(ctx, id, status, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.ScheduleStatus, time.Time) error); ok {
+		r0 = rf(ctx, id, status, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockScheduledPaymentRepo_SetStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetStatus'
+type MockScheduledPaymentRepo_SetStatus_Call struct {
+	*mock.Call
+}
+
+// SetStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - status models.ScheduleStatus
+//   - at time.Time
+func (_e *MockScheduledPaymentRepo_Expecter) SetStatus(ctx interface{}, id interface{}, status interface{}, at interface{}) *MockScheduledPaymentRepo_SetStatus_Call {
+	return &MockScheduledPaymentRepo_SetStatus_Call{Call: _e.mock.On("SetStatus", ctx, id, status, at)}
+}
+
+func (_c *MockScheduledPaymentRepo_SetStatus_Call) Run(run func(ctx context.Context, id string, status models.ScheduleStatus, at time.Time)) *MockScheduledPaymentRepo_SetStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.ScheduleStatus), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockScheduledPaymentRepo_SetStatus_Call) Return(_a0 error) *MockScheduledPaymentRepo_SetStatus_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockScheduledPaymentRepo_SetStatus_Call) RunAndReturn(run func(context.Context, string, models.ScheduleStatus, time.Time) error) *MockScheduledPaymentRepo_SetStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockScheduledPaymentRepo creates a new instance of MockScheduledPaymentRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockScheduledPaymentRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockScheduledPaymentRepo {
+	mock := &MockScheduledPaymentRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
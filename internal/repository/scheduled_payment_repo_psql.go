@@ -0,0 +1,249 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gopay/internal/models"
+)
+
+const (
+	createScheduledPaymentQ = `
+	INSERT INTO scheduled_payments
+	(owner, receiver, amount, frequency, cron, start_at, next_run_at, status, created_at, updated_at)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $9)
+	RETURNING schedule_id
+	`
+
+	findScheduledPaymentQ = `
+	SELECT schedule_id, owner, receiver, amount, frequency, cron, start_at, next_run_at, status, attempts, created_at, updated_at
+	FROM scheduled_payments
+	WHERE schedule_id = $1
+	`
+
+	findScheduledPaymentsByOwnerQ = `
+	SELECT schedule_id, owner, receiver, amount, frequency, cron, start_at, next_run_at, status, attempts, created_at, updated_at
+	FROM scheduled_payments
+	WHERE owner = $1
+	ORDER BY next_run_at ASC
+	`
+
+	findDueScheduledPaymentsQ = `
+	SELECT schedule_id, owner, receiver, amount, frequency, cron, start_at, next_run_at, status, attempts, created_at, updated_at
+	FROM scheduled_payments
+	WHERE status = 'active'
+	AND next_run_at <= $1
+	ORDER BY next_run_at ASC
+	`
+
+	claimScheduledPaymentQ = `
+	SELECT schedule_id, owner, receiver, amount, frequency, cron, start_at, next_run_at, status, attempts, created_at, updated_at
+	FROM scheduled_payments
+	WHERE schedule_id = $1
+	AND status = 'active'
+	AND next_run_at <= $2
+	FOR UPDATE SKIP LOCKED
+	`
+
+	rescheduleScheduledPaymentQ = `
+	UPDATE scheduled_payments
+	SET next_run_at = $2, attempts = $3, updated_at = $4
+	WHERE schedule_id = $1
+	`
+
+	setScheduledPaymentStatusQ = `
+	UPDATE scheduled_payments
+	SET status = $2, updated_at = $3
+	WHERE schedule_id = $1
+	`
+
+	createScheduledRunQ = `
+	INSERT INTO scheduled_payment_runs
+	(schedule_id, scheduled_for, ran_at, attempt, outcome, error)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING run_id
+	`
+
+	findScheduledRunsQ = `
+	SELECT run_id, schedule_id, scheduled_for, ran_at, attempt, outcome, error
+	FROM scheduled_payment_runs
+	WHERE schedule_id = $1
+	ORDER BY ran_at DESC
+	`
+)
+
+type ScheduledPaymentRepoPsql interface {
+	Create(ctx context.Context, schedule models.ScheduledPayment) (string, error)
+	FindOne(ctx context.Context, id string) (models.ScheduledPayment, error)
+	FindByOwner(ctx context.Context, owner string) ([]models.ScheduledPayment, error)
+	FindDue(ctx context.Context, now time.Time) ([]models.ScheduledPayment, error)
+	Claim(ctx context.Context, id string, now time.Time) (models.ScheduledPayment, error)
+	Reschedule(ctx context.Context, id string, nextRunAt time.Time, attempts int, at time.Time) error
+	SetStatus(ctx context.Context, id string, status models.ScheduleStatus, at time.Time) error
+	CreateRun(ctx context.Context, run models.ScheduledRun) (string, error)
+	FindRuns(ctx context.Context, scheduleId string) ([]models.ScheduledRun, error)
+}
+
+var _ ScheduledPaymentRepoPsql = (*scheduledPaymentRepoPsqlImpl)(nil)
+
+type scheduledPaymentRepoPsqlImpl struct {
+	psql *sql.DB
+}
+
+func NewScheduledPaymentRepoPsql(db *sql.DB) *scheduledPaymentRepoPsqlImpl {
+	return &scheduledPaymentRepoPsqlImpl{
+		psql: db,
+	}
+}
+
+func (r *scheduledPaymentRepoPsqlImpl) Create(ctx context.Context, schedule models.ScheduledPayment) (string, error) {
+	var id string
+
+	row := conn(ctx, r.psql).QueryRowContext(ctx, createScheduledPaymentQ, schedule.Owner, schedule.Receiver, schedule.Amount, schedule.Frequency, schedule.Cron, schedule.StartAt, schedule.NextRunAt, models.ScheduleActive, schedule.CreatedAt)
+	err := row.Scan(&id)
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (r *scheduledPaymentRepoPsqlImpl) FindOne(ctx context.Context, id string) (models.ScheduledPayment, error) {
+	// A malformed id cannot match any scheduled payment, and querying with it would fail the
+	// surrounding transaction.
+	if uuid.Validate(id) != nil {
+		return models.ScheduledPayment{}, ErrScheduledPaymentNotFound
+	}
+
+	row := conn(ctx, r.psql).QueryRowContext(ctx, findScheduledPaymentQ, id)
+	s, err := scanScheduledPayment(row)
+	if err == sql.ErrNoRows {
+		return models.ScheduledPayment{}, ErrScheduledPaymentNotFound
+	}
+	if err != nil {
+		return models.ScheduledPayment{}, err
+	}
+
+	return s, nil
+}
+
+func (r *scheduledPaymentRepoPsqlImpl) FindByOwner(ctx context.Context, owner string) ([]models.ScheduledPayment, error) {
+	return r.find(ctx, findScheduledPaymentsByOwnerQ, owner)
+}
+
+// FindDue returns the active schedules whose next run is at or before now.
+func (r *scheduledPaymentRepoPsqlImpl) FindDue(ctx context.Context, now time.Time) ([]models.ScheduledPayment, error) {
+	return r.find(ctx, findDueScheduledPaymentsQ, now)
+}
+
+func (r *scheduledPaymentRepoPsqlImpl) find(ctx context.Context, query string, args ...any) ([]models.ScheduledPayment, error) {
+	schedules := []models.ScheduledPayment{}
+
+	rows, err := conn(ctx, r.psql).QueryContext(ctx, query, args...)
+	if err != nil {
+		return schedules, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		s, err := scanScheduledPayment(rows)
+		if err != nil {
+			return []models.ScheduledPayment{}, err
+		}
+		schedules = append(schedules, s)
+	}
+
+	return schedules, rows.Err()
+}
+
+// Claim locks a due schedule for the surrounding unit of work. Rows already claimed by
+// another replica are skipped and reported as ErrScheduledPaymentNotFound.
+func (r *scheduledPaymentRepoPsqlImpl) Claim(ctx context.Context, id string, now time.Time) (models.ScheduledPayment, error) {
+	if !inTx(ctx) {
+		return models.ScheduledPayment{}, ErrNoUnitOfWork
+	}
+
+	row := conn(ctx, r.psql).QueryRowContext(ctx, claimScheduledPaymentQ, id, now)
+	s, err := scanScheduledPayment(row)
+	if err == sql.ErrNoRows {
+		return models.ScheduledPayment{}, ErrScheduledPaymentNotFound
+	}
+	if err != nil {
+		return models.ScheduledPayment{}, err
+	}
+
+	return s, nil
+}
+
+func (r *scheduledPaymentRepoPsqlImpl) Reschedule(ctx context.Context, id string, nextRunAt time.Time, attempts int, at time.Time) error {
+	return r.exec(ctx, rescheduleScheduledPaymentQ, id, nextRunAt, attempts, at)
+}
+
+func (r *scheduledPaymentRepoPsqlImpl) SetStatus(ctx context.Context, id string, status models.ScheduleStatus, at time.Time) error {
+	return r.exec(ctx, setScheduledPaymentStatusQ, id, status, at)
+}
+
+func (r *scheduledPaymentRepoPsqlImpl) exec(ctx context.Context, query string, args ...any) error {
+	res, err := conn(ctx, r.psql).ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrScheduledPaymentNotFound
+	}
+
+	return nil
+}
+
+func (r *scheduledPaymentRepoPsqlImpl) CreateRun(ctx context.Context, run models.ScheduledRun) (string, error) {
+	var id string
+
+	row := conn(ctx, r.psql).QueryRowContext(ctx, createScheduledRunQ, run.ScheduleId, run.ScheduledFor, run.RanAt, run.Attempt, run.Outcome, run.Error)
+	err := row.Scan(&id)
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// FindRuns returns the runs of a schedule, latest first.
+func (r *scheduledPaymentRepoPsqlImpl) FindRuns(ctx context.Context, scheduleId string) ([]models.ScheduledRun, error) {
+	runs := []models.ScheduledRun{}
+
+	rows, err := conn(ctx, r.psql).QueryContext(ctx, findScheduledRunsQ, scheduleId)
+	if err != nil {
+		return runs, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		run := models.ScheduledRun{}
+		err = rows.Scan(&run.RunId, &run.ScheduleId, &run.ScheduledFor, &run.RanAt, &run.Attempt, &run.Outcome, &run.Error)
+		if err != nil {
+			return []models.ScheduledRun{}, err
+		}
+		runs = append(runs, run)
+	}
+
+	return runs, rows.Err()
+}
+
+func scanScheduledPayment(row scanner) (models.ScheduledPayment, error) {
+	s := models.ScheduledPayment{}
+
+	err := row.Scan(&s.ScheduleId, &s.Owner, &s.Receiver, &s.Amount, &s.Frequency, &s.Cron, &s.StartAt, &s.NextRunAt, &s.Status, &s.Attempts, &s.CreatedAt, &s.UpdatedAt)
+	if err != nil {
+		return models.ScheduledPayment{}, err
+	}
+
+	return s, nil
+}
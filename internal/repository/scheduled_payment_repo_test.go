@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gopay/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScheduledPayment_Create(t *testing.T) {
+	now := time.Now()
+	ctx := context.Background()
+
+	repo := setupScheduledPayments(t, map[string]models.ScheduledPayment{}, func() string { return "s-1" })
+
+	id, err := repo.Create(ctx, models.ScheduledPayment{
+		Owner:     "0001",
+		Receiver:  "0002",
+		Amount:    5000,
+		Frequency: models.FrequencyWeekly,
+		StartAt:   now,
+		NextRunAt: now,
+		CreatedAt: now,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "s-1", id)
+
+	schedule, err := repo.FindOne(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, models.ScheduledPayment{
+		ScheduleId: "s-1",
+		Owner:      "0001",
+		Receiver:   "0002",
+		Amount:     5000,
+		Frequency:  models.FrequencyWeekly,
+		StartAt:    now,
+		NextRunAt:  now,
+		Status:     models.ScheduleActive,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}, schedule)
+
+	_, err = repo.FindOne(ctx, "s-2")
+	assert.ErrorIs(t, err, ErrScheduledPaymentNotFound)
+}
+
+func TestScheduledPayment_FindDue(t *testing.T) {
+	now := time.Now()
+
+	repo := setupScheduledPayments(t, map[string]models.ScheduledPayment{
+		"s-1": {ScheduleId: "s-1", Status: models.ScheduleActive, NextRunAt: now},
+		"s-2": {ScheduleId: "s-2", Status: models.ScheduleActive, NextRunAt: now.Add(-time.Hour)},
+		"s-3": {ScheduleId: "s-3", Status: models.ScheduleActive, NextRunAt: now.Add(time.Hour)},
+		"s-4": {ScheduleId: "s-4", Status: models.SchedulePaused, NextRunAt: now.Add(-time.Hour)},
+	}, nil)
+
+	schedules, err := repo.FindDue(context.Background(), now)
+	assert.NoError(t, err)
+
+	ids := []string{}
+	for _, s := range schedules {
+		ids = append(ids, s.ScheduleId)
+	}
+
+	assert.Equal(t, []string{"s-2", "s-1"}, ids)
+}
+
+func TestScheduledPayment_Claim(t *testing.T) {
+	now := time.Now()
+	ctx := context.Background()
+	uow := NewUnitOfWork()
+
+	repo := setupScheduledPayments(t, map[string]models.ScheduledPayment{
+		"s-1": {ScheduleId: "s-1", Status: models.ScheduleActive, NextRunAt: now},
+		"s-2": {ScheduleId: "s-2", Status: models.ScheduleActive, NextRunAt: now.Add(time.Hour)},
+	}, nil)
+
+	err := uow.Do(ctx, func(ctx context.Context) error {
+		_, err := repo.Claim(ctx, "s-1", now)
+		assert.NoError(t, err)
+
+		_, err = repo.Claim(ctx, "s-2", now)
+		assert.ErrorIs(t, err, ErrScheduledPaymentNotFound)
+
+		return NewUnitOfWork().Do(context.Background(), func(other context.Context) error {
+			_, err := repo.Claim(other, "s-1", now)
+			assert.ErrorIs(t, err, ErrScheduledPaymentNotFound)
+			return nil
+		})
+	})
+	assert.NoError(t, err)
+
+	err = uow.Do(ctx, func(ctx context.Context) error {
+		_, err := repo.Claim(ctx, "s-1", now)
+		return err
+	})
+	assert.NoError(t, err)
+}
+
+func TestScheduledPayment_Update(t *testing.T) {
+	now := time.Now()
+	ctx := context.Background()
+	errBoom := errors.New("boom")
+
+	repo := setupScheduledPayments(t, map[string]models.ScheduledPayment{
+		"s-1": {ScheduleId: "s-1", Status: models.ScheduleActive, NextRunAt: now},
+	}, nil)
+
+	err := NewUnitOfWork().Do(ctx, func(ctx context.Context) error {
+		assert.NoError(t, repo.Reschedule(ctx, "s-1", now.Add(time.Hour), 1, now))
+		assert.NoError(t, repo.SetStatus(ctx, "s-1", models.SchedulePaused, now))
+		return errBoom
+	})
+	assert.ErrorIs(t, err, errBoom)
+
+	schedule, err := repo.FindOne(ctx, "s-1")
+	assert.NoError(t, err)
+	assert.Equal(t, models.ScheduledPayment{ScheduleId: "s-1", Status: models.ScheduleActive, NextRunAt: now}, schedule)
+
+	assert.NoError(t, repo.Reschedule(ctx, "s-1", now.Add(time.Hour), 1, now))
+	schedule, err = repo.FindOne(ctx, "s-1")
+	assert.NoError(t, err)
+	assert.Equal(t, now.Add(time.Hour), schedule.NextRunAt)
+	assert.Equal(t, 1, schedule.Attempts)
+	assert.Equal(t, now, schedule.UpdatedAt)
+
+	assert.ErrorIs(t, repo.SetStatus(ctx, "s-2", models.ScheduleCancelled, now), ErrScheduledPaymentNotFound)
+}
+
+func setupScheduledPayments(_ *testing.T, initialData map[string]models.ScheduledPayment, idGenerator func() string) *scheduledPaymentRepoImpl {
+	repo := NewScheduledPaymentRepo()
+	repo.schedules = initialData
+	repo.idGenerator = idGenerator
+	return repo
+}
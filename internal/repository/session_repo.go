@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gopay/internal/models"
+)
+
+var (
+	ErrSessionNotFound = errors.New("session not found")
+)
+
+type SessionRepo interface {
+	Save(ctx context.Context, session models.Session) error
+	FindOne(ctx context.Context, tokenHash string) (models.Session, error)
+	Remove(ctx context.Context, tokenHash string) error
+	RemoveByAccount(ctx context.Context, accId string) error
+	RemoveExpired(ctx context.Context, accId string, now time.Time) error
+}
+
+var _ SessionRepo = (*sessionRepoImpl)(nil)
+
+type sessionRepoImpl struct {
+	mu       sync.RWMutex
+	sessions map[string]models.Session
+}
+
+func NewSessionRepo() *sessionRepoImpl {
+	return &sessionRepoImpl{
+		sessions: make(map[string]models.Session),
+	}
+}
+
+func (r *sessionRepoImpl) Save(ctx context.Context, session models.Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.sessions[session.TokenHash] = session
+	onRollback(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.sessions, session.TokenHash)
+	})
+
+	return nil
+}
+
+// FindOne returns the session whose token hashes to tokenHash, expired or not.
+func (r *sessionRepoImpl) FindOne(_ context.Context, tokenHash string) (models.Session, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	session, found := r.sessions[tokenHash]
+	if !found {
+		return models.Session{}, ErrSessionNotFound
+	}
+
+	return session, nil
+}
+
+func (r *sessionRepoImpl) Remove(ctx context.Context, tokenHash string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	session, found := r.sessions[tokenHash]
+	if !found {
+		return ErrSessionNotFound
+	}
+
+	r.remove(ctx, session)
+	return nil
+}
+
+// RemoveByAccount logs accId out everywhere.
+func (r *sessionRepoImpl) RemoveByAccount(ctx context.Context, accId string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, s := range r.sessions {
+		if s.AccountId == accId {
+			r.remove(ctx, s)
+		}
+	}
+
+	return nil
+}
+
+// RemoveExpired drops the sessions of accId that expired by now.
+func (r *sessionRepoImpl) RemoveExpired(ctx context.Context, accId string, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, s := range r.sessions {
+		if s.AccountId == accId && !now.Before(s.ExpiresAt) {
+			r.remove(ctx, s)
+		}
+	}
+
+	return nil
+}
+
+// remove must be called with mu held.
+func (r *sessionRepoImpl) remove(ctx context.Context, session models.Session) {
+	delete(r.sessions, session.TokenHash)
+	onRollback(ctx, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.sessions[session.TokenHash] = session
+	})
+}
@@ -0,0 +1,285 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package repository
+
+import (
+	context "context"
+
+	models "github.com/gopay/internal/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockSessionRepo is an autogenerated mock type for the SessionRepo type
+type MockSessionRepo struct {
+	mock.Mock
+}
+
+type MockSessionRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSessionRepo) EXPECT() *MockSessionRepo_Expecter {
+	return &MockSessionRepo_Expecter{mock: &_m.Mock}
+}
+
+// FindOne provides a mock function with given fields: ctx, tokenHash
+func (_m *MockSessionRepo) FindOne(ctx context.Context, tokenHash string) (models.Session, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindOne")
+	}
+
+	var r0 models.Session
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.Session, error)); ok {
+		return rf(ctx, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.Session); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		r0 = ret.Get(0).(models.Session)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSessionRepo_FindOne_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindOne'
+type MockSessionRepo_FindOne_Call struct {
+	*mock.Call
+}
+
+// FindOne is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenHash string
+func (_e *MockSessionRepo_Expecter) FindOne(ctx interface{}, tokenHash interface{}) *MockSessionRepo_FindOne_Call {
+	return &MockSessionRepo_FindOne_Call{Call: _e.mock.On("FindOne", ctx, tokenHash)}
+}
+
+func (_c *MockSessionRepo_FindOne_Call) Run(run func(ctx context.Context, tokenHash string)) *MockSessionRepo_FindOne_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSessionRepo_FindOne_Call) Return(_a0 models.Session, _a1 error) *MockSessionRepo_FindOne_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSessionRepo_FindOne_Call) RunAndReturn(run func(context.Context, string) (models.Session, error)) *MockSessionRepo_FindOne_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Remove provides a mock function with given fields: ctx, tokenHash
+func (_m *MockSessionRepo) Remove(ctx context.Context, tokenHash string) error {
+	ret := _m.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Remove")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSessionRepo_Remove_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Remove'
+type MockSessionRepo_Remove_Call struct {
+	*mock.Call
+}
+
+// Remove is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenHash string
+func (_e *MockSessionRepo_Expecter) Remove(ctx interface{}, tokenHash interface{}) *MockSessionRepo_Remove_Call {
+	return &MockSessionRepo_Remove_Call{Call: _e.mock.On("Remove", ctx, tokenHash)}
+}
+
+func (_c *MockSessionRepo_Remove_Call) Run(run func(ctx context.Context, tokenHash string)) *MockSessionRepo_Remove_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSessionRepo_Remove_Call) Return(_a0 error) *MockSessionRepo_Remove_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSessionRepo_Remove_Call) RunAndReturn(run func(context.Context, string) error) *MockSessionRepo_Remove_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveByAccount provides a mock function with given fields: ctx, accId
+func (_m *MockSessionRepo) RemoveByAccount(ctx context.Context, accId string) error {
+	ret := _m.Called(ctx, accId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveByAccount")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, accId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSessionRepo_RemoveByAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveByAccount'
+type MockSessionRepo_RemoveByAccount_Call struct {
+	*mock.Call
+}
+
+// RemoveByAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accId string
+func (_e *MockSessionRepo_Expecter) RemoveByAccount(ctx interface{}, accId interface{}) *MockSessionRepo_RemoveByAccount_Call {
+	return &MockSessionRepo_RemoveByAccount_Call{Call: _e.mock.On("RemoveByAccount", ctx, accId)}
+}
+
+func (_c *MockSessionRepo_RemoveByAccount_Call) Run(run func(ctx context.Context, accId string)) *MockSessionRepo_RemoveByAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSessionRepo_RemoveByAccount_Call) Return(_a0 error) *MockSessionRepo_RemoveByAccount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSessionRepo_RemoveByAccount_Call) RunAndReturn(run func(context.Context, string) error) *MockSessionRepo_RemoveByAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveExpired provides a mock function with given fields: ctx, accId, now
+func (_m *MockSessionRepo) RemoveExpired(ctx context.Context, accId string, now time.Time) error {
+	ret := _m.Called(ctx, accId, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveExpired")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, accId, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSessionRepo_RemoveExpired_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveExpired'
+type MockSessionRepo_RemoveExpired_Call struct {
+	*mock.Call
+}
+
+// RemoveExpired is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accId string
+//   - now time.Time
+func (_e *MockSessionRepo_Expecter) RemoveExpired(ctx interface{}, accId interface{}, now interface{}) *MockSessionRepo_RemoveExpired_Call {
+	return &MockSessionRepo_RemoveExpired_Call{Call: _e.mock.On("RemoveExpired", ctx, accId, now)}
+}
+
+func (_c *MockSessionRepo_RemoveExpired_Call) Run(run func(ctx context.Context, accId string, now time.Time)) *MockSessionRepo_RemoveExpired_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockSessionRepo_RemoveExpired_Call) Return(_a0 error) *MockSessionRepo_RemoveExpired_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSessionRepo_RemoveExpired_Call) RunAndReturn(run func(context.Context, string, time.Time) error) *MockSessionRepo_RemoveExpired_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function with given fields: ctx, session
+func (_m *MockSessionRepo) Save(ctx context.Context, session models.Session) error {
+	ret := _m.Called(ctx, session)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Session) error); ok {
+		r0 = rf(ctx, session)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSessionRepo_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type MockSessionRepo_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx context.Context
+//   - session models.Session
+func (_e *MockSessionRepo_Expecter) Save(ctx interface{}, session interface{}) *MockSessionRepo_Save_Call {
+	return &MockSessionRepo_Save_Call{Call: _e.mock.On("Save", ctx, session)}
+}
+
+func (_c *MockSessionRepo_Save_Call) Run(run func(ctx context.Context, session models.Session)) *MockSessionRepo_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.Session))
+	})
+	return _c
+}
+
+func (_c *MockSessionRepo_Save_Call) Return(_a0 error) *MockSessionRepo_Save_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockSessionRepo_Save_Call) RunAndReturn(run func(context.Context, models.Session) error) *MockSessionRepo_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSessionRepo creates a new instance of MockSessionRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSessionRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSessionRepo {
+	mock := &MockSessionRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
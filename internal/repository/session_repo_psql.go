@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gopay/internal/models"
+)
+
+const (
+	saveSessionQ = `
+	INSERT INTO sessions
+	(token_hash, account_id, created_at, expires_at)
+	VALUES ($1, $2, $3, $4)
+	`
+
+	findOneSessionQ = `
+	SELECT token_hash, account_id, created_at, expires_at
+	FROM sessions
+	WHERE token_hash = $1
+	`
+
+	removeSessionQ = `
+	DELETE FROM sessions
+	WHERE token_hash = $1
+	`
+
+	removeSessionsByAccountQ = `
+	DELETE FROM sessions
+	WHERE account_id = $1
+	`
+
+	removeExpiredSessionsQ = `
+	DELETE FROM sessions
+	WHERE account_id = $1
+	AND expires_at <= $2
+	`
+)
+
+type SessionRepoPsql interface {
+	Save(ctx context.Context, session models.Session) error
+	FindOne(ctx context.Context, tokenHash string) (models.Session, error)
+	Remove(ctx context.Context, tokenHash string) error
+	RemoveByAccount(ctx context.Context, accId string) error
+	RemoveExpired(ctx context.Context, accId string, now time.Time) error
+}
+
+var _ SessionRepoPsql = (*sessionRepoPsqlImpl)(nil)
+
+type sessionRepoPsqlImpl struct {
+	psql *sql.DB
+}
+
+func NewSessionRepoPsql(db *sql.DB) *sessionRepoPsqlImpl {
+	return &sessionRepoPsqlImpl{
+		psql: db,
+	}
+}
+
+func (r *sessionRepoPsqlImpl) Save(ctx context.Context, session models.Session) error {
+	_, err := conn(ctx, r.psql).ExecContext(ctx, saveSessionQ, session.TokenHash, session.AccountId, session.CreatedAt, session.ExpiresAt)
+	return err
+}
+
+// FindOne returns the session whose token hashes to tokenHash, expired or not.
+func (r *sessionRepoPsqlImpl) FindOne(ctx context.Context, tokenHash string) (models.Session, error) {
+	session := models.Session{}
+	row := conn(ctx, r.psql).QueryRowContext(ctx, findOneSessionQ, tokenHash)
+	err := row.Scan(&session.TokenHash, &session.AccountId, &session.CreatedAt, &session.ExpiresAt)
+	if err == sql.ErrNoRows {
+		return models.Session{}, ErrSessionNotFound
+	}
+	if err != nil {
+		return models.Session{}, err
+	}
+
+	return session, nil
+}
+
+func (r *sessionRepoPsqlImpl) Remove(ctx context.Context, tokenHash string) error {
+	res, err := conn(ctx, r.psql).ExecContext(ctx, removeSessionQ, tokenHash)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return ErrSessionNotFound
+	}
+
+	return nil
+}
+
+// RemoveByAccount logs accId out everywhere.
+func (r *sessionRepoPsqlImpl) RemoveByAccount(ctx context.Context, accId string) error {
+	if uuid.Validate(accId) != nil {
+		return nil
+	}
+
+	_, err := conn(ctx, r.psql).ExecContext(ctx, removeSessionsByAccountQ, accId)
+	return err
+}
+
+// RemoveExpired drops the sessions of accId that expired by now.
+func (r *sessionRepoPsqlImpl) RemoveExpired(ctx context.Context, accId string, now time.Time) error {
+	if uuid.Validate(accId) != nil {
+		return nil
+	}
+
+	_, err := conn(ctx, r.psql).ExecContext(ctx, removeExpiredSessionsQ, accId, now)
+	return err
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gopay/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSession_Remove(t *testing.T) {
+	ctx := context.Background()
+
+	repo := setupSessions(t, map[string]models.Session{
+		"aaaa": {TokenHash: "aaaa", AccountId: "0001"},
+	})
+
+	assert.ErrorIs(t, repo.Remove(ctx, "bbbb"), ErrSessionNotFound)
+	assert.NoError(t, repo.Remove(ctx, "aaaa"))
+
+	_, err := repo.FindOne(ctx, "aaaa")
+	assert.ErrorIs(t, err, ErrSessionNotFound)
+}
+
+func TestSession_RemoveByAccount(t *testing.T) {
+	ctx := context.Background()
+
+	repo := setupSessions(t, map[string]models.Session{
+		"aaaa": {TokenHash: "aaaa", AccountId: "0001"},
+		"bbbb": {TokenHash: "bbbb", AccountId: "0001"},
+		"cccc": {TokenHash: "cccc", AccountId: "0002"},
+	})
+
+	assert.NoError(t, repo.RemoveByAccount(ctx, "0001"))
+
+	assert.Equal(t, map[string]models.Session{
+		"cccc": {TokenHash: "cccc", AccountId: "0002"},
+	}, repo.sessions)
+}
+
+func TestSession_RemoveExpired(t *testing.T) {
+	now := time.Now()
+	ctx := context.Background()
+
+	repo := setupSessions(t, map[string]models.Session{
+		"aaaa": {TokenHash: "aaaa", AccountId: "0001", ExpiresAt: now.Add(-time.Minute)},
+		"bbbb": {TokenHash: "bbbb", AccountId: "0001", ExpiresAt: now},
+		"cccc": {TokenHash: "cccc", AccountId: "0001", ExpiresAt: now.Add(time.Minute)},
+		"dddd": {TokenHash: "dddd", AccountId: "0002", ExpiresAt: now.Add(-time.Minute)},
+	})
+
+	assert.NoError(t, repo.RemoveExpired(ctx, "0001", now))
+
+	assert.Equal(t, map[string]models.Session{
+		"cccc": {TokenHash: "cccc", AccountId: "0001", ExpiresAt: now.Add(time.Minute)},
+		"dddd": {TokenHash: "dddd", AccountId: "0002", ExpiresAt: now.Add(-time.Minute)},
+	}, repo.sessions)
+}
+
+func TestSession_Rollback(t *testing.T) {
+	ctx := context.Background()
+	errBoom := errors.New("boom")
+
+	repo := setupSessions(t, map[string]models.Session{
+		"aaaa": {TokenHash: "aaaa", AccountId: "0001"},
+		"bbbb": {TokenHash: "bbbb", AccountId: "0001"},
+	})
+
+	err := NewUnitOfWork().Do(ctx, func(ctx context.Context) error {
+		assert.NoError(t, repo.RemoveByAccount(ctx, "0001"))
+		assert.NoError(t, repo.Save(ctx, models.Session{TokenHash: "cccc", AccountId: "0001"}))
+		return errBoom
+	})
+	assert.ErrorIs(t, err, errBoom)
+
+	assert.Equal(t, map[string]models.Session{
+		"aaaa": {TokenHash: "aaaa", AccountId: "0001"},
+		"bbbb": {TokenHash: "bbbb", AccountId: "0001"},
+	}, repo.sessions)
+}
+
+func setupSessions(_ *testing.T, initialData map[string]models.Session) *sessionRepoImpl {
+	repo := NewSessionRepo()
+	repo.sessions = initialData
+	return repo
+}
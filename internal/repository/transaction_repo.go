@@ -5,6 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/gopay/internal/models"
 	"github.com/gopay/internal/utils"
@@ -17,42 +20,59 @@ var (
 	ErrMissingSenderField   = fmt.Errorf("sender: %w", ErrMissingFields)
 	ErrMissingReceiverField = fmt.Errorf("receiver: %w", ErrMissingFields)
 	ErrMissingOwnerField    = fmt.Errorf("owner: %w", ErrMissingFields)
+	ErrMissingKindField     = fmt.Errorf("kind: %w", ErrMissingFields)
 	ErrZeroAmount           = errors.New("transaction amount cannot be zero")
 	ErrNegativeBalance      = errors.New("negative balance")
+	ErrAlreadyConsumed      = errors.New("transaction was already consumed")
+	ErrAccountLocked        = errors.New("account is being modified by another operation")
 )
 
 type TransactionRepo interface {
 	FindAll(ctx context.Context, accId string) ([]models.Transaction, error)
+	FindPage(ctx context.Context, accId string, filter models.TransactionFilter) (models.TransactionPage, error)
+	FindUnconsumed(ctx context.Context, accId string) ([]models.Transaction, error)
+	FindRelated(ctx context.Context, id string) ([]models.Transaction, error)
+	FindCounterparties(ctx context.Context, accId string, since time.Time) ([]models.Counterparty, error)
+	FindFeed(ctx context.Context, filter models.FeedFilter) (models.TransactionPage, error)
+	GetOutgoing(ctx context.Context, accId string, since time.Time) (models.Money, error)
 	FindOne(ctx context.Context, id string) (models.Transaction, error)
 	Create(ctx context.Context, transaction models.Transaction) error
 	MarkAsConsumed(ctx context.Context, id string) error
 	GetBalance(ctx context.Context, id string) (models.Balance, error)
-	RollBackConsumed(ctx context.Context, tConsumed []string) error
+	LockAccount(ctx context.Context, accId string) error
 }
 
 var _ TransactionRepo = (*transactionRepoImpl)(nil)
 
+const accountLockTimeout = 5 * time.Second
+
 type transactionRepoImpl struct {
+	mu           sync.RWMutex
 	transactions map[string]models.Transaction
 	idGenerator  func() string
+	accountLocks *keyedLock
 }
 
 func NewTransactionRepo() *transactionRepoImpl {
 	return &transactionRepoImpl{
 		transactions: make(map[string]models.Transaction),
 		idGenerator:  utils.GetTransactionUUID,
+		accountLocks: newKeyedLock(accountLockTimeout, ErrAccountLocked),
 	}
 }
 
 func (r *transactionRepoImpl) GetBalance(ctx context.Context, id string) (models.Balance, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	balance := models.Balance{
 		AccountId: id,
-		Amount:    0.0,
+		Amount:    0,
 	}
 
 	for _, t := range r.transactions {
 		if t.Owner == id && !t.IsConsumed {
-			balance.Amount += float64(t.Amount)
+			balance.Amount += t.Amount
 		}
 	}
 
@@ -64,10 +84,250 @@ func (r *transactionRepoImpl) GetBalance(ctx context.Context, id string) (models
 }
 
 func (r *transactionRepoImpl) FindAll(_ context.Context, accId string) ([]models.Transaction, error) {
+	return r.filter(func(t models.Transaction) bool {
+		return t.Owner == accId
+	}), nil
+}
+
+// FindPage returns up to filter.Limit of accId's transactions matching filter, starting
+// after filter.Cursor. NextCursor is empty on the last page.
+func (r *transactionRepoImpl) FindPage(_ context.Context, accId string, filter models.TransactionFilter) (models.TransactionPage, error) {
+	descending := filter.Order == models.SortDesc
+
+	var (
+		cursorAt time.Time
+		cursorId string
+		err      error
+	)
+	if filter.Cursor != "" {
+		cursorAt, cursorId, err = decodeCursor(filter.Cursor)
+		if err != nil {
+			return models.TransactionPage{}, err
+		}
+	}
+
+	transactions := r.filter(func(t models.Transaction) bool {
+		if t.Owner != accId || !matchesFilter(t, filter) {
+			return false
+		}
+		return filter.Cursor == "" || afterCursor(t.CreatedAt, t.TransactionId, cursorAt, cursorId, descending)
+	})
+
+	sort.SliceStable(transactions, func(i, j int) bool {
+		a, b := transactions[i], transactions[j]
+		if a.CreatedAt.Equal(b.CreatedAt) {
+			return (a.TransactionId < b.TransactionId) != descending
+		}
+		return a.CreatedAt.Before(b.CreatedAt) != descending
+	})
+
+	return newTransactionPage(transactions, filter.Limit), nil
+}
+
+// matchesFilter applies every filter except the cursor and the limit.
+func matchesFilter(t models.Transaction, filter models.TransactionFilter) bool {
+	if !filter.From.IsZero() && t.CreatedAt.Before(filter.From) {
+		return false
+	}
+	if !filter.To.IsZero() && !t.CreatedAt.Before(filter.To) {
+		return false
+	}
+
+	switch filter.Direction {
+	case models.DirectionIn:
+		if t.Amount < 0 {
+			return false
+		}
+	case models.DirectionOut:
+		if t.Amount > 0 {
+			return false
+		}
+	}
+
+	if filter.Counterparty != "" && t.Sender != filter.Counterparty && t.Receiver != filter.Counterparty {
+		return false
+	}
+	if filter.MinAmount != nil && t.Amount.Abs() < *filter.MinAmount {
+		return false
+	}
+	if filter.MaxAmount != nil && t.Amount.Abs() > *filter.MaxAmount {
+		return false
+	}
+	if filter.Consumed != nil && t.IsConsumed != *filter.Consumed {
+		return false
+	}
+	if filter.Kind != "" && t.Kind != filter.Kind {
+		return false
+	}
+	if filter.Memo != "" && !strings.Contains(strings.ToLower(t.Memo), strings.ToLower(filter.Memo)) {
+		return false
+	}
+	for key, value := range filter.Metadata {
+		if v, found := t.Metadata[key]; !found || v != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// newTransactionPage cuts transactions, already in page order, down to limit. Callers
+// fetch one extra row so that a full last page does not get a cursor.
+func newTransactionPage(transactions []models.Transaction, limit int) models.TransactionPage {
+	page := models.TransactionPage{Transactions: transactions}
+
+	if limit > 0 && len(transactions) > limit {
+		page.Transactions = transactions[:limit]
+		last := page.Transactions[limit-1]
+		page.NextCursor = encodeCursor(last.CreatedAt, last.TransactionId)
+	}
+
+	return page
+}
+
+func (r *transactionRepoImpl) FindUnconsumed(_ context.Context, accId string) ([]models.Transaction, error) {
+	return r.filter(func(t models.Transaction) bool {
+		return t.Owner == accId && !t.IsConsumed
+	}), nil
+}
+
+// FindRelated returns the transactions linked to id, such as the refunds of a payment.
+func (r *transactionRepoImpl) FindRelated(_ context.Context, id string) ([]models.Transaction, error) {
+	return r.filter(func(t models.Transaction) bool {
+		return t.RelatedId == id
+	}), nil
+}
+
+// FindCounterparties returns the accounts that accId paid or was paid by since then,
+// most payments first.
+func (r *transactionRepoImpl) FindCounterparties(_ context.Context, accId string, since time.Time) ([]models.Counterparty, error) {
+	payments := r.filter(func(t models.Transaction) bool {
+		return t.Owner == accId && (t.Kind == models.KindPaymentOut || t.Kind == models.KindPaymentIn) && !t.CreatedAt.Before(since)
+	})
+
+	byAccount := map[string]*models.Counterparty{}
+	counterparties := []models.Counterparty{}
+	for _, t := range payments {
+		id := t.Sender
+		if t.Kind == models.KindPaymentOut {
+			id = t.Receiver
+		}
+
+		c, found := byAccount[id]
+		if !found {
+			c = &models.Counterparty{AccountId: id}
+			byAccount[id] = c
+		}
+		c.Payments++
+		if t.CreatedAt.After(c.LastAt) {
+			c.LastAt = t.CreatedAt
+		}
+	}
+
+	for _, c := range byAccount {
+		counterparties = append(counterparties, *c)
+	}
+	sortCounterparties(counterparties)
+
+	return counterparties, nil
+}
+
+func sortCounterparties(counterparties []models.Counterparty) {
+	sort.Slice(counterparties, func(i, j int) bool {
+		a, b := counterparties[i], counterparties[j]
+		if a.Payments != b.Payments {
+			return a.Payments > b.Payments
+		}
+		if !a.LastAt.Equal(b.LastAt) {
+			return a.LastAt.After(b.LastAt)
+		}
+		return a.AccountId < b.AccountId
+	})
+}
+
+// FindFeed returns the page of payments filter.Viewer may see, newest first. Each payment
+// shows once, as its payment_out leg.
+func (r *transactionRepoImpl) FindFeed(_ context.Context, filter models.FeedFilter) (models.TransactionPage, error) {
+	var (
+		cursorAt time.Time
+		cursorId string
+		err      error
+	)
+	if filter.Cursor != "" {
+		cursorAt, cursorId, err = decodeCursor(filter.Cursor)
+		if err != nil {
+			return models.TransactionPage{}, err
+		}
+	}
+
+	friends := make(map[string]bool, len(filter.Friends))
+	for _, id := range filter.Friends {
+		friends[id] = true
+	}
+
+	payments := r.filter(func(t models.Transaction) bool {
+		if t.Kind != models.KindPaymentOut || t.Privacy == "" {
+			return false
+		}
+		if filter.Account != "" && t.Sender != filter.Account && t.Receiver != filter.Account {
+			return false
+		}
+		if !visibleTo(t, filter.Viewer, friends) {
+			return false
+		}
+		return filter.Cursor == "" || afterCursor(t.CreatedAt, t.TransactionId, cursorAt, cursorId, true)
+	})
+
+	sort.SliceStable(payments, func(i, j int) bool {
+		a, b := payments[i], payments[j]
+		if a.CreatedAt.Equal(b.CreatedAt) {
+			return a.TransactionId > b.TransactionId
+		}
+		return a.CreatedAt.After(b.CreatedAt)
+	})
+
+	return newTransactionPage(payments, filter.Limit), nil
+}
+
+// GetOutgoing returns the money accId withdrew or paid since then.
+func (r *transactionRepoImpl) GetOutgoing(_ context.Context, accId string, since time.Time) (models.Money, error) {
+	outgoing := r.filter(func(t models.Transaction) bool {
+		return t.Owner == accId && (t.Kind == models.KindWithdrawal || t.Kind == models.KindPaymentOut) && !t.CreatedAt.Before(since)
+	})
+
+	var total models.Money
+	for _, t := range outgoing {
+		total += t.Amount.Abs()
+	}
+
+	return total, nil
+}
+
+// visibleTo tells whether viewer may see the payment t. The sender and the receiver
+// always see it.
+func visibleTo(t models.Transaction, viewer string, friends map[string]bool) bool {
+	if viewer != "" && (t.Sender == viewer || t.Receiver == viewer) {
+		return true
+	}
+
+	switch t.Privacy {
+	case models.PrivacyPublic:
+		return true
+	case models.PrivacyFriends:
+		return friends[t.Sender] || friends[t.Receiver]
+	}
+	return false
+}
+
+// filter returns the transactions matching keep, oldest first.
+func (r *transactionRepoImpl) filter(keep func(t models.Transaction) bool) []models.Transaction {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	transactions := []models.Transaction{}
 
 	for _, t := range r.transactions {
-		if t.Owner == accId {
+		if keep(t) {
 			transactions = append(transactions, t)
 		}
 	}
@@ -76,10 +336,13 @@ func (r *transactionRepoImpl) FindAll(_ context.Context, accId string) ([]models
 		return transactions[i].CreatedAt.Before(transactions[j].CreatedAt)
 	})
 
-	return transactions, nil
+	return transactions
 }
 
 func (r *transactionRepoImpl) FindOne(_ context.Context, id string) (models.Transaction, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	transaction, found := r.transactions[id]
 
 	if !found {
@@ -89,7 +352,7 @@ func (r *transactionRepoImpl) FindOne(_ context.Context, id string) (models.Tran
 	return transaction, nil
 }
 
-func (r *transactionRepoImpl) Create(_ context.Context, transaction models.Transaction) error {
+func (r *transactionRepoImpl) Create(ctx context.Context, transaction models.Transaction) error {
 	if transaction.Sender == "" {
 		return ErrMissingSenderField
 	}
@@ -101,39 +364,60 @@ func (r *transactionRepoImpl) Create(_ context.Context, transaction models.Trans
 		return ErrMissingOwnerField
 	}
 
+	if transaction.Kind == "" {
+		return ErrMissingKindField
+	}
+
 	if transaction.Amount == 0 {
 		return ErrZeroAmount
 	}
 
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	id := r.idGenerator()
 	transaction.TransactionId = id
 
 	r.transactions[id] = transaction
+	onRollback(ctx, func() { r.delete(id) })
 
 	return nil
 }
 
 func (r *transactionRepoImpl) MarkAsConsumed(ctx context.Context, id string) error {
-	transaction, err := r.FindOne(ctx, id)
-	if err != nil {
-		return err
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	transaction, found := r.transactions[id]
+	if !found {
+		return ErrTransactionNotFound
+	}
+
+	if transaction.IsConsumed {
+		return ErrAlreadyConsumed
 	}
 
+	previous := transaction
 	transaction.IsConsumed = true
 	r.transactions[id] = transaction
+	onRollback(ctx, func() { r.put(previous) })
 
 	return nil
 }
 
-func (r *transactionRepoImpl) RollBackConsumed(ctx context.Context, tConsumed []string) error {
-	for _, tid := range tConsumed {
-		t, exists := r.transactions[tid]
-		if !exists {
-			return fmt.Errorf("transaction id %s: %w", tid, ErrTransactionNotFound)
-		}
-		t.IsConsumed = false
-		r.transactions[tid] = t
-	}
+// LockAccount serializes every operation on accId's funds until the unit of work bound to ctx ends.
+func (r *transactionRepoImpl) LockAccount(ctx context.Context, accId string) error {
+	return r.accountLocks.lockInTx(ctx, accId)
+}
 
-	return nil
+func (r *transactionRepoImpl) put(transaction models.Transaction) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.transactions[transaction.TransactionId] = transaction
+}
+
+func (r *transactionRepoImpl) delete(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.transactions, id)
 }
@@ -7,6 +7,8 @@ import (
 
 	models "github.com/gopay/internal/models"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // MockTransactionRepo is an autogenerated mock type for the TransactionRepo type
@@ -128,6 +130,123 @@ func (_c *MockTransactionRepo_FindAll_Call) RunAndReturn(run func(context.Contex
 	return _c
 }
 
+// FindCounterparties provides a mock function with given fields: ctx, accId, since
+func (_m *MockTransactionRepo) FindCounterparties(ctx context.Context, accId string, since time.Time) ([]models.Counterparty, error) {
+	ret := _m.Called(ctx, accId, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindCounterparties")
+	}
+
+	var r0 []models.Counterparty
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) ([]models.Counterparty, error)); ok {
+		return rf(ctx, accId, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) []models.Counterparty); ok {
+		r0 = rf(ctx, accId, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Counterparty)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, accId, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTransactionRepo_FindCounterparties_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindCounterparties'
+type MockTransactionRepo_FindCounterparties_Call struct {
+	*mock.Call
+}
+
+// FindCounterparties is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accId string
+//   - since time.Time
+func (_e *MockTransactionRepo_Expecter) FindCounterparties(ctx interface{}, accId interface{}, since interface{}) *MockTransactionRepo_FindCounterparties_Call {
+	return &MockTransactionRepo_FindCounterparties_Call{Call: _e.mock.On("FindCounterparties", ctx, accId, since)}
+}
+
+func (_c *MockTransactionRepo_FindCounterparties_Call) Run(run func(ctx context.Context, accId string, since time.Time)) *MockTransactionRepo_FindCounterparties_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepo_FindCounterparties_Call) Return(_a0 []models.Counterparty, _a1 error) *MockTransactionRepo_FindCounterparties_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTransactionRepo_FindCounterparties_Call) RunAndReturn(run func(context.Context, string, time.Time) ([]models.Counterparty, error)) *MockTransactionRepo_FindCounterparties_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindFeed provides a mock function with given fields: ctx, filter
+func (_m *MockTransactionRepo) FindFeed(ctx context.Context, filter models.FeedFilter) (models.TransactionPage, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindFeed")
+	}
+
+	var r0 models.TransactionPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.FeedFilter) (models.TransactionPage, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.FeedFilter) models.TransactionPage); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(models.TransactionPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.FeedFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTransactionRepo_FindFeed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindFeed'
+type MockTransactionRepo_FindFeed_Call struct {
+	*mock.Call
+}
+
+// FindFeed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter models.FeedFilter
+func (_e *MockTransactionRepo_Expecter) FindFeed(ctx interface{}, filter interface{}) *MockTransactionRepo_FindFeed_Call {
+	return &MockTransactionRepo_FindFeed_Call{Call: _e.mock.On("FindFeed", ctx, filter)}
+}
+
+func (_c *MockTransactionRepo_FindFeed_Call) Run(run func(ctx context.Context, filter models.FeedFilter)) *MockTransactionRepo_FindFeed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(models.FeedFilter))
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepo_FindFeed_Call) Return(_a0 models.TransactionPage, _a1 error) *MockTransactionRepo_FindFeed_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTransactionRepo_FindFeed_Call) RunAndReturn(run func(context.Context, models.FeedFilter) (models.TransactionPage, error)) *MockTransactionRepo_FindFeed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FindOne provides a mock function with given fields: ctx, id
 func (_m *MockTransactionRepo) FindOne(ctx context.Context, id string) (models.Transaction, error) {
 	ret := _m.Called(ctx, id)
@@ -185,6 +304,182 @@ func (_c *MockTransactionRepo_FindOne_Call) RunAndReturn(run func(context.Contex
 	return _c
 }
 
+// FindPage provides a mock function with given fields: ctx, accId, filter
+func (_m *MockTransactionRepo) FindPage(ctx context.Context, accId string, filter models.TransactionFilter) (models.TransactionPage, error) {
+	ret := _m.Called(ctx, accId, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindPage")
+	}
+
+	var r0 models.TransactionPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.TransactionFilter) (models.TransactionPage, error)); ok {
+		return rf(ctx, accId, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.TransactionFilter) models.TransactionPage); ok {
+		r0 = rf(ctx, accId, filter)
+	} else {
+		r0 = ret.Get(0).(models.TransactionPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.TransactionFilter) error); ok {
+		r1 = rf(ctx, accId, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTransactionRepo_FindPage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindPage'
+type MockTransactionRepo_FindPage_Call struct {
+	*mock.Call
+}
+
+// FindPage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accId string
+//   - filter models.TransactionFilter
+func (_e *MockTransactionRepo_Expecter) FindPage(ctx interface{}, accId interface{}, filter interface{}) *MockTransactionRepo_FindPage_Call {
+	return &MockTransactionRepo_FindPage_Call{Call: _e.mock.On("FindPage", ctx, accId, filter)}
+}
+
+func (_c *MockTransactionRepo_FindPage_Call) Run(run func(ctx context.Context, accId string, filter models.TransactionFilter)) *MockTransactionRepo_FindPage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.TransactionFilter))
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepo_FindPage_Call) Return(_a0 models.TransactionPage, _a1 error) *MockTransactionRepo_FindPage_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTransactionRepo_FindPage_Call) RunAndReturn(run func(context.Context, string, models.TransactionFilter) (models.TransactionPage, error)) *MockTransactionRepo_FindPage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindRelated provides a mock function with given fields: ctx, id
+func (_m *MockTransactionRepo) FindRelated(ctx context.Context, id string) ([]models.Transaction, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindRelated")
+	}
+
+	var r0 []models.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.Transaction, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.Transaction); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTransactionRepo_FindRelated_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindRelated'
+type MockTransactionRepo_FindRelated_Call struct {
+	*mock.Call
+}
+
+// FindRelated is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockTransactionRepo_Expecter) FindRelated(ctx interface{}, id interface{}) *MockTransactionRepo_FindRelated_Call {
+	return &MockTransactionRepo_FindRelated_Call{Call: _e.mock.On("FindRelated", ctx, id)}
+}
+
+func (_c *MockTransactionRepo_FindRelated_Call) Run(run func(ctx context.Context, id string)) *MockTransactionRepo_FindRelated_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepo_FindRelated_Call) Return(_a0 []models.Transaction, _a1 error) *MockTransactionRepo_FindRelated_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTransactionRepo_FindRelated_Call) RunAndReturn(run func(context.Context, string) ([]models.Transaction, error)) *MockTransactionRepo_FindRelated_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindUnconsumed provides a mock function with given fields: ctx, accId
+func (_m *MockTransactionRepo) FindUnconsumed(ctx context.Context, accId string) ([]models.Transaction, error) {
+	ret := _m.Called(ctx, accId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindUnconsumed")
+	}
+
+	var r0 []models.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.Transaction, error)); ok {
+		return rf(ctx, accId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.Transaction); ok {
+		r0 = rf(ctx, accId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, accId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTransactionRepo_FindUnconsumed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindUnconsumed'
+type MockTransactionRepo_FindUnconsumed_Call struct {
+	*mock.Call
+}
+
+// FindUnconsumed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accId string
+func (_e *MockTransactionRepo_Expecter) FindUnconsumed(ctx interface{}, accId interface{}) *MockTransactionRepo_FindUnconsumed_Call {
+	return &MockTransactionRepo_FindUnconsumed_Call{Call: _e.mock.On("FindUnconsumed", ctx, accId)}
+}
+
+func (_c *MockTransactionRepo_FindUnconsumed_Call) Run(run func(ctx context.Context, accId string)) *MockTransactionRepo_FindUnconsumed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepo_FindUnconsumed_Call) Return(_a0 []models.Transaction, _a1 error) *MockTransactionRepo_FindUnconsumed_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTransactionRepo_FindUnconsumed_Call) RunAndReturn(run func(context.Context, string) ([]models.Transaction, error)) *MockTransactionRepo_FindUnconsumed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetBalance provides a mock function with given fields: ctx, id
 func (_m *MockTransactionRepo) GetBalance(ctx context.Context, id string) (models.Balance, error) {
 	ret := _m.Called(ctx, id)
@@ -242,17 +537,75 @@ func (_c *MockTransactionRepo_GetBalance_Call) RunAndReturn(run func(context.Con
 	return _c
 }
 
-// MarkAsConsumed provides a mock function with given fields: ctx, id
-func (_m *MockTransactionRepo) MarkAsConsumed(ctx context.Context, id string) error {
-	ret := _m.Called(ctx, id)
+// GetOutgoing provides a mock function with given fields: ctx, accId, since
+func (_m *MockTransactionRepo) GetOutgoing(ctx context.Context, accId string, since time.Time) (models.Money, error) {
+	ret := _m.Called(ctx, accId, since)
 
 	if len(ret) == 0 {
-		panic("no return value specified for MarkAsConsumed")
+		panic("no return value specified for GetOutgoing")
+	}
+
+	var r0 models.Money
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (models.Money, error)); ok {
+		return rf(ctx, accId, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) models.Money); ok {
+		r0 = rf(ctx, accId, since)
+	} else {
+		r0 = ret.Get(0).(models.Money)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, accId, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTransactionRepo_GetOutgoing_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOutgoing'
+type MockTransactionRepo_GetOutgoing_Call struct {
+	*mock.Call
+}
+
+// GetOutgoing is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accId string
+//   - since time.Time
+func (_e *MockTransactionRepo_Expecter) GetOutgoing(ctx interface{}, accId interface{}, since interface{}) *MockTransactionRepo_GetOutgoing_Call {
+	return &MockTransactionRepo_GetOutgoing_Call{Call: _e.mock.On("GetOutgoing", ctx, accId, since)}
+}
+
+func (_c *MockTransactionRepo_GetOutgoing_Call) Run(run func(ctx context.Context, accId string, since time.Time)) *MockTransactionRepo_GetOutgoing_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockTransactionRepo_GetOutgoing_Call) Return(_a0 models.Money, _a1 error) *MockTransactionRepo_GetOutgoing_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTransactionRepo_GetOutgoing_Call) RunAndReturn(run func(context.Context, string, time.Time) (models.Money, error)) *MockTransactionRepo_GetOutgoing_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LockAccount provides a mock function with given fields: ctx, accId
+func (_m *MockTransactionRepo) LockAccount(ctx context.Context, accId string) error {
+	ret := _m.Called(ctx, accId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockAccount")
 	}
 
 	var r0 error
 	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, id)
+		r0 = rf(ctx, accId)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -260,46 +613,46 @@ func (_m *MockTransactionRepo) MarkAsConsumed(ctx context.Context, id string) er
 	return r0
 }
 
-// MockTransactionRepo_MarkAsConsumed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkAsConsumed'
-type MockTransactionRepo_MarkAsConsumed_Call struct {
+// MockTransactionRepo_LockAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LockAccount'
+type MockTransactionRepo_LockAccount_Call struct {
 	*mock.Call
 }
 
-// MarkAsConsumed is a helper method to define mock.On call
+// LockAccount is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-func (_e *MockTransactionRepo_Expecter) MarkAsConsumed(ctx interface{}, id interface{}) *MockTransactionRepo_MarkAsConsumed_Call {
-	return &MockTransactionRepo_MarkAsConsumed_Call{Call: _e.mock.On("MarkAsConsumed", ctx, id)}
+//   - accId string
+func (_e *MockTransactionRepo_Expecter) LockAccount(ctx interface{}, accId interface{}) *MockTransactionRepo_LockAccount_Call {
+	return &MockTransactionRepo_LockAccount_Call{Call: _e.mock.On("LockAccount", ctx, accId)}
 }
 
-func (_c *MockTransactionRepo_MarkAsConsumed_Call) Run(run func(ctx context.Context, id string)) *MockTransactionRepo_MarkAsConsumed_Call {
+func (_c *MockTransactionRepo_LockAccount_Call) Run(run func(ctx context.Context, accId string)) *MockTransactionRepo_LockAccount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *MockTransactionRepo_MarkAsConsumed_Call) Return(_a0 error) *MockTransactionRepo_MarkAsConsumed_Call {
+func (_c *MockTransactionRepo_LockAccount_Call) Return(_a0 error) *MockTransactionRepo_LockAccount_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockTransactionRepo_MarkAsConsumed_Call) RunAndReturn(run func(context.Context, string) error) *MockTransactionRepo_MarkAsConsumed_Call {
+func (_c *MockTransactionRepo_LockAccount_Call) RunAndReturn(run func(context.Context, string) error) *MockTransactionRepo_LockAccount_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RollBackConsumed provides a mock function with given fields: ctx, tConsumed
-func (_m *MockTransactionRepo) RollBackConsumed(ctx context.Context, tConsumed []string) error {
-	ret := _m.Called(ctx, tConsumed)
+// MarkAsConsumed provides a mock function with given fields: ctx, id
+func (_m *MockTransactionRepo) MarkAsConsumed(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RollBackConsumed")
+		panic("no return value specified for MarkAsConsumed")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, []string) error); ok {
-		r0 = rf(ctx, tConsumed)
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -307,31 +660,31 @@ func (_m *MockTransactionRepo) RollBackConsumed(ctx context.Context, tConsumed [
 	return r0
 }
 
-// MockTransactionRepo_RollBackConsumed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RollBackConsumed'
-type MockTransactionRepo_RollBackConsumed_Call struct {
+// MockTransactionRepo_MarkAsConsumed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkAsConsumed'
+type MockTransactionRepo_MarkAsConsumed_Call struct {
 	*mock.Call
 }
 
-// RollBackConsumed is a helper method to define mock.On call
+// MarkAsConsumed is a helper method to define mock.On call
 //   - ctx context.Context
-//   - tConsumed []string
-func (_e *MockTransactionRepo_Expecter) RollBackConsumed(ctx interface{}, tConsumed interface{}) *MockTransactionRepo_RollBackConsumed_Call {
-	return &MockTransactionRepo_RollBackConsumed_Call{Call: _e.mock.On("RollBackConsumed", ctx, tConsumed)}
+//   - id string
+func (_e *MockTransactionRepo_Expecter) MarkAsConsumed(ctx interface{}, id interface{}) *MockTransactionRepo_MarkAsConsumed_Call {
+	return &MockTransactionRepo_MarkAsConsumed_Call{Call: _e.mock.On("MarkAsConsumed", ctx, id)}
 }
 
-func (_c *MockTransactionRepo_RollBackConsumed_Call) Run(run func(ctx context.Context, tConsumed []string)) *MockTransactionRepo_RollBackConsumed_Call {
+func (_c *MockTransactionRepo_MarkAsConsumed_Call) Run(run func(ctx context.Context, id string)) *MockTransactionRepo_MarkAsConsumed_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].([]string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
 
-func (_c *MockTransactionRepo_RollBackConsumed_Call) Return(_a0 error) *MockTransactionRepo_RollBackConsumed_Call {
+func (_c *MockTransactionRepo_MarkAsConsumed_Call) Return(_a0 error) *MockTransactionRepo_MarkAsConsumed_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *MockTransactionRepo_RollBackConsumed_Call) RunAndReturn(run func(context.Context, []string) error) *MockTransactionRepo_RollBackConsumed_Call {
+func (_c *MockTransactionRepo_MarkAsConsumed_Call) RunAndReturn(run func(context.Context, string) error) *MockTransactionRepo_MarkAsConsumed_Call {
 	_c.Call.Return(run)
 	return _c
 }
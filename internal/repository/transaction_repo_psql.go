@@ -3,31 +3,58 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/gopay/internal/models"
+	"github.com/lib/pq"
 )
 
 const (
 	createTransQ = `
 	INSERT INTO transactions 
-	(owner, sender, receiver, created_at, amount, is_consumed) 
-	VALUES ($1, $2, $3, $4, $5, $6) 
+	(owner, sender, receiver, created_at, amount, is_consumed, kind, related_id, memo, metadata, privacy) 
+	VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, '')::uuid, $9, $10, $11) 
 	`
 
 	findAllTransQ = `
-	SELECT transaction_id, owner, sender, receiver, created_at, amount, is_consumed
+	SELECT transaction_id, owner, sender, receiver, created_at, amount, is_consumed, kind, COALESCE(related_id::text, ''), memo, metadata, privacy
 	FROM transactions
+	WHERE owner = $1
+	ORDER BY created_at ASC
+	`
+
+	findPageTransQ = `
+	SELECT transaction_id, owner, sender, receiver, created_at, amount, is_consumed, kind, COALESCE(related_id::text, ''), memo, metadata, privacy
+	FROM transactions
+	WHERE owner = $1
+	`
+
+	findUnconsumedTransQ = `
+	SELECT transaction_id, owner, sender, receiver, created_at, amount, is_consumed, kind, COALESCE(related_id::text, ''), memo, metadata, privacy
+	FROM transactions
+	WHERE owner = $1
+	AND is_consumed = false
+	ORDER BY created_at ASC
+	`
+
+	findRelatedTransQ = `
+	SELECT transaction_id, owner, sender, receiver, created_at, amount, is_consumed, kind, COALESCE(related_id::text, ''), memo, metadata, privacy
+	FROM transactions
+	WHERE related_id = $1
 	ORDER BY created_at ASC
 	`
 
 	findOneTransQ = `
-	SELECT transaction_id, owner, sender, receiver, created_at, amount, is_consumed
+	SELECT transaction_id, owner, sender, receiver, created_at, amount, is_consumed, kind, COALESCE(related_id::text, ''), memo, metadata, privacy
 	FROM transactions
 	WHERE transaction_id = $1
 	`
 
 	getBalanceQ = `
-	SELECT SUM(amount)
+	SELECT COALESCE(SUM(amount), 0)
 	FROM transactions
 	WHERE is_consumed = false
 	AND owner = $1
@@ -37,16 +64,57 @@ const (
 	UPDATE transactions
 	SET is_consumed = true
 	WHERE transaction_id = $1
+	AND is_consumed = false
+	`
+
+	getOutgoingQ = `
+	SELECT COALESCE(SUM(ABS(amount)), 0)
+	FROM transactions
+	WHERE owner = $1
+	AND kind IN ('withdrawal', 'payment_out')
+	AND created_at >= $2
+	`
+
+	setLockTimeoutQ = `SET LOCAL lock_timeout = '5s'`
+
+	findCounterpartiesQ = `
+	SELECT CASE WHEN kind = 'payment_out' THEN receiver ELSE sender END AS counterparty, COUNT(*), MAX(created_at)
+	FROM transactions
+	WHERE owner = $1
+	AND kind IN ('payment_out', 'payment_in')
+	AND created_at >= $2
+	GROUP BY counterparty
+	ORDER BY COUNT(*) DESC, MAX(created_at) DESC, counterparty
+	`
+
+	findFeedTransQ = `
+	SELECT transaction_id, owner, sender, receiver, created_at, amount, is_consumed, kind, COALESCE(related_id::text, ''), memo, metadata, privacy
+	FROM transactions
+	WHERE kind = 'payment_out'
+	AND privacy <> ''
+	`
+
+	lockAccountQ = `
+	SELECT account_id
+	FROM accounts
+	WHERE account_id = $1
+	FOR UPDATE
 	`
 )
 
 type TransactionRepoPsql interface {
 	FindAll(ctx context.Context, accId string) ([]models.Transaction, error)
+	FindPage(ctx context.Context, accId string, filter models.TransactionFilter) (models.TransactionPage, error)
 	FindOne(ctx context.Context, id string) (models.Transaction, error)
 	Create(ctx context.Context, transaction models.Transaction) error
 	MarkAsConsumed(ctx context.Context, id string) error
 	GetBalance(ctx context.Context, id string) (models.Balance, error)
-	RollBackConsumed(ctx context.Context, tConsumed []string) error
+	FindUnconsumed(ctx context.Context, accId string) ([]models.Transaction, error)
+	FindRelated(ctx context.Context, id string) ([]models.Transaction, error)
+	FindCounterparties(ctx context.Context, accId string, since time.Time) ([]models.Counterparty, error)
+	FindFeed(ctx context.Context, filter models.FeedFilter) (models.TransactionPage, error)
+	GetOutgoing(ctx context.Context, accId string, since time.Time) (models.Money, error)
+	LockAccount(ctx context.Context, accId string) error
 }
 
 var _ TransactionRepoPsql = (*transactionRepoPsqlImpl)(nil)
@@ -64,7 +132,7 @@ func NewTransactionRepoPsql(db *sql.DB) *transactionRepoPsqlImpl {
 func (r *transactionRepoPsqlImpl) FindAll(ctx context.Context, accId string) ([]models.Transaction, error) {
 	transactions := []models.Transaction{}
 
-	rows, err := r.psql.Query(findAllTransQ)
+	rows, err := conn(ctx, r.psql).QueryContext(ctx, findAllTransQ, accId)
 	if err != nil {
 		return transactions, err
 	}
@@ -72,18 +140,294 @@ func (r *transactionRepoPsqlImpl) FindAll(ctx context.Context, accId string) ([]
 
 	for rows.Next() {
 		t := models.Transaction{}
-		rows.Scan(&t.TransactionId, &t.Owner, &t.Sender, &t.Receiver, &t.CreatedAt, &t.Amount, &t.IsConsumed)
+		err = rows.Scan(&t.TransactionId, &t.Owner, &t.Sender, &t.Receiver, &t.CreatedAt, &t.Amount, &t.IsConsumed, &t.Kind, &t.RelatedId, &t.Memo, &t.Metadata, &t.Privacy)
+		if err != nil {
+			return []models.Transaction{}, err
+		}
+		transactions = append(transactions, t)
+	}
+
+	return transactions, rows.Err()
+}
+
+// FindPage returns up to filter.Limit of accId's transactions matching filter, starting
+// after filter.Cursor. NextCursor is empty on the last page.
+func (r *transactionRepoPsqlImpl) FindPage(ctx context.Context, accId string, filter models.TransactionFilter) (models.TransactionPage, error) {
+	query, args, err := buildFindPageQuery(accId, filter)
+	if err != nil {
+		return models.TransactionPage{}, err
+	}
+
+	rows, err := conn(ctx, r.psql).QueryContext(ctx, query, args...)
+	if err != nil {
+		return models.TransactionPage{}, err
+	}
+	defer rows.Close()
+
+	transactions := []models.Transaction{}
+	for rows.Next() {
+		t := models.Transaction{}
+		err = rows.Scan(&t.TransactionId, &t.Owner, &t.Sender, &t.Receiver, &t.CreatedAt, &t.Amount, &t.IsConsumed, &t.Kind, &t.RelatedId, &t.Memo, &t.Metadata, &t.Privacy)
+		if err != nil {
+			return models.TransactionPage{}, err
+		}
 		transactions = append(transactions, t)
 	}
+	if err = rows.Err(); err != nil {
+		return models.TransactionPage{}, err
+	}
+
+	return newTransactionPage(transactions, filter.Limit), nil
+}
+
+// likeEscaper makes user input match literally inside a LIKE pattern, whose default
+// escape character is the backslash.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// buildFindPageQuery appends a condition to findPageTransQ for every filter that is set.
+// The row after the last one of the page is fetched too, to know whether there is a next page.
+func buildFindPageQuery(accId string, filter models.TransactionFilter) (string, []any, error) {
+	query := findPageTransQ
+	args := []any{accId}
+
+	where := func(cond string, values ...any) {
+		for _, v := range values {
+			args = append(args, v)
+			cond = strings.Replace(cond, "?", fmt.Sprintf("$%d", len(args)), 1)
+		}
+		query += "\tAND " + cond + "\n"
+	}
+
+	if !filter.From.IsZero() {
+		where("created_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		where("created_at < ?", filter.To)
+	}
+
+	switch filter.Direction {
+	case models.DirectionIn:
+		where("amount > 0")
+	case models.DirectionOut:
+		where("amount < 0")
+	}
+
+	if filter.Counterparty != "" {
+		where("(sender = ? OR receiver = ?)", filter.Counterparty, filter.Counterparty)
+	}
+	if filter.MinAmount != nil {
+		where("ABS(amount) >= ?", *filter.MinAmount)
+	}
+	if filter.MaxAmount != nil {
+		where("ABS(amount) <= ?", *filter.MaxAmount)
+	}
+	if filter.Consumed != nil {
+		where("is_consumed = ?", *filter.Consumed)
+	}
+	if filter.Kind != "" {
+		where("kind = ?", filter.Kind)
+	}
+	if filter.Memo != "" {
+		where("memo ILIKE ?", "%"+likeEscaper.Replace(filter.Memo)+"%")
+	}
+	if len(filter.Metadata) > 0 {
+		where("metadata @> ?::jsonb", filter.Metadata)
+	}
+
+	order := "ASC"
+	if filter.Order == models.SortDesc {
+		order = "DESC"
+	}
+
+	if filter.Cursor != "" {
+		cursorAt, cursorId, err := decodeCursor(filter.Cursor)
+		if err != nil {
+			return "", nil, err
+		}
+
+		if uuid.Validate(cursorId) != nil {
+			return "", nil, ErrInvalidCursor
+		}
+
+		if order == "DESC" {
+			where("(created_at, transaction_id) < (?, ?)", cursorAt, cursorId)
+		} else {
+			where("(created_at, transaction_id) > (?, ?)", cursorAt, cursorId)
+		}
+	}
+
+	query += fmt.Sprintf("\tORDER BY created_at %s, transaction_id %s\n", order, order)
+
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit+1)
+		query += fmt.Sprintf("\tLIMIT $%d\n", len(args))
+	}
 
-	return transactions, nil
+	return query, args, nil
+}
+
+func (r *transactionRepoPsqlImpl) FindFeed(ctx context.Context, filter models.FeedFilter) (models.TransactionPage, error) {
+	query, args, err := buildFindFeedQuery(filter)
+	if err != nil {
+		return models.TransactionPage{}, err
+	}
+
+	rows, err := conn(ctx, r.psql).QueryContext(ctx, query, args...)
+	if err != nil {
+		return models.TransactionPage{}, err
+	}
+	defer rows.Close()
+
+	transactions := []models.Transaction{}
+	for rows.Next() {
+		t := models.Transaction{}
+		err = rows.Scan(&t.TransactionId, &t.Owner, &t.Sender, &t.Receiver, &t.CreatedAt, &t.Amount, &t.IsConsumed, &t.Kind, &t.RelatedId, &t.Memo, &t.Metadata, &t.Privacy)
+		if err != nil {
+			return models.TransactionPage{}, err
+		}
+		transactions = append(transactions, t)
+	}
+	if err = rows.Err(); err != nil {
+		return models.TransactionPage{}, err
+	}
+
+	return newTransactionPage(transactions, filter.Limit), nil
+}
+
+// buildFindFeedQuery appends the account, the visibility and the cursor conditions to
+// findFeedTransQ, newest first.
+func buildFindFeedQuery(filter models.FeedFilter) (string, []any, error) {
+	query := findFeedTransQ
+	args := []any{}
+
+	where := func(cond string, values ...any) {
+		for _, v := range values {
+			args = append(args, v)
+			cond = strings.Replace(cond, "?", fmt.Sprintf("$%d", len(args)), 1)
+		}
+		query += "\tAND " + cond + "\n"
+	}
+
+	if filter.Account != "" {
+		if uuid.Validate(filter.Account) != nil {
+			return "", nil, ErrAccountNotFound
+		}
+		where("(sender = ? OR receiver = ?)", filter.Account, filter.Account)
+	}
+
+	if filter.Viewer == "" {
+		where("privacy = 'public'")
+	} else {
+		friends := pq.Array(filter.Friends)
+		where("(privacy = 'public' OR (privacy = 'friends' AND (sender = ANY(?::uuid[]) OR receiver = ANY(?::uuid[]))) OR sender = ? OR receiver = ?)",
+			friends, friends, filter.Viewer, filter.Viewer)
+	}
+
+	if filter.Cursor != "" {
+		cursorAt, cursorId, err := decodeCursor(filter.Cursor)
+		if err != nil {
+			return "", nil, err
+		}
+
+		if uuid.Validate(cursorId) != nil {
+			return "", nil, ErrInvalidCursor
+		}
+
+		where("(created_at, transaction_id) < (?, ?)", cursorAt, cursorId)
+	}
+
+	query += "\tORDER BY created_at DESC, transaction_id DESC\n"
+
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit+1)
+		query += fmt.Sprintf("\tLIMIT $%d\n", len(args))
+	}
+
+	return query, args, nil
+}
+
+func (r *transactionRepoPsqlImpl) GetOutgoing(ctx context.Context, accId string, since time.Time) (models.Money, error) {
+	var total models.Money
+
+	err := conn(ctx, r.psql).QueryRowContext(ctx, getOutgoingQ, accId, since).Scan(&total)
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func (r *transactionRepoPsqlImpl) FindUnconsumed(ctx context.Context, accId string) ([]models.Transaction, error) {
+	transactions := []models.Transaction{}
+
+	rows, err := conn(ctx, r.psql).QueryContext(ctx, findUnconsumedTransQ, accId)
+	if err != nil {
+		return transactions, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		t := models.Transaction{}
+		err = rows.Scan(&t.TransactionId, &t.Owner, &t.Sender, &t.Receiver, &t.CreatedAt, &t.Amount, &t.IsConsumed, &t.Kind, &t.RelatedId, &t.Memo, &t.Metadata, &t.Privacy)
+		if err != nil {
+			return []models.Transaction{}, err
+		}
+		transactions = append(transactions, t)
+	}
+
+	return transactions, rows.Err()
+}
+
+// FindCounterparties returns the accounts that accId paid or was paid by since then,
+// most payments first.
+func (r *transactionRepoPsqlImpl) FindCounterparties(ctx context.Context, accId string, since time.Time) ([]models.Counterparty, error) {
+	counterparties := []models.Counterparty{}
+
+	rows, err := conn(ctx, r.psql).QueryContext(ctx, findCounterpartiesQ, accId, since)
+	if err != nil {
+		return counterparties, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		c := models.Counterparty{}
+		err = rows.Scan(&c.AccountId, &c.Payments, &c.LastAt)
+		if err != nil {
+			return []models.Counterparty{}, err
+		}
+		counterparties = append(counterparties, c)
+	}
+
+	return counterparties, rows.Err()
+}
+
+// FindRelated returns the transactions linked to id, such as the refunds of a payment.
+func (r *transactionRepoPsqlImpl) FindRelated(ctx context.Context, id string) ([]models.Transaction, error) {
+	transactions := []models.Transaction{}
+
+	rows, err := conn(ctx, r.psql).QueryContext(ctx, findRelatedTransQ, id)
+	if err != nil {
+		return transactions, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		t := models.Transaction{}
+		err = rows.Scan(&t.TransactionId, &t.Owner, &t.Sender, &t.Receiver, &t.CreatedAt, &t.Amount, &t.IsConsumed, &t.Kind, &t.RelatedId, &t.Memo, &t.Metadata, &t.Privacy)
+		if err != nil {
+			return []models.Transaction{}, err
+		}
+		transactions = append(transactions, t)
+	}
+
+	return transactions, rows.Err()
 }
 
 func (r *transactionRepoPsqlImpl) FindOne(ctx context.Context, id string) (models.Transaction, error) {
 	t := models.Transaction{}
 
-	row := r.psql.QueryRow(findOneTransQ, id)
-	err := row.Scan(&t.TransactionId, &t.Owner, &t.Sender, &t.Receiver, &t.CreatedAt, &t.Amount, &t.IsConsumed)
+	row := conn(ctx, r.psql).QueryRowContext(ctx, findOneTransQ, id)
+	err := row.Scan(&t.TransactionId, &t.Owner, &t.Sender, &t.Receiver, &t.CreatedAt, &t.Amount, &t.IsConsumed, &t.Kind, &t.RelatedId, &t.Memo, &t.Metadata, &t.Privacy)
 	if err == sql.ErrNoRows {
 		return t, ErrTransactionNotFound
 	}
@@ -106,11 +450,15 @@ func (r *transactionRepoPsqlImpl) Create(ctx context.Context, transaction models
 		return ErrMissingOwnerField
 	}
 
+	if transaction.Kind == "" {
+		return ErrMissingKindField
+	}
+
 	if transaction.Amount == 0 {
 		return ErrZeroAmount
 	}
 
-	_, err := r.psql.Exec(createTransQ, transaction.Owner, transaction.Sender, transaction.Receiver, transaction.CreatedAt, transaction.Amount, transaction.IsConsumed)
+	_, err := conn(ctx, r.psql).ExecContext(ctx, createTransQ, transaction.Owner, transaction.Sender, transaction.Receiver, transaction.CreatedAt, transaction.Amount, transaction.IsConsumed, transaction.Kind, transaction.RelatedId, transaction.Memo, transaction.Metadata, transaction.Privacy)
 	if err != nil {
 		return err
 	}
@@ -119,7 +467,7 @@ func (r *transactionRepoPsqlImpl) Create(ctx context.Context, transaction models
 }
 
 func (r *transactionRepoPsqlImpl) MarkAsConsumed(ctx context.Context, id string) error {
-	res, err := r.psql.Exec(updateAsConsumedQ, id)
+	res, err := conn(ctx, r.psql).ExecContext(ctx, updateAsConsumedQ, id)
 	if err != nil {
 		return err
 	}
@@ -130,7 +478,11 @@ func (r *transactionRepoPsqlImpl) MarkAsConsumed(ctx context.Context, id string)
 	}
 
 	if rowsAffected == 0 {
-		return ErrTransactionNotFound
+		_, err = r.FindOne(ctx, id)
+		if err != nil {
+			return err
+		}
+		return ErrAlreadyConsumed
 	}
 
 	return nil
@@ -139,10 +491,10 @@ func (r *transactionRepoPsqlImpl) MarkAsConsumed(ctx context.Context, id string)
 func (r *transactionRepoPsqlImpl) GetBalance(ctx context.Context, id string) (models.Balance, error) {
 	balance := models.Balance{
 		AccountId: id,
-		Amount:    0.0,
+		Amount:    0,
 	}
 
-	row := r.psql.QueryRow(getBalanceQ, id)
+	row := conn(ctx, r.psql).QueryRowContext(ctx, getBalanceQ, id)
 	err := row.Scan(&balance.Amount)
 	if err != nil {
 		return balance, err
@@ -155,6 +507,26 @@ func (r *transactionRepoPsqlImpl) GetBalance(ctx context.Context, id string) (mo
 	return balance, nil
 }
 
-func (r *transactionRepoPsqlImpl) RollBackConsumed(ctx context.Context, tConsumed []string) error {
-	return nil
+// LockAccount takes a row lock on the account, held until the surrounding unit of work
+// commits or rolls back. Waiting longer than the lock timeout yields ErrAccountLocked.
+func (r *transactionRepoPsqlImpl) LockAccount(ctx context.Context, accId string) error {
+	if !inTx(ctx) {
+		return ErrNoUnitOfWork
+	}
+
+	_, err := conn(ctx, r.psql).ExecContext(ctx, setLockTimeoutQ)
+	if err != nil {
+		return err
+	}
+
+	var id string
+	err = conn(ctx, r.psql).QueryRowContext(ctx, lockAccountQ, accId).Scan(&id)
+	if err == sql.ErrNoRows {
+		return ErrAccountNotFound
+	}
+	if isLockConflict(err) {
+		return ErrAccountLocked
+	}
+
+	return err
 }
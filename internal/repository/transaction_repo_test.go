@@ -9,96 +9,6 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-func TestTransaction_RollBackConsumed(t *testing.T) {
-	time := time.Now()
-	id := "001"
-
-	type args struct {
-		ctx           context.Context
-		data          map[string]models.Transaction
-		transConsumed []string
-	}
-
-	scenarios := map[string]struct {
-		given   args
-		wantErr error
-	}{
-		"happy-path": {
-			given: args{
-				ctx: context.Background(),
-				data: map[string]models.Transaction{
-					"1000000": {
-						TransactionId: "1000000",
-						Owner:         id,
-						Sender:        id,
-						Receiver:      id,
-						CreatedAt:     time,
-						Amount:        7000.00,
-						IsConsumed:    false,
-					},
-					"2000000": {
-						TransactionId: "2000000",
-						Owner:         id,
-						Sender:        id,
-						Receiver:      id,
-						CreatedAt:     time,
-						Amount:        3000.00,
-						IsConsumed:    false,
-					},
-				},
-				transConsumed: []string{"1000000", "2000000"},
-			},
-			wantErr: nil,
-		},
-		"invalid-transaction": {
-			given: args{
-				ctx: context.Background(),
-				data: map[string]models.Transaction{
-					"1000000": {
-						TransactionId: "1000000",
-						Owner:         id,
-						Sender:        id,
-						Receiver:      id,
-						CreatedAt:     time,
-						Amount:        7000.00,
-						IsConsumed:    false,
-					},
-					"2000000": {
-						TransactionId: "2000000",
-						Owner:         id,
-						Sender:        id,
-						Receiver:      id,
-						CreatedAt:     time,
-						Amount:        3000.00,
-						IsConsumed:    false,
-					},
-				},
-				transConsumed: []string{"1000000", "3000000"},
-			},
-			wantErr: ErrTransactionNotFound,
-		},
-	}
-
-	for name, tcase := range scenarios {
-		tcase := tcase
-		t.Run(name, func(t *testing.T) {
-			repo := setupTransactions(t, tcase.given.data, nil)
-
-			err := repo.RollBackConsumed(tcase.given.ctx, tcase.given.transConsumed)
-
-			if tcase.wantErr == nil {
-				assert.NoError(t, err)
-				for _, tr := range tcase.given.data {
-					result, _ := repo.FindOne(tcase.given.ctx, tr.TransactionId)
-					assert.Equal(t, false, result.IsConsumed)
-				}
-			} else {
-				assert.ErrorIs(t, err, tcase.wantErr)
-			}
-		})
-	}
-}
-
 func TestTransaction_GetBalance(t *testing.T) {
 	time := time.Now()
 	id := "1000"
@@ -123,7 +33,7 @@ func TestTransaction_GetBalance(t *testing.T) {
 						Sender:        id,
 						Receiver:      id,
 						CreatedAt:     time,
-						Amount:        7000.00,
+						Amount:        7000,
 						IsConsumed:    false,
 					},
 					"2000000": {
@@ -132,7 +42,7 @@ func TestTransaction_GetBalance(t *testing.T) {
 						Sender:        id,
 						Receiver:      id,
 						CreatedAt:     time,
-						Amount:        3000.00,
+						Amount:        3000,
 						IsConsumed:    false,
 					},
 				},
@@ -140,7 +50,7 @@ func TestTransaction_GetBalance(t *testing.T) {
 
 			want: models.Balance{
 				AccountId: id,
-				Amount:    10000.00,
+				Amount:    10000,
 			},
 			wantErr: nil,
 		},
@@ -155,7 +65,7 @@ func TestTransaction_GetBalance(t *testing.T) {
 						Sender:        id,
 						Receiver:      id,
 						CreatedAt:     time,
-						Amount:        7000.00,
+						Amount:        7000,
 						IsConsumed:    false,
 					},
 					"2000000": {
@@ -164,7 +74,7 @@ func TestTransaction_GetBalance(t *testing.T) {
 						Sender:        id,
 						Receiver:      id,
 						CreatedAt:     time,
-						Amount:        3000.00,
+						Amount:        3000,
 						IsConsumed:    true,
 					},
 					"3000000": {
@@ -173,7 +83,7 @@ func TestTransaction_GetBalance(t *testing.T) {
 						Sender:        id,
 						Receiver:      id,
 						CreatedAt:     time,
-						Amount:        -3000.00,
+						Amount:        -3000,
 						IsConsumed:    true,
 					},
 				},
@@ -181,7 +91,7 @@ func TestTransaction_GetBalance(t *testing.T) {
 
 			want: models.Balance{
 				AccountId: id,
-				Amount:    7000.00,
+				Amount:    7000,
 			},
 			wantErr: nil,
 		},
@@ -192,7 +102,7 @@ func TestTransaction_GetBalance(t *testing.T) {
 			},
 			want: models.Balance{
 				AccountId: id,
-				Amount:    0.0,
+				Amount:    0,
 			},
 			wantErr: nil,
 		},
@@ -206,7 +116,7 @@ func TestTransaction_GetBalance(t *testing.T) {
 						Sender:        id,
 						Receiver:      id,
 						CreatedAt:     time,
-						Amount:        -7000.00,
+						Amount:        -7000,
 						IsConsumed:    false,
 					},
 					"2000000": {
@@ -215,14 +125,14 @@ func TestTransaction_GetBalance(t *testing.T) {
 						Sender:        id,
 						Receiver:      id,
 						CreatedAt:     time,
-						Amount:        3000.00,
+						Amount:        3000,
 						IsConsumed:    false,
 					},
 				},
 			},
 			want: models.Balance{
 				AccountId: id,
-				Amount:    -4000.0,
+				Amount:    -4000,
 			},
 			wantErr: ErrNegativeBalance,
 		},
@@ -270,7 +180,7 @@ func TestTransactions_FindAll(t *testing.T) {
 						Sender:        "0001",
 						Receiver:      "0001",
 						CreatedAt:     time,
-						Amount:        7000.00,
+						Amount:        7000,
 						IsConsumed:    false,
 					},
 				},
@@ -283,7 +193,7 @@ func TestTransactions_FindAll(t *testing.T) {
 					Sender:        "0001",
 					Receiver:      "0001",
 					CreatedAt:     time,
-					Amount:        7000.00,
+					Amount:        7000,
 					IsConsumed:    false,
 				},
 			},
@@ -316,6 +226,114 @@ func TestTransactions_FindAll(t *testing.T) {
 	}
 }
 
+func TestTransactions_FindPage(t *testing.T) {
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	consumed := true
+	minAmount := models.Money(5000)
+
+	data := map[string]models.Transaction{
+		"1000000": {TransactionId: "1000000", Owner: "0001", Sender: "0001", Receiver: "0001", CreatedAt: start, Amount: 10000, Kind: models.KindDeposit, Memo: "Salary", Metadata: models.Metadata{"payroll": "march"}},
+		"2000000": {TransactionId: "2000000", Owner: "0001", Sender: "0001", Receiver: "0002", CreatedAt: start.Add(time.Hour), Amount: -3000, IsConsumed: true, Kind: models.KindPaymentOut, Memo: "rent, march", Metadata: models.Metadata{"payroll": "april"}},
+		"3000000": {TransactionId: "3000000", Owner: "0001", Sender: "0003", Receiver: "0001", CreatedAt: start.Add(2 * time.Hour), Amount: 6000, Kind: models.KindPaymentIn},
+		"4000000": {TransactionId: "4000000", Owner: "0001", Sender: "0001", Receiver: "0001", CreatedAt: start.Add(2 * time.Hour), Amount: -500, Kind: models.KindWithdrawal},
+		"5000000": {TransactionId: "5000000", Owner: "0002", Sender: "0001", Receiver: "0002", CreatedAt: start.Add(time.Hour), Amount: 3000, Kind: models.KindPaymentIn},
+	}
+
+	ids := func(transactions []models.Transaction) []string {
+		result := []string{}
+		for _, t := range transactions {
+			result = append(result, t.TransactionId)
+		}
+		return result
+	}
+
+	scenarios := map[string]struct {
+		filter models.TransactionFilter
+		want   []string
+	}{
+		"all-oldest-first": {
+			filter: models.TransactionFilter{},
+			want:   []string{"1000000", "2000000", "3000000", "4000000"},
+		},
+		"newest-first": {
+			filter: models.TransactionFilter{Order: models.SortDesc},
+			want:   []string{"4000000", "3000000", "2000000", "1000000"},
+		},
+		"date-range": {
+			filter: models.TransactionFilter{From: start.Add(time.Hour), To: start.Add(2 * time.Hour)},
+			want:   []string{"2000000"},
+		},
+		"direction-out": {
+			filter: models.TransactionFilter{Direction: models.DirectionOut},
+			want:   []string{"2000000", "4000000"},
+		},
+		"counterparty": {
+			filter: models.TransactionFilter{Counterparty: "0003"},
+			want:   []string{"3000000"},
+		},
+		"min-amount": {
+			filter: models.TransactionFilter{MinAmount: &minAmount},
+			want:   []string{"1000000", "3000000"},
+		},
+		"consumed": {
+			filter: models.TransactionFilter{Consumed: &consumed},
+			want:   []string{"2000000"},
+		},
+		"kind": {
+			filter: models.TransactionFilter{Kind: models.KindPaymentIn},
+			want:   []string{"3000000"},
+		},
+		"memo-ignores-case": {
+			filter: models.TransactionFilter{Memo: "MARCH"},
+			want:   []string{"2000000"},
+		},
+		"metadata": {
+			filter: models.TransactionFilter{Metadata: models.Metadata{"payroll": "march"}},
+			want:   []string{"1000000"},
+		},
+		"metadata-missing-key": {
+			filter: models.TransactionFilter{Metadata: models.Metadata{"invoice": "march"}},
+			want:   []string{},
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			repo := setupTransactions(t, data, nil)
+
+			page, err := repo.FindPage(context.Background(), "0001", tcase.filter)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tcase.want, ids(page.Transactions))
+			assert.Empty(t, page.NextCursor)
+		})
+	}
+
+	t.Run("walks-pages-with-cursor", func(t *testing.T) {
+		repo := setupTransactions(t, data, nil)
+		filter := models.TransactionFilter{Limit: 3, Order: models.SortDesc}
+
+		page, err := repo.FindPage(context.Background(), "0001", filter)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"4000000", "3000000", "2000000"}, ids(page.Transactions))
+		assert.NotEmpty(t, page.NextCursor)
+
+		filter.Cursor = page.NextCursor
+		page, err = repo.FindPage(context.Background(), "0001", filter)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"1000000"}, ids(page.Transactions))
+		assert.Empty(t, page.NextCursor)
+	})
+
+	t.Run("invalid-cursor", func(t *testing.T) {
+		repo := setupTransactions(t, data, nil)
+
+		_, err := repo.FindPage(context.Background(), "0001", models.TransactionFilter{Cursor: "not a cursor"})
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+	})
+}
+
 func TestTransactions_FindOne(t *testing.T) {
 	time := time.Now()
 
@@ -340,7 +358,7 @@ func TestTransactions_FindOne(t *testing.T) {
 						Sender:        "0001",
 						Receiver:      "0001",
 						CreatedAt:     time,
-						Amount:        7000.00,
+						Amount:        7000,
 						IsConsumed:    false,
 					},
 				},
@@ -353,7 +371,7 @@ func TestTransactions_FindOne(t *testing.T) {
 				Sender:        "0001",
 				Receiver:      "0001",
 				CreatedAt:     time,
-				Amount:        7000.00,
+				Amount:        7000,
 				IsConsumed:    false,
 			},
 
@@ -369,7 +387,7 @@ func TestTransactions_FindOne(t *testing.T) {
 						Sender:        "0001",
 						Receiver:      "0001",
 						CreatedAt:     time,
-						Amount:        7000.00,
+						Amount:        7000,
 						IsConsumed:    false,
 					},
 				},
@@ -428,6 +446,7 @@ func TestTransaction_Create(t *testing.T) {
 					CreatedAt:  time,
 					Amount:     1000,
 					IsConsumed: false,
+					Kind:       models.KindDeposit,
 				},
 				data: map[string]models.Transaction{},
 			},
@@ -439,10 +458,28 @@ func TestTransaction_Create(t *testing.T) {
 				CreatedAt:     time,
 				Amount:        1000,
 				IsConsumed:    false,
+				Kind:          models.KindDeposit,
 			},
 			wantErr: nil,
 		},
 
+		"missing kind": {
+			given: args{
+				ctx: context.Background(),
+				transaction: models.Transaction{
+					Owner:      "0001",
+					Sender:     "0001",
+					Receiver:   "0001",
+					CreatedAt:  time,
+					Amount:     1000,
+					IsConsumed: false,
+				},
+				data: map[string]models.Transaction{},
+			},
+			want:    models.Transaction{},
+			wantErr: ErrMissingKindField,
+		},
+
 		"missing owner": {
 			given: args{
 				ctx: context.Background(),
@@ -521,6 +558,7 @@ func TestTransaction_Create(t *testing.T) {
 					CreatedAt:  time,
 					Amount:     0,
 					IsConsumed: false,
+					Kind:       models.KindDeposit,
 				},
 				data: map[string]models.Transaction{},
 			},
@@ -554,3 +592,146 @@ func setupTransactions(_ *testing.T, initialData map[string]models.Transaction,
 	repo.idGenerator = idGenerator
 	return repo
 }
+
+func TestTransaction_LockAccount(t *testing.T) {
+	ctx := context.Background()
+	repo := setupTransactions(t, map[string]models.Transaction{}, nil)
+	repo.accountLocks = newKeyedLock(10*time.Millisecond, ErrAccountLocked)
+	uow := NewUnitOfWork()
+
+	assert.ErrorIs(t, repo.LockAccount(ctx, "0001"), ErrNoUnitOfWork)
+
+	err := uow.Do(ctx, func(ctx context.Context) error {
+		assert.NoError(t, repo.LockAccount(ctx, "0001"))
+		assert.NoError(t, repo.LockAccount(ctx, "0001"))
+
+		return NewUnitOfWork().Do(context.Background(), func(other context.Context) error {
+			assert.ErrorIs(t, repo.LockAccount(other, "0001"), ErrAccountLocked)
+			assert.NoError(t, repo.LockAccount(other, "0002"))
+			return nil
+		})
+	})
+	assert.NoError(t, err)
+
+	err = uow.Do(ctx, func(ctx context.Context) error {
+		return repo.LockAccount(ctx, "0001")
+	})
+	assert.NoError(t, err)
+}
+
+func TestTransactions_FindCounterparties(t *testing.T) {
+	now := time.Now()
+
+	repo := setupTransactions(t, map[string]models.Transaction{
+		"1": {TransactionId: "1", Owner: "0001", Sender: "0001", Receiver: "0002", Kind: models.KindPaymentOut, CreatedAt: now.Add(-3 * time.Hour)},
+		"2": {TransactionId: "2", Owner: "0001", Sender: "0002", Receiver: "0001", Kind: models.KindPaymentIn, CreatedAt: now.Add(-2 * time.Hour)},
+		"3": {TransactionId: "3", Owner: "0001", Sender: "0001", Receiver: "0003", Kind: models.KindPaymentOut, CreatedAt: now.Add(-time.Hour)},
+		"4": {TransactionId: "4", Owner: "0001", Sender: "0001", Receiver: "0004", Kind: models.KindPaymentOut, CreatedAt: now.Add(-48 * time.Hour)},
+		"5": {TransactionId: "5", Owner: "0001", Sender: "0001", Receiver: "0001", Kind: models.KindDeposit, CreatedAt: now},
+		"6": {TransactionId: "6", Owner: "0002", Sender: "0001", Receiver: "0002", Kind: models.KindPaymentIn, CreatedAt: now.Add(-3 * time.Hour)},
+		"7": {TransactionId: "7", Owner: "0001", Sender: "0001", Receiver: "0005", Kind: models.KindPaymentOut, CreatedAt: now.Add(-time.Hour)},
+	}, nil)
+
+	counterparties, err := repo.FindCounterparties(context.Background(), "0001", now.Add(-24*time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Counterparty{
+		{AccountId: "0002", Payments: 2, LastAt: now.Add(-2 * time.Hour)},
+		{AccountId: "0003", Payments: 1, LastAt: now.Add(-time.Hour)},
+		{AccountId: "0005", Payments: 1, LastAt: now.Add(-time.Hour)},
+	}, counterparties)
+}
+
+func TestTransactions_FindFeed(t *testing.T) {
+	now := time.Now()
+
+	payment := func(id string, sender string, receiver string, privacy models.Privacy, at time.Duration) models.Transaction {
+		return models.Transaction{TransactionId: id, Owner: sender, Sender: sender, Receiver: receiver, Amount: -100, Kind: models.KindPaymentOut, Privacy: privacy, CreatedAt: now.Add(at)}
+	}
+
+	data := map[string]models.Transaction{
+		"1": payment("1", "0001", "0002", models.PrivacyPublic, 1),
+		"2": payment("2", "0002", "0003", models.PrivacyFriends, 2),
+		"3": payment("3", "0003", "0004", models.PrivacyPrivate, 3),
+		"4": payment("4", "0004", "0001", models.PrivacyFriends, 4),
+		"5": {TransactionId: "5", Owner: "0002", Sender: "0001", Receiver: "0002", Amount: 100, Kind: models.KindPaymentIn, Privacy: models.PrivacyPublic, CreatedAt: now.Add(1)},
+		"6": {TransactionId: "6", Owner: "0001", Sender: "0001", Receiver: "0005", Amount: -100, Kind: models.KindPaymentOut, CreatedAt: now.Add(5)},
+		"7": payment("7", "0005", "0003", models.PrivacyPublic, 6),
+	}
+
+	scenarios := map[string]struct {
+		filter models.FeedFilter
+		want   []string
+	}{
+		"anonymous-sees-public": {
+			filter: models.FeedFilter{},
+			want:   []string{"7", "1"},
+		},
+		"friends-see-friends-only": {
+			filter: models.FeedFilter{Viewer: "0005", Friends: []string{"0002"}},
+			want:   []string{"7", "2", "1"},
+		},
+		"participants-see-private": {
+			filter: models.FeedFilter{Viewer: "0004"},
+			want:   []string{"7", "4", "3", "1"},
+		},
+		"account-feed": {
+			filter: models.FeedFilter{Viewer: "0004", Account: "0003"},
+			want:   []string{"7", "3"},
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			repo := setupTransactions(t, data, nil)
+
+			page, err := repo.FindFeed(context.Background(), tcase.filter)
+			assert.NoError(t, err)
+
+			ids := []string{}
+			for _, p := range page.Transactions {
+				ids = append(ids, p.TransactionId)
+			}
+			assert.Equal(t, tcase.want, ids)
+		})
+	}
+
+	t.Run("pages", func(t *testing.T) {
+		repo := setupTransactions(t, data, nil)
+
+		page, err := repo.FindFeed(context.Background(), models.FeedFilter{Viewer: "0004", Limit: 3})
+		assert.NoError(t, err)
+		assert.Len(t, page.Transactions, 3)
+		assert.NotEmpty(t, page.NextCursor)
+
+		page, err = repo.FindFeed(context.Background(), models.FeedFilter{Viewer: "0004", Limit: 3, Cursor: page.NextCursor})
+		assert.NoError(t, err)
+		assert.Equal(t, "1", page.Transactions[0].TransactionId)
+		assert.Empty(t, page.NextCursor)
+	})
+}
+
+func TestTransactions_GetOutgoing(t *testing.T) {
+	now := time.Now()
+
+	repo := setupTransactions(t, map[string]models.Transaction{
+		"1": {TransactionId: "1", Owner: "0001", Sender: "0001", Receiver: "0002", Amount: -1000, Kind: models.KindPaymentOut, CreatedAt: now.Add(-time.Hour)},
+		"2": {TransactionId: "2", Owner: "0001", Sender: "0001", Receiver: "0001", Amount: -500, Kind: models.KindWithdrawal, CreatedAt: now.Add(-2 * time.Hour)},
+		"3": {TransactionId: "3", Owner: "0001", Sender: "0001", Receiver: "0001", Amount: 5000, Kind: models.KindDeposit, CreatedAt: now.Add(-time.Hour)},
+		"4": {TransactionId: "4", Owner: "0001", Sender: "0001", Receiver: "0001", Amount: 300, Kind: models.KindChange, CreatedAt: now.Add(-time.Hour)},
+		"5": {TransactionId: "5", Owner: "0001", Sender: "0001", Receiver: "0003", Amount: -2000, Kind: models.KindPaymentOut, CreatedAt: now.Add(-48 * time.Hour)},
+		"6": {TransactionId: "6", Owner: "0002", Sender: "0001", Receiver: "0002", Amount: 1000, Kind: models.KindPaymentIn, CreatedAt: now.Add(-time.Hour)},
+	}, nil)
+
+	outgoing, err := repo.GetOutgoing(context.Background(), "0001", now.Add(-24*time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, models.Money(1500), outgoing)
+
+	outgoing, err = repo.GetOutgoing(context.Background(), "0001", now.Add(-72*time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, models.Money(3500), outgoing)
+
+	outgoing, err = repo.GetOutgoing(context.Background(), "0002", now.Add(-72*time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, models.Money(0), outgoing)
+}
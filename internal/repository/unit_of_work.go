@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	ErrRollbackFailed = errors.New("unit of work could not be rolled back")
+	ErrNoUnitOfWork   = errors.New("operation must run inside a unit of work")
+)
+
+// UnitOfWork runs fn atomically: every repository change made with the ctx handed
+// to fn is either applied as a whole or discarded when fn returns an error.
+// Calls to Do made while already inside a unit of work join the outer one.
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+var _ UnitOfWork = (*unitOfWorkImpl)(nil)
+
+type unitOfWorkImpl struct{}
+
+func NewUnitOfWork() *unitOfWorkImpl {
+	return &unitOfWorkImpl{}
+}
+
+type memTxKey struct{}
+
+// memTx is the in-memory counterpart of a database transaction: repositories
+// register an undo step for each change they make while it is active.
+// Locks taken while it is active are held until it ends.
+type memTx struct {
+	undo    []func()
+	release []func()
+	locked  map[heldLock]bool
+}
+
+type heldLock struct {
+	lock *keyedLock
+	key  string
+}
+
+func (tx *memTx) rollback() {
+	for i := len(tx.undo) - 1; i >= 0; i-- {
+		tx.undo[i]()
+	}
+	tx.undo = nil
+}
+
+func (tx *memTx) releaseLocks() {
+	for i := len(tx.release) - 1; i >= 0; i-- {
+		tx.release[i]()
+	}
+	tx.release = nil
+}
+
+func (u *unitOfWorkImpl) Do(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if _, ok := ctx.Value(memTxKey{}).(*memTx); ok {
+		return fn(ctx)
+	}
+
+	tx := &memTx{locked: make(map[heldLock]bool)}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.rollback()
+			tx.releaseLocks()
+			panic(p)
+		}
+	}()
+
+	err = fn(context.WithValue(ctx, memTxKey{}, tx))
+	if err != nil {
+		tx.rollback()
+	}
+	tx.releaseLocks()
+
+	return err
+}
+
+// onRollback registers undo to be run if the in-memory unit of work bound to ctx fails.
+// Outside a unit of work changes are final and undo is discarded.
+func onRollback(ctx context.Context, undo func()) {
+	tx, ok := ctx.Value(memTxKey{}).(*memTx)
+	if !ok {
+		return
+	}
+	tx.undo = append(tx.undo, undo)
+}
+
+// keyedLock hands out one exclusive lock per key, e.g. per account. Waiting longer
+// than timeout for a key fails with errBusy.
+type keyedLock struct {
+	mu      sync.Mutex
+	locks   map[string]chan struct{}
+	timeout time.Duration
+	errBusy error
+}
+
+func newKeyedLock(timeout time.Duration, errBusy error) *keyedLock {
+	return &keyedLock{
+		locks:   make(map[string]chan struct{}),
+		timeout: timeout,
+		errBusy: errBusy,
+	}
+}
+
+func (l *keyedLock) slot(key string) chan struct{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	ch, found := l.locks[key]
+	if !found {
+		ch = make(chan struct{}, 1)
+		l.locks[key] = ch
+	}
+	return ch
+}
+
+// lockInTx acquires key for the in-memory unit of work bound to ctx and releases it
+// when that unit of work ends. Re-locking a key already held by the same unit is a no-op.
+func (l *keyedLock) lockInTx(ctx context.Context, key string) error {
+	tx, ok := ctx.Value(memTxKey{}).(*memTx)
+	if !ok {
+		return ErrNoUnitOfWork
+	}
+
+	held := heldLock{lock: l, key: key}
+	if tx.locked[held] {
+		return nil
+	}
+
+	ch := l.slot(key)
+
+	select {
+	case ch <- struct{}{}:
+	default:
+		timer := time.NewTimer(l.timeout)
+		defer timer.Stop()
+
+		select {
+		case ch <- struct{}{}:
+		case <-timer.C:
+			return l.errBusy
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	tx.locked[held] = true
+	tx.release = append(tx.release, func() {
+		delete(tx.locked, held)
+		<-ch
+	})
+
+	return nil
+}
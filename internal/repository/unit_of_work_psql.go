@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+var _ UnitOfWork = (*unitOfWorkPsqlImpl)(nil)
+
+type unitOfWorkPsqlImpl struct {
+	psql *sql.DB
+}
+
+func NewUnitOfWorkPsql(db *sql.DB) *unitOfWorkPsqlImpl {
+	return &unitOfWorkPsqlImpl{
+		psql: db,
+	}
+}
+
+type psqlTxKey struct{}
+
+// querier is the subset of *sql.DB and *sql.Tx used by the Postgres repositories.
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// scanner is the subset of *sql.Row and *sql.Rows used to scan a single row.
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// conn returns the transaction bound to ctx by a unit of work, or db when there is none.
+func conn(ctx context.Context, db *sql.DB) querier {
+	if tx, ok := ctx.Value(psqlTxKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+func inTx(ctx context.Context) bool {
+	_, ok := ctx.Value(psqlTxKey{}).(*sql.Tx)
+	return ok
+}
+
+// isLockConflict reports whether err is Postgres giving up on a lock.
+func isLockConflict(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+
+	switch pqErr.Code {
+	case "55P03", "40P01", "40001": // lock_not_available, deadlock_detected, serialization_failure
+		return true
+	}
+	return false
+}
+
+func (u *unitOfWorkPsqlImpl) Do(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if inTx(ctx) {
+		return fn(ctx)
+	}
+
+	tx, err := u.psql.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	err = fn(context.WithValue(ctx, psqlTxKey{}, tx))
+	if err != nil {
+		rbErr := tx.Rollback()
+		if rbErr != nil && !errors.Is(rbErr, sql.ErrTxDone) {
+			return errors.Join(err, fmt.Errorf("%w: %v", ErrRollbackFailed, rbErr))
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
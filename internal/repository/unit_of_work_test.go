@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gopay/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitOfWork_Do(t *testing.T) {
+	now := time.Now()
+	id := "0001"
+	errBoom := errors.New("boom")
+
+	type args struct {
+		ctx  context.Context
+		data map[string]models.Transaction
+		fn   func(ctx context.Context, repo *transactionRepoImpl) error
+	}
+
+	scenarios := map[string]struct {
+		given   args
+		want    map[string]models.Transaction
+		wantErr error
+	}{
+		"commit": {
+			given: args{
+				ctx: context.Background(),
+				data: map[string]models.Transaction{
+					"1000000": {
+						TransactionId: "1000000",
+						Owner:         id,
+						Sender:        id,
+						Receiver:      id,
+						CreatedAt:     now,
+						Amount:        7000,
+						IsConsumed:    false,
+					},
+				},
+				fn: func(ctx context.Context, repo *transactionRepoImpl) error {
+					err := repo.MarkAsConsumed(ctx, "1000000")
+					if err != nil {
+						return err
+					}
+
+					return repo.Create(ctx, models.Transaction{
+						Owner:     id,
+						Sender:    id,
+						Receiver:  id,
+						CreatedAt: now,
+						Amount:    5000,
+						Kind:      models.KindDeposit,
+					})
+				},
+			},
+			want: map[string]models.Transaction{
+				"1000000": {
+					TransactionId: "1000000",
+					Owner:         id,
+					Sender:        id,
+					Receiver:      id,
+					CreatedAt:     now,
+					Amount:        7000,
+					IsConsumed:    true,
+				},
+				"2000000": {
+					TransactionId: "2000000",
+					Owner:         id,
+					Sender:        id,
+					Receiver:      id,
+					CreatedAt:     now,
+					Amount:        5000,
+					IsConsumed:    false,
+					Kind:          models.KindDeposit,
+				},
+			},
+			wantErr: nil,
+		},
+		"rollback": {
+			given: args{
+				ctx: context.Background(),
+				data: map[string]models.Transaction{
+					"1000000": {
+						TransactionId: "1000000",
+						Owner:         id,
+						Sender:        id,
+						Receiver:      id,
+						CreatedAt:     now,
+						Amount:        7000,
+						IsConsumed:    false,
+					},
+				},
+				fn: func(ctx context.Context, repo *transactionRepoImpl) error {
+					err := repo.MarkAsConsumed(ctx, "1000000")
+					if err != nil {
+						return err
+					}
+
+					err = repo.Create(ctx, models.Transaction{
+						Owner:     id,
+						Sender:    id,
+						Receiver:  id,
+						CreatedAt: now,
+						Amount:    5000,
+						Kind:      models.KindDeposit,
+					})
+					if err != nil {
+						return err
+					}
+
+					return errBoom
+				},
+			},
+			want: map[string]models.Transaction{
+				"1000000": {
+					TransactionId: "1000000",
+					Owner:         id,
+					Sender:        id,
+					Receiver:      id,
+					CreatedAt:     now,
+					Amount:        7000,
+					IsConsumed:    false,
+				},
+			},
+			wantErr: errBoom,
+		},
+		"nested-rollback": {
+			given: args{
+				ctx:  context.Background(),
+				data: map[string]models.Transaction{},
+				fn: func(ctx context.Context, repo *transactionRepoImpl) error {
+					return NewUnitOfWork().Do(ctx, func(ctx context.Context) error {
+						err := repo.Create(ctx, models.Transaction{
+							Owner:     id,
+							Sender:    id,
+							Receiver:  id,
+							CreatedAt: now,
+							Amount:    5000,
+							Kind:      models.KindDeposit,
+						})
+						if err != nil {
+							return err
+						}
+
+						return errBoom
+					})
+				},
+			},
+			want:    map[string]models.Transaction{},
+			wantErr: errBoom,
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			repo := setupTransactions(t, tcase.given.data, func() string { return "2000000" })
+			uow := NewUnitOfWork()
+
+			err := uow.Do(tcase.given.ctx, func(ctx context.Context) error {
+				return tcase.given.fn(ctx, repo)
+			})
+
+			if tcase.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tcase.wantErr)
+			}
+
+			assert.Equal(t, tcase.want, repo.transactions)
+		})
+	}
+}
@@ -1,12 +1,18 @@
 package internal
 
-import "github.com/julienschmidt/httprouter"
+import (
+	"net/http"
 
-func Router(registers ...HandlerRegister) *httprouter.Router {
+	"github.com/gopay/internal/service"
+	"github.com/julienschmidt/httprouter"
+)
+
+// Router serves the routes of registers to the callers authSvc authenticates.
+func Router(authSvc service.AuthService, registers ...HandlerRegister) http.Handler {
 	router := httprouter.New()
 
 	for _, r := range registers {
 		r.Register(router)
 	}
-	return router
+	return authenticate(authSvc, router)
 }
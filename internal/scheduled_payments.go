@@ -0,0 +1,167 @@
+package internal
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/gopay/internal/models"
+	"github.com/gopay/internal/repository"
+	"github.com/gopay/internal/service"
+	"github.com/gopay/internal/utils"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/julienschmidt/httprouter"
+	"github.com/rs/zerolog/log"
+)
+
+func (h *apiHandler) CreateScheduledPayment(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	owner := params.ByName(AccountIdParam)
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, OneMegabyte))
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	defer r.Body.Close()
+
+	req := models.ScheduledPaymentReq{}
+	err = jsoniter.Unmarshal(body, &req)
+	if err != nil {
+		log.Error().Err(err).Msg(err.Error())
+		utils.ErrorWithMessage(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	schedule, err := h.scheduleSvc.Create(r.Context(), owner, req)
+	if err == service.ErrInvalidAmount || err == service.ErrInvalidPaymentOp || err == service.ErrInvalidFrequency ||
+		err == service.ErrInvalidCron || err == service.ErrStartInPast {
+		log.Error().Err(err).Msg("Handler::CreateScheduledPayment")
+		utils.ErrorWithMessage(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err == repository.ErrAccountNotFound {
+		log.Error().Err(err).Msg("Handler::CreateScheduledPayment")
+		utils.ErrorWithMessage(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::CreateScheduledPayment")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	res, err := jsoniter.Marshal(&schedule)
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::CreateScheduledPayment")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.WithPayload(w, http.StatusCreated, res)
+}
+
+func (h *apiHandler) GetScheduledPayments(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	owner := params.ByName(AccountIdParam)
+
+	schedules, err := h.scheduleSvc.GetAll(r.Context(), owner)
+	if err == repository.ErrAccountNotFound {
+		log.Error().Err(err).Msg("Handler::GetScheduledPayments")
+		utils.ErrorWithMessage(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::GetScheduledPayments")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	res, err := jsoniter.Marshal(&schedules)
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::GetScheduledPayments")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.WithPayload(w, http.StatusOK, res)
+}
+
+func (h *apiHandler) GetScheduledRuns(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	owner := params.ByName(AccountIdParam)
+	id := params.ByName(ScheduleIdParam)
+
+	runs, err := h.scheduleSvc.GetRuns(r.Context(), id, owner)
+	if err == repository.ErrScheduledPaymentNotFound {
+		log.Error().Err(err).Msg("Handler::GetScheduledRuns")
+		utils.ErrorWithMessage(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	if err == service.ErrScheduleNotOwned {
+		log.Error().Err(err).Msg("Handler::GetScheduledRuns")
+		utils.ErrorWithMessage(w, http.StatusForbidden, err.Error())
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::GetScheduledRuns")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	res, err := jsoniter.Marshal(&runs)
+	if err != nil {
+		log.Error().Err(err).Msg("Handler::GetScheduledRuns")
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.WithPayload(w, http.StatusOK, res)
+}
+
+func (h *apiHandler) PauseScheduledPayment(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	h.changeScheduledPayment(w, r, params, "Handler::PauseScheduledPayment", h.scheduleSvc.Pause)
+}
+
+func (h *apiHandler) ResumeScheduledPayment(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	h.changeScheduledPayment(w, r, params, "Handler::ResumeScheduledPayment", h.scheduleSvc.Resume)
+}
+
+func (h *apiHandler) CancelScheduledPayment(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	h.changeScheduledPayment(w, r, params, "Handler::CancelScheduledPayment", h.scheduleSvc.Cancel)
+}
+
+func (h *apiHandler) changeScheduledPayment(w http.ResponseWriter, r *http.Request, params httprouter.Params, name string, change func(ctx context.Context, id string, owner string) error) {
+	owner := params.ByName(AccountIdParam)
+	id := params.ByName(ScheduleIdParam)
+
+	err := change(r.Context(), id, owner)
+	if err == repository.ErrScheduledPaymentNotFound {
+		log.Error().Err(err).Msg(name)
+		utils.ErrorWithMessage(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	if err == service.ErrScheduleNotOwned {
+		log.Error().Err(err).Msg(name)
+		utils.ErrorWithMessage(w, http.StatusForbidden, err.Error())
+		return
+	}
+
+	if err == service.ErrScheduleFinished {
+		log.Error().Err(err).Msg(name)
+		utils.ErrorWithMessage(w, http.StatusConflict, err.Error())
+		return
+	}
+
+	if err != nil {
+		log.Error().Err(err).Msg(name)
+		utils.ErrorWithMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	utils.WithPayload(w, http.StatusOK, nil)
+}
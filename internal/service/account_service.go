@@ -2,37 +2,372 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/gopay/internal/models"
 	"github.com/gopay/internal/repository"
 )
 
+var (
+	ErrAccountInactive      = errors.New("account cannot move money")
+	ErrAccountFrozen        = fmt.Errorf("account is frozen: %w", ErrAccountInactive)
+	ErrAccountClosed        = fmt.Errorf("account is closed: %w", ErrAccountInactive)
+	ErrInvalidStatusChange  = errors.New("invalid account status change")
+	ErrStatusReasonRequired = fmt.Errorf("a reason is required: %w", ErrInvalidStatusChange)
+	ErrStatusReasonTooLong  = fmt.Errorf("reason must be at most %d characters: %w", maxStatusReasonLength, ErrInvalidStatusChange)
+	ErrStatusTransition     = errors.New("account status does not allow this change")
+	ErrCloseWithBalance     = errors.New("an account with a balance can only be closed with a payout account")
+	ErrCloseWithHolds       = errors.New("an account with active holds cannot be closed")
+	ErrCloseWithPayouts     = errors.New("an account with payouts in progress cannot be closed")
+	ErrInvalidAccountFilter = errors.New("invalid account search")
+	ErrInvalidAccountLimit  = fmt.Errorf("limit must be between 1 and %d: %w", maxPageLimit, ErrInvalidAccountFilter)
+	ErrInvalidAccountSort   = fmt.Errorf("sort must be name, lastName or createdAt: %w", ErrInvalidAccountFilter)
+	ErrInvalidAccountOrder  = fmt.Errorf("order must be asc or desc: %w", ErrInvalidAccountFilter)
+	ErrInvalidAccountQuery  = fmt.Errorf("search must be at most %d characters: %w", maxAccountQueryLength, ErrInvalidAccountFilter)
+)
+
+const (
+	maxStatusReasonLength = 255
+	maxAccountQueryLength = 100
+)
+
+// statusTransitions lists the statuses an account can move to from each status. Closing
+// is final.
+var statusTransitions = map[models.AccountStatus][]models.AccountStatus{
+	models.AccountActive: {models.AccountFrozen, models.AccountClosed},
+	models.AccountFrozen: {models.AccountActive, models.AccountClosed},
+}
+
 type AccountService interface {
-	GetAllAccounts(ctx context.Context) ([]models.Account, error)
+	GetAllAccounts(ctx context.Context, filter models.AccountFilter) (models.AccountPage, error)
 	GetAccount(ctx context.Context, id string) (models.Account, error)
-	CreateAccount(ctx context.Context, name string, lastname string) (string, error)
+	CreateAccount(ctx context.Context, req models.AccReq) (string, error)
+	LookupHandle(ctx context.Context, handle string) (models.AccountSummary, error)
+	UpdateProfile(ctx context.Context, id string, req models.AccountPatchReq) (models.Account, error)
+	Freeze(ctx context.Context, id string, req models.AccountStatusReq) (models.AccountStatusChange, error)
+	Unfreeze(ctx context.Context, id string, req models.AccountStatusReq) (models.AccountStatusChange, error)
+	Close(ctx context.Context, id string, req models.AccountStatusReq) (models.AccountStatusChange, error)
+	GetStatusChanges(ctx context.Context, id string) ([]models.AccountStatusChange, error)
 }
 
 var _ AccountService = (*accountServiceImpl)(nil)
 
 type accountServiceImpl struct {
-	accountRepo repository.AccountRepo
+	accountRepo     repository.AccountRepo
+	transactionRepo repository.TransactionRepo
+	credentialRepo  repository.CredentialRepo
+	payoutRepo      repository.PayoutRepo
+	transactionSvc  TransactionService
+	uow             repository.UnitOfWork
 }
 
-func NewAccountService(accountRepo repository.AccountRepo) *accountServiceImpl {
+func NewAccountService(accountRepo repository.AccountRepo, transactionRepo repository.TransactionRepo, credentialRepo repository.CredentialRepo, payoutRepo repository.PayoutRepo, transactionSvc TransactionService, uow repository.UnitOfWork) *accountServiceImpl {
 	return &accountServiceImpl{
-		accountRepo: accountRepo,
+		accountRepo:     accountRepo,
+		transactionRepo: transactionRepo,
+		credentialRepo:  credentialRepo,
+		payoutRepo:      payoutRepo,
+		transactionSvc:  transactionSvc,
+		uow:             uow,
 	}
 }
 
-func (r *accountServiceImpl) GetAllAccounts(ctx context.Context) ([]models.Account, error) {
-	return r.accountRepo.FindAll(ctx)
+// GetAllAccounts pages through the accounts, searching them by name when filter.Query is set.
+func (r *accountServiceImpl) GetAllAccounts(ctx context.Context, filter models.AccountFilter) (models.AccountPage, error) {
+	filter, err := validateAccountFilter(filter)
+	if err != nil {
+		return models.AccountPage{}, err
+	}
+
+	return r.accountRepo.FindPage(ctx, filter)
 }
 
 func (r *accountServiceImpl) GetAccount(ctx context.Context, id string) (models.Account, error) {
 	return r.accountRepo.FindOne(ctx, id)
 }
 
-func (r *accountServiceImpl) CreateAccount(ctx context.Context, name string, lastname string) (string, error) {
-	return r.accountRepo.Create(ctx, name, lastname)
+// CreateAccount opens an account that logs in with req.Password, claiming req.Handle
+// for it if set.
+func (r *accountServiceImpl) CreateAccount(ctx context.Context, req models.AccReq) (string, error) {
+	handle, err := normalizeHandle(req.Handle)
+	if err != nil {
+		return "", err
+	}
+
+	err = validatePassword(req.Password)
+	if err != nil {
+		return "", err
+	}
+
+	hash, err := hashPassword(req.Password)
+	if err != nil {
+		return "", err
+	}
+
+	var id string
+
+	err = r.uow.Do(ctx, func(ctx context.Context) error {
+		id, err = r.accountRepo.Create(ctx, req.Name, req.LastName)
+		if err != nil {
+			return err
+		}
+
+		err = r.credentialRepo.Save(ctx, models.Credential{AccountId: id, PasswordHash: hash, UpdatedAt: clockNow()})
+		if err != nil || handle == "" {
+			return err
+		}
+
+		acc, err := r.accountRepo.FindOne(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		acc.Handle = handle
+		acc.UpdatedAt = clockNow()
+		return r.accountRepo.Update(ctx, acc)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// LookupHandle tells who handle, with or without its '@', belongs to. Closed accounts
+// are not found.
+func (r *accountServiceImpl) LookupHandle(ctx context.Context, handle string) (models.AccountSummary, error) {
+	handle = strings.TrimPrefix(handle, "@")
+	if !validHandle(handle) {
+		return models.AccountSummary{}, ErrInvalidHandle
+	}
+
+	acc, err := r.accountRepo.FindByHandle(ctx, handle)
+	if err != nil {
+		return models.AccountSummary{}, err
+	}
+
+	if acc.Status == models.AccountClosed {
+		return models.AccountSummary{}, repository.ErrAccountNotFound
+	}
+
+	return accountSummary(acc), nil
+}
+
+// UpdateProfile changes the profile fields set in req and leaves the others as they are.
+// Closed accounts cannot be changed.
+func (r *accountServiceImpl) UpdateProfile(ctx context.Context, id string, req models.AccountPatchReq) (models.Account, error) {
+	var acc models.Account
+
+	err := r.uow.Do(ctx, func(ctx context.Context) error {
+		current, err := r.accountRepo.FindOne(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if current.Status == models.AccountClosed {
+			return ErrAccountClosed
+		}
+
+		acc, err = applyProfile(current, req)
+		if err != nil {
+			return err
+		}
+
+		acc.UpdatedAt = clockNow()
+		return r.accountRepo.Update(ctx, acc)
+	})
+	if err != nil {
+		return models.Account{}, err
+	}
+
+	return acc, nil
+}
+
+// Freeze stops an active account from sending money. It can still receive.
+func (r *accountServiceImpl) Freeze(ctx context.Context, id string, req models.AccountStatusReq) (models.AccountStatusChange, error) {
+	return r.changeStatus(ctx, id, req.Reason, models.AccountFrozen, nil)
+}
+
+func (r *accountServiceImpl) Unfreeze(ctx context.Context, id string, req models.AccountStatusReq) (models.AccountStatusChange, error) {
+	return r.changeStatus(ctx, id, req.Reason, models.AccountActive, nil)
+}
+
+// Close shuts an account for good. An account with money left on it is only closed if
+// req names an account to pay the balance out to, and never while holds are active or
+// payouts are in progress, since their money could come back to it.
+func (r *accountServiceImpl) Close(ctx context.Context, id string, req models.AccountStatusReq) (models.AccountStatusChange, error) {
+	return r.changeStatus(ctx, id, req.Reason, models.AccountClosed, func(ctx context.Context, change *models.AccountStatusChange) error {
+		balance, err := r.transactionSvc.GetBalance(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if balance.Held > 0 {
+			return ErrCloseWithHolds
+		}
+
+		reserved, err := r.payoutRepo.GetReserved(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if reserved > 0 {
+			return ErrCloseWithPayouts
+		}
+
+		if balance.Amount == 0 {
+			return nil
+		}
+
+		if req.PayoutTo == "" {
+			return ErrCloseWithBalance
+		}
+
+		err = r.transactionSvc.Sweep(ctx, id, req.PayoutTo, balance.Amount)
+		if err != nil {
+			return err
+		}
+
+		change.PayoutTo = req.PayoutTo
+		change.Amount = balance.Amount
+		return nil
+	})
+}
+
+// GetStatusChanges returns the audit trail of the account, oldest first.
+func (r *accountServiceImpl) GetStatusChanges(ctx context.Context, id string) ([]models.AccountStatusChange, error) {
+	_, err := r.accountRepo.FindOne(ctx, id)
+	if err != nil {
+		return []models.AccountStatusChange{}, err
+	}
+
+	return r.accountRepo.FindStatusChanges(ctx, id)
+}
+
+// changeStatus moves the account to status and records why, holding the account lock.
+// Operations that check the status take the same lock first, so they either finish
+// before the change or see the new status. settle runs before the status changes and
+// may fail the change or add to its record.
+func (r *accountServiceImpl) changeStatus(ctx context.Context, id string, reason string, status models.AccountStatus, settle func(ctx context.Context, change *models.AccountStatusChange) error) (models.AccountStatusChange, error) {
+	reason = sanitizeText(reason)
+	if reason == "" {
+		return models.AccountStatusChange{}, ErrStatusReasonRequired
+	}
+
+	if utf8.RuneCountInString(reason) > maxStatusReasonLength {
+		return models.AccountStatusChange{}, ErrStatusReasonTooLong
+	}
+
+	var change models.AccountStatusChange
+
+	err := r.uow.Do(ctx, func(ctx context.Context) error {
+		err := r.transactionRepo.LockAccount(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		acc, err := r.accountRepo.FindOne(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if !canTransition(acc.Status, status) {
+			return ErrStatusTransition
+		}
+
+		change = models.AccountStatusChange{
+			AccountId: id,
+			From:      acc.Status,
+			To:        status,
+			Reason:    reason,
+			CreatedAt: clockNow(),
+		}
+
+		if settle != nil {
+			err = settle(ctx, &change)
+			if err != nil {
+				return err
+			}
+		}
+
+		change.ChangeId, err = r.accountRepo.SetStatus(ctx, change)
+		return err
+	})
+	if err != nil {
+		return models.AccountStatusChange{}, err
+	}
+
+	return change, nil
+}
+
+// accountSummary keeps what anyone may see of acc.
+func accountSummary(acc models.Account) models.AccountSummary {
+	return models.AccountSummary{
+		AccountId:   acc.AccountId,
+		Handle:      acc.Handle,
+		DisplayName: acc.DisplayName,
+		Name:        acc.Name,
+		LastName:    acc.LastName,
+	}
+}
+
+func validateAccountFilter(filter models.AccountFilter) (models.AccountFilter, error) {
+	if filter.Limit == 0 {
+		filter.Limit = defaultPageLimit
+	}
+	if filter.Limit < 0 || filter.Limit > maxPageLimit {
+		return filter, ErrInvalidAccountLimit
+	}
+
+	if filter.Sort == "" {
+		filter.Sort = models.SortByCreatedAt
+	}
+	if filter.Sort != models.SortByName && filter.Sort != models.SortByLastName && filter.Sort != models.SortByCreatedAt {
+		return filter, ErrInvalidAccountSort
+	}
+
+	if filter.Order == "" {
+		filter.Order = models.SortAsc
+	}
+	if filter.Order != models.SortAsc && filter.Order != models.SortDesc {
+		return filter, ErrInvalidAccountOrder
+	}
+
+	filter.Query = sanitizeText(filter.Query)
+	if utf8.RuneCountInString(filter.Query) > maxAccountQueryLength {
+		return filter, ErrInvalidAccountQuery
+	}
+
+	return filter, nil
+}
+
+func canTransition(from models.AccountStatus, to models.AccountStatus) bool {
+	for _, allowed := range statusTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// canSend fails unless acc may move money out of its balance: only active accounts can.
+func canSend(acc models.Account) error {
+	switch acc.Status {
+	case models.AccountFrozen:
+		return ErrAccountFrozen
+	case models.AccountClosed:
+		return ErrAccountClosed
+	}
+	return nil
+}
+
+// canReceive fails if acc may not be paid: frozen accounts still can, closed ones cannot.
+func canReceive(acc models.Account) error {
+	if acc.Status == models.AccountClosed {
+		return ErrAccountClosed
+	}
+	return nil
 }
@@ -0,0 +1,716 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gopay/internal/models"
+	"github.com/gopay/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAccountService_GetAllAccounts(t *testing.T) {
+	ctx := context.Background()
+
+	scenarios := map[string]struct {
+		given   models.AccountFilter
+		want    models.AccountFilter
+		wantErr error
+	}{
+		"defaults": {
+			given:   models.AccountFilter{},
+			want:    models.AccountFilter{Limit: defaultPageLimit, Sort: models.SortByCreatedAt, Order: models.SortAsc},
+			wantErr: nil,
+		},
+		"search-is-cleaned-up": {
+			given:   models.AccountFilter{Limit: 10, Query: "  caio \u202e henrique ", Sort: models.SortByName, Order: models.SortDesc},
+			want:    models.AccountFilter{Limit: 10, Query: "caio henrique", Sort: models.SortByName, Order: models.SortDesc},
+			wantErr: nil,
+		},
+		"limit-too-high": {
+			given:   models.AccountFilter{Limit: maxPageLimit + 1},
+			wantErr: ErrInvalidAccountLimit,
+		},
+		"unknown-sort": {
+			given:   models.AccountFilter{Sort: "balance"},
+			wantErr: ErrInvalidAccountSort,
+		},
+		"unknown-order": {
+			given:   models.AccountFilter{Order: "up"},
+			wantErr: ErrInvalidAccountOrder,
+		},
+		"search-too-long": {
+			given:   models.AccountFilter{Query: strings.Repeat("a", maxAccountQueryLength+1)},
+			wantErr: ErrInvalidAccountFilter,
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			service, deps := setupAccountService(t)
+			page := models.AccountPage{Accounts: []models.Account{{AccountId: "0001"}}}
+			if tcase.wantErr == nil {
+				deps.accRepoMock.On("FindPage", ctx, tcase.want).Return(page, nil)
+			}
+
+			result, err := service.GetAllAccounts(ctx, tcase.given)
+
+			if tcase.wantErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, page, result)
+			} else {
+				assert.ErrorIs(t, err, tcase.wantErr)
+			}
+		})
+	}
+}
+
+func TestAccountService_Freeze(t *testing.T) {
+	now := time.Now()
+	setupClock(now)
+	defer resetClock()
+
+	var (
+		ctx = context.Background()
+		id  = "0001"
+	)
+
+	scenarios := map[string]struct {
+		given   models.AccountStatusReq
+		doMocks func(deps accountServiceDependencies)
+		want    models.AccountStatusChange
+		wantErr error
+	}{
+		"happy-path": {
+			given: models.AccountStatusReq{Reason: "  card   reported stolen "},
+			doMocks: func(deps accountServiceDependencies) {
+				change := models.AccountStatusChange{
+					AccountId: id,
+					From:      models.AccountActive,
+					To:        models.AccountFrozen,
+					Reason:    "card reported stolen",
+					CreatedAt: now,
+				}
+
+				deps.transRepoMock.On("LockAccount", ctx, id).Return(nil)
+				deps.accRepoMock.On("FindOne", ctx, id).Return(models.Account{AccountId: id, Status: models.AccountActive}, nil)
+				deps.accRepoMock.On("SetStatus", ctx, change).Return("c-1", nil)
+			},
+			want: models.AccountStatusChange{
+				ChangeId:  "c-1",
+				AccountId: id,
+				From:      models.AccountActive,
+				To:        models.AccountFrozen,
+				Reason:    "card reported stolen",
+				CreatedAt: now,
+			},
+			wantErr: nil,
+		},
+		"already-frozen": {
+			given: models.AccountStatusReq{Reason: "fraud"},
+			doMocks: func(deps accountServiceDependencies) {
+				deps.transRepoMock.On("LockAccount", ctx, id).Return(nil)
+				deps.accRepoMock.On("FindOne", ctx, id).Return(models.Account{AccountId: id, Status: models.AccountFrozen}, nil)
+			},
+			want:    models.AccountStatusChange{},
+			wantErr: ErrStatusTransition,
+		},
+		"closed-is-final": {
+			given: models.AccountStatusReq{Reason: "fraud"},
+			doMocks: func(deps accountServiceDependencies) {
+				deps.transRepoMock.On("LockAccount", ctx, id).Return(nil)
+				deps.accRepoMock.On("FindOne", ctx, id).Return(models.Account{AccountId: id, Status: models.AccountClosed}, nil)
+			},
+			want:    models.AccountStatusChange{},
+			wantErr: ErrStatusTransition,
+		},
+		"missing-reason": {
+			given:   models.AccountStatusReq{Reason: " \t "},
+			want:    models.AccountStatusChange{},
+			wantErr: ErrStatusReasonRequired,
+		},
+		"reason-too-long": {
+			given:   models.AccountStatusReq{Reason: strings.Repeat("a", maxStatusReasonLength+1)},
+			want:    models.AccountStatusChange{},
+			wantErr: ErrInvalidStatusChange,
+		},
+		"changed-in-the-meantime": {
+			given: models.AccountStatusReq{Reason: "fraud"},
+			doMocks: func(deps accountServiceDependencies) {
+				deps.transRepoMock.On("LockAccount", ctx, id).Return(nil)
+				deps.accRepoMock.On("FindOne", ctx, id).Return(models.Account{AccountId: id, Status: models.AccountActive}, nil)
+				deps.accRepoMock.On("SetStatus", ctx, mock.Anything).Return("", repository.ErrAccountStatusChanged)
+			},
+			want:    models.AccountStatusChange{},
+			wantErr: repository.ErrAccountStatusChanged,
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			service, deps := setupAccountService(t)
+			if tcase.doMocks != nil {
+				tcase.doMocks(deps)
+			}
+
+			change, err := service.Freeze(ctx, id, tcase.given)
+
+			if tcase.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tcase.wantErr)
+			}
+			assert.Equal(t, tcase.want, change)
+		})
+	}
+}
+
+func TestAccountService_Unfreeze(t *testing.T) {
+	now := time.Now()
+	setupClock(now)
+	defer resetClock()
+
+	ctx := context.Background()
+
+	service, deps := setupAccountService(t)
+	deps.transRepoMock.On("LockAccount", ctx, "0001").Return(nil)
+	deps.accRepoMock.On("FindOne", ctx, "0001").Return(models.Account{AccountId: "0001", Status: models.AccountActive}, nil)
+
+	_, err := service.Unfreeze(ctx, "0001", models.AccountStatusReq{Reason: "cleared"})
+
+	assert.ErrorIs(t, err, ErrStatusTransition)
+}
+
+func TestAccountService_Close(t *testing.T) {
+	now := time.Now()
+	setupClock(now)
+	defer resetClock()
+
+	var (
+		ctx      = context.Background()
+		id       = "0001"
+		payoutTo = "0002"
+	)
+
+	scenarios := map[string]struct {
+		given   models.AccountStatusReq
+		doMocks func(deps accountServiceDependencies)
+		want    models.AccountStatusChange
+		wantErr error
+	}{
+		"zero-balance": {
+			given: models.AccountStatusReq{Reason: "customer request"},
+			doMocks: func(deps accountServiceDependencies) {
+				change := models.AccountStatusChange{
+					AccountId: id,
+					From:      models.AccountActive,
+					To:        models.AccountClosed,
+					Reason:    "customer request",
+					CreatedAt: now,
+				}
+
+				deps.transRepoMock.On("LockAccount", ctx, id).Return(nil)
+				deps.accRepoMock.On("FindOne", ctx, id).Return(models.Account{AccountId: id, Status: models.AccountActive}, nil)
+				deps.transactionSvcMock.On("GetBalance", ctx, id).Return(models.Balance{AccountId: id}, nil)
+				deps.payoutRepoMock.On("GetReserved", ctx, id).Return(models.Money(0), nil)
+				deps.accRepoMock.On("SetStatus", ctx, change).Return("c-1", nil)
+			},
+			want: models.AccountStatusChange{
+				ChangeId:  "c-1",
+				AccountId: id,
+				From:      models.AccountActive,
+				To:        models.AccountClosed,
+				Reason:    "customer request",
+				CreatedAt: now,
+			},
+			wantErr: nil,
+		},
+		"frozen-with-payout": {
+			given: models.AccountStatusReq{Reason: "fraud", PayoutTo: payoutTo},
+			doMocks: func(deps accountServiceDependencies) {
+				change := models.AccountStatusChange{
+					AccountId: id,
+					From:      models.AccountFrozen,
+					To:        models.AccountClosed,
+					Reason:    "fraud",
+					PayoutTo:  payoutTo,
+					Amount:    4200,
+					CreatedAt: now,
+				}
+
+				deps.transRepoMock.On("LockAccount", ctx, id).Return(nil)
+				deps.accRepoMock.On("FindOne", ctx, id).Return(models.Account{AccountId: id, Status: models.AccountFrozen}, nil)
+				deps.transactionSvcMock.On("GetBalance", ctx, id).Return(models.Balance{AccountId: id, Amount: 4200, Available: 4200}, nil)
+				deps.payoutRepoMock.On("GetReserved", ctx, id).Return(models.Money(0), nil)
+				deps.transactionSvcMock.On("Sweep", ctx, id, payoutTo, models.Money(4200)).Return(nil)
+				deps.accRepoMock.On("SetStatus", ctx, change).Return("c-1", nil)
+			},
+			want: models.AccountStatusChange{
+				ChangeId:  "c-1",
+				AccountId: id,
+				From:      models.AccountFrozen,
+				To:        models.AccountClosed,
+				Reason:    "fraud",
+				PayoutTo:  payoutTo,
+				Amount:    4200,
+				CreatedAt: now,
+			},
+			wantErr: nil,
+		},
+		"balance-without-payout": {
+			given: models.AccountStatusReq{Reason: "customer request"},
+			doMocks: func(deps accountServiceDependencies) {
+				deps.transRepoMock.On("LockAccount", ctx, id).Return(nil)
+				deps.accRepoMock.On("FindOne", ctx, id).Return(models.Account{AccountId: id, Status: models.AccountActive}, nil)
+				deps.transactionSvcMock.On("GetBalance", ctx, id).Return(models.Balance{AccountId: id, Amount: 100, Available: 100}, nil)
+				deps.payoutRepoMock.On("GetReserved", ctx, id).Return(models.Money(0), nil)
+			},
+			want:    models.AccountStatusChange{},
+			wantErr: ErrCloseWithBalance,
+		},
+		"active-holds": {
+			given: models.AccountStatusReq{Reason: "customer request", PayoutTo: payoutTo},
+			doMocks: func(deps accountServiceDependencies) {
+				deps.transRepoMock.On("LockAccount", ctx, id).Return(nil)
+				deps.accRepoMock.On("FindOne", ctx, id).Return(models.Account{AccountId: id, Status: models.AccountActive}, nil)
+				deps.transactionSvcMock.On("GetBalance", ctx, id).Return(models.Balance{AccountId: id, Amount: 100, Held: 100}, nil)
+			},
+			want:    models.AccountStatusChange{},
+			wantErr: ErrCloseWithHolds,
+		},
+		"payouts-in-progress": {
+			given: models.AccountStatusReq{Reason: "customer request", PayoutTo: payoutTo},
+			doMocks: func(deps accountServiceDependencies) {
+				deps.transRepoMock.On("LockAccount", ctx, id).Return(nil)
+				deps.accRepoMock.On("FindOne", ctx, id).Return(models.Account{AccountId: id, Status: models.AccountActive}, nil)
+				deps.transactionSvcMock.On("GetBalance", ctx, id).Return(models.Balance{AccountId: id, Amount: 100, Available: 100}, nil)
+				deps.payoutRepoMock.On("GetReserved", ctx, id).Return(models.Money(2000), nil)
+			},
+			want:    models.AccountStatusChange{},
+			wantErr: ErrCloseWithPayouts,
+		},
+		"payout-to-closed-account": {
+			given: models.AccountStatusReq{Reason: "customer request", PayoutTo: payoutTo},
+			doMocks: func(deps accountServiceDependencies) {
+				deps.transRepoMock.On("LockAccount", ctx, id).Return(nil)
+				deps.accRepoMock.On("FindOne", ctx, id).Return(models.Account{AccountId: id, Status: models.AccountActive}, nil)
+				deps.transactionSvcMock.On("GetBalance", ctx, id).Return(models.Balance{AccountId: id, Amount: 100, Available: 100}, nil)
+				deps.payoutRepoMock.On("GetReserved", ctx, id).Return(models.Money(0), nil)
+				deps.transactionSvcMock.On("Sweep", ctx, id, payoutTo, models.Money(100)).Return(ErrAccountClosed)
+			},
+			want:    models.AccountStatusChange{},
+			wantErr: ErrAccountClosed,
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			service, deps := setupAccountService(t)
+			if tcase.doMocks != nil {
+				tcase.doMocks(deps)
+			}
+
+			change, err := service.Close(ctx, id, tcase.given)
+
+			if tcase.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tcase.wantErr)
+			}
+			assert.Equal(t, tcase.want, change)
+		})
+	}
+}
+
+func TestAccountService_CreateAccount(t *testing.T) {
+	now := time.Now()
+	setupClock(now)
+	defer resetClock()
+
+	ctx := context.Background()
+	setupPasswordIterations(t)
+
+	saved := mock.MatchedBy(func(c models.Credential) bool {
+		return c.AccountId == "0001" && checkPassword(c.PasswordHash, "s3cret-pass") && c.UpdatedAt.Equal(now)
+	})
+
+	scenarios := map[string]struct {
+		given   models.AccReq
+		doMocks func(deps accountServiceDependencies)
+		wantErr error
+	}{
+		"without-handle": {
+			given: models.AccReq{Name: "Caio", LastName: "Henrique", Password: "s3cret-pass"},
+			doMocks: func(deps accountServiceDependencies) {
+				deps.accRepoMock.On("Create", ctx, "Caio", "Henrique").Return("0001", nil)
+				deps.credentialRepoMock.On("Save", ctx, saved).Return(nil)
+			},
+			wantErr: nil,
+		},
+		"with-handle": {
+			given: models.AccReq{Name: "Caio", LastName: "Henrique", Password: "s3cret-pass", Handle: "@Caio_H"},
+			doMocks: func(deps accountServiceDependencies) {
+				acc := models.Account{AccountId: "0001", Name: "Caio", LastName: "Henrique", Status: models.AccountActive}
+				claimed := acc
+				claimed.Handle = "Caio_H"
+				claimed.UpdatedAt = now
+
+				deps.accRepoMock.On("Create", ctx, "Caio", "Henrique").Return("0001", nil)
+				deps.credentialRepoMock.On("Save", ctx, saved).Return(nil)
+				deps.accRepoMock.On("FindOne", ctx, "0001").Return(acc, nil)
+				deps.accRepoMock.On("Update", ctx, claimed).Return(nil)
+			},
+			wantErr: nil,
+		},
+		"handle-taken": {
+			given: models.AccReq{Name: "Caio", LastName: "Henrique", Password: "s3cret-pass", Handle: "caio"},
+			doMocks: func(deps accountServiceDependencies) {
+				deps.accRepoMock.On("Create", ctx, "Caio", "Henrique").Return("0001", nil)
+				deps.credentialRepoMock.On("Save", ctx, saved).Return(nil)
+				deps.accRepoMock.On("FindOne", ctx, "0001").Return(models.Account{AccountId: "0001"}, nil)
+				deps.accRepoMock.On("Update", ctx, mock.Anything).Return(repository.ErrHandleTaken)
+			},
+			wantErr: repository.ErrHandleTaken,
+		},
+		"password-too-short": {
+			given:   models.AccReq{Name: "Caio", LastName: "Henrique", Password: "short"},
+			wantErr: ErrPasswordLength,
+		},
+		"without-password": {
+			given:   models.AccReq{Name: "Caio", LastName: "Henrique", Handle: "caio"},
+			wantErr: ErrInvalidPassword,
+		},
+		"reserved-handle": {
+			given:   models.AccReq{Name: "Caio", LastName: "Henrique", Password: "s3cret-pass", Handle: "Support"},
+			wantErr: ErrReservedHandle,
+		},
+		"handle-starting-with-digit": {
+			given:   models.AccReq{Name: "Caio", LastName: "Henrique", Password: "s3cret-pass", Handle: "1caio"},
+			wantErr: ErrInvalidHandle,
+		},
+		"handle-with-dash": {
+			given:   models.AccReq{Name: "Caio", LastName: "Henrique", Password: "s3cret-pass", Handle: "caio-h"},
+			wantErr: ErrInvalidHandle,
+		},
+		"handle-too-short": {
+			given:   models.AccReq{Name: "Caio", LastName: "Henrique", Password: "s3cret-pass", Handle: "ch"},
+			wantErr: ErrInvalidHandle,
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			service, deps := setupAccountService(t)
+			if tcase.doMocks != nil {
+				tcase.doMocks(deps)
+			}
+
+			id, err := service.CreateAccount(ctx, tcase.given)
+
+			if tcase.wantErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, "0001", id)
+			} else {
+				assert.ErrorIs(t, err, tcase.wantErr)
+			}
+		})
+	}
+}
+
+func TestAccountService_LookupHandle(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("found", func(t *testing.T) {
+		service, deps := setupAccountService(t)
+		deps.accRepoMock.On("FindByHandle", ctx, "caio").Return(models.Account{
+			AccountId: "0001",
+			Name:      "Caio",
+			LastName:  "Henrique",
+			Handle:    "Caio",
+			Email:     "caio@example.com",
+			Status:    models.AccountFrozen,
+		}, nil)
+
+		summary, err := service.LookupHandle(ctx, "@caio")
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.AccountSummary{AccountId: "0001", Handle: "Caio", Name: "Caio", LastName: "Henrique"}, summary)
+	})
+
+	t.Run("closed-account", func(t *testing.T) {
+		service, deps := setupAccountService(t)
+		deps.accRepoMock.On("FindByHandle", ctx, "caio").Return(models.Account{AccountId: "0001", Handle: "caio", Status: models.AccountClosed}, nil)
+
+		_, err := service.LookupHandle(ctx, "caio")
+
+		assert.ErrorIs(t, err, repository.ErrAccountNotFound)
+	})
+
+	t.Run("not-a-handle", func(t *testing.T) {
+		service, _ := setupAccountService(t)
+
+		_, err := service.LookupHandle(ctx, "@ca io")
+
+		assert.ErrorIs(t, err, ErrInvalidHandle)
+	})
+}
+
+func TestAccountService_UpdateProfile(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	setupClock(now)
+	defer resetClock()
+
+	var (
+		ctx     = context.Background()
+		id      = "0001"
+		created = now.Add(-24 * time.Hour)
+		current = models.Account{
+			AccountId:   id,
+			Name:        "Shankar",
+			LastName:    "Nakai",
+			Status:      models.AccountActive,
+			DisplayName: "shankar",
+			Phone:       "+5511912345678",
+			CreatedAt:   created,
+			UpdatedAt:   created,
+		}
+	)
+
+	str := func(s string) *string { return &s }
+	privacy := func(p models.Privacy) *models.Privacy { return &p }
+
+	scenarios := map[string]struct {
+		given   models.AccountPatchReq
+		doMocks func(deps accountServiceDependencies)
+		want    models.Account
+		wantErr error
+	}{
+		"happy-path": {
+			given: models.AccountPatchReq{
+				Email:       str("  Shankar@Example.COM "),
+				Phone:       str("+55 (11) 98765-4321"),
+				DateOfBirth: str("1990-02-28"),
+				Address:     &models.Address{Line1: " Rua A,  1 ", City: "Sao Paulo", PostalCode: "01000-000", Country: "br"},
+			},
+			doMocks: func(deps accountServiceDependencies) {
+				want := current
+				want.Email = "shankar@example.com"
+				want.Phone = "+5511987654321"
+				want.DateOfBirth = "1990-02-28"
+				want.Address = &models.Address{Line1: "Rua A, 1", City: "Sao Paulo", PostalCode: "01000-000", Country: "BR"}
+				want.UpdatedAt = now
+
+				deps.accRepoMock.On("FindOne", ctx, id).Return(current, nil)
+				deps.accRepoMock.On("Update", ctx, want).Return(nil)
+			},
+			want: models.Account{
+				AccountId:   id,
+				Name:        "Shankar",
+				LastName:    "Nakai",
+				Status:      models.AccountActive,
+				Email:       "shankar@example.com",
+				Phone:       "+5511987654321",
+				DisplayName: "shankar",
+				DateOfBirth: "1990-02-28",
+				Address:     &models.Address{Line1: "Rua A, 1", City: "Sao Paulo", PostalCode: "01000-000", Country: "BR"},
+				CreatedAt:   created,
+				UpdatedAt:   now,
+			},
+			wantErr: nil,
+		},
+		"clear-optional-fields": {
+			given: models.AccountPatchReq{
+				Phone:       str(""),
+				DisplayName: str(" "),
+				Address:     &models.Address{},
+			},
+			doMocks: func(deps accountServiceDependencies) {
+				withAddress := current
+				withAddress.Address = &models.Address{Line1: "Rua A, 1", City: "Sao Paulo", PostalCode: "01000-000", Country: "BR"}
+
+				want := current
+				want.Phone = ""
+				want.DisplayName = ""
+				want.UpdatedAt = now
+
+				deps.accRepoMock.On("FindOne", ctx, id).Return(withAddress, nil)
+				deps.accRepoMock.On("Update", ctx, want).Return(nil)
+			},
+			want: models.Account{
+				AccountId: id,
+				Name:      "Shankar",
+				LastName:  "Nakai",
+				Status:    models.AccountActive,
+				CreatedAt: created,
+				UpdatedAt: now,
+			},
+			wantErr: nil,
+		},
+		"default-privacy": {
+			given: models.AccountPatchReq{DefaultPrivacy: privacy(models.PrivacyPublic)},
+			doMocks: func(deps accountServiceDependencies) {
+				want := current
+				want.DefaultPrivacy = models.PrivacyPublic
+				want.UpdatedAt = now
+
+				deps.accRepoMock.On("FindOne", ctx, id).Return(current, nil)
+				deps.accRepoMock.On("Update", ctx, want).Return(nil)
+			},
+			want: models.Account{
+				AccountId:      id,
+				Name:           "Shankar",
+				LastName:       "Nakai",
+				Status:         models.AccountActive,
+				DisplayName:    "shankar",
+				Phone:          "+5511912345678",
+				DefaultPrivacy: models.PrivacyPublic,
+				CreatedAt:      created,
+				UpdatedAt:      now,
+			},
+			wantErr: nil,
+		},
+		"invalid-default-privacy": {
+			given: models.AccountPatchReq{DefaultPrivacy: privacy("everyone")},
+			doMocks: func(deps accountServiceDependencies) {
+				deps.accRepoMock.On("FindOne", ctx, id).Return(current, nil)
+			},
+			want:    models.Account{},
+			wantErr: ErrInvalidPrivacy,
+		},
+		"name-cannot-be-cleared": {
+			given: models.AccountPatchReq{Name: str("  ")},
+			doMocks: func(deps accountServiceDependencies) {
+				deps.accRepoMock.On("FindOne", ctx, id).Return(current, nil)
+			},
+			want:    models.Account{},
+			wantErr: ErrInvalidName,
+		},
+		"invalid-email": {
+			given: models.AccountPatchReq{Email: str("Shankar <shankar@example.com>")},
+			doMocks: func(deps accountServiceDependencies) {
+				deps.accRepoMock.On("FindOne", ctx, id).Return(current, nil)
+			},
+			want:    models.Account{},
+			wantErr: ErrInvalidEmail,
+		},
+		"local-phone": {
+			given: models.AccountPatchReq{Phone: str("11 98765-4321")},
+			doMocks: func(deps accountServiceDependencies) {
+				deps.accRepoMock.On("FindOne", ctx, id).Return(current, nil)
+			},
+			want:    models.Account{},
+			wantErr: ErrInvalidPhone,
+		},
+		"born-in-the-future": {
+			given: models.AccountPatchReq{DateOfBirth: str("2024-06-02")},
+			doMocks: func(deps accountServiceDependencies) {
+				deps.accRepoMock.On("FindOne", ctx, id).Return(current, nil)
+			},
+			want:    models.Account{},
+			wantErr: ErrInvalidDateOfBirth,
+		},
+		"malformed-date-of-birth": {
+			given: models.AccountPatchReq{DateOfBirth: str("28/02/1990")},
+			doMocks: func(deps accountServiceDependencies) {
+				deps.accRepoMock.On("FindOne", ctx, id).Return(current, nil)
+			},
+			want:    models.Account{},
+			wantErr: ErrInvalidProfile,
+		},
+		"incomplete-address": {
+			given: models.AccountPatchReq{Address: &models.Address{Line1: "Rua A, 1", Country: "Brazil"}},
+			doMocks: func(deps accountServiceDependencies) {
+				deps.accRepoMock.On("FindOne", ctx, id).Return(current, nil)
+			},
+			want:    models.Account{},
+			wantErr: ErrInvalidAddress,
+		},
+		"email-taken": {
+			given: models.AccountPatchReq{Email: str("jessica@example.com")},
+			doMocks: func(deps accountServiceDependencies) {
+				deps.accRepoMock.On("FindOne", ctx, id).Return(current, nil)
+				deps.accRepoMock.On("Update", ctx, mock.Anything).Return(repository.ErrEmailTaken)
+			},
+			want:    models.Account{},
+			wantErr: repository.ErrEmailTaken,
+		},
+		"closed-account": {
+			given: models.AccountPatchReq{DisplayName: str("shan")},
+			doMocks: func(deps accountServiceDependencies) {
+				closed := current
+				closed.Status = models.AccountClosed
+				deps.accRepoMock.On("FindOne", ctx, id).Return(closed, nil)
+			},
+			want:    models.Account{},
+			wantErr: ErrAccountClosed,
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			service, deps := setupAccountService(t)
+			if tcase.doMocks != nil {
+				tcase.doMocks(deps)
+			}
+
+			acc, err := service.UpdateProfile(ctx, id, tcase.given)
+
+			if tcase.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tcase.wantErr)
+			}
+			assert.Equal(t, tcase.want, acc)
+		})
+	}
+}
+
+func TestAccountStatusRules(t *testing.T) {
+	active := models.Account{Status: models.AccountActive}
+	frozen := models.Account{Status: models.AccountFrozen}
+	closed := models.Account{Status: models.AccountClosed}
+
+	assert.NoError(t, canSend(active))
+	assert.ErrorIs(t, canSend(frozen), ErrAccountFrozen)
+	assert.ErrorIs(t, canSend(closed), ErrAccountClosed)
+
+	assert.NoError(t, canReceive(active))
+	assert.NoError(t, canReceive(frozen))
+	assert.ErrorIs(t, canReceive(closed), ErrAccountInactive)
+}
+
+type accountServiceDependencies struct {
+	accRepoMock        *repository.MockAccountRepo
+	transRepoMock      *repository.MockTransactionRepo
+	credentialRepoMock *repository.MockCredentialRepo
+	payoutRepoMock     *repository.MockPayoutRepo
+	transactionSvcMock *MockTransactionService
+	uowMock            *repository.MockUnitOfWork
+}
+
+func setupAccountService(t *testing.T) (*accountServiceImpl, accountServiceDependencies) {
+	deps := accountServiceDependencies{
+		accRepoMock:        repository.NewMockAccountRepo(t),
+		transRepoMock:      repository.NewMockTransactionRepo(t),
+		credentialRepoMock: repository.NewMockCredentialRepo(t),
+		payoutRepoMock:     repository.NewMockPayoutRepo(t),
+		transactionSvcMock: NewMockTransactionService(t),
+		uowMock:            repository.NewMockUnitOfWork(t),
+	}
+
+	deps.uowMock.EXPECT().Do(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		}).
+		Maybe()
+
+	return NewAccountService(deps.accRepoMock, deps.transRepoMock, deps.credentialRepoMock, deps.payoutRepoMock, deps.transactionSvcMock, deps.uowMock), deps
+}
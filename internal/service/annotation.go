@@ -0,0 +1,93 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gopay/internal/models"
+)
+
+const (
+	maxMemoLength          = 140
+	maxMetadataKeys        = 20
+	maxMetadataKeyLength   = 40
+	maxMetadataValueLength = 500
+)
+
+var (
+	ErrInvalidAnnotation    = errors.New("invalid memo or metadata")
+	ErrMemoTooLong          = fmt.Errorf("memo must be at most %d characters: %w", maxMemoLength, ErrInvalidAnnotation)
+	ErrTooManyMetadataKeys  = fmt.Errorf("metadata can have at most %d keys: %w", maxMetadataKeys, ErrInvalidAnnotation)
+	ErrInvalidMetadataKey   = fmt.Errorf("metadata keys must be 1 to %d letters, digits, '_', '-' or '.': %w", maxMetadataKeyLength, ErrInvalidAnnotation)
+	ErrMetadataValueTooLong = fmt.Errorf("metadata values must be at most %d characters: %w", maxMetadataValueLength, ErrInvalidAnnotation)
+	ErrInvalidPrivacy       = errors.New("privacy must be public, friends or private")
+)
+
+// sanitizeAnnotation cleans up the memo and metadata values with sanitizeText and
+// checks them against the length limits. An empty privacy is left for the caller to fill.
+func sanitizeAnnotation(note models.Annotation) (models.Annotation, error) {
+	clean := models.Annotation{Memo: sanitizeText(note.Memo), Privacy: note.Privacy}
+	if utf8.RuneCountInString(clean.Memo) > maxMemoLength {
+		return models.Annotation{}, ErrMemoTooLong
+	}
+
+	if clean.Privacy != "" && !clean.Privacy.IsValid() {
+		return models.Annotation{}, ErrInvalidPrivacy
+	}
+
+	if len(note.Metadata) > maxMetadataKeys {
+		return models.Annotation{}, ErrTooManyMetadataKeys
+	}
+
+	for key, value := range note.Metadata {
+		if !validMetadataKey(key) {
+			return models.Annotation{}, ErrInvalidMetadataKey
+		}
+
+		value = sanitizeText(value)
+		if utf8.RuneCountInString(value) > maxMetadataValueLength {
+			return models.Annotation{}, ErrMetadataValueTooLong
+		}
+
+		if clean.Metadata == nil {
+			clean.Metadata = make(models.Metadata, len(note.Metadata))
+		}
+		clean.Metadata[key] = value
+	}
+
+	return clean, nil
+}
+
+// sanitizeText drops invalid UTF-8 as well as control and invisible formatting characters,
+// such as bidirectional overrides, and collapses whitespace into single spaces.
+func sanitizeText(s string) string {
+	s = strings.ToValidUTF8(s, "")
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return ' '
+		}
+		if unicode.IsControl(r) || unicode.Is(unicode.Cf, r) {
+			return -1
+		}
+		return r
+	}, s)
+
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func validMetadataKey(key string) bool {
+	if key == "" || len(key) > maxMetadataKeyLength {
+		return false
+	}
+
+	for _, r := range key {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-' || r == '.') {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gopay/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizeAnnotation(t *testing.T) {
+	tooManyKeys := models.Metadata{}
+	for i := 0; i <= maxMetadataKeys; i++ {
+		tooManyKeys[fmt.Sprintf("key%d", i)] = "v"
+	}
+
+	scenarios := map[string]struct {
+		given   models.Annotation
+		want    models.Annotation
+		wantErr error
+	}{
+		"empty": {
+			given: models.Annotation{},
+			want:  models.Annotation{},
+		},
+		"collapses-whitespace": {
+			given: models.Annotation{Memo: "  pizza \t\n night  "},
+			want:  models.Annotation{Memo: "pizza night"},
+		},
+		"drops-control-and-format-characters": {
+			given: models.Annotation{Memo: "rent\x00\u202egpj.exe\u200b"},
+			want:  models.Annotation{Memo: "rentgpj.exe"},
+		},
+		"drops-invalid-utf8": {
+			given: models.Annotation{Memo: "caf\xe9"},
+			want:  models.Annotation{Memo: "caf"},
+		},
+		"memo-at-limit": {
+			given: models.Annotation{Memo: strings.Repeat("é", maxMemoLength)},
+			want:  models.Annotation{Memo: strings.Repeat("é", maxMemoLength)},
+		},
+		"memo-too-long": {
+			given:   models.Annotation{Memo: strings.Repeat("é", maxMemoLength+1)},
+			wantErr: ErrMemoTooLong,
+		},
+		"keeps-privacy": {
+			given: models.Annotation{Memo: "tacos", Privacy: models.PrivacyFriends},
+			want:  models.Annotation{Memo: "tacos", Privacy: models.PrivacyFriends},
+		},
+		"invalid-privacy": {
+			given:   models.Annotation{Privacy: "everyone"},
+			wantErr: ErrInvalidPrivacy,
+		},
+		"metadata-values-sanitized": {
+			given: models.Annotation{Metadata: models.Metadata{"invoice.id": " INV\n-7 "}},
+			want:  models.Annotation{Metadata: models.Metadata{"invoice.id": "INV -7"}},
+		},
+		"invalid-metadata-key": {
+			given:   models.Annotation{Metadata: models.Metadata{"invoice id": "7"}},
+			wantErr: ErrInvalidMetadataKey,
+		},
+		"empty-metadata-key": {
+			given:   models.Annotation{Metadata: models.Metadata{"": "7"}},
+			wantErr: ErrInvalidMetadataKey,
+		},
+		"too-many-keys": {
+			given:   models.Annotation{Metadata: tooManyKeys},
+			wantErr: ErrTooManyMetadataKeys,
+		},
+		"metadata-value-too-long": {
+			given:   models.Annotation{Metadata: models.Metadata{"note": strings.Repeat("a", maxMetadataValueLength+1)}},
+			wantErr: ErrMetadataValueTooLong,
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			result, err := sanitizeAnnotation(tcase.given)
+
+			if tcase.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tcase.wantErr)
+			}
+
+			assert.Equal(t, tcase.want, result)
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gopay/internal/models"
+	"github.com/gopay/internal/repository"
+)
+
+const sessionTokenSize = 32
+
+var (
+	ErrInvalidCredentials = errors.New("invalid login or password")
+	ErrInvalidToken       = errors.New("invalid or expired token")
+	ErrWrongPassword      = errors.New("current password is incorrect")
+)
+
+// unknownLoginHash is checked against when a login matches no credential, so that
+// failed logins take as long whether the account exists or not.
+var unknownLoginHash = sync.OnceValue(func() string {
+	hash, _ := hashPassword("unknown login")
+	return hash
+})
+
+// AuthService logs accounts in with their password and tells who a bearer token belongs
+// to. Tokens are opaque: each one is a session that lasts until it expires or is logged
+// out. The admin token, when set, is the operator's.
+type AuthService interface {
+	Login(ctx context.Context, req models.LoginReq) (models.LoginRes, error)
+	Logout(ctx context.Context, token string) error
+	Authenticate(ctx context.Context, token string) (models.Principal, error)
+	ChangePassword(ctx context.Context, accId string, req models.PasswordReq) error
+	ResetPassword(ctx context.Context, accId string, req models.PasswordReq) error
+}
+
+var _ AuthService = (*authServiceImpl)(nil)
+
+type authServiceImpl struct {
+	adminToken     string
+	sessionTTL     time.Duration
+	credentialRepo repository.CredentialRepo
+	sessionRepo    repository.SessionRepo
+	accountRepo    repository.AccountRepo
+	uow            repository.UnitOfWork
+}
+
+func NewAuthService(adminToken string, sessionTTL time.Duration, credentialRepo repository.CredentialRepo, sessionRepo repository.SessionRepo, accountRepo repository.AccountRepo, uow repository.UnitOfWork) *authServiceImpl {
+	return &authServiceImpl{
+		adminToken:     adminToken,
+		sessionTTL:     sessionTTL,
+		credentialRepo: credentialRepo,
+		sessionRepo:    sessionRepo,
+		accountRepo:    accountRepo,
+		uow:            uow,
+	}
+}
+
+// Login opens a session for the account req.Login names, by id or handle, if
+// req.Password is its password. Closed accounts cannot log in.
+func (r *authServiceImpl) Login(ctx context.Context, req models.LoginReq) (models.LoginRes, error) {
+	acc, err := r.findLogin(ctx, strings.TrimSpace(req.Login))
+	if err != nil && err != repository.ErrAccountNotFound {
+		return models.LoginRes{}, err
+	}
+
+	hash, found := unknownLoginHash(), false
+	if err == nil {
+		credential, err := r.credentialRepo.FindOne(ctx, acc.AccountId)
+		if err != nil && err != repository.ErrCredentialNotFound {
+			return models.LoginRes{}, err
+		}
+		if err == nil {
+			hash, found = credential.PasswordHash, true
+		}
+	}
+
+	matched := checkPassword(hash, req.Password)
+	if !matched || !found || acc.Status == models.AccountClosed {
+		return models.LoginRes{}, ErrInvalidCredentials
+	}
+
+	token, err := newSessionToken()
+	if err != nil {
+		return models.LoginRes{}, err
+	}
+
+	now := clockNow()
+	session := models.Session{
+		TokenHash: hashToken(token),
+		AccountId: acc.AccountId,
+		CreatedAt: now,
+		ExpiresAt: now.Add(r.sessionTTL),
+	}
+
+	err = r.uow.Do(ctx, func(ctx context.Context) error {
+		err := r.sessionRepo.RemoveExpired(ctx, acc.AccountId, now)
+		if err != nil {
+			return err
+		}
+
+		return r.sessionRepo.Save(ctx, session)
+	})
+	if err != nil {
+		return models.LoginRes{}, err
+	}
+
+	return models.LoginRes{
+		Token:     token,
+		AccountId: acc.AccountId,
+		ExpiresAt: session.ExpiresAt,
+	}, nil
+}
+
+// findLogin looks login up as a handle, with or without its '@', and as an account id
+// otherwise. Account ids are never valid handles.
+func (r *authServiceImpl) findLogin(ctx context.Context, login string) (models.Account, error) {
+	handle := strings.TrimPrefix(login, "@")
+	if validHandle(handle) {
+		return r.accountRepo.FindByHandle(ctx, handle)
+	}
+
+	return r.accountRepo.FindOne(ctx, login)
+}
+
+// Logout ends the session of token. Tokens without a session, the admin token among
+// them, are left alone.
+func (r *authServiceImpl) Logout(ctx context.Context, token string) error {
+	err := r.sessionRepo.Remove(ctx, hashToken(token))
+	if err == repository.ErrSessionNotFound {
+		return nil
+	}
+
+	return err
+}
+
+// Authenticate returns who token belongs to, or ErrInvalidToken if it is no session's
+// or its session expired.
+func (r *authServiceImpl) Authenticate(ctx context.Context, token string) (models.Principal, error) {
+	if r.adminToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(r.adminToken)) == 1 {
+		return models.Principal{Admin: true}, nil
+	}
+
+	session, err := r.sessionRepo.FindOne(ctx, hashToken(token))
+	if err == repository.ErrSessionNotFound {
+		return models.Principal{}, ErrInvalidToken
+	}
+	if err != nil {
+		return models.Principal{}, err
+	}
+
+	if !clockNow().Before(session.ExpiresAt) {
+		return models.Principal{}, ErrInvalidToken
+	}
+
+	return models.Principal{AccountId: session.AccountId}, nil
+}
+
+// ChangePassword sets the password of accId when req.CurrentPassword is its current one.
+// Every session of the account is logged out, the one making the change included.
+func (r *authServiceImpl) ChangePassword(ctx context.Context, accId string, req models.PasswordReq) error {
+	err := validatePassword(req.Password)
+	if err != nil {
+		return err
+	}
+
+	credential, err := r.credentialRepo.FindOne(ctx, accId)
+	if err != nil && err != repository.ErrCredentialNotFound {
+		return err
+	}
+
+	if err != nil || !checkPassword(credential.PasswordHash, req.CurrentPassword) {
+		return ErrWrongPassword
+	}
+
+	return r.setPassword(ctx, accId, req.Password)
+}
+
+// ResetPassword sets the password of accId without asking for the current one, for
+// operators to let owners back in. Every session of the account is logged out.
+func (r *authServiceImpl) ResetPassword(ctx context.Context, accId string, req models.PasswordReq) error {
+	err := validatePassword(req.Password)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.accountRepo.FindOne(ctx, accId)
+	if err != nil {
+		return err
+	}
+
+	return r.setPassword(ctx, accId, req.Password)
+}
+
+func (r *authServiceImpl) setPassword(ctx context.Context, accId string, password string) error {
+	hash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	return r.uow.Do(ctx, func(ctx context.Context) error {
+		err := r.credentialRepo.Save(ctx, models.Credential{AccountId: accId, PasswordHash: hash, UpdatedAt: clockNow()})
+		if err != nil {
+			return err
+		}
+
+		return r.sessionRepo.RemoveByAccount(ctx, accId)
+	})
+}
+
+func newSessionToken() (string, error) {
+	b := make([]byte, sessionTokenSize)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken is what sessions are stored under, so that a leaked sessions table does not
+// leak usable tokens.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,303 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gopay/internal/models"
+	"github.com/gopay/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthService_Login(t *testing.T) {
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+	setupClock(now)
+	defer resetClock()
+
+	ctx := context.Background()
+	setupPasswordIterations(t)
+
+	hash, err := hashPassword("s3cret-pass")
+	require.NoError(t, err)
+
+	active := models.Account{AccountId: "0001", Handle: "caio", Status: models.AccountActive}
+	credential := models.Credential{AccountId: "0001", PasswordHash: hash}
+
+	scenarios := map[string]struct {
+		given   models.LoginReq
+		doMocks func(deps authServiceDependencies)
+		wantErr error
+	}{
+		"by-handle": {
+			given: models.LoginReq{Login: "@caio", Password: "s3cret-pass"},
+			doMocks: func(deps authServiceDependencies) {
+				deps.accRepoMock.On("FindByHandle", ctx, "caio").Return(active, nil)
+				deps.credentialRepoMock.On("FindOne", ctx, "0001").Return(credential, nil)
+				deps.sessionRepoMock.On("RemoveExpired", ctx, "0001", now).Return(nil)
+				deps.sessionRepoMock.On("Save", ctx, mock.MatchedBy(func(s models.Session) bool {
+					return s.AccountId == "0001" && len(s.TokenHash) == 64 && s.ExpiresAt.Equal(now.Add(time.Hour))
+				})).Return(nil)
+			},
+		},
+		"by-account-id": {
+			given: models.LoginReq{Login: "7e0ac9a3-5d4c-4c1b-9c61-0f6f3b6b2a10", Password: "s3cret-pass"},
+			doMocks: func(deps authServiceDependencies) {
+				acc := models.Account{AccountId: "7e0ac9a3-5d4c-4c1b-9c61-0f6f3b6b2a10", Status: models.AccountFrozen}
+
+				deps.accRepoMock.On("FindOne", ctx, acc.AccountId).Return(acc, nil)
+				deps.credentialRepoMock.On("FindOne", ctx, acc.AccountId).Return(models.Credential{AccountId: acc.AccountId, PasswordHash: hash}, nil)
+				deps.sessionRepoMock.On("RemoveExpired", ctx, acc.AccountId, now).Return(nil)
+				deps.sessionRepoMock.On("Save", ctx, mock.Anything).Return(nil)
+			},
+		},
+		"wrong-password": {
+			given: models.LoginReq{Login: "caio", Password: "s3cret-pasS"},
+			doMocks: func(deps authServiceDependencies) {
+				deps.accRepoMock.On("FindByHandle", ctx, "caio").Return(active, nil)
+				deps.credentialRepoMock.On("FindOne", ctx, "0001").Return(credential, nil)
+			},
+			wantErr: ErrInvalidCredentials,
+		},
+		"unknown-login": {
+			given: models.LoginReq{Login: "nobody", Password: "s3cret-pass"},
+			doMocks: func(deps authServiceDependencies) {
+				deps.accRepoMock.On("FindByHandle", ctx, "nobody").Return(models.Account{}, repository.ErrAccountNotFound)
+			},
+			wantErr: ErrInvalidCredentials,
+		},
+		"no-credential": {
+			given: models.LoginReq{Login: "caio", Password: "unknown login"},
+			doMocks: func(deps authServiceDependencies) {
+				deps.accRepoMock.On("FindByHandle", ctx, "caio").Return(active, nil)
+				deps.credentialRepoMock.On("FindOne", ctx, "0001").Return(models.Credential{}, repository.ErrCredentialNotFound)
+			},
+			wantErr: ErrInvalidCredentials,
+		},
+		"closed-account": {
+			given: models.LoginReq{Login: "caio", Password: "s3cret-pass"},
+			doMocks: func(deps authServiceDependencies) {
+				deps.accRepoMock.On("FindByHandle", ctx, "caio").Return(models.Account{AccountId: "0001", Status: models.AccountClosed}, nil)
+				deps.credentialRepoMock.On("FindOne", ctx, "0001").Return(credential, nil)
+			},
+			wantErr: ErrInvalidCredentials,
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			service, deps := setupAuthService(t)
+			tcase.doMocks(deps)
+
+			res, err := service.Login(ctx, tcase.given)
+
+			if tcase.wantErr != nil {
+				assert.ErrorIs(t, err, tcase.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NotEmpty(t, res.Token)
+			assert.Equal(t, now.Add(time.Hour), res.ExpiresAt)
+			deps.sessionRepoMock.AssertCalled(t, "Save", ctx, mock.MatchedBy(func(s models.Session) bool {
+				return s.TokenHash == hashToken(res.Token) && s.AccountId == res.AccountId
+			}))
+		})
+	}
+}
+
+func TestAuthService_Authenticate(t *testing.T) {
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+	setupClock(now)
+	defer resetClock()
+
+	ctx := context.Background()
+
+	scenarios := map[string]struct {
+		token   string
+		doMocks func(deps authServiceDependencies)
+		want    models.Principal
+		wantErr error
+	}{
+		"session": {
+			token: "token",
+			doMocks: func(deps authServiceDependencies) {
+				deps.sessionRepoMock.On("FindOne", ctx, hashToken("token")).Return(models.Session{AccountId: "0001", ExpiresAt: now.Add(time.Second)}, nil)
+			},
+			want: models.Principal{AccountId: "0001"},
+		},
+		"expired-session": {
+			token: "token",
+			doMocks: func(deps authServiceDependencies) {
+				deps.sessionRepoMock.On("FindOne", ctx, hashToken("token")).Return(models.Session{AccountId: "0001", ExpiresAt: now}, nil)
+			},
+			wantErr: ErrInvalidToken,
+		},
+		"unknown-token": {
+			token: "token",
+			doMocks: func(deps authServiceDependencies) {
+				deps.sessionRepoMock.On("FindOne", ctx, hashToken("token")).Return(models.Session{}, repository.ErrSessionNotFound)
+			},
+			wantErr: ErrInvalidToken,
+		},
+		"admin-token": {
+			token: "admin-token",
+			want:  models.Principal{Admin: true},
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			service, deps := setupAuthService(t)
+			if tcase.doMocks != nil {
+				tcase.doMocks(deps)
+			}
+
+			principal, err := service.Authenticate(ctx, tcase.token)
+
+			if tcase.wantErr != nil {
+				assert.ErrorIs(t, err, tcase.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tcase.want, principal)
+			}
+		})
+	}
+
+	t.Run("no-admin-token", func(t *testing.T) {
+		service, deps := setupAuthService(t)
+		service.adminToken = ""
+		deps.sessionRepoMock.On("FindOne", ctx, hashToken("")).Return(models.Session{}, repository.ErrSessionNotFound)
+
+		_, err := service.Authenticate(ctx, "")
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+}
+
+func TestAuthService_Logout(t *testing.T) {
+	ctx := context.Background()
+
+	service, deps := setupAuthService(t)
+	deps.sessionRepoMock.On("Remove", ctx, hashToken("token")).Return(nil)
+	deps.sessionRepoMock.On("Remove", ctx, hashToken("admin-token")).Return(repository.ErrSessionNotFound)
+
+	assert.NoError(t, service.Logout(ctx, "token"))
+	assert.NoError(t, service.Logout(ctx, "admin-token"))
+}
+
+func TestAuthService_ChangePassword(t *testing.T) {
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+	setupClock(now)
+	defer resetClock()
+
+	ctx := context.Background()
+	setupPasswordIterations(t)
+
+	hash, err := hashPassword("s3cret-pass")
+	require.NoError(t, err)
+
+	scenarios := map[string]struct {
+		given   models.PasswordReq
+		doMocks func(deps authServiceDependencies)
+		wantErr error
+	}{
+		"changed": {
+			given: models.PasswordReq{CurrentPassword: "s3cret-pass", Password: "n3w-s3cret"},
+			doMocks: func(deps authServiceDependencies) {
+				deps.credentialRepoMock.On("FindOne", ctx, "0001").Return(models.Credential{AccountId: "0001", PasswordHash: hash}, nil)
+				deps.credentialRepoMock.On("Save", ctx, mock.MatchedBy(func(c models.Credential) bool {
+					return c.AccountId == "0001" && checkPassword(c.PasswordHash, "n3w-s3cret") && c.UpdatedAt.Equal(now)
+				})).Return(nil)
+				deps.sessionRepoMock.On("RemoveByAccount", ctx, "0001").Return(nil)
+			},
+		},
+		"wrong-current-password": {
+			given: models.PasswordReq{CurrentPassword: "guess-pass", Password: "n3w-s3cret"},
+			doMocks: func(deps authServiceDependencies) {
+				deps.credentialRepoMock.On("FindOne", ctx, "0001").Return(models.Credential{AccountId: "0001", PasswordHash: hash}, nil)
+			},
+			wantErr: ErrWrongPassword,
+		},
+		"no-credential": {
+			given: models.PasswordReq{CurrentPassword: "s3cret-pass", Password: "n3w-s3cret"},
+			doMocks: func(deps authServiceDependencies) {
+				deps.credentialRepoMock.On("FindOne", ctx, "0001").Return(models.Credential{}, repository.ErrCredentialNotFound)
+			},
+			wantErr: ErrWrongPassword,
+		},
+		"new-password-too-short": {
+			given:   models.PasswordReq{CurrentPassword: "s3cret-pass", Password: "short"},
+			wantErr: ErrPasswordLength,
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			service, deps := setupAuthService(t)
+			if tcase.doMocks != nil {
+				tcase.doMocks(deps)
+			}
+
+			err := service.ChangePassword(ctx, "0001", tcase.given)
+
+			if tcase.wantErr != nil {
+				assert.ErrorIs(t, err, tcase.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAuthService_ResetPassword(t *testing.T) {
+	ctx := context.Background()
+	setupPasswordIterations(t)
+
+	t.Run("reset", func(t *testing.T) {
+		service, deps := setupAuthService(t)
+		deps.accRepoMock.On("FindOne", ctx, "0001").Return(models.Account{AccountId: "0001"}, nil)
+		deps.credentialRepoMock.On("Save", ctx, mock.MatchedBy(func(c models.Credential) bool {
+			return c.AccountId == "0001" && checkPassword(c.PasswordHash, "n3w-s3cret")
+		})).Return(nil)
+		deps.sessionRepoMock.On("RemoveByAccount", ctx, "0001").Return(nil)
+
+		assert.NoError(t, service.ResetPassword(ctx, "0001", models.PasswordReq{Password: "n3w-s3cret"}))
+	})
+
+	t.Run("unknown-account", func(t *testing.T) {
+		service, deps := setupAuthService(t)
+		deps.accRepoMock.On("FindOne", ctx, "0009").Return(models.Account{}, repository.ErrAccountNotFound)
+
+		err := service.ResetPassword(ctx, "0009", models.PasswordReq{Password: "n3w-s3cret"})
+		assert.ErrorIs(t, err, repository.ErrAccountNotFound)
+	})
+}
+
+type authServiceDependencies struct {
+	credentialRepoMock *repository.MockCredentialRepo
+	sessionRepoMock    *repository.MockSessionRepo
+	accRepoMock        *repository.MockAccountRepo
+	uowMock            *repository.MockUnitOfWork
+}
+
+func setupAuthService(t *testing.T) (*authServiceImpl, authServiceDependencies) {
+	deps := authServiceDependencies{
+		credentialRepoMock: repository.NewMockCredentialRepo(t),
+		sessionRepoMock:    repository.NewMockSessionRepo(t),
+		accRepoMock:        repository.NewMockAccountRepo(t),
+		uowMock:            repository.NewMockUnitOfWork(t),
+	}
+
+	deps.uowMock.EXPECT().Do(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		}).
+		Maybe()
+
+	return NewAuthService("admin-token", time.Hour, deps.credentialRepoMock, deps.sessionRepoMock, deps.accRepoMock, deps.uowMock), deps
+}
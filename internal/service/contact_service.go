@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gopay/internal/models"
+	"github.com/gopay/internal/repository"
+)
+
+var (
+	ErrInvalidContact      = errors.New("an account cannot be its own contact")
+	ErrInvalidContactLimit = fmt.Errorf("limit must be between 1 and %d", maxPageLimit)
+)
+
+// recentWindow is how far back payments make someone a recent counterparty.
+const recentWindow = 90 * 24 * time.Hour
+
+type ContactService interface {
+	Add(ctx context.Context, owner string, req models.ContactReq) (models.Contact, error)
+	Remove(ctx context.Context, owner string, accId string) error
+	SetFavorite(ctx context.Context, owner string, accId string, favorite bool) (models.Contact, error)
+	GetAll(ctx context.Context, owner string, filter models.ContactFilter) ([]models.ContactEntry, error)
+}
+
+var _ ContactService = (*contactServiceImpl)(nil)
+
+type contactServiceImpl struct {
+	contactRepo     repository.ContactRepo
+	accountRepo     repository.AccountRepo
+	transactionRepo repository.TransactionRepo
+}
+
+func NewContactService(contactRepo repository.ContactRepo, accountRepo repository.AccountRepo, transactionRepo repository.TransactionRepo) *contactServiceImpl {
+	return &contactServiceImpl{
+		contactRepo:     contactRepo,
+		accountRepo:     accountRepo,
+		transactionRepo: transactionRepo,
+	}
+}
+
+// Add saves req.Contact, an account id or a handle, to owner's contacts. Closed
+// accounts cannot be added.
+func (r *contactServiceImpl) Add(ctx context.Context, owner string, req models.ContactReq) (models.Contact, error) {
+	_, err := r.accountRepo.FindOne(ctx, owner)
+	if err != nil {
+		return models.Contact{}, err
+	}
+
+	acc, err := findAccountRef(ctx, r.accountRepo, req.Contact)
+	if err != nil {
+		return models.Contact{}, err
+	}
+
+	if acc.AccountId == owner {
+		return models.Contact{}, ErrInvalidContact
+	}
+
+	if acc.Status == models.AccountClosed {
+		return models.Contact{}, repository.ErrAccountNotFound
+	}
+
+	now := clockNow()
+	contact := models.Contact{
+		Owner:     owner,
+		AccountId: acc.AccountId,
+		Favorite:  req.Favorite,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	err = r.contactRepo.Add(ctx, contact)
+	if err != nil {
+		return models.Contact{}, err
+	}
+
+	return contact, nil
+}
+
+func (r *contactServiceImpl) Remove(ctx context.Context, owner string, accId string) error {
+	return r.contactRepo.Remove(ctx, owner, accId)
+}
+
+func (r *contactServiceImpl) SetFavorite(ctx context.Context, owner string, accId string, favorite bool) (models.Contact, error) {
+	return r.contactRepo.SetFavorite(ctx, owner, accId, favorite, clockNow())
+}
+
+// GetAll lists owner's saved contacts together with the accounts it paid or was paid by
+// over the last recentWindow. Favorites come first, then the most frequent
+// counterparties, then the most recent ones. Closed accounts are left out.
+func (r *contactServiceImpl) GetAll(ctx context.Context, owner string, filter models.ContactFilter) ([]models.ContactEntry, error) {
+	if filter.Limit == 0 {
+		filter.Limit = defaultPageLimit
+	}
+	if filter.Limit < 0 || filter.Limit > maxPageLimit {
+		return []models.ContactEntry{}, ErrInvalidContactLimit
+	}
+
+	_, err := r.accountRepo.FindOne(ctx, owner)
+	if err != nil {
+		return []models.ContactEntry{}, err
+	}
+
+	contacts, err := r.contactRepo.FindByOwner(ctx, owner)
+	if err != nil {
+		return []models.ContactEntry{}, err
+	}
+
+	counterparties, err := r.transactionRepo.FindCounterparties(ctx, owner, clockNow().Add(-recentWindow))
+	if err != nil {
+		return []models.ContactEntry{}, err
+	}
+
+	byAccount := map[string]*models.ContactEntry{}
+	entry := func(accId string) *models.ContactEntry {
+		e, found := byAccount[accId]
+		if !found {
+			e = &models.ContactEntry{Account: models.AccountSummary{AccountId: accId}}
+			byAccount[accId] = e
+		}
+		return e
+	}
+
+	for _, c := range contacts {
+		e := entry(c.AccountId)
+		e.Saved = true
+		e.Favorite = c.Favorite
+	}
+
+	for _, c := range counterparties {
+		e := entry(c.AccountId)
+		lastAt := c.LastAt
+		e.Payments = c.Payments
+		e.LastPaymentAt = &lastAt
+	}
+
+	entries := make([]models.ContactEntry, 0, len(byAccount))
+	for _, e := range byAccount {
+		if filter.Favorites && !e.Favorite {
+			continue
+		}
+		entries = append(entries, *e)
+	}
+	sortContacts(entries)
+
+	result := []models.ContactEntry{}
+	for _, e := range entries {
+		if len(result) == filter.Limit {
+			break
+		}
+
+		acc, err := r.accountRepo.FindOne(ctx, e.Account.AccountId)
+		if err == repository.ErrAccountNotFound || acc.Status == models.AccountClosed {
+			continue
+		}
+		if err != nil {
+			return []models.ContactEntry{}, err
+		}
+
+		e.Account = accountSummary(acc)
+		result = append(result, e)
+	}
+
+	return result, nil
+}
+
+func sortContacts(entries []models.ContactEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.Favorite != b.Favorite {
+			return a.Favorite
+		}
+		if a.Payments != b.Payments {
+			return a.Payments > b.Payments
+		}
+		if a.LastPaymentAt != nil && b.LastPaymentAt != nil && !a.LastPaymentAt.Equal(*b.LastPaymentAt) {
+			return a.LastPaymentAt.After(*b.LastPaymentAt)
+		}
+		return a.Account.AccountId < b.Account.AccountId
+	})
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gopay/internal/models"
+	"github.com/gopay/internal/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContactService_Add(t *testing.T) {
+	now := time.Now()
+	setupClock(now)
+	defer resetClock()
+
+	var (
+		ctx   = context.Background()
+		owner = "0001"
+	)
+
+	scenarios := map[string]struct {
+		given   models.ContactReq
+		doMocks func(deps contactServiceDependencies)
+		want    models.Contact
+		wantErr error
+	}{
+		"happy-path": {
+			given: models.ContactReq{Contact: "0002", Favorite: true},
+			doMocks: func(deps contactServiceDependencies) {
+				deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{AccountId: owner}, nil)
+				deps.accRepoMock.On("FindOne", ctx, "0002").Return(models.Account{AccountId: "0002"}, nil)
+				deps.contactRepoMock.On("Add", ctx, models.Contact{Owner: owner, AccountId: "0002", Favorite: true, CreatedAt: now, UpdatedAt: now}).Return(nil)
+			},
+			want:    models.Contact{Owner: owner, AccountId: "0002", Favorite: true, CreatedAt: now, UpdatedAt: now},
+			wantErr: nil,
+		},
+		"by-handle": {
+			given: models.ContactReq{Contact: "@rafa"},
+			doMocks: func(deps contactServiceDependencies) {
+				deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{AccountId: owner}, nil)
+				deps.accRepoMock.On("FindByHandle", ctx, "rafa").Return(models.Account{AccountId: "0003", Handle: "Rafa"}, nil)
+				deps.contactRepoMock.On("Add", ctx, models.Contact{Owner: owner, AccountId: "0003", CreatedAt: now, UpdatedAt: now}).Return(nil)
+			},
+			want:    models.Contact{Owner: owner, AccountId: "0003", CreatedAt: now, UpdatedAt: now},
+			wantErr: nil,
+		},
+		"self": {
+			given: models.ContactReq{Contact: "@me_myself"},
+			doMocks: func(deps contactServiceDependencies) {
+				deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{AccountId: owner}, nil)
+				deps.accRepoMock.On("FindByHandle", ctx, "me_myself").Return(models.Account{AccountId: owner}, nil)
+			},
+			want:    models.Contact{},
+			wantErr: ErrInvalidContact,
+		},
+		"closed-account": {
+			given: models.ContactReq{Contact: "0002"},
+			doMocks: func(deps contactServiceDependencies) {
+				deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{AccountId: owner}, nil)
+				deps.accRepoMock.On("FindOne", ctx, "0002").Return(models.Account{AccountId: "0002", Status: models.AccountClosed}, nil)
+			},
+			want:    models.Contact{},
+			wantErr: repository.ErrAccountNotFound,
+		},
+		"already-saved": {
+			given: models.ContactReq{Contact: "0002"},
+			doMocks: func(deps contactServiceDependencies) {
+				deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{AccountId: owner}, nil)
+				deps.accRepoMock.On("FindOne", ctx, "0002").Return(models.Account{AccountId: "0002"}, nil)
+				deps.contactRepoMock.On("Add", ctx, models.Contact{Owner: owner, AccountId: "0002", CreatedAt: now, UpdatedAt: now}).Return(repository.ErrContactExists)
+			},
+			want:    models.Contact{},
+			wantErr: repository.ErrContactExists,
+		},
+		"unknown-owner": {
+			given: models.ContactReq{Contact: "0002"},
+			doMocks: func(deps contactServiceDependencies) {
+				deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{}, repository.ErrAccountNotFound)
+			},
+			want:    models.Contact{},
+			wantErr: repository.ErrAccountNotFound,
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			service, deps := setupContactService(t)
+			if tcase.doMocks != nil {
+				tcase.doMocks(deps)
+			}
+
+			contact, err := service.Add(ctx, owner, tcase.given)
+
+			if tcase.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tcase.wantErr)
+			}
+			assert.Equal(t, tcase.want, contact)
+		})
+	}
+}
+
+func TestContactService_GetAll(t *testing.T) {
+	now := time.Now()
+	setupClock(now)
+	defer resetClock()
+
+	var (
+		ctx     = context.Background()
+		owner   = "0001"
+		since   = now.Add(-recentWindow)
+		earlier = now.Add(-time.Hour)
+		latest  = now.Add(-time.Minute)
+	)
+
+	scenarios := map[string]struct {
+		given   models.ContactFilter
+		doMocks func(deps contactServiceDependencies)
+		want    []models.ContactEntry
+		wantErr error
+	}{
+		"favorites-first-then-most-paid": {
+			given: models.ContactFilter{},
+			doMocks: func(deps contactServiceDependencies) {
+				deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{AccountId: owner}, nil)
+				deps.contactRepoMock.On("FindByOwner", ctx, owner).Return([]models.Contact{
+					{Owner: owner, AccountId: "0002"},
+					{Owner: owner, AccountId: "0005", Favorite: true},
+				}, nil)
+				deps.transRepoMock.On("FindCounterparties", ctx, owner, since).Return([]models.Counterparty{
+					{AccountId: "0003", Payments: 4, LastAt: earlier},
+					{AccountId: "0002", Payments: 1, LastAt: latest},
+					{AccountId: "0004", Payments: 1, LastAt: earlier},
+				}, nil)
+				deps.accRepoMock.On("FindOne", ctx, "0005").Return(models.Account{AccountId: "0005", Handle: "ana"}, nil)
+				deps.accRepoMock.On("FindOne", ctx, "0003").Return(models.Account{AccountId: "0003", Name: "Bia"}, nil)
+				deps.accRepoMock.On("FindOne", ctx, "0002").Return(models.Account{AccountId: "0002", Status: models.AccountClosed}, nil)
+				deps.accRepoMock.On("FindOne", ctx, "0004").Return(models.Account{AccountId: "0004", Email: "x@example.com"}, nil)
+			},
+			want: []models.ContactEntry{
+				{Account: models.AccountSummary{AccountId: "0005", Handle: "ana"}, Saved: true, Favorite: true},
+				{Account: models.AccountSummary{AccountId: "0003", Name: "Bia"}, Payments: 4, LastPaymentAt: &earlier},
+				{Account: models.AccountSummary{AccountId: "0004"}, Payments: 1, LastPaymentAt: &earlier},
+			},
+			wantErr: nil,
+		},
+		"favorites-only-with-limit": {
+			given: models.ContactFilter{Favorites: true, Limit: 1},
+			doMocks: func(deps contactServiceDependencies) {
+				deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{AccountId: owner}, nil)
+				deps.contactRepoMock.On("FindByOwner", ctx, owner).Return([]models.Contact{
+					{Owner: owner, AccountId: "0002", Favorite: true},
+					{Owner: owner, AccountId: "0003", Favorite: true},
+					{Owner: owner, AccountId: "0004"},
+				}, nil)
+				deps.transRepoMock.On("FindCounterparties", ctx, owner, since).Return([]models.Counterparty{
+					{AccountId: "0003", Payments: 2, LastAt: latest},
+				}, nil)
+				deps.accRepoMock.On("FindOne", ctx, "0003").Return(models.Account{AccountId: "0003"}, nil)
+			},
+			want: []models.ContactEntry{
+				{Account: models.AccountSummary{AccountId: "0003"}, Saved: true, Favorite: true, Payments: 2, LastPaymentAt: &latest},
+			},
+			wantErr: nil,
+		},
+		"invalid-limit": {
+			given:   models.ContactFilter{Limit: maxPageLimit + 1},
+			want:    []models.ContactEntry{},
+			wantErr: ErrInvalidContactLimit,
+		},
+		"unknown-owner": {
+			given: models.ContactFilter{},
+			doMocks: func(deps contactServiceDependencies) {
+				deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{}, repository.ErrAccountNotFound)
+			},
+			want:    []models.ContactEntry{},
+			wantErr: repository.ErrAccountNotFound,
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			service, deps := setupContactService(t)
+			if tcase.doMocks != nil {
+				tcase.doMocks(deps)
+			}
+
+			contacts, err := service.GetAll(ctx, owner, tcase.given)
+
+			if tcase.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tcase.wantErr)
+			}
+			assert.Equal(t, tcase.want, contacts)
+		})
+	}
+}
+
+type contactServiceDependencies struct {
+	contactRepoMock *repository.MockContactRepo
+	accRepoMock     *repository.MockAccountRepo
+	transRepoMock   *repository.MockTransactionRepo
+}
+
+func setupContactService(t *testing.T) (*contactServiceImpl, contactServiceDependencies) {
+	deps := contactServiceDependencies{
+		contactRepoMock: repository.NewMockContactRepo(t),
+		accRepoMock:     repository.NewMockAccountRepo(t),
+		transRepoMock:   repository.NewMockTransactionRepo(t),
+	}
+
+	return NewContactService(deps.contactRepoMock, deps.accRepoMock, deps.transRepoMock), deps
+}
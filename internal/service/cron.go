@@ -0,0 +1,147 @@
+package service
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidCron = errors.New("cron expression must have five fields: minute hour day-of-month month day-of-week")
+)
+
+// Cron schedules are looked up this far ahead at most, e.g. for "0 0 30 2 *".
+const maxCronLookahead = 5 * 365 * 24 * time.Hour
+
+// cronSchedule is a parsed five field cron expression. Each field is a bit set of the
+// values it matches. Fields support "*", lists, ranges and steps such as "1-5" or "*/15".
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domAny, dowAny                bool
+}
+
+func parseCron(expr string) (cronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return cronSchedule{}, ErrInvalidCron
+	}
+
+	var (
+		c   cronSchedule
+		err error
+	)
+
+	if c.minute, err = parseCronField(fields[0], 0, 59); err != nil {
+		return cronSchedule{}, err
+	}
+	if c.hour, err = parseCronField(fields[1], 0, 23); err != nil {
+		return cronSchedule{}, err
+	}
+	if c.dom, err = parseCronField(fields[2], 1, 31); err != nil {
+		return cronSchedule{}, err
+	}
+	if c.month, err = parseCronField(fields[3], 1, 12); err != nil {
+		return cronSchedule{}, err
+	}
+	// Both 0 and 7 stand for Sunday.
+	if c.dow, err = parseCronField(fields[4], 0, 7); err != nil {
+		return cronSchedule{}, err
+	}
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+
+	c.domAny = strings.HasPrefix(fields[2], "*")
+	c.dowAny = strings.HasPrefix(fields[4], "*")
+
+	return c, nil
+}
+
+func parseCronField(field string, min int, max int) (uint64, error) {
+	var bits uint64
+
+	for _, part := range strings.Split(field, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepStr)
+			if err != nil || step <= 0 {
+				return 0, ErrInvalidCron
+			}
+		}
+
+		lo, hi := min, max
+		switch {
+		case rng == "*":
+		case strings.Contains(rng, "-"):
+			loStr, hiStr, _ := strings.Cut(rng, "-")
+			var err1, err2 error
+			lo, err1 = strconv.Atoi(loStr)
+			hi, err2 = strconv.Atoi(hiStr)
+			if err1 != nil || err2 != nil {
+				return 0, ErrInvalidCron
+			}
+		default:
+			v, err := strconv.Atoi(rng)
+			if err != nil {
+				return 0, ErrInvalidCron
+			}
+			lo = v
+			if !hasStep {
+				hi = v
+			}
+		}
+
+		if lo < min || hi > max || lo > hi {
+			return 0, ErrInvalidCron
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+
+	return bits, nil
+}
+
+// next returns the first minute strictly after after that matches the schedule, in UTC.
+func (c cronSchedule) next(after time.Time) (time.Time, bool) {
+	t := after.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(maxCronLookahead)
+
+	for t.Before(limit) {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !c.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = t.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t, true
+	}
+
+	return time.Time{}, false
+}
+
+// matchesDay follows cron: when both day fields are restricted, either one matching is enough.
+func (c cronSchedule) matchesDay(t time.Time) bool {
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+
+	if !c.domAny && !c.dowAny {
+		return dom || dow
+	}
+	return dom && dow
+}
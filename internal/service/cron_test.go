@@ -0,0 +1,88 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCron_Next(t *testing.T) {
+	// A Wednesday.
+	after := time.Date(2024, 1, 31, 10, 30, 0, 0, time.UTC)
+
+	scenarios := map[string]struct {
+		expr    string
+		want    time.Time
+		wantErr error
+	}{
+		"every-minute": {
+			expr: "* * * * *",
+			want: time.Date(2024, 1, 31, 10, 31, 0, 0, time.UTC),
+		},
+		"every-quarter-hour": {
+			expr: "*/15 * * * *",
+			want: time.Date(2024, 1, 31, 10, 45, 0, 0, time.UTC),
+		},
+		"daily-at-nine": {
+			expr: "0 9 * * *",
+			want: time.Date(2024, 2, 1, 9, 0, 0, 0, time.UTC),
+		},
+		"weekdays-at-eight": {
+			expr: "0 8 * * 1-5",
+			want: time.Date(2024, 2, 1, 8, 0, 0, 0, time.UTC),
+		},
+		"sunday-as-seven": {
+			expr: "0 0 * * 7",
+			want: time.Date(2024, 2, 4, 0, 0, 0, 0, time.UTC),
+		},
+		"first-of-month": {
+			expr: "0 0 1 * *",
+			want: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+		},
+		"leap-day": {
+			expr: "0 12 29 2 *",
+			want: time.Date(2024, 2, 29, 12, 0, 0, 0, time.UTC),
+		},
+		"day-of-month-or-weekday": {
+			expr: "0 0 15 * 5",
+			want: time.Date(2024, 2, 2, 0, 0, 0, 0, time.UTC),
+		},
+		"too-few-fields": {
+			expr:    "0 9 * *",
+			wantErr: ErrInvalidCron,
+		},
+		"out-of-range": {
+			expr:    "61 * * * *",
+			wantErr: ErrInvalidCron,
+		},
+		"bad-step": {
+			expr:    "*/0 * * * *",
+			wantErr: ErrInvalidCron,
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			cron, err := parseCron(tcase.expr)
+			if tcase.wantErr != nil {
+				assert.ErrorIs(t, err, tcase.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+
+			next, found := cron.next(after)
+			assert.True(t, found)
+			assert.Equal(t, tcase.want, next)
+		})
+	}
+
+	t.Run("never-matches", func(t *testing.T) {
+		cron, err := parseCron("0 0 30 2 *")
+		assert.NoError(t, err)
+
+		_, found := cron.next(after)
+		assert.False(t, found)
+	})
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/gopay/internal/models"
+	"github.com/gopay/internal/repository"
+)
+
+const (
+	maxWaiverReasonLength = 255
+	bpsScale              = 10000
+)
+
+var (
+	ErrInvalidFeeWaiver     = errors.New("invalid fee waiver")
+	ErrInvalidFeeOperation  = fmt.Errorf("operation must be withdrawal, payment or all: %w", ErrInvalidFeeWaiver)
+	ErrWaiverReasonTooLong  = fmt.Errorf("reason must be at most %d characters: %w", maxWaiverReasonLength, ErrInvalidFeeWaiver)
+	ErrWaiverAlreadyExpired = fmt.Errorf("expiresAt must be in the future: %w", ErrInvalidFeeWaiver)
+)
+
+// FeeService prices withdrawals and payments with the fee schedule and keeps the
+// accounts' fee waivers.
+type FeeService interface {
+	Quote(ctx context.Context, accId string, operation models.FeeOperation, amount models.Money) (models.Money, error)
+	GetSchedule(ctx context.Context) models.FeeSchedule
+	GetWaivers(ctx context.Context, accId string) ([]models.FeeWaiver, error)
+	AddWaiver(ctx context.Context, accId string, req models.FeeWaiverReq) (models.FeeWaiver, error)
+	RemoveWaiver(ctx context.Context, accId string, operation models.FeeOperation) error
+}
+
+var _ FeeService = (*feeServiceImpl)(nil)
+
+type feeServiceImpl struct {
+	schedule      models.FeeSchedule
+	feeWaiverRepo repository.FeeWaiverRepo
+	accountRepo   repository.AccountRepo
+}
+
+func NewFeeService(schedule models.FeeSchedule, feeWaiverRepo repository.FeeWaiverRepo, accountRepo repository.AccountRepo) *feeServiceImpl {
+	return &feeServiceImpl{
+		schedule:      schedule,
+		feeWaiverRepo: feeWaiverRepo,
+		accountRepo:   accountRepo,
+	}
+}
+
+// Quote returns the fee accId pays on operation for amount, zero when the operation is
+// free or the account has a waiver for it that has not expired.
+func (r *feeServiceImpl) Quote(ctx context.Context, accId string, operation models.FeeOperation, amount models.Money) (models.Money, error) {
+	rule, found := r.schedule[operation]
+	if !found || amount <= 0 {
+		return 0, nil
+	}
+
+	waivers, err := r.feeWaiverRepo.FindByAccount(ctx, accId)
+	if err != nil {
+		return 0, err
+	}
+
+	now := clockNow()
+	for _, w := range waivers {
+		if (w.Operation == operation || w.Operation == models.FeeAll) && (w.ExpiresAt == nil || now.Before(*w.ExpiresAt)) {
+			return 0, nil
+		}
+	}
+
+	return fee(rule, amount), nil
+}
+
+// fee prices amount with rule. Percentages round half up to the cent.
+func fee(rule models.FeeRule, amount models.Money) models.Money {
+	flat, bps := rule.Flat, rule.Bps
+	for _, tier := range rule.Tiers {
+		if tier.UpTo == 0 || amount <= tier.UpTo {
+			flat, bps = tier.Flat, tier.Bps
+			break
+		}
+	}
+
+	// Splitting amount keeps amount*bps from overflowing.
+	whole, rest := amount/bpsScale, amount%bpsScale
+	f := flat + whole*models.Money(bps) + (rest*models.Money(bps)+bpsScale/2)/bpsScale
+	f = max(f, rule.Min)
+	if rule.Max > 0 {
+		f = min(f, rule.Max)
+	}
+
+	return f
+}
+
+func (r *feeServiceImpl) GetSchedule(_ context.Context) models.FeeSchedule {
+	return r.schedule
+}
+
+func (r *feeServiceImpl) GetWaivers(ctx context.Context, accId string) ([]models.FeeWaiver, error) {
+	_, err := r.accountRepo.FindOne(ctx, accId)
+	if err != nil {
+		return []models.FeeWaiver{}, err
+	}
+
+	return r.feeWaiverRepo.FindByAccount(ctx, accId)
+}
+
+// AddWaiver waives the fees of req.Operation for accId, replacing the waiver it may
+// already have for that operation.
+func (r *feeServiceImpl) AddWaiver(ctx context.Context, accId string, req models.FeeWaiverReq) (models.FeeWaiver, error) {
+	if !req.Operation.IsValid() {
+		return models.FeeWaiver{}, ErrInvalidFeeOperation
+	}
+
+	if utf8.RuneCountInString(req.Reason) > maxWaiverReasonLength {
+		return models.FeeWaiver{}, ErrWaiverReasonTooLong
+	}
+
+	now := clockNow()
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		return models.FeeWaiver{}, ErrWaiverAlreadyExpired
+	}
+
+	_, err := r.accountRepo.FindOne(ctx, accId)
+	if err != nil {
+		return models.FeeWaiver{}, err
+	}
+
+	waiver := models.FeeWaiver{
+		AccountId: accId,
+		Operation: req.Operation,
+		Reason:    req.Reason,
+		ExpiresAt: req.ExpiresAt,
+		CreatedAt: now,
+	}
+
+	err = r.feeWaiverRepo.Save(ctx, waiver)
+	if err != nil {
+		return models.FeeWaiver{}, err
+	}
+
+	return waiver, nil
+}
+
+func (r *feeServiceImpl) RemoveWaiver(ctx context.Context, accId string, operation models.FeeOperation) error {
+	_, err := r.accountRepo.FindOne(ctx, accId)
+	if err != nil {
+		return err
+	}
+
+	return r.feeWaiverRepo.Remove(ctx, accId, operation)
+}
@@ -0,0 +1,309 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package service
+
+import (
+	context "context"
+
+	models "github.com/gopay/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockFeeService is an autogenerated mock type for the FeeService type
+type MockFeeService struct {
+	mock.Mock
+}
+
+type MockFeeService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockFeeService) EXPECT() *MockFeeService_Expecter {
+	return &MockFeeService_Expecter{mock: &_m.Mock}
+}
+
+// AddWaiver provides a mock function with given fields: ctx, accId, req
+func (_m *MockFeeService) AddWaiver(ctx context.Context, accId string, req models.FeeWaiverReq) (models.FeeWaiver, error) {
+	ret := _m.Called(ctx, accId, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddWaiver")
+	}
+
+	var r0 models.FeeWaiver
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.FeeWaiverReq) (models.FeeWaiver, error)); ok {
+		return rf(ctx, accId, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.FeeWaiverReq) models.FeeWaiver); ok {
+		r0 = rf(ctx, accId, req)
+	} else {
+		r0 = ret.Get(0).(models.FeeWaiver)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.FeeWaiverReq) error); ok {
+		r1 = rf(ctx, accId, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFeeService_AddWaiver_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddWaiver'
+type MockFeeService_AddWaiver_Call struct {
+	*mock.Call
+}
+
+// AddWaiver is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accId string
+//   - req models.FeeWaiverReq
+func (_e *MockFeeService_Expecter) AddWaiver(ctx interface{}, accId interface{}, req interface{}) *MockFeeService_AddWaiver_Call {
+	return &MockFeeService_AddWaiver_Call{Call: _e.mock.On("AddWaiver", ctx, accId, req)}
+}
+
+func (_c *MockFeeService_AddWaiver_Call) Run(run func(ctx context.Context, accId string, req models.FeeWaiverReq)) *MockFeeService_AddWaiver_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.FeeWaiverReq))
+	})
+	return _c
+}
+
+func (_c *MockFeeService_AddWaiver_Call) Return(_a0 models.FeeWaiver, _a1 error) *MockFeeService_AddWaiver_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFeeService_AddWaiver_Call) RunAndReturn(run func(context.Context, string, models.FeeWaiverReq) (models.FeeWaiver, error)) *MockFeeService_AddWaiver_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSchedule provides a mock function with given fields: ctx
+func (_m *MockFeeService) GetSchedule(ctx context.Context) models.FeeSchedule {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSchedule")
+	}
+
+	var r0 models.FeeSchedule
+	if rf, ok := ret.Get(0).(func(context.Context) models.FeeSchedule); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(models.FeeSchedule)
+		}
+	}
+
+	return r0
+}
+
+// MockFeeService_GetSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSchedule'
+type MockFeeService_GetSchedule_Call struct {
+	*mock.Call
+}
+
+// GetSchedule is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockFeeService_Expecter) GetSchedule(ctx interface{}) *MockFeeService_GetSchedule_Call {
+	return &MockFeeService_GetSchedule_Call{Call: _e.mock.On("GetSchedule", ctx)}
+}
+
+func (_c *MockFeeService_GetSchedule_Call) Run(run func(ctx context.Context)) *MockFeeService_GetSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockFeeService_GetSchedule_Call) Return(_a0 models.FeeSchedule) *MockFeeService_GetSchedule_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockFeeService_GetSchedule_Call) RunAndReturn(run func(context.Context) models.FeeSchedule) *MockFeeService_GetSchedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWaivers provides a mock function with given fields: ctx, accId
+func (_m *MockFeeService) GetWaivers(ctx context.Context, accId string) ([]models.FeeWaiver, error) {
+	ret := _m.Called(ctx, accId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWaivers")
+	}
+
+	var r0 []models.FeeWaiver
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.FeeWaiver, error)); ok {
+		return rf(ctx, accId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.FeeWaiver); ok {
+		r0 = rf(ctx, accId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.FeeWaiver)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, accId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFeeService_GetWaivers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWaivers'
+type MockFeeService_GetWaivers_Call struct {
+	*mock.Call
+}
+
+// GetWaivers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accId string
+func (_e *MockFeeService_Expecter) GetWaivers(ctx interface{}, accId interface{}) *MockFeeService_GetWaivers_Call {
+	return &MockFeeService_GetWaivers_Call{Call: _e.mock.On("GetWaivers", ctx, accId)}
+}
+
+func (_c *MockFeeService_GetWaivers_Call) Run(run func(ctx context.Context, accId string)) *MockFeeService_GetWaivers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockFeeService_GetWaivers_Call) Return(_a0 []models.FeeWaiver, _a1 error) *MockFeeService_GetWaivers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFeeService_GetWaivers_Call) RunAndReturn(run func(context.Context, string) ([]models.FeeWaiver, error)) *MockFeeService_GetWaivers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Quote provides a mock function with given fields: ctx, accId, operation, amount
+func (_m *MockFeeService) Quote(ctx context.Context, accId string, operation models.FeeOperation, amount models.Money) (models.Money, error) {
+	ret := _m.Called(ctx, accId, operation, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Quote")
+	}
+
+	var r0 models.Money
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.FeeOperation, models.Money) (models.Money, error)); ok {
+		return rf(ctx, accId, operation, amount)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.FeeOperation, models.Money) models.Money); ok {
+		r0 = rf(ctx, accId, operation, amount)
+	} else {
+		r0 = ret.Get(0).(models.Money)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.FeeOperation, models.Money) error); ok {
+		r1 = rf(ctx, accId, operation, amount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockFeeService_Quote_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Quote'
+type MockFeeService_Quote_Call struct {
+	*mock.Call
+}
+
+// Quote is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accId string
+//   - operation models.FeeOperation
+//   - amount models.Money
+func (_e *MockFeeService_Expecter) Quote(ctx interface{}, accId interface{}, operation interface{}, amount interface{}) *MockFeeService_Quote_Call {
+	return &MockFeeService_Quote_Call{Call: _e.mock.On("Quote", ctx, accId, operation, amount)}
+}
+
+func (_c *MockFeeService_Quote_Call) Run(run func(ctx context.Context, accId string, operation models.FeeOperation, amount models.Money)) *MockFeeService_Quote_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.FeeOperation), args[3].(models.Money))
+	})
+	return _c
+}
+
+func (_c *MockFeeService_Quote_Call) Return(_a0 models.Money, _a1 error) *MockFeeService_Quote_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockFeeService_Quote_Call) RunAndReturn(run func(context.Context, string, models.FeeOperation, models.Money) (models.Money, error)) *MockFeeService_Quote_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveWaiver provides a mock function with given fields: ctx, accId, operation
+func (_m *MockFeeService) RemoveWaiver(ctx context.Context, accId string, operation models.FeeOperation) error {
+	ret := _m.Called(ctx, accId, operation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveWaiver")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.FeeOperation) error); ok {
+		r0 = rf(ctx, accId, operation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockFeeService_RemoveWaiver_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveWaiver'
+type MockFeeService_RemoveWaiver_Call struct {
+	*mock.Call
+}
+
+// RemoveWaiver is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accId string
+//   - operation models.FeeOperation
+func (_e *MockFeeService_Expecter) RemoveWaiver(ctx interface{}, accId interface{}, operation interface{}) *MockFeeService_RemoveWaiver_Call {
+	return &MockFeeService_RemoveWaiver_Call{Call: _e.mock.On("RemoveWaiver", ctx, accId, operation)}
+}
+
+func (_c *MockFeeService_RemoveWaiver_Call) Run(run func(ctx context.Context, accId string, operation models.FeeOperation)) *MockFeeService_RemoveWaiver_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.FeeOperation))
+	})
+	return _c
+}
+
+func (_c *MockFeeService_RemoveWaiver_Call) Return(_a0 error) *MockFeeService_RemoveWaiver_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockFeeService_RemoveWaiver_Call) RunAndReturn(run func(context.Context, string, models.FeeOperation) error) *MockFeeService_RemoveWaiver_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockFeeService creates a new instance of MockFeeService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockFeeService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockFeeService {
+	mock := &MockFeeService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
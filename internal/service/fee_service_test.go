@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gopay/internal/models"
+	"github.com/gopay/internal/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFee(t *testing.T) {
+	tiered := models.FeeRule{
+		Tiers: []models.FeeTier{
+			{UpTo: 2000},
+			{UpTo: 10000, Flat: 25},
+			{Bps: 100},
+		},
+		Max: 500,
+	}
+
+	scenarios := map[string]struct {
+		rule   models.FeeRule
+		amount models.Money
+		want   models.Money
+	}{
+		"flat":                {rule: models.FeeRule{Flat: 50}, amount: 12345, want: 50},
+		"percent":             {rule: models.FeeRule{Bps: 150}, amount: 10000, want: 150},
+		"percent-rounds-up":   {rule: models.FeeRule{Bps: 150}, amount: 1033, want: 15},
+		"percent-rounds-down": {rule: models.FeeRule{Bps: 150}, amount: 1000, want: 15},
+		"flat-and-percent":    {rule: models.FeeRule{Flat: 100, Bps: 150}, amount: 10000, want: 250},
+		"min":                 {rule: models.FeeRule{Bps: 100, Min: 50}, amount: 1000, want: 50},
+		"max":                 {rule: models.FeeRule{Bps: 100, Max: 1000}, amount: 500000, want: 1000},
+		"first-tier":          {rule: tiered, amount: 2000, want: 0},
+		"second-tier":         {rule: tiered, amount: 2001, want: 25},
+		"last-tier":           {rule: tiered, amount: 30000, want: 300},
+		"last-tier-capped":    {rule: tiered, amount: 90000, want: 500},
+		"no-overflow":         {rule: models.FeeRule{Bps: 10000}, amount: 9223372036854775807, want: 9223372036854775807},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tcase.want, fee(tcase.rule, tcase.amount))
+		})
+	}
+}
+
+func TestFeeService_Quote(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		now   = time.Now()
+		owner = "0001"
+	)
+
+	setupClock(now)
+	defer resetClock()
+
+	later := now.Add(time.Hour)
+	earlier := now.Add(-time.Hour)
+
+	scenarios := map[string]struct {
+		operation models.FeeOperation
+		amount    models.Money
+		doMocks   func(deps feeServiceDependencies)
+		want      models.Money
+	}{
+		"charged": {
+			operation: models.FeeWithdrawal,
+			amount:    10000,
+			doMocks: func(deps feeServiceDependencies) {
+				deps.feeWaiverRepoMock.On("FindByAccount", ctx, owner).Return([]models.FeeWaiver{}, nil)
+			},
+			want: 250,
+		},
+		"free-operation": {
+			operation: models.FeePayment,
+			amount:    10000,
+			want:      0,
+		},
+		"waived": {
+			operation: models.FeeWithdrawal,
+			amount:    10000,
+			doMocks: func(deps feeServiceDependencies) {
+				deps.feeWaiverRepoMock.On("FindByAccount", ctx, owner).Return([]models.FeeWaiver{
+					{AccountId: owner, Operation: models.FeeWithdrawal, ExpiresAt: &later},
+				}, nil)
+			},
+			want: 0,
+		},
+		"all-waived": {
+			operation: models.FeeWithdrawal,
+			amount:    10000,
+			doMocks: func(deps feeServiceDependencies) {
+				deps.feeWaiverRepoMock.On("FindByAccount", ctx, owner).Return([]models.FeeWaiver{
+					{AccountId: owner, Operation: models.FeeAll},
+				}, nil)
+			},
+			want: 0,
+		},
+		"waiver-expired": {
+			operation: models.FeeWithdrawal,
+			amount:    10000,
+			doMocks: func(deps feeServiceDependencies) {
+				deps.feeWaiverRepoMock.On("FindByAccount", ctx, owner).Return([]models.FeeWaiver{
+					{AccountId: owner, Operation: models.FeeWithdrawal, ExpiresAt: &earlier},
+					{AccountId: owner, Operation: models.FeePayment},
+				}, nil)
+			},
+			want: 250,
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			service, deps := setupFeeService(t)
+			if tcase.doMocks != nil {
+				tcase.doMocks(deps)
+			}
+
+			fee, err := service.Quote(ctx, owner, tcase.operation, tcase.amount)
+			assert.NoError(t, err)
+			assert.Equal(t, tcase.want, fee)
+		})
+	}
+}
+
+func TestFeeService_AddWaiver(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		now   = time.Now()
+		owner = "0001"
+	)
+
+	setupClock(now)
+	defer resetClock()
+
+	later := now.Add(time.Hour)
+
+	scenarios := map[string]struct {
+		given   models.FeeWaiverReq
+		doMocks func(deps feeServiceDependencies)
+		want    models.FeeWaiver
+		wantErr error
+	}{
+		"happy-path": {
+			given: models.FeeWaiverReq{Operation: models.FeePayment, Reason: "promo", ExpiresAt: &later},
+			doMocks: func(deps feeServiceDependencies) {
+				deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{AccountId: owner}, nil)
+				deps.feeWaiverRepoMock.On("Save", ctx, models.FeeWaiver{
+					AccountId: owner, Operation: models.FeePayment, Reason: "promo", ExpiresAt: &later, CreatedAt: now,
+				}).Return(nil)
+			},
+			want:    models.FeeWaiver{AccountId: owner, Operation: models.FeePayment, Reason: "promo", ExpiresAt: &later, CreatedAt: now},
+			wantErr: nil,
+		},
+		"invalid-operation": {
+			given:   models.FeeWaiverReq{Operation: "fx"},
+			want:    models.FeeWaiver{},
+			wantErr: ErrInvalidFeeOperation,
+		},
+		"reason-too-long": {
+			given:   models.FeeWaiverReq{Operation: models.FeeAll, Reason: strings.Repeat("a", maxWaiverReasonLength+1)},
+			want:    models.FeeWaiver{},
+			wantErr: ErrWaiverReasonTooLong,
+		},
+		"already-expired": {
+			given:   models.FeeWaiverReq{Operation: models.FeeAll, ExpiresAt: &now},
+			want:    models.FeeWaiver{},
+			wantErr: ErrWaiverAlreadyExpired,
+		},
+		"unknown-account": {
+			given: models.FeeWaiverReq{Operation: models.FeeAll},
+			doMocks: func(deps feeServiceDependencies) {
+				deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{}, repository.ErrAccountNotFound)
+			},
+			want:    models.FeeWaiver{},
+			wantErr: repository.ErrAccountNotFound,
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			service, deps := setupFeeService(t)
+			if tcase.doMocks != nil {
+				tcase.doMocks(deps)
+			}
+
+			waiver, err := service.AddWaiver(ctx, owner, tcase.given)
+
+			if tcase.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tcase.wantErr)
+			}
+			assert.Equal(t, tcase.want, waiver)
+		})
+	}
+}
+
+type feeServiceDependencies struct {
+	feeWaiverRepoMock *repository.MockFeeWaiverRepo
+	accRepoMock       *repository.MockAccountRepo
+}
+
+func setupFeeService(t *testing.T) (*feeServiceImpl, feeServiceDependencies) {
+	deps := feeServiceDependencies{
+		feeWaiverRepoMock: repository.NewMockFeeWaiverRepo(t),
+		accRepoMock:       repository.NewMockAccountRepo(t),
+	}
+
+	schedule := models.FeeSchedule{
+		models.FeeWithdrawal: {Flat: 100, Bps: 150},
+	}
+
+	return NewFeeService(schedule, deps.feeWaiverRepoMock, deps.accRepoMock), deps
+}
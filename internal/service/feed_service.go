@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gopay/internal/models"
+	"github.com/gopay/internal/repository"
+)
+
+var (
+	ErrInvalidFeedLimit = fmt.Errorf("limit must be between 1 and %d", maxPageLimit)
+)
+
+type FeedService interface {
+	GetFeed(ctx context.Context, filter models.FeedFilter) (models.FeedPage, error)
+}
+
+var _ FeedService = (*feedServiceImpl)(nil)
+
+type feedServiceImpl struct {
+	transactionRepo repository.TransactionRepo
+	accountRepo     repository.AccountRepo
+	contactRepo     repository.ContactRepo
+}
+
+func NewFeedService(transactionRepo repository.TransactionRepo, accountRepo repository.AccountRepo, contactRepo repository.ContactRepo) *feedServiceImpl {
+	return &feedServiceImpl{
+		transactionRepo: transactionRepo,
+		accountRepo:     accountRepo,
+		contactRepo:     contactRepo,
+	}
+}
+
+// GetFeed returns the page of payments filter.Viewer may see: the public ones, the
+// friends-only ones of the accounts that saved the viewer as a contact and the viewer's
+// own. Amounts are left out unless the viewer sent or received the payment.
+func (r *feedServiceImpl) GetFeed(ctx context.Context, filter models.FeedFilter) (models.FeedPage, error) {
+	if filter.Limit == 0 {
+		filter.Limit = defaultPageLimit
+	}
+	if filter.Limit < 0 || filter.Limit > maxPageLimit {
+		return models.FeedPage{}, ErrInvalidFeedLimit
+	}
+
+	filter.Friends = nil
+	if filter.Viewer != "" {
+		_, err := r.accountRepo.FindOne(ctx, filter.Viewer)
+		if err != nil {
+			return models.FeedPage{}, err
+		}
+
+		filter.Friends, err = r.contactRepo.FindOwners(ctx, filter.Viewer)
+		if err != nil {
+			return models.FeedPage{}, err
+		}
+	}
+
+	if filter.Account != "" {
+		_, err := r.accountRepo.FindOne(ctx, filter.Account)
+		if err != nil {
+			return models.FeedPage{}, err
+		}
+	}
+
+	page, err := r.transactionRepo.FindFeed(ctx, filter)
+	if err != nil {
+		return models.FeedPage{}, err
+	}
+
+	summaries := map[string]models.AccountSummary{}
+	summary := func(id string) (models.AccountSummary, error) {
+		s, found := summaries[id]
+		if found {
+			return s, nil
+		}
+
+		acc, err := r.accountRepo.FindOne(ctx, id)
+		if err != nil {
+			return models.AccountSummary{}, err
+		}
+
+		s = accountSummary(acc)
+		summaries[id] = s
+		return s, nil
+	}
+
+	feed := models.FeedPage{Items: []models.FeedItem{}, NextCursor: page.NextCursor}
+	for _, t := range page.Transactions {
+		item := models.FeedItem{
+			TransactionId: t.TransactionId,
+			Memo:          t.Memo,
+			Privacy:       t.Privacy,
+			CreatedAt:     t.CreatedAt,
+		}
+
+		item.Sender, err = summary(t.Sender)
+		if err != nil {
+			return models.FeedPage{}, err
+		}
+
+		item.Receiver, err = summary(t.Receiver)
+		if err != nil {
+			return models.FeedPage{}, err
+		}
+
+		if filter.Viewer != "" && (t.Sender == filter.Viewer || t.Receiver == filter.Viewer) {
+			amount := t.Amount.Abs()
+			item.Amount = &amount
+		}
+
+		feed.Items = append(feed.Items, item)
+	}
+
+	return feed, nil
+}
+
+// defaultPrivacy is the privacy of the payments acc makes without choosing one.
+func defaultPrivacy(acc models.Account) models.Privacy {
+	if acc.DefaultPrivacy.IsValid() {
+		return acc.DefaultPrivacy
+	}
+	return models.PrivacyPrivate
+}
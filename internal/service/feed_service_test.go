@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gopay/internal/models"
+	"github.com/gopay/internal/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeedService_GetFeed(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		now    = time.Now()
+		viewer = "0001"
+		friend = "0002"
+		other  = "0003"
+	)
+
+	amount := func(m models.Money) *models.Money { return &m }
+
+	accounts := func(deps feedServiceDependencies) {
+		deps.accRepoMock.On("FindOne", ctx, viewer).Return(models.Account{AccountId: viewer, Name: "Ana", Email: "ana@example.com"}, nil).Maybe()
+		deps.accRepoMock.On("FindOne", ctx, friend).Return(models.Account{AccountId: friend, Name: "Bia", Handle: "bia"}, nil).Maybe()
+		deps.accRepoMock.On("FindOne", ctx, other).Return(models.Account{AccountId: other, Name: "Cai"}, nil).Maybe()
+	}
+
+	payments := []models.Transaction{
+		{TransactionId: "t-2", Owner: friend, Sender: friend, Receiver: other, Amount: -2500, Kind: models.KindPaymentOut, Memo: "pizza", Privacy: models.PrivacyFriends, CreatedAt: now},
+		{TransactionId: "t-1", Owner: viewer, Sender: viewer, Receiver: friend, Amount: -1000, Kind: models.KindPaymentOut, Memo: "rent", Privacy: models.PrivacyPrivate, CreatedAt: now.Add(-time.Hour)},
+	}
+
+	scenarios := map[string]struct {
+		given   models.FeedFilter
+		doMocks func(deps feedServiceDependencies)
+		want    models.FeedPage
+		wantErr error
+	}{
+		"amounts-only-for-participants": {
+			given: models.FeedFilter{Viewer: viewer, Limit: 2, Friends: []string{"ignored"}},
+			doMocks: func(deps feedServiceDependencies) {
+				accounts(deps)
+				deps.contactRepoMock.On("FindOwners", ctx, viewer).Return([]string{friend}, nil)
+				deps.transRepoMock.On("FindFeed", ctx, models.FeedFilter{Viewer: viewer, Limit: 2, Friends: []string{friend}}).
+					Return(models.TransactionPage{Transactions: payments, NextCursor: "next"}, nil)
+			},
+			want: models.FeedPage{
+				Items: []models.FeedItem{
+					{
+						TransactionId: "t-2",
+						Sender:        models.AccountSummary{AccountId: friend, Name: "Bia", Handle: "bia"},
+						Receiver:      models.AccountSummary{AccountId: other, Name: "Cai"},
+						Memo:          "pizza",
+						Privacy:       models.PrivacyFriends,
+						CreatedAt:     now,
+					},
+					{
+						TransactionId: "t-1",
+						Sender:        models.AccountSummary{AccountId: viewer, Name: "Ana"},
+						Receiver:      models.AccountSummary{AccountId: friend, Name: "Bia", Handle: "bia"},
+						Amount:        amount(1000),
+						Memo:          "rent",
+						Privacy:       models.PrivacyPrivate,
+						CreatedAt:     now.Add(-time.Hour),
+					},
+				},
+				NextCursor: "next",
+			},
+			wantErr: nil,
+		},
+		"anonymous": {
+			given: models.FeedFilter{Account: other},
+			doMocks: func(deps feedServiceDependencies) {
+				accounts(deps)
+				deps.transRepoMock.On("FindFeed", ctx, models.FeedFilter{Account: other, Limit: defaultPageLimit}).
+					Return(models.TransactionPage{Transactions: []models.Transaction{}}, nil)
+			},
+			want:    models.FeedPage{Items: []models.FeedItem{}},
+			wantErr: nil,
+		},
+		"unknown-viewer": {
+			given: models.FeedFilter{Viewer: "0009"},
+			doMocks: func(deps feedServiceDependencies) {
+				deps.accRepoMock.On("FindOne", ctx, "0009").Return(models.Account{}, repository.ErrAccountNotFound)
+			},
+			want:    models.FeedPage{},
+			wantErr: repository.ErrAccountNotFound,
+		},
+		"unknown-account": {
+			given: models.FeedFilter{Account: "0009"},
+			doMocks: func(deps feedServiceDependencies) {
+				deps.accRepoMock.On("FindOne", ctx, "0009").Return(models.Account{}, repository.ErrAccountNotFound)
+			},
+			want:    models.FeedPage{},
+			wantErr: repository.ErrAccountNotFound,
+		},
+		"invalid-limit": {
+			given:   models.FeedFilter{Limit: -1},
+			want:    models.FeedPage{},
+			wantErr: ErrInvalidFeedLimit,
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			service, deps := setupFeedService(t)
+			if tcase.doMocks != nil {
+				tcase.doMocks(deps)
+			}
+
+			page, err := service.GetFeed(ctx, tcase.given)
+
+			if tcase.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tcase.wantErr)
+			}
+			assert.Equal(t, tcase.want, page)
+		})
+	}
+}
+
+type feedServiceDependencies struct {
+	transRepoMock   *repository.MockTransactionRepo
+	accRepoMock     *repository.MockAccountRepo
+	contactRepoMock *repository.MockContactRepo
+}
+
+func setupFeedService(t *testing.T) (*feedServiceImpl, feedServiceDependencies) {
+	deps := feedServiceDependencies{
+		transRepoMock:   repository.NewMockTransactionRepo(t),
+		accRepoMock:     repository.NewMockAccountRepo(t),
+		contactRepoMock: repository.NewMockContactRepo(t),
+	}
+
+	return NewFeedService(deps.transRepoMock, deps.accRepoMock, deps.contactRepoMock), deps
+}
@@ -0,0 +1,296 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gopay/internal/models"
+	"github.com/gopay/internal/repository"
+)
+
+var (
+	ErrHoldExpired        = errors.New("hold has expired")
+	ErrHoldForbidden      = errors.New("hold belongs to other accounts")
+	ErrHoldMerchantOnly   = errors.New("only the merchant can capture or void a hold")
+	ErrCaptureExceedsHold = errors.New("capture exceeds the amount held")
+	ErrInvalidExpiry      = fmt.Errorf("expiry must be in the future and at most %d days away", maxHoldTTL/(24*time.Hour))
+)
+
+const (
+	holdTTL    = 7 * 24 * time.Hour
+	maxHoldTTL = 30 * 24 * time.Hour
+)
+
+type HoldService interface {
+	Authorize(ctx context.Context, owner string, req models.HoldReq) (models.Hold, error)
+	Capture(ctx context.Context, id string, accId string, amount models.Money) (models.Hold, error)
+	Void(ctx context.Context, id string, accId string) (models.Hold, error)
+	Get(ctx context.Context, id string, accId string) (models.Hold, error)
+	GetAll(ctx context.Context, owner string, status models.HoldStatus) ([]models.Hold, error)
+}
+
+var _ HoldService = (*holdServiceImpl)(nil)
+
+type holdServiceImpl struct {
+	holdRepo        repository.HoldRepo
+	accountRepo     repository.AccountRepo
+	transactionRepo repository.TransactionRepo
+	transactionSvc  TransactionService
+	uow             repository.UnitOfWork
+}
+
+func NewHoldService(holdRepo repository.HoldRepo, accountRepo repository.AccountRepo, transactionRepo repository.TransactionRepo, transactionSvc TransactionService, uow repository.UnitOfWork) *holdServiceImpl {
+	return &holdServiceImpl{
+		holdRepo:        holdRepo,
+		accountRepo:     accountRepo,
+		transactionRepo: transactionRepo,
+		transactionSvc:  transactionSvc,
+		uow:             uow,
+	}
+}
+
+// Authorize reserves req.Amount of owner's available balance for req.Merchant. The
+// balance is checked under the owner's account lock, so concurrent holds and debits
+// cannot reserve the same money twice.
+func (r *holdServiceImpl) Authorize(ctx context.Context, owner string, req models.HoldReq) (models.Hold, error) {
+	if req.Amount <= 0 {
+		return models.Hold{}, ErrInvalidAmount
+	}
+
+	if owner == req.Merchant {
+		return models.Hold{}, ErrInvalidPaymentOp
+	}
+
+	note, err := sanitizeAnnotation(models.Annotation{Memo: req.Memo})
+	if err != nil {
+		return models.Hold{}, err
+	}
+
+	now := clockNow()
+	expiresAt := req.ExpiresAt
+	if expiresAt.IsZero() {
+		expiresAt = now.Add(holdTTL)
+	}
+
+	if !expiresAt.After(now) || expiresAt.After(now.Add(maxHoldTTL)) {
+		return models.Hold{}, ErrInvalidExpiry
+	}
+
+	merchant, err := r.accountRepo.FindOne(ctx, req.Merchant)
+	if err != nil {
+		return models.Hold{}, err
+	}
+
+	err = canReceive(merchant)
+	if err != nil {
+		return models.Hold{}, err
+	}
+
+	hold := models.Hold{
+		Owner:     owner,
+		Merchant:  req.Merchant,
+		Amount:    req.Amount,
+		Memo:      note.Memo,
+		CreatedAt: now,
+		ExpiresAt: expiresAt,
+	}
+
+	err = r.uow.Do(ctx, func(ctx context.Context) error {
+		err := r.transactionRepo.LockAccount(ctx, owner)
+		if err != nil {
+			return err
+		}
+
+		// Read under the lock, so a freeze or close that got it first is seen.
+		acc, err := r.accountRepo.FindOne(ctx, owner)
+		if err != nil {
+			return err
+		}
+
+		err = canSend(acc)
+		if err != nil {
+			return err
+		}
+
+		balance, err := r.transactionSvc.GetBalance(ctx, owner)
+		if err != nil {
+			return err
+		}
+
+		if balance.Available < req.Amount {
+			return ErrInsufficentBalance
+		}
+
+		hold.HoldId, err = r.holdRepo.Create(ctx, hold)
+		return err
+	})
+	if err != nil {
+		return models.Hold{}, err
+	}
+
+	hold.Status = models.HoldActive
+	hold.UpdatedAt = now
+
+	return hold, nil
+}
+
+// Capture pays amount of the hold to its merchant, or all of it if amount is zero, and
+// releases the rest. The hold is resolved in the same unit of work as the payment, so a
+// failed payment leaves it active and a hold cannot be captured twice.
+func (r *holdServiceImpl) Capture(ctx context.Context, id string, accId string, amount models.Money) (models.Hold, error) {
+	if amount < 0 {
+		return models.Hold{}, ErrInvalidAmount
+	}
+
+	hold, err := r.active(ctx, id, accId)
+	if err != nil {
+		return models.Hold{}, err
+	}
+
+	if amount == 0 {
+		amount = hold.Amount
+	}
+
+	if amount > hold.Amount {
+		return models.Hold{}, ErrCaptureExceedsHold
+	}
+
+	now := clockNow()
+	err = r.uow.Do(ctx, func(ctx context.Context) error {
+		err := r.holdRepo.Resolve(ctx, id, models.HoldCaptured, amount, now)
+		if err != nil {
+			return err
+		}
+
+		note := models.Annotation{
+			Memo:     hold.Memo,
+			Metadata: models.Metadata{"hold_id": id},
+		}
+
+		_, err = r.transactionSvc.Pay(ctx, hold.Owner, hold.Merchant, amount, note)
+		return err
+	})
+	if err != nil {
+		return models.Hold{}, err
+	}
+
+	hold.Status = models.HoldCaptured
+	hold.Captured = amount
+	hold.UpdatedAt = now
+
+	return hold, nil
+}
+
+// Void releases the whole hold without paying the merchant.
+func (r *holdServiceImpl) Void(ctx context.Context, id string, accId string) (models.Hold, error) {
+	hold, err := r.active(ctx, id, accId)
+	if err != nil {
+		return models.Hold{}, err
+	}
+
+	now := clockNow()
+	err = r.holdRepo.Resolve(ctx, id, models.HoldVoided, 0, now)
+	if err != nil {
+		return models.Hold{}, err
+	}
+
+	hold.Status = models.HoldVoided
+	hold.UpdatedAt = now
+
+	return hold, nil
+}
+
+func (r *holdServiceImpl) Get(ctx context.Context, id string, accId string) (models.Hold, error) {
+	hold, err := r.holdRepo.FindOne(ctx, id)
+	if err != nil {
+		return models.Hold{}, err
+	}
+
+	if !holdParty(hold, accId) {
+		return models.Hold{}, ErrHoldForbidden
+	}
+
+	return holdsWithExpiry([]models.Hold{hold}, "")[0], nil
+}
+
+// GetAll lists the holds on owner's account, newest first. An empty status matches any.
+func (r *holdServiceImpl) GetAll(ctx context.Context, owner string, status models.HoldStatus) ([]models.Hold, error) {
+	_, err := r.accountRepo.FindOne(ctx, owner)
+	if err != nil {
+		return []models.Hold{}, err
+	}
+
+	holds, err := r.holdRepo.FindByOwner(ctx, owner, storedHoldStatus(status))
+	if err != nil {
+		return []models.Hold{}, err
+	}
+
+	return holdsWithExpiry(holds, status), nil
+}
+
+// active loads a hold that accId is the merchant of and that can still be captured or
+// voided. The owner cannot settle its own hold, it gets the money back when the hold
+// expires. An active hold found past its expiry is marked expired on the way.
+func (r *holdServiceImpl) active(ctx context.Context, id string, accId string) (models.Hold, error) {
+	hold, err := r.holdRepo.FindOne(ctx, id)
+	if err != nil {
+		return models.Hold{}, err
+	}
+
+	if !holdParty(hold, accId) {
+		return models.Hold{}, ErrHoldForbidden
+	}
+
+	if hold.Merchant != accId {
+		return models.Hold{}, ErrHoldMerchantOnly
+	}
+
+	if hold.Status != models.HoldActive {
+		return models.Hold{}, repository.ErrHoldNotActive
+	}
+
+	if !clockNow().Before(hold.ExpiresAt) {
+		err = r.holdRepo.Resolve(ctx, id, models.HoldExpired, 0, clockNow())
+		if err != nil && err != repository.ErrHoldNotActive {
+			return models.Hold{}, err
+		}
+		return models.Hold{}, ErrHoldExpired
+	}
+
+	return hold, nil
+}
+
+// holdParty reports whether accId may see hold: both the owner and the merchant can.
+func holdParty(hold models.Hold, accId string) bool {
+	return hold.Owner == accId || hold.Merchant == accId
+}
+
+// storedHoldStatus is the status to look up to find holds in status: a hold past its
+// expiry may still be stored as active.
+func storedHoldStatus(status models.HoldStatus) models.HoldStatus {
+	if status == models.HoldExpired {
+		return ""
+	}
+	return status
+}
+
+// holdsWithExpiry reports active holds past their expiry as expired and keeps those in
+// status, or all of them if status is empty. Expired holds no longer count as held either way.
+func holdsWithExpiry(holds []models.Hold, status models.HoldStatus) []models.Hold {
+	now := clockNow()
+	result := make([]models.Hold, 0, len(holds))
+
+	for _, h := range holds {
+		if h.Status == models.HoldActive && !now.Before(h.ExpiresAt) {
+			h.Status = models.HoldExpired
+		}
+		if status != "" && h.Status != status {
+			continue
+		}
+		result = append(result, h)
+	}
+
+	return result
+}
@@ -0,0 +1,396 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gopay/internal/models"
+	"github.com/gopay/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHoldService_Authorize(t *testing.T) {
+	now := time.Now()
+	setupClock(now)
+	defer resetClock()
+
+	var (
+		ctx      = context.Background()
+		owner    = "0001"
+		merchant = "0002"
+	)
+
+	scenarios := map[string]struct {
+		given   models.HoldReq
+		doMocks func(deps holdServiceDependencies)
+		want    models.Hold
+		wantErr error
+	}{
+		"happy-path": {
+			given: models.HoldReq{Merchant: merchant, Amount: 2500, Memo: "  hotel  "},
+			doMocks: func(deps holdServiceDependencies) {
+				deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{AccountId: owner}, nil)
+				deps.accRepoMock.On("FindOne", ctx, merchant).Return(models.Account{AccountId: merchant}, nil)
+				deps.transRepoMock.On("LockAccount", ctx, owner).Return(nil)
+				deps.transactionSvcMock.On("GetBalance", ctx, owner).Return(models.Balance{AccountId: owner, Amount: 5000, Held: 2000, Available: 3000}, nil)
+				deps.holdRepoMock.On("Create", ctx, models.Hold{
+					Owner:     owner,
+					Merchant:  merchant,
+					Amount:    2500,
+					Memo:      "hotel",
+					CreatedAt: now,
+					ExpiresAt: now.Add(holdTTL),
+				}).Return("h-1", nil)
+			},
+			want: models.Hold{
+				HoldId:    "h-1",
+				Owner:     owner,
+				Merchant:  merchant,
+				Amount:    2500,
+				Status:    models.HoldActive,
+				Memo:      "hotel",
+				CreatedAt: now,
+				ExpiresAt: now.Add(holdTTL),
+				UpdatedAt: now,
+			},
+			wantErr: nil,
+		},
+		"held-money-is-not-available": {
+			given: models.HoldReq{Merchant: merchant, Amount: 3500},
+			doMocks: func(deps holdServiceDependencies) {
+				deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{AccountId: owner}, nil)
+				deps.accRepoMock.On("FindOne", ctx, merchant).Return(models.Account{AccountId: merchant}, nil)
+				deps.transRepoMock.On("LockAccount", ctx, owner).Return(nil)
+				deps.transactionSvcMock.On("GetBalance", ctx, owner).Return(models.Balance{AccountId: owner, Amount: 5000, Held: 2000, Available: 3000}, nil)
+			},
+			want:    models.Hold{},
+			wantErr: ErrInsufficentBalance,
+		},
+		"invalid-amount": {
+			given:   models.HoldReq{Merchant: merchant, Amount: 0},
+			want:    models.Hold{},
+			wantErr: ErrInvalidAmount,
+		},
+		"self-hold": {
+			given:   models.HoldReq{Merchant: owner, Amount: 100},
+			want:    models.Hold{},
+			wantErr: ErrInvalidPaymentOp,
+		},
+		"expiry-in-the-past": {
+			given:   models.HoldReq{Merchant: merchant, Amount: 100, ExpiresAt: now.Add(-time.Minute)},
+			want:    models.Hold{},
+			wantErr: ErrInvalidExpiry,
+		},
+		"expiry-too-far": {
+			given:   models.HoldReq{Merchant: merchant, Amount: 100, ExpiresAt: now.Add(maxHoldTTL + time.Minute)},
+			want:    models.Hold{},
+			wantErr: ErrInvalidExpiry,
+		},
+		"frozen-owner": {
+			given: models.HoldReq{Merchant: merchant, Amount: 100},
+			doMocks: func(deps holdServiceDependencies) {
+				deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{AccountId: owner, Status: models.AccountFrozen}, nil)
+				deps.accRepoMock.On("FindOne", ctx, merchant).Return(models.Account{AccountId: merchant}, nil)
+				deps.transRepoMock.On("LockAccount", ctx, owner).Return(nil)
+			},
+			want:    models.Hold{},
+			wantErr: ErrAccountFrozen,
+		},
+		"unknown-merchant": {
+			given: models.HoldReq{Merchant: "0009", Amount: 100},
+			doMocks: func(deps holdServiceDependencies) {
+				deps.accRepoMock.On("FindOne", ctx, "0009").Return(models.Account{}, repository.ErrAccountNotFound)
+			},
+			want:    models.Hold{},
+			wantErr: repository.ErrAccountNotFound,
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			service, deps := setupHoldService(t)
+			if tcase.doMocks != nil {
+				tcase.doMocks(deps)
+			}
+
+			hold, err := service.Authorize(ctx, owner, tcase.given)
+
+			if tcase.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tcase.wantErr)
+			}
+			assert.Equal(t, tcase.want, hold)
+		})
+	}
+}
+
+func TestHoldService_Capture(t *testing.T) {
+	now := time.Now()
+	setupClock(now)
+	defer resetClock()
+
+	var (
+		ctx  = context.Background()
+		hold = models.Hold{
+			HoldId:    "h-1",
+			Owner:     "0001",
+			Merchant:  "0002",
+			Amount:    2500,
+			Status:    models.HoldActive,
+			Memo:      "hotel",
+			CreatedAt: now.Add(-time.Hour),
+			ExpiresAt: now.Add(time.Hour),
+		}
+		expired = models.Hold{
+			HoldId:    "h-2",
+			Owner:     "0001",
+			Merchant:  "0002",
+			Amount:    2500,
+			Status:    models.HoldActive,
+			CreatedAt: now.Add(-2 * holdTTL),
+			ExpiresAt: now.Add(-holdTTL),
+		}
+		note = models.Annotation{Memo: "hotel", Metadata: models.Metadata{"hold_id": "h-1"}}
+	)
+
+	type args struct {
+		id     string
+		accId  string
+		amount models.Money
+	}
+
+	scenarios := map[string]struct {
+		given   args
+		doMocks func(deps holdServiceDependencies)
+		want    models.Money
+		wantErr error
+	}{
+		"full-capture": {
+			given: args{id: "h-1", accId: "0002", amount: 0},
+			doMocks: func(deps holdServiceDependencies) {
+				deps.holdRepoMock.On("FindOne", ctx, "h-1").Return(hold, nil)
+				deps.holdRepoMock.On("Resolve", ctx, "h-1", models.HoldCaptured, models.Money(2500), now).Return(nil)
+				deps.transactionSvcMock.On("Pay", ctx, "0001", "0002", models.Money(2500), note).Return(models.Receipt{}, nil)
+			},
+			want:    2500,
+			wantErr: nil,
+		},
+		"partial-capture": {
+			given: args{id: "h-1", accId: "0002", amount: 1800},
+			doMocks: func(deps holdServiceDependencies) {
+				deps.holdRepoMock.On("FindOne", ctx, "h-1").Return(hold, nil)
+				deps.holdRepoMock.On("Resolve", ctx, "h-1", models.HoldCaptured, models.Money(1800), now).Return(nil)
+				deps.transactionSvcMock.On("Pay", ctx, "0001", "0002", models.Money(1800), note).Return(models.Receipt{}, nil)
+			},
+			want:    1800,
+			wantErr: nil,
+		},
+		"payment-fails": {
+			given: args{id: "h-1", accId: "0002", amount: 0},
+			doMocks: func(deps holdServiceDependencies) {
+				deps.holdRepoMock.On("FindOne", ctx, "h-1").Return(hold, nil)
+				deps.holdRepoMock.On("Resolve", ctx, "h-1", models.HoldCaptured, models.Money(2500), now).Return(nil)
+				deps.transactionSvcMock.On("Pay", ctx, "0001", "0002", models.Money(2500), note).Return(models.Receipt{}, ErrInsufficentBalance)
+			},
+			wantErr: ErrInsufficentBalance,
+		},
+		"exceeds-hold": {
+			given: args{id: "h-1", accId: "0002", amount: 2501},
+			doMocks: func(deps holdServiceDependencies) {
+				deps.holdRepoMock.On("FindOne", ctx, "h-1").Return(hold, nil)
+			},
+			wantErr: ErrCaptureExceedsHold,
+		},
+		"not-a-party": {
+			given: args{id: "h-1", accId: "0003", amount: 0},
+			doMocks: func(deps holdServiceDependencies) {
+				deps.holdRepoMock.On("FindOne", ctx, "h-1").Return(hold, nil)
+			},
+			wantErr: ErrHoldForbidden,
+		},
+		"owner-captures": {
+			given: args{id: "h-1", accId: "0001", amount: 0},
+			doMocks: func(deps holdServiceDependencies) {
+				deps.holdRepoMock.On("FindOne", ctx, "h-1").Return(hold, nil)
+			},
+			wantErr: ErrHoldMerchantOnly,
+		},
+		"already-resolved": {
+			given: args{id: "h-1", accId: "0002", amount: 0},
+			doMocks: func(deps holdServiceDependencies) {
+				voided := hold
+				voided.Status = models.HoldVoided
+				deps.holdRepoMock.On("FindOne", ctx, "h-1").Return(voided, nil)
+			},
+			wantErr: repository.ErrHoldNotActive,
+		},
+		"expired": {
+			given: args{id: "h-2", accId: "0002", amount: 0},
+			doMocks: func(deps holdServiceDependencies) {
+				deps.holdRepoMock.On("FindOne", ctx, "h-2").Return(expired, nil)
+				deps.holdRepoMock.On("Resolve", ctx, "h-2", models.HoldExpired, models.Money(0), now).Return(nil)
+			},
+			wantErr: ErrHoldExpired,
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			service, deps := setupHoldService(t)
+			if tcase.doMocks != nil {
+				tcase.doMocks(deps)
+			}
+
+			captured, err := service.Capture(ctx, tcase.given.id, tcase.given.accId, tcase.given.amount)
+
+			if tcase.wantErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, models.HoldCaptured, captured.Status)
+				assert.Equal(t, tcase.want, captured.Captured)
+			} else {
+				assert.ErrorIs(t, err, tcase.wantErr)
+			}
+		})
+	}
+}
+
+func TestHoldService_Void(t *testing.T) {
+	now := time.Now()
+	setupClock(now)
+	defer resetClock()
+
+	ctx := context.Background()
+	hold := models.Hold{HoldId: "h-1", Owner: "0001", Merchant: "0002", Amount: 2500, Status: models.HoldActive, ExpiresAt: now.Add(time.Hour)}
+
+	scenarios := map[string]struct {
+		given   string
+		doMocks func(deps holdServiceDependencies)
+		wantErr error
+	}{
+		"merchant-voids": {
+			given: "0002",
+			doMocks: func(deps holdServiceDependencies) {
+				deps.holdRepoMock.On("FindOne", ctx, "h-1").Return(hold, nil)
+				deps.holdRepoMock.On("Resolve", ctx, "h-1", models.HoldVoided, models.Money(0), now).Return(nil)
+			},
+			wantErr: nil,
+		},
+		"owner-voids": {
+			given: "0001",
+			doMocks: func(deps holdServiceDependencies) {
+				deps.holdRepoMock.On("FindOne", ctx, "h-1").Return(hold, nil)
+			},
+			wantErr: ErrHoldMerchantOnly,
+		},
+		"not-a-party": {
+			given: "0003",
+			doMocks: func(deps holdServiceDependencies) {
+				deps.holdRepoMock.On("FindOne", ctx, "h-1").Return(hold, nil)
+			},
+			wantErr: ErrHoldForbidden,
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			service, deps := setupHoldService(t)
+			if tcase.doMocks != nil {
+				tcase.doMocks(deps)
+			}
+
+			voided, err := service.Void(ctx, "h-1", tcase.given)
+
+			if tcase.wantErr == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, models.HoldVoided, voided.Status)
+				assert.Equal(t, now, voided.UpdatedAt)
+			} else {
+				assert.ErrorIs(t, err, tcase.wantErr)
+			}
+		})
+	}
+}
+
+func TestHoldService_GetAll(t *testing.T) {
+	now := time.Now()
+	setupClock(now)
+	defer resetClock()
+
+	var (
+		ctx   = context.Background()
+		owner = "0001"
+		live  = models.Hold{HoldId: "h-1", Owner: owner, Status: models.HoldActive, ExpiresAt: now.Add(time.Hour)}
+		stale = models.Hold{HoldId: "h-2", Owner: owner, Status: models.HoldActive, ExpiresAt: now.Add(-time.Hour)}
+	)
+
+	expiredStale := stale
+	expiredStale.Status = models.HoldExpired
+
+	scenarios := map[string]struct {
+		status     models.HoldStatus
+		repoStatus models.HoldStatus
+		want       []models.Hold
+	}{
+		"any-status": {
+			status:     "",
+			repoStatus: "",
+			want:       []models.Hold{live, expiredStale},
+		},
+		"active-hides-expired": {
+			status:     models.HoldActive,
+			repoStatus: models.HoldActive,
+			want:       []models.Hold{live},
+		},
+		"expired": {
+			status:     models.HoldExpired,
+			repoStatus: "",
+			want:       []models.Hold{expiredStale},
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			service, deps := setupHoldService(t)
+			deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{AccountId: owner}, nil)
+			deps.holdRepoMock.On("FindByOwner", ctx, owner, tcase.repoStatus).Return([]models.Hold{live, stale}, nil)
+
+			holds, err := service.GetAll(ctx, owner, tcase.status)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tcase.want, holds)
+		})
+	}
+}
+
+type holdServiceDependencies struct {
+	holdRepoMock       *repository.MockHoldRepo
+	accRepoMock        *repository.MockAccountRepo
+	transRepoMock      *repository.MockTransactionRepo
+	transactionSvcMock *MockTransactionService
+	uowMock            *repository.MockUnitOfWork
+}
+
+func setupHoldService(t *testing.T) (*holdServiceImpl, holdServiceDependencies) {
+	deps := holdServiceDependencies{
+		holdRepoMock:       repository.NewMockHoldRepo(t),
+		accRepoMock:        repository.NewMockAccountRepo(t),
+		transRepoMock:      repository.NewMockTransactionRepo(t),
+		transactionSvcMock: NewMockTransactionService(t),
+		uowMock:            repository.NewMockUnitOfWork(t),
+	}
+
+	deps.uowMock.EXPECT().Do(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		}).
+		Maybe()
+
+	return NewHoldService(deps.holdRepoMock, deps.accRepoMock, deps.transRepoMock, deps.transactionSvcMock, deps.uowMock), deps
+}
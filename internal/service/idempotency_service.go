@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gopay/internal/models"
+	"github.com/gopay/internal/repository"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	ErrIdempotencyKeyReused = errors.New("idempotency key was already used with a different request")
+	ErrRequestInProgress    = errors.New("a request with this idempotency key is still being processed")
+)
+
+// Keys older than this are forgotten and may be reused for a new request.
+const idempotencyKeyTTL = 24 * time.Hour
+
+// A request owns the key it claimed for this long past its last renewal. Keys whose
+// lease ran out were left behind by a request that never finished, e.g. because the
+// process crashed, and may be claimed again.
+const idempotencyLease = time.Minute
+
+type IdempotencyService interface {
+	Begin(ctx context.Context, scope string, key string, requestHash string) (models.IdempotencyRecord, error)
+	KeepAlive(ctx context.Context, record models.IdempotencyRecord)
+	Complete(ctx context.Context, record models.IdempotencyRecord, statusCode int, body []byte) error
+	Release(ctx context.Context, record models.IdempotencyRecord) error
+}
+
+var _ IdempotencyService = (*idempotencyServiceImpl)(nil)
+
+type idempotencyServiceImpl struct {
+	idempotencyRepo repository.IdempotencyRepo
+}
+
+func NewIdempotencyService(idempotencyRepo repository.IdempotencyRepo) *idempotencyServiceImpl {
+	return &idempotencyServiceImpl{
+		idempotencyRepo: idempotencyRepo,
+	}
+}
+
+// Begin claims key for a request. A record that is not Completed means the caller owns
+// the key and must either Complete or Release it, and should KeepAlive it meanwhile; a
+// Completed record holds the stored response to replay. Expired keys and keys whose
+// lease ran out are claimed like new ones.
+func (r *idempotencyServiceImpl) Begin(ctx context.Context, scope string, key string, requestHash string) (models.IdempotencyRecord, error) {
+	now := clockNow()
+	record := models.IdempotencyRecord{
+		Scope:       scope,
+		Key:         key,
+		RequestHash: requestHash,
+		CreatedAt:   now,
+		LeasedUntil: now.Add(idempotencyLease),
+	}
+
+	err := r.idempotencyRepo.Create(ctx, record)
+	if err == nil {
+		return record, nil
+	}
+	if err != repository.ErrIdempotencyKeyExists {
+		return models.IdempotencyRecord{}, err
+	}
+
+	existing, err := r.idempotencyRepo.FindOne(ctx, scope, key)
+	if err != nil {
+		return models.IdempotencyRecord{}, err
+	}
+
+	expired := now.Sub(existing.CreatedAt) > idempotencyKeyTTL
+	abandoned := !existing.Completed && now.After(existing.LeasedUntil)
+	if expired || abandoned {
+		// Only one of the requests racing for a stale key can swap it, the others find
+		// it taken.
+		err = r.idempotencyRepo.Reclaim(ctx, record, now.Add(-idempotencyKeyTTL))
+		if err == repository.ErrIdempotencyKeyNotFound {
+			return models.IdempotencyRecord{}, ErrRequestInProgress
+		}
+		if err != nil {
+			return models.IdempotencyRecord{}, err
+		}
+
+		return record, nil
+	}
+
+	if existing.RequestHash != requestHash {
+		return models.IdempotencyRecord{}, ErrIdempotencyKeyReused
+	}
+
+	if !existing.Completed {
+		return models.IdempotencyRecord{}, ErrRequestInProgress
+	}
+
+	return existing, nil
+}
+
+// KeepAlive renews the lease on the key record claimed until ctx is done, so that a
+// request running longer than idempotencyLease is not taken for one left behind.
+func (r *idempotencyServiceImpl) KeepAlive(ctx context.Context, record models.IdempotencyRecord) {
+	ticker := time.NewTicker(idempotencyLease / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		record.LeasedUntil = clockNow().Add(idempotencyLease)
+		err := r.idempotencyRepo.Renew(ctx, record)
+		if err == repository.ErrIdempotencyKeyNotFound {
+			return
+		}
+		if err != nil {
+			log.Error().Err(err).Str("key", record.Key).Msg("IdempotencyService::KeepAlive")
+		}
+	}
+}
+
+func (r *idempotencyServiceImpl) Complete(ctx context.Context, record models.IdempotencyRecord, statusCode int, body []byte) error {
+	return r.idempotencyRepo.Complete(ctx, record, statusCode, body)
+}
+
+// Release forgets the key record claimed so the request can be retried, e.g. after a
+// server error.
+func (r *idempotencyServiceImpl) Release(ctx context.Context, record models.IdempotencyRecord) error {
+	return r.idempotencyRepo.Delete(ctx, record)
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gopay/internal/models"
+	"github.com/gopay/internal/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyService_Begin(t *testing.T) {
+	now := time.Now()
+	setupClock(now)
+	defer resetClock()
+
+	var (
+		ctx   = context.Background()
+		scope = "0001"
+		key   = "key-1"
+		hash  = "hash"
+		fresh = models.IdempotencyRecord{
+			Scope:       scope,
+			Key:         key,
+			RequestHash: hash,
+			CreatedAt:   now,
+			LeasedUntil: now.Add(idempotencyLease),
+		}
+		completed = models.IdempotencyRecord{
+			Scope:       scope,
+			Key:         key,
+			RequestHash: hash,
+			Completed:   true,
+			StatusCode:  201,
+			CreatedAt:   now.Add(-time.Minute),
+		}
+	)
+
+	scenarios := map[string]struct {
+		given   string
+		doMocks func(deps idempotencyServiceDependencies)
+		want    models.IdempotencyRecord
+		wantErr error
+	}{
+		"first-request": {
+			given: hash,
+			doMocks: func(deps idempotencyServiceDependencies) {
+				deps.idempotencyRepoMock.On("Create", ctx, fresh).Return(nil)
+			},
+			want:    fresh,
+			wantErr: nil,
+		},
+		"replay": {
+			given: hash,
+			doMocks: func(deps idempotencyServiceDependencies) {
+				deps.idempotencyRepoMock.On("Create", ctx, fresh).Return(repository.ErrIdempotencyKeyExists)
+				deps.idempotencyRepoMock.On("FindOne", ctx, scope, key).Return(completed, nil)
+			},
+			want:    completed,
+			wantErr: nil,
+		},
+		"different-body": {
+			given: "other",
+			doMocks: func(deps idempotencyServiceDependencies) {
+				other := fresh
+				other.RequestHash = "other"
+				deps.idempotencyRepoMock.On("Create", ctx, other).Return(repository.ErrIdempotencyKeyExists)
+				deps.idempotencyRepoMock.On("FindOne", ctx, scope, key).Return(completed, nil)
+			},
+			want:    models.IdempotencyRecord{},
+			wantErr: ErrIdempotencyKeyReused,
+		},
+		"in-progress": {
+			given: hash,
+			doMocks: func(deps idempotencyServiceDependencies) {
+				pending := completed
+				pending.Completed = false
+				pending.CreatedAt = now.Add(-2 * idempotencyLease)
+				pending.LeasedUntil = now.Add(idempotencyLease / 2)
+				deps.idempotencyRepoMock.On("Create", ctx, fresh).Return(repository.ErrIdempotencyKeyExists)
+				deps.idempotencyRepoMock.On("FindOne", ctx, scope, key).Return(pending, nil)
+			},
+			want:    models.IdempotencyRecord{},
+			wantErr: ErrRequestInProgress,
+		},
+		"expired-key": {
+			given: "other",
+			doMocks: func(deps idempotencyServiceDependencies) {
+				expired := completed
+				expired.CreatedAt = now.Add(-2 * idempotencyKeyTTL)
+				other := fresh
+				other.RequestHash = "other"
+				deps.idempotencyRepoMock.On("Create", ctx, other).Return(repository.ErrIdempotencyKeyExists)
+				deps.idempotencyRepoMock.On("FindOne", ctx, scope, key).Return(expired, nil)
+				deps.idempotencyRepoMock.On("Reclaim", ctx, other, now.Add(-idempotencyKeyTTL)).Return(nil)
+			},
+			want: models.IdempotencyRecord{
+				Scope:       scope,
+				Key:         key,
+				RequestHash: "other",
+				CreatedAt:   now,
+				LeasedUntil: now.Add(idempotencyLease),
+			},
+			wantErr: nil,
+		},
+		"abandoned-key": {
+			given: hash,
+			doMocks: func(deps idempotencyServiceDependencies) {
+				abandoned := completed
+				abandoned.Completed = false
+				abandoned.CreatedAt = now.Add(-2 * idempotencyLease)
+				abandoned.LeasedUntil = now.Add(-idempotencyLease)
+				deps.idempotencyRepoMock.On("Create", ctx, fresh).Return(repository.ErrIdempotencyKeyExists)
+				deps.idempotencyRepoMock.On("FindOne", ctx, scope, key).Return(abandoned, nil)
+				deps.idempotencyRepoMock.On("Reclaim", ctx, fresh, now.Add(-idempotencyKeyTTL)).Return(nil)
+			},
+			want:    fresh,
+			wantErr: nil,
+		},
+		"reclaimed-by-another-request": {
+			given: hash,
+			doMocks: func(deps idempotencyServiceDependencies) {
+				abandoned := completed
+				abandoned.Completed = false
+				abandoned.CreatedAt = now.Add(-2 * idempotencyLease)
+				abandoned.LeasedUntil = now.Add(-idempotencyLease)
+				deps.idempotencyRepoMock.On("Create", ctx, fresh).Return(repository.ErrIdempotencyKeyExists)
+				deps.idempotencyRepoMock.On("FindOne", ctx, scope, key).Return(abandoned, nil)
+				deps.idempotencyRepoMock.On("Reclaim", ctx, fresh, now.Add(-idempotencyKeyTTL)).Return(repository.ErrIdempotencyKeyNotFound)
+			},
+			want:    models.IdempotencyRecord{},
+			wantErr: ErrRequestInProgress,
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			service, deps := setupIdempotencyService(t)
+			if tcase.doMocks != nil {
+				tcase.doMocks(deps)
+			}
+
+			record, err := service.Begin(ctx, scope, key, tcase.given)
+
+			if tcase.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tcase.wantErr)
+			}
+
+			assert.Equal(t, tcase.want, record)
+		})
+	}
+}
+
+type idempotencyServiceDependencies struct {
+	idempotencyRepoMock *repository.MockIdempotencyRepo
+}
+
+func setupIdempotencyService(t *testing.T) (*idempotencyServiceImpl, idempotencyServiceDependencies) {
+	deps := idempotencyServiceDependencies{
+		idempotencyRepoMock: repository.NewMockIdempotencyRepo(t),
+	}
+
+	return NewIdempotencyService(deps.idempotencyRepoMock), deps
+}
@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/gopay/internal/models"
+	"github.com/gopay/internal/repository"
+	"github.com/rs/zerolog/log"
+)
+
+// InterestService accrues interest every day on the accounts' end-of-day balances and
+// pays it out once a month. Days are UTC days.
+type InterestService interface {
+	GetAccrual(ctx context.Context, accId string) (models.InterestStatus, error)
+	AccrueDue(ctx context.Context) error
+	Run(ctx context.Context, interval time.Duration)
+}
+
+var _ InterestService = (*interestServiceImpl)(nil)
+
+type interestServiceImpl struct {
+	rate           models.InterestRate
+	interestRepo   repository.InterestRepo
+	accountRepo    repository.AccountRepo
+	ledger         LedgerService
+	transactionSvc TransactionService
+	uow            repository.UnitOfWork
+}
+
+func NewInterestService(rate models.InterestRate, interestRepo repository.InterestRepo, accountRepo repository.AccountRepo, ledger LedgerService, transactionSvc TransactionService, uow repository.UnitOfWork) *interestServiceImpl {
+	return &interestServiceImpl{
+		rate:           rate,
+		interestRepo:   interestRepo,
+		accountRepo:    accountRepo,
+		ledger:         ledger,
+		transactionSvc: transactionSvc,
+		uow:            uow,
+	}
+}
+
+// GetAccrual returns the interest accId earned over the days accrued so far and was not
+// paid yet.
+func (r *interestServiceImpl) GetAccrual(ctx context.Context, accId string) (models.InterestStatus, error) {
+	_, err := r.accountRepo.FindOne(ctx, accId)
+	if err != nil {
+		return models.InterestStatus{}, err
+	}
+
+	today := startOfDay(clockNow())
+	status := models.InterestStatus{
+		AccountId:    accId,
+		Rate:         r.rate,
+		NextPayoutAt: time.Date(today.Year(), today.Month()+1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	accrual, err := r.interestRepo.FindOne(ctx, accId)
+	if err == repository.ErrInterestAccrualNotFound {
+		return status, nil
+	}
+	if err != nil {
+		return models.InterestStatus{}, err
+	}
+
+	accruedThrough := startOfDay(accrual.AccruedThrough)
+	status.Accrued = accrual.Accrued
+	status.Payable = accrual.Accrued.Money()
+	status.AccruedThrough = &accruedThrough
+
+	return status, nil
+}
+
+// AccrueDue accrues every day that ended since the last run and pays out the months
+// that ended with them. An account starts accruing on the day AccrueDue first sees it,
+// so turning interest on pays nothing for the past; closed accounts stop accruing.
+func (r *interestServiceImpl) AccrueDue(ctx context.Context) error {
+	accounts, err := r.accountRepo.FindAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	today := startOfDay(clockNow())
+	for _, acc := range accounts {
+		if acc.Status == models.AccountClosed {
+			continue
+		}
+
+		err := r.accrue(ctx, acc.AccountId, today)
+		if err != nil {
+			log.Error().Err(err).Str("account", acc.AccountId).Msg("InterestService::AccrueDue")
+		}
+	}
+
+	return nil
+}
+
+// accrue brings the accrual of accId up to the day before today. It is claimed inside
+// the unit of work that updates it, so several replicas can accrue at the same time.
+func (r *interestServiceImpl) accrue(ctx context.Context, accId string, today time.Time) error {
+	now := clockNow()
+	yesterday := today.AddDate(0, 0, -1)
+
+	_, err := r.interestRepo.FindOne(ctx, accId)
+	if err == repository.ErrInterestAccrualNotFound {
+		err = r.interestRepo.Open(ctx, models.InterestAccrual{AccountId: accId, AccruedThrough: yesterday, UpdatedAt: now})
+	}
+	if err != nil {
+		return err
+	}
+
+	err = r.uow.Do(ctx, func(ctx context.Context) error {
+		accrual, err := r.interestRepo.Claim(ctx, accId)
+		if err != nil {
+			return err
+		}
+
+		day := startOfDay(accrual.AccruedThrough).AddDate(0, 0, 1)
+		if !day.Before(today) {
+			return nil
+		}
+
+		for ; day.Before(today); day = day.AddDate(0, 0, 1) {
+			next := day.AddDate(0, 0, 1)
+
+			balance, err := r.ledger.GetBalanceAt(ctx, accId, next)
+			if err != nil {
+				return err
+			}
+			accrual.Accrued += dailyInterest(balance, r.rate, day)
+			accrual.AccruedThrough = day
+
+			if next.Day() != 1 {
+				continue
+			}
+
+			// The month ended: its whole cents are paid and the fraction carries over.
+			payable := accrual.Accrued.Money()
+			if payable > 0 {
+				err = r.transactionSvc.PayInterest(ctx, accId, payable, models.Annotation{Memo: "interest " + day.Format("2006-01")})
+				if err != nil {
+					return err
+				}
+				accrual.Accrued -= models.AccrualOf(payable)
+			}
+		}
+
+		accrual.UpdatedAt = now
+		return r.interestRepo.Save(ctx, accrual)
+	})
+	if errors.Is(err, repository.ErrInterestAccrualNotFound) {
+		return nil
+	}
+
+	return err
+}
+
+// dailyInterest is what balance earns at rate over day, rounded half up to the accrual unit.
+func dailyInterest(balance models.Money, rate models.InterestRate, day time.Time) models.Accrual {
+	if balance <= 0 || rate.AprBps <= 0 {
+		return 0
+	}
+
+	days, basis := dayFraction(rate.DayCount, day)
+
+	// balance * apr / 10000 * days / basis, in accrual units. Large balances would
+	// overflow the product before the division.
+	num := new(big.Int).Mul(big.NewInt(int64(balance)), big.NewInt(rate.AprBps*days*models.AccrualUnitsPerCent))
+	den := big.NewInt(bpsScale * basis)
+	num.Add(num, new(big.Int).Rsh(den, 1))
+
+	return models.Accrual(num.Quo(num, den).Int64())
+}
+
+// dayFraction returns the share of a year day counts for under dc, as days over basis.
+func dayFraction(dc models.DayCount, day time.Time) (int64, int64) {
+	switch dc {
+	case models.DayCountActual360:
+		return 1, 360
+	case models.DayCountActualActual:
+		return 1, int64(time.Date(day.Year(), 12, 31, 0, 0, 0, 0, time.UTC).YearDay())
+	case models.DayCount30360:
+		return days360(day, day.AddDate(0, 0, 1)), 360
+	}
+
+	return 1, 365
+}
+
+// days360 counts the days from from to to as if every month had 30 days: a 31st counts
+// as the 30th, and so does the end date when the start date is a 30th or 31st.
+func days360(from time.Time, to time.Time) int64 {
+	d1, d2 := from.Day(), to.Day()
+	if d1 == 31 {
+		d1 = 30
+	}
+	if d2 == 31 && d1 == 30 {
+		d2 = 30
+	}
+
+	return int64((to.Year()-from.Year())*360 + int(to.Month()-from.Month())*30 + d2 - d1)
+}
+
+func startOfDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Run accrues right away and then every interval until ctx is done.
+func (r *interestServiceImpl) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := r.AccrueDue(ctx)
+		if err != nil {
+			log.Error().Err(err).Msg("InterestService::Run")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,275 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gopay/internal/models"
+	"github.com/gopay/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDailyInterest(t *testing.T) {
+	day := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	scenarios := map[string]struct {
+		balance models.Money
+		rate    models.InterestRate
+		day     time.Time
+		want    models.Accrual
+	}{
+		"actual-365": {
+			balance: 1000000,
+			rate:    models.InterestRate{AprBps: 365, DayCount: models.DayCountActual365},
+			day:     day,
+			want:    models.AccrualOf(100),
+		},
+		"actual-360": {
+			balance: 1000000,
+			rate:    models.InterestRate{AprBps: 360, DayCount: models.DayCountActual360},
+			day:     day,
+			want:    models.AccrualOf(100),
+		},
+		"actual-actual-leap-year": {
+			balance: 1000000,
+			rate:    models.InterestRate{AprBps: 366, DayCount: models.DayCountActualActual},
+			day:     time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC),
+			want:    models.AccrualOf(100),
+		},
+		"30-360-the-31st-earns-nothing": {
+			balance: 1000000,
+			rate:    models.InterestRate{AprBps: 360, DayCount: models.DayCount30360},
+			day:     time.Date(2026, 3, 30, 0, 0, 0, 0, time.UTC),
+			want:    0,
+		},
+		"30-360-month-end": {
+			balance: 1000000,
+			rate:    models.InterestRate{AprBps: 360, DayCount: models.DayCount30360},
+			day:     time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+			want:    models.AccrualOf(100),
+		},
+		"30-360-end-of-february": {
+			balance: 1000000,
+			rate:    models.InterestRate{AprBps: 360, DayCount: models.DayCount30360},
+			day:     time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC),
+			want:    models.AccrualOf(300),
+		},
+		"fraction-of-a-cent": {
+			balance: 10000,
+			rate:    models.InterestRate{AprBps: 500, DayCount: models.DayCountActual365},
+			day:     day,
+			want:    1369863,
+		},
+		"large-balance": {
+			balance: 9000000000000000,
+			rate:    models.InterestRate{AprBps: 365, DayCount: models.DayCountActual365},
+			day:     day,
+			want:    models.AccrualOf(900000000000),
+		},
+		"empty-balance": {
+			balance: 0,
+			rate:    models.InterestRate{AprBps: 500, DayCount: models.DayCountActual365},
+			day:     day,
+			want:    0,
+		},
+		"no-rate": {
+			balance: 1000000,
+			rate:    models.InterestRate{DayCount: models.DayCountActual365},
+			day:     day,
+			want:    0,
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tcase.want, dailyInterest(tcase.balance, tcase.rate, tcase.day))
+		})
+	}
+}
+
+func TestInterestService_AccrueDue(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		owner = models.Account{AccountId: "0001", Status: models.AccountActive}
+		march = func(day int) time.Time { return time.Date(2026, 3, day, 0, 0, 0, 0, time.UTC) }
+	)
+
+	// 100.00 at 5% earns 1.369863 cents a day.
+	const daily = models.Accrual(1369863)
+
+	scenarios := map[string]struct {
+		now     time.Time
+		doMocks func(deps interestServiceDependencies)
+	}{
+		"opens-new-account": {
+			now: march(4).Add(10 * time.Hour),
+			doMocks: func(deps interestServiceDependencies) {
+				opened := models.InterestAccrual{AccountId: "0001", AccruedThrough: march(3), UpdatedAt: march(4).Add(10 * time.Hour)}
+
+				deps.accRepoMock.On("FindAll", ctx).Return([]models.Account{owner}, nil)
+				deps.interestRepoMock.On("FindOne", ctx, "0001").Return(models.InterestAccrual{}, repository.ErrInterestAccrualNotFound)
+				deps.interestRepoMock.On("Open", ctx, opened).Return(nil)
+				deps.interestRepoMock.On("Claim", ctx, "0001").Return(opened, nil)
+			},
+		},
+		"accrues-missed-days": {
+			now: march(4).Add(10 * time.Hour),
+			doMocks: func(deps interestServiceDependencies) {
+				accrual := models.InterestAccrual{AccountId: "0001", Accrued: 7, AccruedThrough: march(1)}
+
+				deps.accRepoMock.On("FindAll", ctx).Return([]models.Account{owner}, nil)
+				deps.interestRepoMock.On("FindOne", ctx, "0001").Return(accrual, nil)
+				deps.interestRepoMock.On("Claim", ctx, "0001").Return(accrual, nil)
+				deps.ledgerMock.On("GetBalanceAt", ctx, "0001", march(3)).Return(models.Money(10000), nil)
+				deps.ledgerMock.On("GetBalanceAt", ctx, "0001", march(4)).Return(models.Money(0), nil)
+				deps.interestRepoMock.On("Save", ctx, models.InterestAccrual{
+					AccountId:      "0001",
+					Accrued:        7 + daily,
+					AccruedThrough: march(3),
+					UpdatedAt:      march(4).Add(10 * time.Hour),
+				}).Return(nil)
+			},
+		},
+		"pays-out-whole-cents-at-month-end": {
+			now: time.Date(2026, 4, 1, 0, 30, 0, 0, time.UTC),
+			doMocks: func(deps interestServiceDependencies) {
+				accrual := models.InterestAccrual{AccountId: "0001", Accrued: models.AccrualOf(40) + 500000, AccruedThrough: march(30)}
+
+				deps.accRepoMock.On("FindAll", ctx).Return([]models.Account{owner}, nil)
+				deps.interestRepoMock.On("FindOne", ctx, "0001").Return(accrual, nil)
+				deps.interestRepoMock.On("Claim", ctx, "0001").Return(accrual, nil)
+				deps.ledgerMock.On("GetBalanceAt", ctx, "0001", time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)).Return(models.Money(10000), nil)
+				deps.transactionSvcMock.On("PayInterest", ctx, "0001", models.Money(41), models.Annotation{Memo: "interest 2026-03"}).Return(nil)
+				deps.interestRepoMock.On("Save", ctx, models.InterestAccrual{
+					AccountId:      "0001",
+					Accrued:        500000 + daily - models.AccrualOf(1),
+					AccruedThrough: march(31),
+					UpdatedAt:      time.Date(2026, 4, 1, 0, 30, 0, 0, time.UTC),
+				}).Return(nil)
+			},
+		},
+		"failed-payout-keeps-accrual": {
+			now: time.Date(2026, 4, 1, 0, 30, 0, 0, time.UTC),
+			doMocks: func(deps interestServiceDependencies) {
+				accrual := models.InterestAccrual{AccountId: "0001", Accrued: models.AccrualOf(40), AccruedThrough: march(30)}
+
+				deps.accRepoMock.On("FindAll", ctx).Return([]models.Account{owner}, nil)
+				deps.interestRepoMock.On("FindOne", ctx, "0001").Return(accrual, nil)
+				deps.interestRepoMock.On("Claim", ctx, "0001").Return(accrual, nil)
+				deps.ledgerMock.On("GetBalanceAt", ctx, "0001", mock.Anything).Return(models.Money(0), nil)
+				deps.transactionSvcMock.On("PayInterest", ctx, "0001", models.Money(40), mock.Anything).Return(ErrAccountClosed)
+			},
+		},
+		"claimed-by-another-replica": {
+			now: march(4).Add(10 * time.Hour),
+			doMocks: func(deps interestServiceDependencies) {
+				deps.accRepoMock.On("FindAll", ctx).Return([]models.Account{owner}, nil)
+				deps.interestRepoMock.On("FindOne", ctx, "0001").Return(models.InterestAccrual{AccountId: "0001", AccruedThrough: march(1)}, nil)
+				deps.interestRepoMock.On("Claim", ctx, "0001").Return(models.InterestAccrual{}, repository.ErrInterestAccrualNotFound)
+			},
+		},
+		"closed-account-skipped": {
+			now: march(4).Add(10 * time.Hour),
+			doMocks: func(deps interestServiceDependencies) {
+				deps.accRepoMock.On("FindAll", ctx).Return([]models.Account{{AccountId: "0002", Status: models.AccountClosed}}, nil)
+			},
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			setupClock(tcase.now)
+			defer resetClock()
+
+			service, deps := setupInterestService(t)
+			tcase.doMocks(deps)
+
+			err := service.AccrueDue(ctx)
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestInterestService_GetAccrual(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+	accruedThrough := time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC)
+	nextPayout := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	setupClock(now)
+	defer resetClock()
+
+	t.Run("accrued", func(t *testing.T) {
+		service, deps := setupInterestService(t)
+		deps.accRepoMock.On("FindOne", ctx, "0001").Return(models.Account{AccountId: "0001"}, nil)
+		deps.interestRepoMock.On("FindOne", ctx, "0001").Return(models.InterestAccrual{
+			AccountId:      "0001",
+			Accrued:        models.AccrualOf(1234) + 5678,
+			AccruedThrough: accruedThrough,
+		}, nil)
+
+		status, err := service.GetAccrual(ctx, "0001")
+		assert.NoError(t, err)
+		assert.Equal(t, models.InterestStatus{
+			AccountId:      "0001",
+			Rate:           models.InterestRate{AprBps: 500, DayCount: models.DayCountActual365},
+			Accrued:        models.AccrualOf(1234) + 5678,
+			Payable:        1234,
+			AccruedThrough: &accruedThrough,
+			NextPayoutAt:   nextPayout,
+		}, status)
+	})
+
+	t.Run("not-accruing-yet", func(t *testing.T) {
+		service, deps := setupInterestService(t)
+		deps.accRepoMock.On("FindOne", ctx, "0001").Return(models.Account{AccountId: "0001"}, nil)
+		deps.interestRepoMock.On("FindOne", ctx, "0001").Return(models.InterestAccrual{}, repository.ErrInterestAccrualNotFound)
+
+		status, err := service.GetAccrual(ctx, "0001")
+		assert.NoError(t, err)
+		assert.Equal(t, models.Accrual(0), status.Accrued)
+		assert.Nil(t, status.AccruedThrough)
+		assert.Equal(t, nextPayout, status.NextPayoutAt)
+	})
+
+	t.Run("unknown-account", func(t *testing.T) {
+		service, deps := setupInterestService(t)
+		deps.accRepoMock.On("FindOne", ctx, "0009").Return(models.Account{}, repository.ErrAccountNotFound)
+
+		_, err := service.GetAccrual(ctx, "0009")
+		assert.ErrorIs(t, err, repository.ErrAccountNotFound)
+	})
+}
+
+type interestServiceDependencies struct {
+	interestRepoMock   *repository.MockInterestRepo
+	accRepoMock        *repository.MockAccountRepo
+	ledgerMock         *MockLedgerService
+	transactionSvcMock *MockTransactionService
+	uowMock            *repository.MockUnitOfWork
+}
+
+func setupInterestService(t *testing.T) (*interestServiceImpl, interestServiceDependencies) {
+	deps := interestServiceDependencies{
+		interestRepoMock:   repository.NewMockInterestRepo(t),
+		accRepoMock:        repository.NewMockAccountRepo(t),
+		ledgerMock:         NewMockLedgerService(t),
+		transactionSvcMock: NewMockTransactionService(t),
+		uowMock:            repository.NewMockUnitOfWork(t),
+	}
+
+	deps.uowMock.EXPECT().Do(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		}).
+		Maybe()
+
+	rate := models.InterestRate{AprBps: 500, DayCount: models.DayCountActual365}
+
+	return NewInterestService(rate, deps.interestRepoMock, deps.accRepoMock, deps.ledgerMock, deps.transactionSvcMock, deps.uowMock), deps
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/gopay/internal/models"
+	"github.com/gopay/internal/repository"
+)
+
+// LedgerService records every money movement as a balanced journal entry. It is called by
+// TransactionService inside the same unit of work as the matching transactions.
+type LedgerService interface {
+	RecordDeposit(ctx context.Context, accId string, amount models.Money) error
+	RecordWithdrawal(ctx context.Context, accId string, amount models.Money) error
+	RecordPayment(ctx context.Context, sender string, receiver string, amount models.Money) error
+	RecordRefund(ctx context.Context, payer string, payee string, amount models.Money) error
+	RecordPayoutReservation(ctx context.Context, owner string, amount models.Money) error
+	RecordPayout(ctx context.Context, receiver string, amount models.Money) error
+	RecordPayoutRelease(ctx context.Context, owner string, amount models.Money) error
+	RecordFee(ctx context.Context, accId string, amount models.Money) error
+	RecordInterest(ctx context.Context, accId string, amount models.Money) error
+	GetRevenue(ctx context.Context) (models.Revenue, error)
+	GetBalanceAt(ctx context.Context, accId string, at time.Time) (models.Money, error)
+	GetEntries(ctx context.Context, accId string) ([]models.JournalEntry, error)
+	Check(ctx context.Context) (models.LedgerCheck, error)
+}
+
+var _ LedgerService = (*ledgerServiceImpl)(nil)
+
+type ledgerServiceImpl struct {
+	ledgerRepo  repository.LedgerRepo
+	accountRepo repository.AccountRepo
+}
+
+func NewLedgerService(ledgerRepo repository.LedgerRepo, accountRepo repository.AccountRepo) *ledgerServiceImpl {
+	return &ledgerServiceImpl{
+		ledgerRepo:  ledgerRepo,
+		accountRepo: accountRepo,
+	}
+}
+
+func (r *ledgerServiceImpl) RecordDeposit(ctx context.Context, accId string, amount models.Money) error {
+	return r.record(ctx, "deposit", models.SystemCashInAccount, accId, amount)
+}
+
+func (r *ledgerServiceImpl) RecordWithdrawal(ctx context.Context, accId string, amount models.Money) error {
+	return r.record(ctx, "withdrawal", accId, models.SystemCashOutAccount, amount)
+}
+
+func (r *ledgerServiceImpl) RecordPayment(ctx context.Context, sender string, receiver string, amount models.Money) error {
+	return r.record(ctx, "payment", sender, receiver, amount)
+}
+
+func (r *ledgerServiceImpl) RecordRefund(ctx context.Context, payer string, payee string, amount models.Money) error {
+	return r.record(ctx, "refund", payer, payee, amount)
+}
+
+func (r *ledgerServiceImpl) RecordPayoutReservation(ctx context.Context, owner string, amount models.Money) error {
+	return r.record(ctx, "payout reservation", owner, models.SystemPayoutClearingAccount, amount)
+}
+
+func (r *ledgerServiceImpl) RecordPayout(ctx context.Context, receiver string, amount models.Money) error {
+	return r.record(ctx, "payout", models.SystemPayoutClearingAccount, receiver, amount)
+}
+
+func (r *ledgerServiceImpl) RecordPayoutRelease(ctx context.Context, owner string, amount models.Money) error {
+	return r.record(ctx, "payout release", models.SystemPayoutClearingAccount, owner, amount)
+}
+
+func (r *ledgerServiceImpl) RecordFee(ctx context.Context, accId string, amount models.Money) error {
+	return r.record(ctx, "fee", accId, models.SystemRevenueAccount, amount)
+}
+
+func (r *ledgerServiceImpl) RecordInterest(ctx context.Context, accId string, amount models.Money) error {
+	return r.record(ctx, "interest", models.SystemInterestAccount, accId, amount)
+}
+
+// GetRevenue returns the fees collected so far.
+func (r *ledgerServiceImpl) GetRevenue(ctx context.Context) (models.Revenue, error) {
+	amount, err := r.ledgerRepo.GetBalance(ctx, models.SystemRevenueAccount)
+	if err != nil {
+		return models.Revenue{}, err
+	}
+
+	return models.Revenue{AccountId: models.SystemRevenueAccount, Amount: amount}, nil
+}
+
+// GetBalanceAt returns accId's balance as it was at at, e.g. at the end of a day when
+// at is the next midnight.
+func (r *ledgerServiceImpl) GetBalanceAt(ctx context.Context, accId string, at time.Time) (models.Money, error) {
+	return r.ledgerRepo.GetBalanceAt(ctx, accId, at)
+}
+
+// record moves amount from one ledger account to another.
+func (r *ledgerServiceImpl) record(ctx context.Context, description string, from string, to string, amount models.Money) error {
+	if amount <= 0 {
+		return ErrInvalidAmount
+	}
+
+	entry := models.JournalEntry{
+		Description: description,
+		CreatedAt:   clockNow(),
+		Postings: []models.Posting{
+			{AccountId: from, Amount: -amount},
+			{AccountId: to, Amount: amount},
+		},
+	}
+
+	_, err := r.ledgerRepo.CreateEntry(ctx, entry)
+	return err
+}
+
+func (r *ledgerServiceImpl) GetEntries(ctx context.Context, accId string) ([]models.JournalEntry, error) {
+	_, err := r.accountRepo.FindOne(ctx, accId)
+	if err != nil {
+		return []models.JournalEntry{}, err
+	}
+
+	return r.ledgerRepo.FindEntries(ctx, accId)
+}
+
+// Check verifies that every journal entry in the ledger sums to zero.
+func (r *ledgerServiceImpl) Check(ctx context.Context) (models.LedgerCheck, error) {
+	unbalanced, err := r.ledgerRepo.FindUnbalanced(ctx)
+	if err != nil {
+		return models.LedgerCheck{}, err
+	}
+
+	return models.LedgerCheck{
+		Balanced:          len(unbalanced) == 0,
+		UnbalancedEntries: unbalanced,
+	}, nil
+}
@@ -0,0 +1,702 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package service
+
+import (
+	context "context"
+
+	models "github.com/gopay/internal/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// MockLedgerService is an autogenerated mock type for the LedgerService type
+type MockLedgerService struct {
+	mock.Mock
+}
+
+type MockLedgerService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockLedgerService) EXPECT() *MockLedgerService_Expecter {
+	return &MockLedgerService_Expecter{mock: &_m.Mock}
+}
+
+// Check provides a mock function with given fields: ctx
+func (_m *MockLedgerService) Check(ctx context.Context) (models.LedgerCheck, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Check")
+	}
+
+	var r0 models.LedgerCheck
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (models.LedgerCheck, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) models.LedgerCheck); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(models.LedgerCheck)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLedgerService_Check_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Check'
+type MockLedgerService_Check_Call struct {
+	*mock.Call
+}
+
+// Check is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockLedgerService_Expecter) Check(ctx interface{}) *MockLedgerService_Check_Call {
+	return &MockLedgerService_Check_Call{Call: _e.mock.On("Check", ctx)}
+}
+
+func (_c *MockLedgerService_Check_Call) Run(run func(ctx context.Context)) *MockLedgerService_Check_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockLedgerService_Check_Call) Return(_a0 models.LedgerCheck, _a1 error) *MockLedgerService_Check_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLedgerService_Check_Call) RunAndReturn(run func(context.Context) (models.LedgerCheck, error)) *MockLedgerService_Check_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBalanceAt provides a mock function with given fields: ctx, accId, at
+func (_m *MockLedgerService) GetBalanceAt(ctx context.Context, accId string, at time.Time) (models.Money, error) {
+	ret := _m.Called(ctx, accId, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBalanceAt")
+	}
+
+	var r0 models.Money
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (models.Money, error)); ok {
+		return rf(ctx, accId, at)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) models.Money); ok {
+		r0 = rf(ctx, accId, at)
+	} else {
+		r0 = ret.Get(0).(models.Money)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) error); ok {
+		r1 = rf(ctx, accId, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLedgerService_GetBalanceAt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBalanceAt'
+type MockLedgerService_GetBalanceAt_Call struct {
+	*mock.Call
+}
+
+// GetBalanceAt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accId string
+//   - at time.Time
+func (_e *MockLedgerService_Expecter) GetBalanceAt(ctx interface{}, accId interface{}, at interface{}) *MockLedgerService_GetBalanceAt_Call {
+	return &MockLedgerService_GetBalanceAt_Call{Call: _e.mock.On("GetBalanceAt", ctx, accId, at)}
+}
+
+func (_c *MockLedgerService_GetBalanceAt_Call) Run(run func(ctx context.Context, accId string, at time.Time)) *MockLedgerService_GetBalanceAt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockLedgerService_GetBalanceAt_Call) Return(_a0 models.Money, _a1 error) *MockLedgerService_GetBalanceAt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLedgerService_GetBalanceAt_Call) RunAndReturn(run func(context.Context, string, time.Time) (models.Money, error)) *MockLedgerService_GetBalanceAt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEntries provides a mock function with given fields: ctx, accId
+func (_m *MockLedgerService) GetEntries(ctx context.Context, accId string) ([]models.JournalEntry, error) {
+	ret := _m.Called(ctx, accId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEntries")
+	}
+
+	var r0 []models.JournalEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.JournalEntry, error)); ok {
+		return rf(ctx, accId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.JournalEntry); ok {
+		r0 = rf(ctx, accId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.JournalEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, accId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLedgerService_GetEntries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEntries'
+type MockLedgerService_GetEntries_Call struct {
+	*mock.Call
+}
+
+// GetEntries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accId string
+func (_e *MockLedgerService_Expecter) GetEntries(ctx interface{}, accId interface{}) *MockLedgerService_GetEntries_Call {
+	return &MockLedgerService_GetEntries_Call{Call: _e.mock.On("GetEntries", ctx, accId)}
+}
+
+func (_c *MockLedgerService_GetEntries_Call) Run(run func(ctx context.Context, accId string)) *MockLedgerService_GetEntries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockLedgerService_GetEntries_Call) Return(_a0 []models.JournalEntry, _a1 error) *MockLedgerService_GetEntries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLedgerService_GetEntries_Call) RunAndReturn(run func(context.Context, string) ([]models.JournalEntry, error)) *MockLedgerService_GetEntries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRevenue provides a mock function with given fields: ctx
+func (_m *MockLedgerService) GetRevenue(ctx context.Context) (models.Revenue, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRevenue")
+	}
+
+	var r0 models.Revenue
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (models.Revenue, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) models.Revenue); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(models.Revenue)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLedgerService_GetRevenue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRevenue'
+type MockLedgerService_GetRevenue_Call struct {
+	*mock.Call
+}
+
+// GetRevenue is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockLedgerService_Expecter) GetRevenue(ctx interface{}) *MockLedgerService_GetRevenue_Call {
+	return &MockLedgerService_GetRevenue_Call{Call: _e.mock.On("GetRevenue", ctx)}
+}
+
+func (_c *MockLedgerService_GetRevenue_Call) Run(run func(ctx context.Context)) *MockLedgerService_GetRevenue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockLedgerService_GetRevenue_Call) Return(_a0 models.Revenue, _a1 error) *MockLedgerService_GetRevenue_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLedgerService_GetRevenue_Call) RunAndReturn(run func(context.Context) (models.Revenue, error)) *MockLedgerService_GetRevenue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordDeposit provides a mock function with given fields: ctx, accId, amount
+func (_m *MockLedgerService) RecordDeposit(ctx context.Context, accId string, amount models.Money) error {
+	ret := _m.Called(ctx, accId, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordDeposit")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.Money) error); ok {
+		r0 = rf(ctx, accId, amount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockLedgerService_RecordDeposit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordDeposit'
+type MockLedgerService_RecordDeposit_Call struct {
+	*mock.Call
+}
+
+// RecordDeposit is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accId string
+//   - amount models.Money
+func (_e *MockLedgerService_Expecter) RecordDeposit(ctx interface{}, accId interface{}, amount interface{}) *MockLedgerService_RecordDeposit_Call {
+	return &MockLedgerService_RecordDeposit_Call{Call: _e.mock.On("RecordDeposit", ctx, accId, amount)}
+}
+
+func (_c *MockLedgerService_RecordDeposit_Call) Run(run func(ctx context.Context, accId string, amount models.Money)) *MockLedgerService_RecordDeposit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.Money))
+	})
+	return _c
+}
+
+func (_c *MockLedgerService_RecordDeposit_Call) Return(_a0 error) *MockLedgerService_RecordDeposit_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLedgerService_RecordDeposit_Call) RunAndReturn(run func(context.Context, string, models.Money) error) *MockLedgerService_RecordDeposit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordFee provides a mock function with given fields: ctx, accId, amount
+func (_m *MockLedgerService) RecordFee(ctx context.Context, accId string, amount models.Money) error {
+	ret := _m.Called(ctx, accId, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordFee")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.Money) error); ok {
+		r0 = rf(ctx, accId, amount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockLedgerService_RecordFee_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordFee'
+type MockLedgerService_RecordFee_Call struct {
+	*mock.Call
+}
+
+// RecordFee is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accId string
+//   - amount models.Money
+func (_e *MockLedgerService_Expecter) RecordFee(ctx interface{}, accId interface{}, amount interface{}) *MockLedgerService_RecordFee_Call {
+	return &MockLedgerService_RecordFee_Call{Call: _e.mock.On("RecordFee", ctx, accId, amount)}
+}
+
+func (_c *MockLedgerService_RecordFee_Call) Run(run func(ctx context.Context, accId string, amount models.Money)) *MockLedgerService_RecordFee_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.Money))
+	})
+	return _c
+}
+
+func (_c *MockLedgerService_RecordFee_Call) Return(_a0 error) *MockLedgerService_RecordFee_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLedgerService_RecordFee_Call) RunAndReturn(run func(context.Context, string, models.Money) error) *MockLedgerService_RecordFee_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordInterest provides a mock function with given fields: ctx, accId, amount
+func (_m *MockLedgerService) RecordInterest(ctx context.Context, accId string, amount models.Money) error {
+	ret := _m.Called(ctx, accId, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordInterest")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.Money) error); ok {
+		r0 = rf(ctx, accId, amount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockLedgerService_RecordInterest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordInterest'
+type MockLedgerService_RecordInterest_Call struct {
+	*mock.Call
+}
+
+// RecordInterest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accId string
+//   - amount models.Money
+func (_e *MockLedgerService_Expecter) RecordInterest(ctx interface{}, accId interface{}, amount interface{}) *MockLedgerService_RecordInterest_Call {
+	return &MockLedgerService_RecordInterest_Call{Call: _e.mock.On("RecordInterest", ctx, accId, amount)}
+}
+
+func (_c *MockLedgerService_RecordInterest_Call) Run(run func(ctx context.Context, accId string, amount models.Money)) *MockLedgerService_RecordInterest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.Money))
+	})
+	return _c
+}
+
+func (_c *MockLedgerService_RecordInterest_Call) Return(_a0 error) *MockLedgerService_RecordInterest_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLedgerService_RecordInterest_Call) RunAndReturn(run func(context.Context, string, models.Money) error) *MockLedgerService_RecordInterest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordPayment provides a mock function with given fields: ctx, sender, receiver, amount
+func (_m *MockLedgerService) RecordPayment(ctx context.Context, sender string, receiver string, amount models.Money) error {
+	ret := _m.Called(ctx, sender, receiver, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordPayment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.Money) error); ok {
+		r0 = rf(ctx, sender, receiver, amount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockLedgerService_RecordPayment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordPayment'
+type MockLedgerService_RecordPayment_Call struct {
+	*mock.Call
+}
+
+// RecordPayment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sender string
+//   - receiver string
+//   - amount models.Money
+func (_e *MockLedgerService_Expecter) RecordPayment(ctx interface{}, sender interface{}, receiver interface{}, amount interface{}) *MockLedgerService_RecordPayment_Call {
+	return &MockLedgerService_RecordPayment_Call{Call: _e.mock.On("RecordPayment", ctx, sender, receiver, amount)}
+}
+
+func (_c *MockLedgerService_RecordPayment_Call) Run(run func(ctx context.Context, sender string, receiver string, amount models.Money)) *MockLedgerService_RecordPayment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(models.Money))
+	})
+	return _c
+}
+
+func (_c *MockLedgerService_RecordPayment_Call) Return(_a0 error) *MockLedgerService_RecordPayment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLedgerService_RecordPayment_Call) RunAndReturn(run func(context.Context, string, string, models.Money) error) *MockLedgerService_RecordPayment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordPayout provides a mock function with given fields: ctx, receiver, amount
+func (_m *MockLedgerService) RecordPayout(ctx context.Context, receiver string, amount models.Money) error {
+	ret := _m.Called(ctx, receiver, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordPayout")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.Money) error); ok {
+		r0 = rf(ctx, receiver, amount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockLedgerService_RecordPayout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordPayout'
+type MockLedgerService_RecordPayout_Call struct {
+	*mock.Call
+}
+
+// RecordPayout is a helper method to define mock.On call
+//   - ctx context.Context
+//   - receiver string
+//   - amount models.Money
+func (_e *MockLedgerService_Expecter) RecordPayout(ctx interface{}, receiver interface{}, amount interface{}) *MockLedgerService_RecordPayout_Call {
+	return &MockLedgerService_RecordPayout_Call{Call: _e.mock.On("RecordPayout", ctx, receiver, amount)}
+}
+
+func (_c *MockLedgerService_RecordPayout_Call) Run(run func(ctx context.Context, receiver string, amount models.Money)) *MockLedgerService_RecordPayout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.Money))
+	})
+	return _c
+}
+
+func (_c *MockLedgerService_RecordPayout_Call) Return(_a0 error) *MockLedgerService_RecordPayout_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLedgerService_RecordPayout_Call) RunAndReturn(run func(context.Context, string, models.Money) error) *MockLedgerService_RecordPayout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordPayoutRelease provides a mock function with given fields: ctx, owner, amount
+func (_m *MockLedgerService) RecordPayoutRelease(ctx context.Context, owner string, amount models.Money) error {
+	ret := _m.Called(ctx, owner, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordPayoutRelease")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.Money) error); ok {
+		r0 = rf(ctx, owner, amount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockLedgerService_RecordPayoutRelease_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordPayoutRelease'
+type MockLedgerService_RecordPayoutRelease_Call struct {
+	*mock.Call
+}
+
+// RecordPayoutRelease is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - amount models.Money
+func (_e *MockLedgerService_Expecter) RecordPayoutRelease(ctx interface{}, owner interface{}, amount interface{}) *MockLedgerService_RecordPayoutRelease_Call {
+	return &MockLedgerService_RecordPayoutRelease_Call{Call: _e.mock.On("RecordPayoutRelease", ctx, owner, amount)}
+}
+
+func (_c *MockLedgerService_RecordPayoutRelease_Call) Run(run func(ctx context.Context, owner string, amount models.Money)) *MockLedgerService_RecordPayoutRelease_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.Money))
+	})
+	return _c
+}
+
+func (_c *MockLedgerService_RecordPayoutRelease_Call) Return(_a0 error) *MockLedgerService_RecordPayoutRelease_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLedgerService_RecordPayoutRelease_Call) RunAndReturn(run func(context.Context, string, models.Money) error) *MockLedgerService_RecordPayoutRelease_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordPayoutReservation provides a mock function with given fields: ctx, owner, amount
+func (_m *MockLedgerService) RecordPayoutReservation(ctx context.Context, owner string, amount models.Money) error {
+	ret := _m.Called(ctx, owner, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordPayoutReservation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.Money) error); ok {
+		r0 = rf(ctx, owner, amount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockLedgerService_RecordPayoutReservation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordPayoutReservation'
+type MockLedgerService_RecordPayoutReservation_Call struct {
+	*mock.Call
+}
+
+// RecordPayoutReservation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - amount models.Money
+func (_e *MockLedgerService_Expecter) RecordPayoutReservation(ctx interface{}, owner interface{}, amount interface{}) *MockLedgerService_RecordPayoutReservation_Call {
+	return &MockLedgerService_RecordPayoutReservation_Call{Call: _e.mock.On("RecordPayoutReservation", ctx, owner, amount)}
+}
+
+func (_c *MockLedgerService_RecordPayoutReservation_Call) Run(run func(ctx context.Context, owner string, amount models.Money)) *MockLedgerService_RecordPayoutReservation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.Money))
+	})
+	return _c
+}
+
+func (_c *MockLedgerService_RecordPayoutReservation_Call) Return(_a0 error) *MockLedgerService_RecordPayoutReservation_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLedgerService_RecordPayoutReservation_Call) RunAndReturn(run func(context.Context, string, models.Money) error) *MockLedgerService_RecordPayoutReservation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordRefund provides a mock function with given fields: ctx, payer, payee, amount
+func (_m *MockLedgerService) RecordRefund(ctx context.Context, payer string, payee string, amount models.Money) error {
+	ret := _m.Called(ctx, payer, payee, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordRefund")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.Money) error); ok {
+		r0 = rf(ctx, payer, payee, amount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockLedgerService_RecordRefund_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordRefund'
+type MockLedgerService_RecordRefund_Call struct {
+	*mock.Call
+}
+
+// RecordRefund is a helper method to define mock.On call
+//   - ctx context.Context
+//   - payer string
+//   - payee string
+//   - amount models.Money
+func (_e *MockLedgerService_Expecter) RecordRefund(ctx interface{}, payer interface{}, payee interface{}, amount interface{}) *MockLedgerService_RecordRefund_Call {
+	return &MockLedgerService_RecordRefund_Call{Call: _e.mock.On("RecordRefund", ctx, payer, payee, amount)}
+}
+
+func (_c *MockLedgerService_RecordRefund_Call) Run(run func(ctx context.Context, payer string, payee string, amount models.Money)) *MockLedgerService_RecordRefund_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(models.Money))
+	})
+	return _c
+}
+
+func (_c *MockLedgerService_RecordRefund_Call) Return(_a0 error) *MockLedgerService_RecordRefund_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLedgerService_RecordRefund_Call) RunAndReturn(run func(context.Context, string, string, models.Money) error) *MockLedgerService_RecordRefund_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordWithdrawal provides a mock function with given fields: ctx, accId, amount
+func (_m *MockLedgerService) RecordWithdrawal(ctx context.Context, accId string, amount models.Money) error {
+	ret := _m.Called(ctx, accId, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordWithdrawal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.Money) error); ok {
+		r0 = rf(ctx, accId, amount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockLedgerService_RecordWithdrawal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordWithdrawal'
+type MockLedgerService_RecordWithdrawal_Call struct {
+	*mock.Call
+}
+
+// RecordWithdrawal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accId string
+//   - amount models.Money
+func (_e *MockLedgerService_Expecter) RecordWithdrawal(ctx interface{}, accId interface{}, amount interface{}) *MockLedgerService_RecordWithdrawal_Call {
+	return &MockLedgerService_RecordWithdrawal_Call{Call: _e.mock.On("RecordWithdrawal", ctx, accId, amount)}
+}
+
+func (_c *MockLedgerService_RecordWithdrawal_Call) Run(run func(ctx context.Context, accId string, amount models.Money)) *MockLedgerService_RecordWithdrawal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.Money))
+	})
+	return _c
+}
+
+func (_c *MockLedgerService_RecordWithdrawal_Call) Return(_a0 error) *MockLedgerService_RecordWithdrawal_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLedgerService_RecordWithdrawal_Call) RunAndReturn(run func(context.Context, string, models.Money) error) *MockLedgerService_RecordWithdrawal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockLedgerService creates a new instance of MockLedgerService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockLedgerService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockLedgerService {
+	mock := &MockLedgerService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gopay/internal/models"
+	"github.com/gopay/internal/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLedgerService_Record(t *testing.T) {
+	now := time.Now()
+	setupClock(now)
+	defer resetClock()
+
+	var (
+		ctx      = context.Background()
+		owner    = "0001"
+		receiver = "0002"
+	)
+
+	scenarios := map[string]struct {
+		do      func(service *ledgerServiceImpl) error
+		doMocks func(deps ledgerServiceDependencies)
+		wantErr error
+	}{
+		"deposit": {
+			do: func(service *ledgerServiceImpl) error {
+				return service.RecordDeposit(ctx, owner, 1000)
+			},
+			doMocks: func(deps ledgerServiceDependencies) {
+				deps.ledgerRepoMock.On("CreateEntry", ctx, models.JournalEntry{
+					Description: "deposit",
+					CreatedAt:   now,
+					Postings: []models.Posting{
+						{AccountId: models.SystemCashInAccount, Amount: -1000},
+						{AccountId: owner, Amount: 1000},
+					},
+				}).Return("e-1", nil)
+			},
+			wantErr: nil,
+		},
+		"withdrawal": {
+			do: func(service *ledgerServiceImpl) error {
+				return service.RecordWithdrawal(ctx, owner, 1000)
+			},
+			doMocks: func(deps ledgerServiceDependencies) {
+				deps.ledgerRepoMock.On("CreateEntry", ctx, models.JournalEntry{
+					Description: "withdrawal",
+					CreatedAt:   now,
+					Postings: []models.Posting{
+						{AccountId: owner, Amount: -1000},
+						{AccountId: models.SystemCashOutAccount, Amount: 1000},
+					},
+				}).Return("e-1", nil)
+			},
+			wantErr: nil,
+		},
+		"payment": {
+			do: func(service *ledgerServiceImpl) error {
+				return service.RecordPayment(ctx, owner, receiver, 1000)
+			},
+			doMocks: func(deps ledgerServiceDependencies) {
+				deps.ledgerRepoMock.On("CreateEntry", ctx, models.JournalEntry{
+					Description: "payment",
+					CreatedAt:   now,
+					Postings: []models.Posting{
+						{AccountId: owner, Amount: -1000},
+						{AccountId: receiver, Amount: 1000},
+					},
+				}).Return("e-1", nil)
+			},
+			wantErr: nil,
+		},
+		"refund": {
+			do: func(service *ledgerServiceImpl) error {
+				return service.RecordRefund(ctx, receiver, owner, 400)
+			},
+			doMocks: func(deps ledgerServiceDependencies) {
+				deps.ledgerRepoMock.On("CreateEntry", ctx, models.JournalEntry{
+					Description: "refund",
+					CreatedAt:   now,
+					Postings: []models.Posting{
+						{AccountId: receiver, Amount: -400},
+						{AccountId: owner, Amount: 400},
+					},
+				}).Return("e-1", nil)
+			},
+			wantErr: nil,
+		},
+		"interest": {
+			do: func(service *ledgerServiceImpl) error {
+				return service.RecordInterest(ctx, owner, 41)
+			},
+			doMocks: func(deps ledgerServiceDependencies) {
+				deps.ledgerRepoMock.On("CreateEntry", ctx, models.JournalEntry{
+					Description: "interest",
+					CreatedAt:   now,
+					Postings: []models.Posting{
+						{AccountId: models.SystemInterestAccount, Amount: -41},
+						{AccountId: owner, Amount: 41},
+					},
+				}).Return("e-1", nil)
+			},
+			wantErr: nil,
+		},
+		"invalid-amount": {
+			do: func(service *ledgerServiceImpl) error {
+				return service.RecordPayment(ctx, owner, receiver, -1000)
+			},
+			wantErr: ErrInvalidAmount,
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			service, deps := setupLedgerService(t)
+			if tcase.doMocks != nil {
+				tcase.doMocks(deps)
+			}
+
+			err := tcase.do(service)
+
+			if tcase.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tcase.wantErr)
+			}
+		})
+	}
+}
+
+type ledgerServiceDependencies struct {
+	ledgerRepoMock *repository.MockLedgerRepo
+	accRepoMock    *repository.MockAccountRepo
+}
+
+func setupLedgerService(t *testing.T) (*ledgerServiceImpl, ledgerServiceDependencies) {
+	deps := ledgerServiceDependencies{
+		ledgerRepoMock: repository.NewMockLedgerRepo(t),
+		accRepoMock:    repository.NewMockAccountRepo(t),
+	}
+
+	return NewLedgerService(deps.ledgerRepoMock, deps.accRepoMock), deps
+}
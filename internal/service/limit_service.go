@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gopay/internal/models"
+	"github.com/gopay/internal/repository"
+)
+
+// Names of the limits, as they appear in LimitExceeded and in the JSON of models.Limits.
+const (
+	LimitMaxDeposit    = "maxDeposit"
+	LimitMaxWithdrawal = "maxWithdrawal"
+	LimitMaxPayment    = "maxPayment"
+	LimitDaily         = "daily"
+	LimitWeekly        = "weekly"
+	LimitMonthly       = "monthly"
+)
+
+const (
+	dayWindow   = 24 * time.Hour
+	weekWindow  = 7 * dayWindow
+	monthWindow = 30 * dayWindow
+)
+
+var (
+	ErrLimitExceeded = errors.New("transaction limit exceeded")
+	ErrInvalidLimits = errors.New("limits cannot be negative")
+)
+
+// LimitError is ErrLimitExceeded along with the limit that was exceeded and what is
+// left of it.
+type LimitError struct {
+	models.LimitExceeded
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("%s limit of %s exceeded, %s remaining", e.Limit, e.Max, e.Remaining)
+}
+
+func (e *LimitError) Unwrap() error {
+	return ErrLimitExceeded
+}
+
+type LimitService interface {
+	Check(ctx context.Context, owner string, kind models.TransactionKind, largest models.Money, total models.Money) error
+	GetLimits(ctx context.Context, accId string) (models.LimitStatus, error)
+	SetLimits(ctx context.Context, accId string, overrides models.LimitOverrides) (models.LimitStatus, error)
+}
+
+var _ LimitService = (*limitServiceImpl)(nil)
+
+type limitServiceImpl struct {
+	defaults        models.Limits
+	limitRepo       repository.LimitRepo
+	accountRepo     repository.AccountRepo
+	transactionRepo repository.TransactionRepo
+	payoutRepo      repository.PayoutRepo
+}
+
+func NewLimitService(defaults models.Limits, limitRepo repository.LimitRepo, accountRepo repository.AccountRepo, transactionRepo repository.TransactionRepo, payoutRepo repository.PayoutRepo) *limitServiceImpl {
+	return &limitServiceImpl{
+		defaults:        defaults,
+		limitRepo:       limitRepo,
+		accountRepo:     accountRepo,
+		transactionRepo: transactionRepo,
+		payoutRepo:      payoutRepo,
+	}
+}
+
+// limitWindow is a rolling-window limit: at most max sent out over the last length.
+type limitWindow struct {
+	name   string
+	max    models.Money
+	length time.Duration
+}
+
+func limitWindows(limits models.Limits) []limitWindow {
+	return []limitWindow{
+		{name: LimitDaily, max: limits.Daily, length: dayWindow},
+		{name: LimitWeekly, max: limits.Weekly, length: weekWindow},
+		{name: LimitMonthly, max: limits.Monthly, length: monthWindow},
+	}
+}
+
+// Check fails with a *LimitError when owner may not send total out at once in
+// transactions of kind, the largest of them being largest. Deposits only have a
+// per-transaction limit. Check must run under owner's account lock, otherwise concurrent
+// transactions could each fit in what is left of a window and go over it together.
+func (r *limitServiceImpl) Check(ctx context.Context, owner string, kind models.TransactionKind, largest models.Money, total models.Money) error {
+	overrides, err := r.limitRepo.FindOne(ctx, owner)
+	if err != nil {
+		return err
+	}
+	limits := overrides.Apply(r.defaults)
+
+	var single limitWindow
+	switch kind {
+	case models.KindDeposit:
+		single = limitWindow{name: LimitMaxDeposit, max: limits.MaxDeposit}
+	case models.KindWithdrawal:
+		single = limitWindow{name: LimitMaxWithdrawal, max: limits.MaxWithdrawal}
+	case models.KindPaymentOut:
+		single = limitWindow{name: LimitMaxPayment, max: limits.MaxPayment}
+	}
+
+	if single.max > 0 && largest > single.max {
+		return &LimitError{models.LimitExceeded{Limit: single.name, Max: single.max, Remaining: single.max}}
+	}
+
+	if kind == models.KindDeposit {
+		return nil
+	}
+
+	reserved, err := r.payoutRepo.GetReserved(ctx, owner)
+	if err != nil {
+		return err
+	}
+
+	now := clockNow()
+	for _, w := range limitWindows(limits) {
+		if w.max == 0 {
+			continue
+		}
+
+		used, err := r.used(ctx, owner, now.Add(-w.length), reserved)
+		if err != nil {
+			return err
+		}
+
+		remaining := max(w.max-used, 0)
+		if total > remaining {
+			return &LimitError{models.LimitExceeded{Limit: w.name, Max: w.max, Remaining: remaining}}
+		}
+	}
+
+	return nil
+}
+
+// used returns what owner sent out since then plus reserved, what its payouts in
+// progress hold back. Reserved money counts in every window: it is on its way out.
+func (r *limitServiceImpl) used(ctx context.Context, owner string, since time.Time, reserved models.Money) (models.Money, error) {
+	sent, err := r.transactionRepo.GetOutgoing(ctx, owner, since)
+	if err != nil {
+		return 0, err
+	}
+
+	return sent + reserved, nil
+}
+
+// GetLimits returns the limits of accId, the defaults with its overrides, and how much
+// it sent out or reserved for payouts over each window.
+func (r *limitServiceImpl) GetLimits(ctx context.Context, accId string) (models.LimitStatus, error) {
+	_, err := r.accountRepo.FindOne(ctx, accId)
+	if err != nil {
+		return models.LimitStatus{}, err
+	}
+
+	overrides, err := r.limitRepo.FindOne(ctx, accId)
+	if err != nil {
+		return models.LimitStatus{}, err
+	}
+
+	status := models.LimitStatus{
+		AccountId: accId,
+		Limits:    overrides.Apply(r.defaults),
+		Overrides: overrides,
+	}
+
+	reserved, err := r.payoutRepo.GetReserved(ctx, accId)
+	if err != nil {
+		return models.LimitStatus{}, err
+	}
+
+	now := clockNow()
+	windows := limitWindows(status.Limits)
+	used := []*models.Money{&status.Used.Daily, &status.Used.Weekly, &status.Used.Monthly}
+	remaining := []**models.Money{&status.Remaining.Daily, &status.Remaining.Weekly, &status.Remaining.Monthly}
+
+	for i, w := range windows {
+		*used[i], err = r.used(ctx, accId, now.Add(-w.length), reserved)
+		if err != nil {
+			return models.LimitStatus{}, err
+		}
+
+		if w.max > 0 {
+			left := max(w.max-*used[i], 0)
+			*remaining[i] = &left
+		}
+	}
+
+	return status, nil
+}
+
+// SetLimits replaces the limit overrides of accId.
+func (r *limitServiceImpl) SetLimits(ctx context.Context, accId string, overrides models.LimitOverrides) (models.LimitStatus, error) {
+	for _, o := range []*models.Money{overrides.MaxDeposit, overrides.MaxWithdrawal, overrides.MaxPayment, overrides.Daily, overrides.Weekly, overrides.Monthly} {
+		if o != nil && *o < 0 {
+			return models.LimitStatus{}, ErrInvalidLimits
+		}
+	}
+
+	_, err := r.accountRepo.FindOne(ctx, accId)
+	if err != nil {
+		return models.LimitStatus{}, err
+	}
+
+	err = r.limitRepo.Save(ctx, accId, overrides)
+	if err != nil {
+		return models.LimitStatus{}, err
+	}
+
+	return r.GetLimits(ctx, accId)
+}
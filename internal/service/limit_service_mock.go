@@ -0,0 +1,202 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package service
+
+import (
+	context "context"
+
+	models "github.com/gopay/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockLimitService is an autogenerated mock type for the LimitService type
+type MockLimitService struct {
+	mock.Mock
+}
+
+type MockLimitService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockLimitService) EXPECT() *MockLimitService_Expecter {
+	return &MockLimitService_Expecter{mock: &_m.Mock}
+}
+
+// Check provides a mock function with given fields: ctx, owner, kind, largest, total
+func (_m *MockLimitService) Check(ctx context.Context, owner string, kind models.TransactionKind, largest models.Money, total models.Money) error {
+	ret := _m.Called(ctx, owner, kind, largest, total)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Check")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.TransactionKind, models.Money, models.Money) error); ok {
+		r0 = rf(ctx, owner, kind, largest, total)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockLimitService_Check_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Check'
+type MockLimitService_Check_Call struct {
+	*mock.Call
+}
+
+// Check is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - kind models.TransactionKind
+//   - largest models.Money
+//   - total models.Money
+func (_e *MockLimitService_Expecter) Check(ctx interface{}, owner interface{}, kind interface{}, largest interface{}, total interface{}) *MockLimitService_Check_Call {
+	return &MockLimitService_Check_Call{Call: _e.mock.On("Check", ctx, owner, kind, largest, total)}
+}
+
+func (_c *MockLimitService_Check_Call) Run(run func(ctx context.Context, owner string, kind models.TransactionKind, largest models.Money, total models.Money)) *MockLimitService_Check_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.TransactionKind), args[3].(models.Money), args[4].(models.Money))
+	})
+	return _c
+}
+
+func (_c *MockLimitService_Check_Call) Return(_a0 error) *MockLimitService_Check_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLimitService_Check_Call) RunAndReturn(run func(context.Context, string, models.TransactionKind, models.Money, models.Money) error) *MockLimitService_Check_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLimits provides a mock function with given fields: ctx, accId
+func (_m *MockLimitService) GetLimits(ctx context.Context, accId string) (models.LimitStatus, error) {
+	ret := _m.Called(ctx, accId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLimits")
+	}
+
+	var r0 models.LimitStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.LimitStatus, error)); ok {
+		return rf(ctx, accId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.LimitStatus); ok {
+		r0 = rf(ctx, accId)
+	} else {
+		r0 = ret.Get(0).(models.LimitStatus)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, accId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLimitService_GetLimits_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLimits'
+type MockLimitService_GetLimits_Call struct {
+	*mock.Call
+}
+
+// GetLimits is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accId string
+func (_e *MockLimitService_Expecter) GetLimits(ctx interface{}, accId interface{}) *MockLimitService_GetLimits_Call {
+	return &MockLimitService_GetLimits_Call{Call: _e.mock.On("GetLimits", ctx, accId)}
+}
+
+func (_c *MockLimitService_GetLimits_Call) Run(run func(ctx context.Context, accId string)) *MockLimitService_GetLimits_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockLimitService_GetLimits_Call) Return(_a0 models.LimitStatus, _a1 error) *MockLimitService_GetLimits_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLimitService_GetLimits_Call) RunAndReturn(run func(context.Context, string) (models.LimitStatus, error)) *MockLimitService_GetLimits_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetLimits provides a mock function with given fields: ctx, accId, overrides
+func (_m *MockLimitService) SetLimits(ctx context.Context, accId string, overrides models.LimitOverrides) (models.LimitStatus, error) {
+	ret := _m.Called(ctx, accId, overrides)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetLimits")
+	}
+
+	var r0 models.LimitStatus
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.LimitOverrides) (models.LimitStatus, error)); ok {
+		return rf(ctx, accId, overrides)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.LimitOverrides) models.LimitStatus); ok {
+		r0 = rf(ctx, accId, overrides)
+	} else {
+		r0 = ret.Get(0).(models.LimitStatus)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.LimitOverrides) error); ok {
+		r1 = rf(ctx, accId, overrides)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockLimitService_SetLimits_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetLimits'
+type MockLimitService_SetLimits_Call struct {
+	*mock.Call
+}
+
+// SetLimits is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accId string
+//   - overrides models.LimitOverrides
+func (_e *MockLimitService_Expecter) SetLimits(ctx interface{}, accId interface{}, overrides interface{}) *MockLimitService_SetLimits_Call {
+	return &MockLimitService_SetLimits_Call{Call: _e.mock.On("SetLimits", ctx, accId, overrides)}
+}
+
+func (_c *MockLimitService_SetLimits_Call) Run(run func(ctx context.Context, accId string, overrides models.LimitOverrides)) *MockLimitService_SetLimits_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.LimitOverrides))
+	})
+	return _c
+}
+
+func (_c *MockLimitService_SetLimits_Call) Return(_a0 models.LimitStatus, _a1 error) *MockLimitService_SetLimits_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockLimitService_SetLimits_Call) RunAndReturn(run func(context.Context, string, models.LimitOverrides) (models.LimitStatus, error)) *MockLimitService_SetLimits_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockLimitService creates a new instance of MockLimitService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockLimitService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockLimitService {
+	mock := &MockLimitService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
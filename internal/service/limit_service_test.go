@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gopay/internal/models"
+	"github.com/gopay/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLimitService_Check(t *testing.T) {
+	var (
+		ctx   = context.Background()
+		now   = time.Now()
+		owner = "0001"
+	)
+
+	setupClock(now)
+	defer resetClock()
+
+	money := func(m models.Money) *models.Money { return &m }
+
+	type args struct {
+		kind    models.TransactionKind
+		largest models.Money
+		total   models.Money
+	}
+
+	scenarios := map[string]struct {
+		given   args
+		doMocks func(deps limitServiceDependencies)
+		wantErr error
+	}{
+		"within-limits": {
+			given: args{kind: models.KindPaymentOut, largest: 5000, total: 5000},
+			doMocks: func(deps limitServiceDependencies) {
+				deps.limitRepoMock.On("FindOne", ctx, owner).Return(models.LimitOverrides{}, nil)
+				deps.payoutRepoMock.On("GetReserved", ctx, owner).Return(models.Money(0), nil)
+				deps.transRepoMock.On("GetOutgoing", ctx, owner, now.Add(-dayWindow)).Return(models.Money(15000), nil)
+				deps.transRepoMock.On("GetOutgoing", ctx, owner, now.Add(-monthWindow)).Return(models.Money(40000), nil)
+			},
+			wantErr: nil,
+		},
+		"payment-too-large": {
+			given: args{kind: models.KindPaymentOut, largest: 10001, total: 10001},
+			doMocks: func(deps limitServiceDependencies) {
+				deps.limitRepoMock.On("FindOne", ctx, owner).Return(models.LimitOverrides{}, nil)
+			},
+			wantErr: &LimitError{models.LimitExceeded{Limit: LimitMaxPayment, Max: 10000, Remaining: 10000}},
+		},
+		"daily-exceeded": {
+			given: args{kind: models.KindWithdrawal, largest: 5001, total: 5001},
+			doMocks: func(deps limitServiceDependencies) {
+				deps.limitRepoMock.On("FindOne", ctx, owner).Return(models.LimitOverrides{}, nil)
+				deps.payoutRepoMock.On("GetReserved", ctx, owner).Return(models.Money(0), nil)
+				deps.transRepoMock.On("GetOutgoing", ctx, owner, now.Add(-dayWindow)).Return(models.Money(15000), nil)
+			},
+			wantErr: &LimitError{models.LimitExceeded{Limit: LimitDaily, Max: 20000, Remaining: 5000}},
+		},
+		"monthly-used-up": {
+			given: args{kind: models.KindPaymentOut, largest: 100, total: 100},
+			doMocks: func(deps limitServiceDependencies) {
+				deps.limitRepoMock.On("FindOne", ctx, owner).Return(models.LimitOverrides{Monthly: money(30000)}, nil)
+				deps.payoutRepoMock.On("GetReserved", ctx, owner).Return(models.Money(0), nil)
+				deps.transRepoMock.On("GetOutgoing", ctx, owner, now.Add(-dayWindow)).Return(models.Money(0), nil)
+				deps.transRepoMock.On("GetOutgoing", ctx, owner, now.Add(-monthWindow)).Return(models.Money(32000), nil)
+			},
+			wantErr: &LimitError{models.LimitExceeded{Limit: LimitMonthly, Max: 30000, Remaining: 0}},
+		},
+		"override-lifts-limit": {
+			given: args{kind: models.KindPaymentOut, largest: 50000, total: 50000},
+			doMocks: func(deps limitServiceDependencies) {
+				deps.limitRepoMock.On("FindOne", ctx, owner).Return(models.LimitOverrides{MaxPayment: money(0), Daily: money(0), Monthly: money(0)}, nil)
+				deps.payoutRepoMock.On("GetReserved", ctx, owner).Return(models.Money(0), nil)
+			},
+			wantErr: nil,
+		},
+		"batch-checks-windows-only": {
+			given: args{kind: models.KindPaymentOut, largest: 0, total: 18000},
+			doMocks: func(deps limitServiceDependencies) {
+				deps.limitRepoMock.On("FindOne", ctx, owner).Return(models.LimitOverrides{}, nil)
+				deps.payoutRepoMock.On("GetReserved", ctx, owner).Return(models.Money(0), nil)
+				deps.transRepoMock.On("GetOutgoing", ctx, owner, now.Add(-dayWindow)).Return(models.Money(0), nil)
+				deps.transRepoMock.On("GetOutgoing", ctx, owner, now.Add(-monthWindow)).Return(models.Money(0), nil)
+			},
+			wantErr: nil,
+		},
+		"reservations-count": {
+			given: args{kind: models.KindPaymentOut, largest: 5000, total: 5000},
+			doMocks: func(deps limitServiceDependencies) {
+				deps.limitRepoMock.On("FindOne", ctx, owner).Return(models.LimitOverrides{}, nil)
+				deps.payoutRepoMock.On("GetReserved", ctx, owner).Return(models.Money(6000), nil)
+				deps.transRepoMock.On("GetOutgoing", ctx, owner, now.Add(-dayWindow)).Return(models.Money(10000), nil)
+			},
+			wantErr: &LimitError{models.LimitExceeded{Limit: LimitDaily, Max: 20000, Remaining: 4000}},
+		},
+		"deposit-too-large": {
+			given: args{kind: models.KindDeposit, largest: 100001, total: 100001},
+			doMocks: func(deps limitServiceDependencies) {
+				deps.limitRepoMock.On("FindOne", ctx, owner).Return(models.LimitOverrides{}, nil)
+			},
+			wantErr: &LimitError{models.LimitExceeded{Limit: LimitMaxDeposit, Max: 100000, Remaining: 100000}},
+		},
+		"deposit-skips-windows": {
+			given: args{kind: models.KindDeposit, largest: 90000, total: 90000},
+			doMocks: func(deps limitServiceDependencies) {
+				deps.limitRepoMock.On("FindOne", ctx, owner).Return(models.LimitOverrides{}, nil)
+			},
+			wantErr: nil,
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			service, deps := setupLimitService(t)
+			if tcase.doMocks != nil {
+				tcase.doMocks(deps)
+			}
+
+			err := service.Check(ctx, owner, tcase.given.kind, tcase.given.largest, tcase.given.total)
+
+			if tcase.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrLimitExceeded)
+				assert.Equal(t, tcase.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestLimitService_GetLimits(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	owner := "0001"
+	monthly := models.Money(30000)
+
+	setupClock(now)
+	defer resetClock()
+
+	service, deps := setupLimitService(t)
+	deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{AccountId: owner}, nil)
+	deps.limitRepoMock.On("FindOne", ctx, owner).Return(models.LimitOverrides{Monthly: &monthly}, nil)
+	deps.payoutRepoMock.On("GetReserved", ctx, owner).Return(models.Money(1000), nil)
+	deps.transRepoMock.On("GetOutgoing", ctx, owner, now.Add(-dayWindow)).Return(models.Money(24000), nil)
+	deps.transRepoMock.On("GetOutgoing", ctx, owner, now.Add(-weekWindow)).Return(models.Money(27000), nil)
+	deps.transRepoMock.On("GetOutgoing", ctx, owner, now.Add(-monthWindow)).Return(models.Money(34000), nil)
+
+	status, err := service.GetLimits(ctx, owner)
+	assert.NoError(t, err)
+
+	none := models.Money(0)
+	assert.Equal(t, models.LimitStatus{
+		AccountId: owner,
+		Limits:    models.Limits{MaxDeposit: 100000, MaxPayment: 10000, Daily: 20000, Monthly: 30000},
+		Overrides: models.LimitOverrides{Monthly: &monthly},
+		Used:      models.LimitUsage{Daily: 25000, Weekly: 28000, Monthly: 35000},
+		Remaining: models.LimitRemaining{Daily: &none, Monthly: &none},
+	}, status)
+}
+
+func TestLimitService_SetLimits(t *testing.T) {
+	ctx := context.Background()
+	owner := "0001"
+	negative := models.Money(-1)
+	zero := models.Money(0)
+
+	t.Run("negative-limit", func(t *testing.T) {
+		service, _ := setupLimitService(t)
+
+		_, err := service.SetLimits(ctx, owner, models.LimitOverrides{Weekly: &negative})
+		assert.ErrorIs(t, err, ErrInvalidLimits)
+	})
+
+	t.Run("unknown-account", func(t *testing.T) {
+		service, deps := setupLimitService(t)
+		deps.accRepoMock.On("FindOne", ctx, "0009").Return(models.Account{}, repository.ErrAccountNotFound)
+
+		_, err := service.SetLimits(ctx, "0009", models.LimitOverrides{})
+		assert.ErrorIs(t, err, repository.ErrAccountNotFound)
+	})
+
+	t.Run("saves-overrides", func(t *testing.T) {
+		service, deps := setupLimitService(t)
+		overrides := models.LimitOverrides{MaxDeposit: &zero, Daily: &zero, Monthly: &zero}
+
+		deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{AccountId: owner}, nil)
+		deps.limitRepoMock.On("Save", ctx, owner, overrides).Return(nil)
+		deps.limitRepoMock.On("FindOne", ctx, owner).Return(overrides, nil)
+		deps.payoutRepoMock.On("GetReserved", ctx, owner).Return(models.Money(0), nil)
+		deps.transRepoMock.On("GetOutgoing", ctx, owner, mock.Anything).Return(models.Money(0), nil)
+
+		status, err := service.SetLimits(ctx, owner, overrides)
+		assert.NoError(t, err)
+		assert.Equal(t, models.Limits{MaxPayment: 10000}, status.Limits)
+		assert.Equal(t, models.LimitRemaining{}, status.Remaining)
+	})
+}
+
+type limitServiceDependencies struct {
+	limitRepoMock  *repository.MockLimitRepo
+	accRepoMock    *repository.MockAccountRepo
+	transRepoMock  *repository.MockTransactionRepo
+	payoutRepoMock *repository.MockPayoutRepo
+}
+
+func setupLimitService(t *testing.T) (*limitServiceImpl, limitServiceDependencies) {
+	deps := limitServiceDependencies{
+		limitRepoMock:  repository.NewMockLimitRepo(t),
+		accRepoMock:    repository.NewMockAccountRepo(t),
+		transRepoMock:  repository.NewMockTransactionRepo(t),
+		payoutRepoMock: repository.NewMockPayoutRepo(t),
+	}
+
+	defaults := models.Limits{MaxDeposit: 100000, MaxPayment: 10000, Daily: 20000, Monthly: 50000}
+
+	return NewLimitService(defaults, deps.limitRepoMock, deps.accRepoMock, deps.transRepoMock, deps.payoutRepoMock), deps
+}
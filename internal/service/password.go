@@ -0,0 +1,114 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	minPasswordLength = 8
+	maxPasswordLength = 128
+	passwordScheme    = "pbkdf2-sha256"
+	passwordSaltSize  = 16
+	passwordKeySize   = 32
+)
+
+var (
+	ErrInvalidPassword = errors.New("invalid password")
+	ErrPasswordLength  = fmt.Errorf("password must be %d to %d characters: %w", minPasswordLength, maxPasswordLength, ErrInvalidPassword)
+)
+
+// passwordIterations is how many PBKDF2 rounds new hashes use. Hashes keep their own
+// count, so raising it does not lock anyone out.
+var passwordIterations = 600000
+
+func validatePassword(password string) error {
+	n := utf8.RuneCountInString(password)
+	if n < minPasswordLength || n > maxPasswordLength {
+		return ErrPasswordLength
+	}
+	return nil
+}
+
+// hashPassword salts and hashes password with PBKDF2-HMAC-SHA256 and encodes the result
+// as "pbkdf2-sha256$<iterations>$<salt>$<key>".
+func hashPassword(password string) (string, error) {
+	salt := make([]byte, passwordSaltSize)
+	_, err := rand.Read(salt)
+	if err != nil {
+		return "", err
+	}
+
+	key := pbkdf2SHA256([]byte(password), salt, passwordIterations, passwordKeySize)
+
+	return strings.Join([]string{
+		passwordScheme,
+		strconv.Itoa(passwordIterations),
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	}, "$"), nil
+}
+
+// checkPassword tells whether password is the one hash was made from. Malformed hashes
+// match nothing.
+func checkPassword(hash string, password string) bool {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 4 || parts[0] != passwordScheme {
+		return false
+	}
+
+	iterations, err := strconv.Atoi(parts[1])
+	if err != nil || iterations <= 0 {
+		return false
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false
+	}
+
+	want, err := base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil || len(want) == 0 {
+		return false
+	}
+
+	got := pbkdf2SHA256([]byte(password), salt, iterations, len(want))
+	return subtle.ConstantTimeCompare(got, want) == 1
+}
+
+// pbkdf2SHA256 derives a keyLen bytes key from password as RFC 8018 describes, with
+// HMAC-SHA256 as the pseudorandom function.
+func pbkdf2SHA256(password []byte, salt []byte, iterations int, keyLen int) []byte {
+	prf := hmac.New(sha256.New, password)
+	blocks := (keyLen + prf.Size() - 1) / prf.Size()
+
+	key := make([]byte, 0, blocks*prf.Size())
+	u := make([]byte, 0, prf.Size())
+	for block := 1; block <= blocks; block++ {
+		prf.Reset()
+		prf.Write(salt)
+		prf.Write([]byte{byte(block >> 24), byte(block >> 16), byte(block >> 8), byte(block)})
+		u = prf.Sum(u[:0])
+
+		t := append([]byte(nil), u...)
+		for i := 1; i < iterations; i++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for j := range t {
+				t[j] ^= u[j]
+			}
+		}
+		key = append(key, t...)
+	}
+
+	return key[:keyLen]
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPbkdf2SHA256(t *testing.T) {
+	// Test vectors of RFC 7914, section 11.
+	scenarios := map[string]struct {
+		password   string
+		salt       string
+		iterations int
+		want       string
+	}{
+		"one-iteration": {
+			password:   "passwd",
+			salt:       "salt",
+			iterations: 1,
+			want:       "55ac046e56e3089fec1691c22544b605f94185216dde0465e68b9d57c20dacbc49ca9cccf179b645991664b39d77ef317c71b845b1e30bd509112041d3a19783",
+		},
+		"many-iterations": {
+			password:   "Password",
+			salt:       "NaCl",
+			iterations: 80000,
+			want:       "4ddcd8f60b98be21830cee5ef22701f9641a4418d04c0414aeff08876b34ab56a1d425a1225833549adb841b51c9b3176a272bdebba1d078478f62b397f33c8d",
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			key := pbkdf2SHA256([]byte(tcase.password), []byte(tcase.salt), tcase.iterations, 64)
+			assert.Equal(t, tcase.want, hex.EncodeToString(key))
+		})
+	}
+}
+
+func TestCheckPassword(t *testing.T) {
+	setupPasswordIterations(t)
+
+	hash, err := hashPassword("correct horse")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "pbkdf2-sha256$1000$"))
+
+	other, err := hashPassword("correct horse")
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, other, "hashes must be salted")
+
+	assert.True(t, checkPassword(hash, "correct horse"))
+	assert.False(t, checkPassword(hash, "correct horsE"))
+	assert.False(t, checkPassword(hash, ""))
+	assert.False(t, checkPassword("", "correct horse"))
+	assert.False(t, checkPassword("md5$1000$c2FsdA$a2V5", "correct horse"))
+	assert.False(t, checkPassword("pbkdf2-sha256$0$c2FsdA$a2V5", "correct horse"))
+	assert.False(t, checkPassword("pbkdf2-sha256$1000$c2FsdA$", "correct horse"))
+}
+
+func TestValidatePassword(t *testing.T) {
+	assert.ErrorIs(t, validatePassword(""), ErrInvalidPassword)
+	assert.ErrorIs(t, validatePassword("1234567"), ErrPasswordLength)
+	assert.NoError(t, validatePassword("12345678"))
+	assert.NoError(t, validatePassword("ção-ção-"))
+	assert.NoError(t, validatePassword(strings.Repeat("a", maxPasswordLength)))
+	assert.ErrorIs(t, validatePassword(strings.Repeat("a", maxPasswordLength+1)), ErrPasswordLength)
+}
+
+// setupPasswordIterations makes password hashing cheap for the rest of the test.
+func setupPasswordIterations(t *testing.T) {
+	previous := passwordIterations
+	passwordIterations = 1000
+	t.Cleanup(func() {
+		passwordIterations = previous
+	})
+}
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gopay/internal/models"
+	"github.com/gopay/internal/repository"
+)
+
+var (
+	ErrPaymentRequestExpired   = errors.New("payment request has expired")
+	ErrPaymentRequestForbidden = errors.New("payment request belongs to another account")
+	ErrNoteTooLong             = errors.New("note must be at most 140 characters")
+)
+
+const (
+	paymentRequestTTL = 7 * 24 * time.Hour
+	maxNoteLength     = 140
+)
+
+type PaymentRequestService interface {
+	Create(ctx context.Context, requester string, payer string, amount models.Money, note string) (models.PaymentRequest, error)
+	GetIncoming(ctx context.Context, payer string, status models.PaymentRequestStatus) ([]models.PaymentRequest, error)
+	GetOutgoing(ctx context.Context, requester string, status models.PaymentRequestStatus) ([]models.PaymentRequest, error)
+	Accept(ctx context.Context, id string, payer string) error
+	Decline(ctx context.Context, id string, payer string) error
+	Cancel(ctx context.Context, id string, requester string) error
+}
+
+var _ PaymentRequestService = (*paymentRequestServiceImpl)(nil)
+
+type paymentRequestServiceImpl struct {
+	requestRepo    repository.PaymentRequestRepo
+	accountRepo    repository.AccountRepo
+	transactionSvc TransactionService
+	uow            repository.UnitOfWork
+}
+
+func NewPaymentRequestService(requestRepo repository.PaymentRequestRepo, accountRepo repository.AccountRepo, transactionSvc TransactionService, uow repository.UnitOfWork) *paymentRequestServiceImpl {
+	return &paymentRequestServiceImpl{
+		requestRepo:    requestRepo,
+		accountRepo:    accountRepo,
+		transactionSvc: transactionSvc,
+		uow:            uow,
+	}
+}
+
+func (r *paymentRequestServiceImpl) Create(ctx context.Context, requester string, payer string, amount models.Money, note string) (models.PaymentRequest, error) {
+	if amount <= 0 {
+		return models.PaymentRequest{}, ErrInvalidAmount
+	}
+
+	if requester == payer {
+		return models.PaymentRequest{}, ErrInvalidPaymentOp
+	}
+
+	if utf8.RuneCountInString(note) > maxNoteLength {
+		return models.PaymentRequest{}, ErrNoteTooLong
+	}
+
+	_, err := r.accountRepo.FindOne(ctx, requester)
+	if err != nil {
+		return models.PaymentRequest{}, err
+	}
+
+	_, err = r.accountRepo.FindOne(ctx, payer)
+	if err != nil {
+		return models.PaymentRequest{}, err
+	}
+
+	now := clockNow()
+	request := models.PaymentRequest{
+		Requester: requester,
+		Payer:     payer,
+		Amount:    amount,
+		Note:      note,
+		CreatedAt: now,
+		ExpiresAt: now.Add(paymentRequestTTL),
+	}
+
+	id, err := r.requestRepo.Create(ctx, request)
+	if err != nil {
+		return models.PaymentRequest{}, err
+	}
+
+	request.RequestId = id
+	request.Status = models.RequestPending
+	request.UpdatedAt = now
+
+	return request, nil
+}
+
+func (r *paymentRequestServiceImpl) GetIncoming(ctx context.Context, payer string, status models.PaymentRequestStatus) ([]models.PaymentRequest, error) {
+	_, err := r.accountRepo.FindOne(ctx, payer)
+	if err != nil {
+		return []models.PaymentRequest{}, err
+	}
+
+	requests, err := r.requestRepo.FindByPayer(ctx, payer, storedStatus(status))
+	if err != nil {
+		return []models.PaymentRequest{}, err
+	}
+
+	return withExpiry(requests, status), nil
+}
+
+func (r *paymentRequestServiceImpl) GetOutgoing(ctx context.Context, requester string, status models.PaymentRequestStatus) ([]models.PaymentRequest, error) {
+	_, err := r.accountRepo.FindOne(ctx, requester)
+	if err != nil {
+		return []models.PaymentRequest{}, err
+	}
+
+	requests, err := r.requestRepo.FindByRequester(ctx, requester, storedStatus(status))
+	if err != nil {
+		return []models.PaymentRequest{}, err
+	}
+
+	return withExpiry(requests, status), nil
+}
+
+// Accept pays the requester. The request is resolved in the same unit of work as the
+// payment, so a failed payment leaves it pending and two accepts cannot both pay.
+func (r *paymentRequestServiceImpl) Accept(ctx context.Context, id string, payer string) error {
+	request, err := r.pending(ctx, id, func(p models.PaymentRequest) bool { return p.Payer == payer })
+	if err != nil {
+		return err
+	}
+
+	return r.uow.Do(ctx, func(ctx context.Context) error {
+		err := r.requestRepo.Resolve(ctx, id, models.RequestAccepted, clockNow())
+		if err != nil {
+			return err
+		}
+
+		_, err = r.transactionSvc.Pay(ctx, payer, request.Requester, request.Amount, models.Annotation{Memo: request.Note})
+		return err
+	})
+}
+
+func (r *paymentRequestServiceImpl) Decline(ctx context.Context, id string, payer string) error {
+	_, err := r.pending(ctx, id, func(p models.PaymentRequest) bool { return p.Payer == payer })
+	if err != nil {
+		return err
+	}
+
+	return r.requestRepo.Resolve(ctx, id, models.RequestDeclined, clockNow())
+}
+
+func (r *paymentRequestServiceImpl) Cancel(ctx context.Context, id string, requester string) error {
+	_, err := r.pending(ctx, id, func(p models.PaymentRequest) bool { return p.Requester == requester })
+	if err != nil {
+		return err
+	}
+
+	return r.requestRepo.Resolve(ctx, id, models.RequestCancelled, clockNow())
+}
+
+// pending loads a request that allowed may act on and that can still be resolved.
+// A pending request found past its expiry is marked expired on the way.
+func (r *paymentRequestServiceImpl) pending(ctx context.Context, id string, allowed func(p models.PaymentRequest) bool) (models.PaymentRequest, error) {
+	request, err := r.requestRepo.FindOne(ctx, id)
+	if err != nil {
+		return models.PaymentRequest{}, err
+	}
+
+	if !allowed(request) {
+		return models.PaymentRequest{}, ErrPaymentRequestForbidden
+	}
+
+	if request.Status != models.RequestPending {
+		return models.PaymentRequest{}, repository.ErrPaymentRequestNotPending
+	}
+
+	if !clockNow().Before(request.ExpiresAt) {
+		err = r.requestRepo.Resolve(ctx, id, models.RequestExpired, clockNow())
+		if err != nil && err != repository.ErrPaymentRequestNotPending {
+			return models.PaymentRequest{}, err
+		}
+		return models.PaymentRequest{}, ErrPaymentRequestExpired
+	}
+
+	return request, nil
+}
+
+// storedStatus is the status to look up to find requests in status: a request past its
+// expiry may still be stored as pending.
+func storedStatus(status models.PaymentRequestStatus) models.PaymentRequestStatus {
+	if status == models.RequestExpired {
+		return ""
+	}
+	return status
+}
+
+// withExpiry reports pending requests past their expiry as expired and keeps those in
+// status, or all of them if status is empty. They are marked expired for good once acted upon.
+func withExpiry(requests []models.PaymentRequest, status models.PaymentRequestStatus) []models.PaymentRequest {
+	now := clockNow()
+	result := make([]models.PaymentRequest, 0, len(requests))
+
+	for _, p := range requests {
+		if p.Status == models.RequestPending && !now.Before(p.ExpiresAt) {
+			p.Status = models.RequestExpired
+		}
+		if status != "" && p.Status != status {
+			continue
+		}
+		result = append(result, p)
+	}
+
+	return result
+}
@@ -0,0 +1,310 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gopay/internal/models"
+	"github.com/gopay/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPaymentRequestService_Create(t *testing.T) {
+	now := time.Now()
+	setupClock(now)
+	defer resetClock()
+
+	var (
+		ctx       = context.Background()
+		requester = "0001"
+		payer     = "0002"
+	)
+
+	type args struct {
+		payer  string
+		amount models.Money
+		note   string
+	}
+
+	scenarios := map[string]struct {
+		given   args
+		doMocks func(deps paymentRequestServiceDependencies)
+		want    models.PaymentRequest
+		wantErr error
+	}{
+		"happy-path": {
+			given: args{
+				payer:  payer,
+				amount: 1500,
+				note:   "pizza",
+			},
+			doMocks: func(deps paymentRequestServiceDependencies) {
+				deps.accRepoMock.On("FindOne", ctx, requester).Return(models.Account{AccountId: requester}, nil)
+				deps.accRepoMock.On("FindOne", ctx, payer).Return(models.Account{AccountId: payer}, nil)
+				deps.requestRepoMock.On("Create", ctx, models.PaymentRequest{
+					Requester: requester,
+					Payer:     payer,
+					Amount:    1500,
+					Note:      "pizza",
+					CreatedAt: now,
+					ExpiresAt: now.Add(paymentRequestTTL),
+				}).Return("r-1", nil)
+			},
+			want: models.PaymentRequest{
+				RequestId: "r-1",
+				Requester: requester,
+				Payer:     payer,
+				Amount:    1500,
+				Note:      "pizza",
+				Status:    models.RequestPending,
+				CreatedAt: now,
+				ExpiresAt: now.Add(paymentRequestTTL),
+				UpdatedAt: now,
+			},
+			wantErr: nil,
+		},
+		"invalid-amount": {
+			given: args{
+				payer:  payer,
+				amount: 0,
+			},
+			wantErr: ErrInvalidAmount,
+		},
+		"request-from-self": {
+			given: args{
+				payer:  requester,
+				amount: 1500,
+			},
+			wantErr: ErrInvalidPaymentOp,
+		},
+		"payer-not-found": {
+			given: args{
+				payer:  payer,
+				amount: 1500,
+			},
+			doMocks: func(deps paymentRequestServiceDependencies) {
+				deps.accRepoMock.On("FindOne", ctx, requester).Return(models.Account{AccountId: requester}, nil)
+				deps.accRepoMock.On("FindOne", ctx, payer).Return(models.Account{}, repository.ErrAccountNotFound)
+			},
+			wantErr: repository.ErrAccountNotFound,
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			service, deps := setupPaymentRequestService(t)
+			if tcase.doMocks != nil {
+				tcase.doMocks(deps)
+			}
+
+			request, err := service.Create(ctx, requester, tcase.given.payer, tcase.given.amount, tcase.given.note)
+
+			if tcase.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tcase.wantErr)
+			}
+
+			assert.Equal(t, tcase.want, request)
+		})
+	}
+}
+
+func TestPaymentRequestService_Accept(t *testing.T) {
+	now := time.Now()
+	setupClock(now)
+	defer resetClock()
+
+	var (
+		ctx     = context.Background()
+		request = models.PaymentRequest{
+			RequestId: "r-1",
+			Requester: "0001",
+			Payer:     "0002",
+			Amount:    1500,
+			Note:      "pizza",
+			Status:    models.RequestPending,
+			CreatedAt: now.Add(-time.Hour),
+			ExpiresAt: now.Add(time.Hour),
+		}
+		expired = models.PaymentRequest{
+			RequestId: "r-2",
+			Requester: "0001",
+			Payer:     "0002",
+			Amount:    1500,
+			Status:    models.RequestPending,
+			CreatedAt: now.Add(-2 * paymentRequestTTL),
+			ExpiresAt: now.Add(-paymentRequestTTL),
+		}
+	)
+
+	type args struct {
+		id    string
+		payer string
+	}
+
+	scenarios := map[string]struct {
+		given   args
+		doMocks func(deps paymentRequestServiceDependencies)
+		wantErr error
+	}{
+		"happy-path": {
+			given: args{
+				id:    "r-1",
+				payer: "0002",
+			},
+			doMocks: func(deps paymentRequestServiceDependencies) {
+				deps.requestRepoMock.On("FindOne", ctx, "r-1").Return(request, nil)
+				deps.requestRepoMock.On("Resolve", ctx, "r-1", models.RequestAccepted, now).Return(nil)
+				deps.transactionSvcMock.On("Pay", ctx, "0002", "0001", models.Money(1500), models.Annotation{Memo: "pizza"}).Return(models.Receipt{}, nil)
+			},
+			wantErr: nil,
+		},
+		"payment-fails": {
+			given: args{
+				id:    "r-1",
+				payer: "0002",
+			},
+			doMocks: func(deps paymentRequestServiceDependencies) {
+				deps.requestRepoMock.On("FindOne", ctx, "r-1").Return(request, nil)
+				deps.requestRepoMock.On("Resolve", ctx, "r-1", models.RequestAccepted, now).Return(nil)
+				deps.transactionSvcMock.On("Pay", ctx, "0002", "0001", models.Money(1500), models.Annotation{Memo: "pizza"}).Return(models.Receipt{}, ErrInsufficentBalance)
+			},
+			wantErr: ErrInsufficentBalance,
+		},
+		"not-the-payer": {
+			given: args{
+				id:    "r-1",
+				payer: "0001",
+			},
+			doMocks: func(deps paymentRequestServiceDependencies) {
+				deps.requestRepoMock.On("FindOne", ctx, "r-1").Return(request, nil)
+			},
+			wantErr: ErrPaymentRequestForbidden,
+		},
+		"already-resolved": {
+			given: args{
+				id:    "r-1",
+				payer: "0002",
+			},
+			doMocks: func(deps paymentRequestServiceDependencies) {
+				declined := request
+				declined.Status = models.RequestDeclined
+				deps.requestRepoMock.On("FindOne", ctx, "r-1").Return(declined, nil)
+			},
+			wantErr: repository.ErrPaymentRequestNotPending,
+		},
+		"expired": {
+			given: args{
+				id:    "r-2",
+				payer: "0002",
+			},
+			doMocks: func(deps paymentRequestServiceDependencies) {
+				deps.requestRepoMock.On("FindOne", ctx, "r-2").Return(expired, nil)
+				deps.requestRepoMock.On("Resolve", ctx, "r-2", models.RequestExpired, now).Return(nil)
+			},
+			wantErr: ErrPaymentRequestExpired,
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			service, deps := setupPaymentRequestService(t)
+			if tcase.doMocks != nil {
+				tcase.doMocks(deps)
+			}
+
+			err := service.Accept(ctx, tcase.given.id, tcase.given.payer)
+
+			if tcase.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tcase.wantErr)
+			}
+		})
+	}
+}
+
+func TestPaymentRequestService_GetIncoming(t *testing.T) {
+	now := time.Now()
+	setupClock(now)
+	defer resetClock()
+
+	var (
+		ctx   = context.Background()
+		payer = "0002"
+		live  = models.PaymentRequest{RequestId: "r-1", Payer: payer, Status: models.RequestPending, ExpiresAt: now.Add(time.Hour)}
+		stale = models.PaymentRequest{RequestId: "r-2", Payer: payer, Status: models.RequestPending, ExpiresAt: now.Add(-time.Hour)}
+	)
+
+	expiredStale := stale
+	expiredStale.Status = models.RequestExpired
+
+	scenarios := map[string]struct {
+		status     models.PaymentRequestStatus
+		repoStatus models.PaymentRequestStatus
+		stored     []models.PaymentRequest
+		want       []models.PaymentRequest
+	}{
+		"any-status": {
+			status:     "",
+			repoStatus: "",
+			stored:     []models.PaymentRequest{live, stale},
+			want:       []models.PaymentRequest{live, expiredStale},
+		},
+		"pending-hides-expired": {
+			status:     models.RequestPending,
+			repoStatus: models.RequestPending,
+			stored:     []models.PaymentRequest{live, stale},
+			want:       []models.PaymentRequest{live},
+		},
+		"expired": {
+			status:     models.RequestExpired,
+			repoStatus: "",
+			stored:     []models.PaymentRequest{live, stale},
+			want:       []models.PaymentRequest{expiredStale},
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			service, deps := setupPaymentRequestService(t)
+			deps.accRepoMock.On("FindOne", ctx, payer).Return(models.Account{AccountId: payer}, nil)
+			deps.requestRepoMock.On("FindByPayer", ctx, payer, tcase.repoStatus).Return(tcase.stored, nil)
+
+			requests, err := service.GetIncoming(ctx, payer, tcase.status)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tcase.want, requests)
+		})
+	}
+}
+
+type paymentRequestServiceDependencies struct {
+	requestRepoMock    *repository.MockPaymentRequestRepo
+	accRepoMock        *repository.MockAccountRepo
+	transactionSvcMock *MockTransactionService
+	uowMock            *repository.MockUnitOfWork
+}
+
+func setupPaymentRequestService(t *testing.T) (*paymentRequestServiceImpl, paymentRequestServiceDependencies) {
+	deps := paymentRequestServiceDependencies{
+		requestRepoMock:    repository.NewMockPaymentRequestRepo(t),
+		accRepoMock:        repository.NewMockAccountRepo(t),
+		transactionSvcMock: NewMockTransactionService(t),
+		uowMock:            repository.NewMockUnitOfWork(t),
+	}
+
+	deps.uowMock.EXPECT().Do(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		}).
+		Maybe()
+
+	return NewPaymentRequestService(deps.requestRepoMock, deps.accRepoMock, deps.transactionSvcMock, deps.uowMock), deps
+}
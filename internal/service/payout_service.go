@@ -0,0 +1,282 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gopay/internal/models"
+	"github.com/gopay/internal/repository"
+	"github.com/gopay/internal/utils"
+	"github.com/rs/zerolog/log"
+)
+
+const maxPayoutItems = 1000
+
+var (
+	ErrInvalidPayoutBatch = errors.New("invalid payout batch")
+	ErrPayoutBatchSize    = fmt.Errorf("batch must have between 1 and %d items: %w", maxPayoutItems, ErrInvalidPayoutBatch)
+	ErrPayoutNotOwned     = errors.New("payout batch belongs to another account")
+)
+
+type PayoutService interface {
+	CreateBatch(ctx context.Context, owner string, items []models.PayoutReq) (models.PayoutBatch, error)
+	GetBatch(ctx context.Context, id string, owner string) (models.PayoutBatch, error)
+	Drain(ctx context.Context) error
+	Run(ctx context.Context, interval time.Duration)
+}
+
+var _ PayoutService = (*payoutServiceImpl)(nil)
+
+type payoutServiceImpl struct {
+	payoutRepo      repository.PayoutRepo
+	accountRepo     repository.AccountRepo
+	transactionRepo repository.TransactionRepo
+	transactionSvc  TransactionService
+	uow             repository.UnitOfWork
+}
+
+func NewPayoutService(payoutRepo repository.PayoutRepo, accountRepo repository.AccountRepo, transactionRepo repository.TransactionRepo, transactionSvc TransactionService, uow repository.UnitOfWork) *payoutServiceImpl {
+	return &payoutServiceImpl{
+		payoutRepo:      payoutRepo,
+		accountRepo:     accountRepo,
+		transactionRepo: transactionRepo,
+		transactionSvc:  transactionSvc,
+		uow:             uow,
+	}
+}
+
+// CreateBatch accepts a batch of payouts and pays it in the background. The owner's
+// balance is checked once: items are reserved in order while it lasts, and the ones it
+// cannot cover fail with insufficient balance without holding anything.
+func (r *payoutServiceImpl) CreateBatch(ctx context.Context, owner string, items []models.PayoutReq) (models.PayoutBatch, error) {
+	err := validatePayouts(owner, items)
+	if err != nil {
+		return models.PayoutBatch{}, err
+	}
+
+	_, err = r.accountRepo.FindOne(ctx, owner)
+	if err != nil {
+		return models.PayoutBatch{}, err
+	}
+
+	batch := models.PayoutBatch{
+		Owner:     owner,
+		CreatedAt: clockNow(),
+	}
+
+	var id string
+
+	err = r.uow.Do(ctx, func(ctx context.Context) error {
+		err := r.transactionRepo.LockAccount(ctx, owner)
+		if err != nil {
+			return err
+		}
+
+		balance, err := r.transactionSvc.GetBalance(ctx, owner)
+		if err != nil {
+			return err
+		}
+
+		available := balance.Available
+		var largest models.Money
+
+		for i, req := range items {
+			item := models.PayoutItem{
+				Position: i + 1,
+				Receiver: req.Receiver,
+				Amount:   req.Amount,
+				Result:   models.PayoutPending,
+			}
+
+			if req.Amount > available {
+				item.Result = models.PayoutInsufficientBalance
+			} else {
+				available -= req.Amount
+				batch.Reserved += req.Amount
+				largest = max(largest, req.Amount)
+			}
+
+			batch.Total += req.Amount
+			batch.Items = append(batch.Items, item)
+		}
+
+		if batch.Reserved > 0 {
+			err = r.transactionSvc.ReservePayout(ctx, owner, largest, batch.Reserved)
+			if err != nil {
+				return err
+			}
+		}
+
+		id, err = r.payoutRepo.CreateBatch(ctx, batch)
+		return err
+	})
+	if err != nil {
+		return models.PayoutBatch{}, err
+	}
+
+	batch, err = r.payoutRepo.FindBatch(ctx, id)
+	if err != nil {
+		return models.PayoutBatch{}, err
+	}
+
+	utils.Go(func() {
+		err := r.Drain(context.WithoutCancel(ctx))
+		if err != nil {
+			log.Error().Err(err).Str("batch", id).Msg("PayoutService::CreateBatch")
+		}
+	})
+
+	return summarize(batch), nil
+}
+
+func validatePayouts(owner string, items []models.PayoutReq) error {
+	if len(items) == 0 || len(items) > maxPayoutItems {
+		return ErrPayoutBatchSize
+	}
+
+	var total models.Money
+
+	for i, item := range items {
+		if item.Receiver == "" {
+			return fmt.Errorf("item %d: missing receiver: %w", i+1, ErrInvalidPayoutBatch)
+		}
+		if item.Receiver == owner {
+			return fmt.Errorf("item %d: %s: %w", i+1, ErrInvalidPaymentOp, ErrInvalidPayoutBatch)
+		}
+		if item.Amount <= 0 || total+item.Amount < total {
+			return fmt.Errorf("item %d: %s: %w", i+1, ErrInvalidAmount, ErrInvalidPayoutBatch)
+		}
+
+		total += item.Amount
+	}
+
+	return nil
+}
+
+func (r *payoutServiceImpl) GetBatch(ctx context.Context, id string, owner string) (models.PayoutBatch, error) {
+	batch, err := r.payoutRepo.FindBatch(ctx, id)
+	if err != nil {
+		return models.PayoutBatch{}, err
+	}
+
+	if batch.Owner != owner {
+		return models.PayoutBatch{}, ErrPayoutNotOwned
+	}
+
+	return summarize(batch), nil
+}
+
+// summarize fills in the counters and status of batch from its items.
+func summarize(batch models.PayoutBatch) models.PayoutBatch {
+	batch.Succeeded, batch.Failed, batch.Pending = 0, 0, 0
+
+	for _, item := range batch.Items {
+		switch item.Result {
+		case models.PayoutPending:
+			batch.Pending++
+		case models.PayoutSucceeded:
+			batch.Succeeded++
+		default:
+			batch.Failed++
+		}
+	}
+
+	batch.Status = models.BatchCompleted
+	if batch.Pending > 0 {
+		batch.Status = models.BatchProcessing
+	}
+
+	return batch
+}
+
+// Drain pays every pending item once. Each item is claimed inside its own unit of work,
+// so several replicas can drain batches at the same time.
+func (r *payoutServiceImpl) Drain(ctx context.Context) error {
+	pending, err := r.payoutRepo.FindPendingItems(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range pending {
+		r.pay(ctx, item)
+	}
+
+	return nil
+}
+
+// pay moves the reserved amount of item to its receiver. When the receiver does not
+// exist, or the payout fails for good, the amount goes back to the owner instead.
+func (r *payoutServiceImpl) pay(ctx context.Context, item models.PayoutItem) {
+	err := r.uow.Do(ctx, func(ctx context.Context) error {
+		claimed, err := r.payoutRepo.ClaimItem(ctx, item.ItemId)
+		if err != nil {
+			return err
+		}
+
+		err = r.transactionSvc.Payout(ctx, claimed.Owner, claimed.Receiver, claimed.Amount)
+		if err == repository.ErrAccountNotFound {
+			return r.release(ctx, claimed, models.PayoutUnknownReceiver, "")
+		}
+		if err != nil {
+			return err
+		}
+
+		return r.payoutRepo.SetItemResult(ctx, claimed.ItemId, models.PayoutSucceeded, "", clockNow())
+	})
+	if err == nil || err == repository.ErrPayoutItemNotFound {
+		return
+	}
+
+	log.Error().Err(err).Str("item", item.ItemId).Msg("PayoutService::pay")
+
+	// The failed payout was rolled back, so the item is still pending and its amount
+	// still reserved. Failures that may clear up, e.g. a busy account, are left for the
+	// next Drain to try again.
+	if isRetryable(err) {
+		return
+	}
+
+	cause := err
+	err = r.uow.Do(ctx, func(ctx context.Context) error {
+		claimed, err := r.payoutRepo.ClaimItem(ctx, item.ItemId)
+		if err != nil {
+			return err
+		}
+
+		return r.release(ctx, claimed, models.PayoutFailed, cause.Error())
+	})
+	if err != nil && err != repository.ErrPayoutItemNotFound {
+		log.Error().Err(err).Str("item", item.ItemId).Msg("PayoutService::pay")
+	}
+}
+
+func (r *payoutServiceImpl) release(ctx context.Context, item models.PayoutItem, result models.PayoutResult, message string) error {
+	err := r.transactionSvc.ReleasePayout(ctx, item.Owner, item.Amount)
+	if err != nil {
+		return err
+	}
+
+	return r.payoutRepo.SetItemResult(ctx, item.ItemId, result, message, clockNow())
+}
+
+// Run drains pending items right away and then every interval until ctx is done, which
+// picks up batches left unfinished by a restart.
+func (r *payoutServiceImpl) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := r.Drain(ctx)
+		if err != nil {
+			log.Error().Err(err).Msg("PayoutService::Run")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
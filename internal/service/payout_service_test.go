@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gopay/internal/models"
+	"github.com/gopay/internal/repository"
+	"github.com/gopay/internal/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPayoutService_CreateBatch(t *testing.T) {
+	now := time.Now()
+	setupClock(now)
+	utils.SetSyncGoroutine()
+	defer utils.ResetGoroutine()
+	defer resetClock()
+
+	var (
+		ctx   = context.Background()
+		owner = "0001"
+		items = []models.PayoutReq{
+			{Receiver: "0002", Amount: 3000},
+			{Receiver: "0003", Amount: 5000},
+			{Receiver: "0004", Amount: 1000},
+		}
+	)
+
+	scenarios := map[string]struct {
+		given   []models.PayoutReq
+		doMocks func(deps payoutServiceDependencies)
+		want    models.PayoutBatch
+		wantErr error
+	}{
+		"reserves-what-the-balance-covers": {
+			given: items,
+			doMocks: func(deps payoutServiceDependencies) {
+				stored := models.PayoutBatch{
+					Owner:     owner,
+					Total:     9000,
+					Reserved:  4000,
+					CreatedAt: now,
+					Items: []models.PayoutItem{
+						{Position: 1, Receiver: "0002", Amount: 3000, Result: models.PayoutPending},
+						{Position: 2, Receiver: "0003", Amount: 5000, Result: models.PayoutInsufficientBalance},
+						{Position: 3, Receiver: "0004", Amount: 1000, Result: models.PayoutPending},
+					},
+				}
+
+				deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{AccountId: owner}, nil)
+				deps.transRepoMock.On("LockAccount", ctx, owner).Return(nil)
+				deps.transactionSvcMock.On("GetBalance", ctx, owner).Return(models.Balance{AccountId: owner, Amount: 5500, Held: 1000, Available: 4500}, nil)
+				deps.transactionSvcMock.On("ReservePayout", ctx, owner, models.Money(3000), models.Money(4000)).Return(nil)
+				deps.payoutRepoMock.On("CreateBatch", ctx, stored).Return("b-1", nil)
+
+				stored.BatchId = "b-1"
+				deps.payoutRepoMock.On("FindBatch", ctx, "b-1").Return(stored, nil)
+				deps.payoutRepoMock.On("FindPendingItems", mock.Anything).Return([]models.PayoutItem{}, nil)
+			},
+			want: models.PayoutBatch{
+				BatchId:   "b-1",
+				Owner:     owner,
+				Total:     9000,
+				Reserved:  4000,
+				Status:    models.BatchProcessing,
+				Failed:    1,
+				Pending:   2,
+				CreatedAt: now,
+				Items: []models.PayoutItem{
+					{Position: 1, Receiver: "0002", Amount: 3000, Result: models.PayoutPending},
+					{Position: 2, Receiver: "0003", Amount: 5000, Result: models.PayoutInsufficientBalance},
+					{Position: 3, Receiver: "0004", Amount: 1000, Result: models.PayoutPending},
+				},
+			},
+			wantErr: nil,
+		},
+		"empty-batch": {
+			given:   []models.PayoutReq{},
+			wantErr: ErrPayoutBatchSize,
+		},
+		"invalid-amount": {
+			given:   []models.PayoutReq{{Receiver: "0002", Amount: 0}},
+			wantErr: ErrInvalidPayoutBatch,
+		},
+		"pay-self": {
+			given:   []models.PayoutReq{{Receiver: owner, Amount: 100}},
+			wantErr: ErrInvalidPayoutBatch,
+		},
+		"unknown-owner": {
+			given: items,
+			doMocks: func(deps payoutServiceDependencies) {
+				deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{}, repository.ErrAccountNotFound)
+			},
+			wantErr: repository.ErrAccountNotFound,
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			service, deps := setupPayoutService(t)
+			if tcase.doMocks != nil {
+				tcase.doMocks(deps)
+			}
+
+			batch, err := service.CreateBatch(ctx, owner, tcase.given)
+
+			if tcase.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tcase.wantErr)
+			}
+
+			assert.Equal(t, tcase.want, batch)
+		})
+	}
+}
+
+func TestPayoutService_Drain(t *testing.T) {
+	now := time.Now()
+	setupClock(now)
+	defer resetClock()
+
+	var (
+		ctx  = context.Background()
+		item = models.PayoutItem{
+			ItemId:   "i-1",
+			BatchId:  "b-1",
+			Owner:    "0001",
+			Position: 1,
+			Receiver: "0002",
+			Amount:   3000,
+			Result:   models.PayoutPending,
+		}
+		errBoom = errors.New("boom")
+	)
+
+	scenarios := map[string]struct {
+		doMocks func(deps payoutServiceDependencies)
+	}{
+		"paid": {
+			doMocks: func(deps payoutServiceDependencies) {
+				deps.payoutRepoMock.On("FindPendingItems", ctx).Return([]models.PayoutItem{item}, nil)
+				deps.payoutRepoMock.On("ClaimItem", ctx, "i-1").Return(item, nil)
+				deps.transactionSvcMock.On("Payout", ctx, "0001", "0002", models.Money(3000)).Return(nil)
+				deps.payoutRepoMock.On("SetItemResult", ctx, "i-1", models.PayoutSucceeded, "", now).Return(nil)
+			},
+		},
+		"unknown-receiver-is-released": {
+			doMocks: func(deps payoutServiceDependencies) {
+				deps.payoutRepoMock.On("FindPendingItems", ctx).Return([]models.PayoutItem{item}, nil)
+				deps.payoutRepoMock.On("ClaimItem", ctx, "i-1").Return(item, nil)
+				deps.transactionSvcMock.On("Payout", ctx, "0001", "0002", models.Money(3000)).Return(repository.ErrAccountNotFound)
+				deps.transactionSvcMock.On("ReleasePayout", ctx, "0001", models.Money(3000)).Return(nil)
+				deps.payoutRepoMock.On("SetItemResult", ctx, "i-1", models.PayoutUnknownReceiver, "", now).Return(nil)
+			},
+		},
+		"failed-payout-is-released": {
+			doMocks: func(deps payoutServiceDependencies) {
+				deps.payoutRepoMock.On("FindPendingItems", ctx).Return([]models.PayoutItem{item}, nil)
+				deps.payoutRepoMock.On("ClaimItem", ctx, "i-1").Return(item, nil).Twice()
+				deps.transactionSvcMock.On("Payout", ctx, "0001", "0002", models.Money(3000)).Return(errBoom)
+				deps.transactionSvcMock.On("ReleasePayout", ctx, "0001", models.Money(3000)).Return(nil)
+				deps.payoutRepoMock.On("SetItemResult", ctx, "i-1", models.PayoutFailed, errBoom.Error(), now).Return(nil)
+			},
+		},
+		"locked-account-stays-pending": {
+			doMocks: func(deps payoutServiceDependencies) {
+				deps.payoutRepoMock.On("FindPendingItems", ctx).Return([]models.PayoutItem{item}, nil)
+				deps.payoutRepoMock.On("ClaimItem", ctx, "i-1").Return(item, nil).Once()
+				deps.transactionSvcMock.On("Payout", ctx, "0001", "0002", models.Money(3000)).Return(repository.ErrAccountLocked)
+			},
+		},
+		"claimed-by-another-replica": {
+			doMocks: func(deps payoutServiceDependencies) {
+				deps.payoutRepoMock.On("FindPendingItems", ctx).Return([]models.PayoutItem{item}, nil)
+				deps.payoutRepoMock.On("ClaimItem", ctx, "i-1").Return(models.PayoutItem{}, repository.ErrPayoutItemNotFound)
+			},
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			service, deps := setupPayoutService(t)
+			tcase.doMocks(deps)
+
+			err := service.Drain(ctx)
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestPayoutService_GetBatch(t *testing.T) {
+	ctx := context.Background()
+
+	batch := models.PayoutBatch{
+		BatchId: "b-1",
+		Owner:   "0001",
+		Items: []models.PayoutItem{
+			{ItemId: "i-1", Result: models.PayoutSucceeded},
+			{ItemId: "i-2", Result: models.PayoutUnknownReceiver},
+		},
+	}
+
+	service, deps := setupPayoutService(t)
+	deps.payoutRepoMock.On("FindBatch", ctx, "b-1").Return(batch, nil)
+
+	got, err := service.GetBatch(ctx, "b-1", "0001")
+	assert.NoError(t, err)
+	assert.Equal(t, models.BatchCompleted, got.Status)
+	assert.Equal(t, 1, got.Succeeded)
+	assert.Equal(t, 1, got.Failed)
+	assert.Equal(t, 0, got.Pending)
+
+	_, err = service.GetBatch(ctx, "b-1", "0002")
+	assert.ErrorIs(t, err, ErrPayoutNotOwned)
+}
+
+type payoutServiceDependencies struct {
+	payoutRepoMock     *repository.MockPayoutRepo
+	accRepoMock        *repository.MockAccountRepo
+	transRepoMock      *repository.MockTransactionRepo
+	transactionSvcMock *MockTransactionService
+	uowMock            *repository.MockUnitOfWork
+}
+
+func setupPayoutService(t *testing.T) (*payoutServiceImpl, payoutServiceDependencies) {
+	deps := payoutServiceDependencies{
+		payoutRepoMock:     repository.NewMockPayoutRepo(t),
+		accRepoMock:        repository.NewMockAccountRepo(t),
+		transRepoMock:      repository.NewMockTransactionRepo(t),
+		transactionSvcMock: NewMockTransactionService(t),
+		uowMock:            repository.NewMockUnitOfWork(t),
+	}
+
+	deps.uowMock.EXPECT().Do(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		}).
+		Maybe()
+
+	return NewPayoutService(deps.payoutRepoMock, deps.accRepoMock, deps.transRepoMock, deps.transactionSvcMock, deps.uowMock), deps
+}
@@ -0,0 +1,299 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gopay/internal/models"
+	"github.com/gopay/internal/repository"
+)
+
+const (
+	maxNameLength        = 255
+	maxDisplayNameLength = 64
+	maxEmailLength       = 254
+	minPhoneDigits       = 8
+	maxPhoneDigits       = 15
+	maxAddressLineLength = 100
+	maxPostalCodeLength  = 16
+	maxAge               = 150
+	minHandleLength      = 3
+	maxHandleLength      = 30
+)
+
+var (
+	ErrInvalidProfile     = errors.New("invalid account profile")
+	ErrInvalidName        = fmt.Errorf("name and last name are required and must be at most %d characters: %w", maxNameLength, ErrInvalidProfile)
+	ErrInvalidDisplayName = fmt.Errorf("display name must be at most %d characters: %w", maxDisplayNameLength, ErrInvalidProfile)
+	ErrInvalidEmail       = fmt.Errorf("email must be a plain address such as name@example.com: %w", ErrInvalidProfile)
+	ErrInvalidPhone       = fmt.Errorf("phone must be in international format, '+' and %d to %d digits: %w", minPhoneDigits, maxPhoneDigits, ErrInvalidProfile)
+	ErrInvalidDateOfBirth = fmt.Errorf("date of birth must be a past date formatted YYYY-MM-DD: %w", ErrInvalidProfile)
+	ErrInvalidHandle      = fmt.Errorf("handle must be %d to %d letters, digits or '_' starting with a letter: %w", minHandleLength, maxHandleLength, ErrInvalidProfile)
+	ErrReservedHandle     = fmt.Errorf("handle is reserved: %w", ErrInvalidProfile)
+	ErrInvalidAddress     = fmt.Errorf("address needs line1, city, postal code and a two letter country code, lines at most %d characters: %w", maxAddressLineLength, ErrInvalidProfile)
+)
+
+// reservedHandles cannot be claimed, so that nobody passes for us or for a part of the app.
+var reservedHandles = map[string]bool{
+	"admin":         true,
+	"administrator": true,
+	"api":           true,
+	"billing":       true,
+	"fees":          true,
+	"gopay":         true,
+	"help":          true,
+	"me":            true,
+	"moderator":     true,
+	"official":      true,
+	"payments":      true,
+	"revenue":       true,
+	"root":          true,
+	"security":      true,
+	"settings":      true,
+	"staff":         true,
+	"support":       true,
+	"system":        true,
+	"treasury":      true,
+}
+
+// applyProfile returns acc with the fields set in req cleaned up, validated and applied.
+func applyProfile(acc models.Account, req models.AccountPatchReq) (models.Account, error) {
+	var err error
+
+	if req.Name != nil {
+		acc.Name, err = sanitizeName(*req.Name)
+		if err != nil {
+			return models.Account{}, err
+		}
+	}
+
+	if req.LastName != nil {
+		acc.LastName, err = sanitizeName(*req.LastName)
+		if err != nil {
+			return models.Account{}, err
+		}
+	}
+
+	if req.Handle != nil {
+		acc.Handle, err = normalizeHandle(*req.Handle)
+		if err != nil {
+			return models.Account{}, err
+		}
+	}
+
+	if req.DisplayName != nil {
+		acc.DisplayName = sanitizeText(*req.DisplayName)
+		if utf8.RuneCountInString(acc.DisplayName) > maxDisplayNameLength {
+			return models.Account{}, ErrInvalidDisplayName
+		}
+	}
+
+	if req.Email != nil {
+		acc.Email, err = normalizeEmail(*req.Email)
+		if err != nil {
+			return models.Account{}, err
+		}
+	}
+
+	if req.Phone != nil {
+		acc.Phone, err = normalizePhone(*req.Phone)
+		if err != nil {
+			return models.Account{}, err
+		}
+	}
+
+	if req.DateOfBirth != nil {
+		acc.DateOfBirth, err = validateDateOfBirth(*req.DateOfBirth)
+		if err != nil {
+			return models.Account{}, err
+		}
+	}
+
+	if req.Address != nil {
+		acc.Address, err = sanitizeAddress(*req.Address)
+		if err != nil {
+			return models.Account{}, err
+		}
+	}
+
+	if req.DefaultPrivacy != nil {
+		if !req.DefaultPrivacy.IsValid() {
+			return models.Account{}, ErrInvalidPrivacy
+		}
+		acc.DefaultPrivacy = *req.DefaultPrivacy
+	}
+
+	return acc, nil
+}
+
+func sanitizeName(name string) (string, error) {
+	name = sanitizeText(name)
+	if name == "" || utf8.RuneCountInString(name) > maxNameLength {
+		return "", ErrInvalidName
+	}
+	return name, nil
+}
+
+// normalizeHandle drops the '@' handles are written with. The handle keeps the case it
+// was claimed with, it is compared regardless of case.
+func normalizeHandle(handle string) (string, error) {
+	handle = strings.TrimPrefix(strings.TrimSpace(handle), "@")
+	if handle == "" {
+		return "", nil
+	}
+
+	if !validHandle(handle) {
+		return "", ErrInvalidHandle
+	}
+
+	if reservedHandles[strings.ToLower(handle)] {
+		return "", ErrReservedHandle
+	}
+
+	return handle, nil
+}
+
+func validHandle(handle string) bool {
+	if len(handle) < minHandleLength || len(handle) > maxHandleLength {
+		return false
+	}
+
+	for i, r := range handle {
+		letter := r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z'
+		if i == 0 && !letter {
+			return false
+		}
+		if !letter && !(r >= '0' && r <= '9') && r != '_' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// handleRef tells whether ref, which names an account, is a handle rather than an id and
+// returns the handle. Ids never pass for handles: they start with a digit or contain '-'.
+func handleRef(ref string) (string, bool) {
+	if strings.HasPrefix(ref, "@") {
+		return ref[1:], true
+	}
+	return ref, validHandle(ref)
+}
+
+// findAccountRef loads the account ref names, by id or by handle.
+func findAccountRef(ctx context.Context, accountRepo repository.AccountRepo, ref string) (models.Account, error) {
+	if handle, ok := handleRef(ref); ok {
+		return accountRepo.FindByHandle(ctx, handle)
+	}
+	return accountRepo.FindOne(ctx, ref)
+}
+
+// normalizeEmail lowercases the address so that uniqueness does not depend on case.
+func normalizeEmail(email string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return "", nil
+	}
+
+	if len(email) > maxEmailLength {
+		return "", ErrInvalidEmail
+	}
+
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email || !strings.Contains(email[strings.LastIndexByte(email, '@'):], ".") {
+		return "", ErrInvalidEmail
+	}
+
+	return email, nil
+}
+
+// normalizePhone brings a phone number to E.164, dropping the spaces, dashes, dots and
+// parentheses people write numbers with.
+func normalizePhone(phone string) (string, error) {
+	phone = strings.TrimSpace(phone)
+	if phone == "" {
+		return "", nil
+	}
+
+	if !strings.HasPrefix(phone, "+") {
+		return "", ErrInvalidPhone
+	}
+
+	digits := make([]byte, 0, len(phone))
+	for _, r := range phone[1:] {
+		switch {
+		case r >= '0' && r <= '9':
+			digits = append(digits, byte(r))
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')':
+		default:
+			return "", ErrInvalidPhone
+		}
+	}
+
+	if len(digits) < minPhoneDigits || len(digits) > maxPhoneDigits || digits[0] == '0' {
+		return "", ErrInvalidPhone
+	}
+
+	return "+" + string(digits), nil
+}
+
+func validateDateOfBirth(date string) (string, error) {
+	date = strings.TrimSpace(date)
+	if date == "" {
+		return "", nil
+	}
+
+	born, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return "", ErrInvalidDateOfBirth
+	}
+
+	now := clockNow()
+	if !born.Before(now) || born.Before(now.AddDate(-maxAge, 0, 0)) {
+		return "", ErrInvalidDateOfBirth
+	}
+
+	return date, nil
+}
+
+// sanitizeAddress cleans up every line of address. An address with nothing in it
+// removes the address.
+func sanitizeAddress(address models.Address) (*models.Address, error) {
+	clean := models.Address{
+		Line1:      sanitizeText(address.Line1),
+		Line2:      sanitizeText(address.Line2),
+		City:       sanitizeText(address.City),
+		Region:     sanitizeText(address.Region),
+		PostalCode: sanitizeText(address.PostalCode),
+		Country:    strings.ToUpper(sanitizeText(address.Country)),
+	}
+
+	if clean == (models.Address{}) {
+		return nil, nil
+	}
+
+	if clean.Line1 == "" || clean.City == "" || clean.PostalCode == "" || !validCountry(clean.Country) {
+		return nil, ErrInvalidAddress
+	}
+
+	for _, line := range []string{clean.Line1, clean.Line2, clean.City, clean.Region} {
+		if utf8.RuneCountInString(line) > maxAddressLineLength {
+			return nil, ErrInvalidAddress
+		}
+	}
+
+	if utf8.RuneCountInString(clean.PostalCode) > maxPostalCodeLength {
+		return nil, ErrInvalidAddress
+	}
+
+	return &clean, nil
+}
+
+func validCountry(code string) bool {
+	return len(code) == 2 && code[0] >= 'A' && code[0] <= 'Z' && code[1] >= 'A' && code[1] <= 'Z'
+}
@@ -0,0 +1,407 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gopay/internal/models"
+	"github.com/gopay/internal/repository"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	ErrInvalidFrequency = errors.New("frequency must be once, daily, weekly, monthly or cron")
+	ErrStartInPast      = errors.New("start must not be in the past")
+	ErrScheduleFinished = errors.New("scheduled payment was cancelled or has completed")
+	ErrScheduleNotOwned = errors.New("scheduled payment belongs to another account")
+)
+
+// A run failing with a retryable error is tried again after scheduleRetryDelay, up to
+// maxScheduleAttempts times in total; after that it is skipped until the next recurrence.
+const (
+	maxScheduleAttempts = 3
+	scheduleRetryDelay  = time.Hour
+)
+
+type ScheduleService interface {
+	Create(ctx context.Context, owner string, req models.ScheduledPaymentReq) (models.ScheduledPayment, error)
+	GetAll(ctx context.Context, owner string) ([]models.ScheduledPayment, error)
+	GetRuns(ctx context.Context, id string, owner string) ([]models.ScheduledRun, error)
+	Pause(ctx context.Context, id string, owner string) error
+	Resume(ctx context.Context, id string, owner string) error
+	Cancel(ctx context.Context, id string, owner string) error
+	RunDue(ctx context.Context) error
+	Run(ctx context.Context, interval time.Duration)
+}
+
+var _ ScheduleService = (*scheduleServiceImpl)(nil)
+
+type scheduleServiceImpl struct {
+	scheduleRepo   repository.ScheduledPaymentRepo
+	accountRepo    repository.AccountRepo
+	transactionSvc TransactionService
+	uow            repository.UnitOfWork
+}
+
+func NewScheduleService(scheduleRepo repository.ScheduledPaymentRepo, accountRepo repository.AccountRepo, transactionSvc TransactionService, uow repository.UnitOfWork) *scheduleServiceImpl {
+	return &scheduleServiceImpl{
+		scheduleRepo:   scheduleRepo,
+		accountRepo:    accountRepo,
+		transactionSvc: transactionSvc,
+		uow:            uow,
+	}
+}
+
+func (r *scheduleServiceImpl) Create(ctx context.Context, owner string, req models.ScheduledPaymentReq) (models.ScheduledPayment, error) {
+	if req.Amount <= 0 {
+		return models.ScheduledPayment{}, ErrInvalidAmount
+	}
+
+	if owner == req.Receiver {
+		return models.ScheduledPayment{}, ErrInvalidPaymentOp
+	}
+
+	now := clockNow()
+	schedule := models.ScheduledPayment{
+		Owner:     owner,
+		Receiver:  req.Receiver,
+		Amount:    req.Amount,
+		Frequency: req.Frequency,
+		StartAt:   req.StartAt.UTC(),
+		CreatedAt: now,
+	}
+
+	if req.StartAt.IsZero() {
+		schedule.StartAt = now
+	}
+	if schedule.StartAt.Before(now.Add(-time.Minute)) {
+		return models.ScheduledPayment{}, ErrStartInPast
+	}
+	schedule.NextRunAt = schedule.StartAt
+
+	switch req.Frequency {
+	case models.FrequencyOnce, models.FrequencyDaily, models.FrequencyWeekly, models.FrequencyMonthly:
+	case models.FrequencyCron:
+		cron, err := parseCron(req.Cron)
+		if err != nil {
+			return models.ScheduledPayment{}, err
+		}
+
+		// The first run is the first cron match from the start on.
+		first, found := cron.next(schedule.StartAt.Add(-time.Minute))
+		if !found {
+			return models.ScheduledPayment{}, ErrInvalidCron
+		}
+		schedule.Cron = req.Cron
+		schedule.NextRunAt = first
+	default:
+		return models.ScheduledPayment{}, ErrInvalidFrequency
+	}
+
+	_, err := r.accountRepo.FindOne(ctx, owner)
+	if err != nil {
+		return models.ScheduledPayment{}, err
+	}
+
+	_, err = r.accountRepo.FindOne(ctx, req.Receiver)
+	if err != nil {
+		return models.ScheduledPayment{}, err
+	}
+
+	id, err := r.scheduleRepo.Create(ctx, schedule)
+	if err != nil {
+		return models.ScheduledPayment{}, err
+	}
+
+	schedule.ScheduleId = id
+	schedule.Status = models.ScheduleActive
+	schedule.UpdatedAt = now
+
+	return schedule, nil
+}
+
+func (r *scheduleServiceImpl) GetAll(ctx context.Context, owner string) ([]models.ScheduledPayment, error) {
+	_, err := r.accountRepo.FindOne(ctx, owner)
+	if err != nil {
+		return []models.ScheduledPayment{}, err
+	}
+
+	return r.scheduleRepo.FindByOwner(ctx, owner)
+}
+
+func (r *scheduleServiceImpl) GetRuns(ctx context.Context, id string, owner string) ([]models.ScheduledRun, error) {
+	_, err := r.owned(ctx, id, owner)
+	if err != nil {
+		return []models.ScheduledRun{}, err
+	}
+
+	return r.scheduleRepo.FindRuns(ctx, id)
+}
+
+func (r *scheduleServiceImpl) Pause(ctx context.Context, id string, owner string) error {
+	schedule, err := r.owned(ctx, id, owner)
+	if err != nil {
+		return err
+	}
+
+	switch schedule.Status {
+	case models.SchedulePaused:
+		return nil
+	case models.ScheduleActive:
+		return r.scheduleRepo.SetStatus(ctx, id, models.SchedulePaused, clockNow())
+	}
+	return ErrScheduleFinished
+}
+
+// Resume reactivates a paused schedule. Runs missed while it was paused are skipped.
+func (r *scheduleServiceImpl) Resume(ctx context.Context, id string, owner string) error {
+	schedule, err := r.owned(ctx, id, owner)
+	if err != nil {
+		return err
+	}
+
+	switch schedule.Status {
+	case models.ScheduleActive:
+		return nil
+	case models.SchedulePaused:
+	default:
+		return ErrScheduleFinished
+	}
+
+	now := clockNow()
+
+	return r.uow.Do(ctx, func(ctx context.Context) error {
+		if schedule.NextRunAt.Before(now) {
+			next, recurs, err := nextRun(schedule, now)
+			if err != nil {
+				return err
+			}
+			if recurs {
+				err = r.scheduleRepo.Reschedule(ctx, id, next, 0, now)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		return r.scheduleRepo.SetStatus(ctx, id, models.ScheduleActive, now)
+	})
+}
+
+func (r *scheduleServiceImpl) Cancel(ctx context.Context, id string, owner string) error {
+	schedule, err := r.owned(ctx, id, owner)
+	if err != nil {
+		return err
+	}
+
+	if schedule.Status != models.ScheduleActive && schedule.Status != models.SchedulePaused {
+		return ErrScheduleFinished
+	}
+
+	return r.scheduleRepo.SetStatus(ctx, id, models.ScheduleCancelled, clockNow())
+}
+
+func (r *scheduleServiceImpl) owned(ctx context.Context, id string, owner string) (models.ScheduledPayment, error) {
+	schedule, err := r.scheduleRepo.FindOne(ctx, id)
+	if err != nil {
+		return models.ScheduledPayment{}, err
+	}
+
+	if schedule.Owner != owner {
+		return models.ScheduledPayment{}, ErrScheduleNotOwned
+	}
+
+	return schedule, nil
+}
+
+// RunDue pays every schedule that is due. Each one is claimed inside the unit of work
+// that pays it, so several replicas can run the scheduler at the same time.
+func (r *scheduleServiceImpl) RunDue(ctx context.Context) error {
+	due, err := r.scheduleRepo.FindDue(ctx, clockNow())
+	if err != nil {
+		return err
+	}
+
+	for _, s := range due {
+		err := r.runOnce(ctx, s.ScheduleId)
+		if err != nil {
+			log.Error().Err(err).Str("schedule", s.ScheduleId).Msg("ScheduleService::RunDue")
+		}
+	}
+
+	return nil
+}
+
+func (r *scheduleServiceImpl) runOnce(ctx context.Context, id string) error {
+	now := clockNow()
+	claimed := false
+
+	err := r.uow.Do(ctx, func(ctx context.Context) error {
+		schedule, err := r.scheduleRepo.Claim(ctx, id, now)
+		if err != nil {
+			return err
+		}
+		claimed = true
+
+		_, err = r.transactionSvc.Pay(ctx, schedule.Owner, schedule.Receiver, schedule.Amount, models.Annotation{})
+		if err != nil {
+			return err
+		}
+
+		return r.record(ctx, schedule, now, nil)
+	})
+	if err == repository.ErrScheduledPaymentNotFound {
+		return nil
+	}
+	if err == nil || !claimed {
+		return err
+	}
+
+	// The failed payment was rolled back together with the claim, so the failure is
+	// recorded in a unit of work of its own.
+	payErr := err
+	err = r.uow.Do(ctx, func(ctx context.Context) error {
+		schedule, err := r.scheduleRepo.Claim(ctx, id, now)
+		if err != nil {
+			return err
+		}
+
+		return r.record(ctx, schedule, now, payErr)
+	})
+	if err == repository.ErrScheduledPaymentNotFound {
+		return nil
+	}
+
+	return err
+}
+
+// record stores the outcome of running schedule and moves it to its next run.
+func (r *scheduleServiceImpl) record(ctx context.Context, schedule models.ScheduledPayment, now time.Time, payErr error) error {
+	run := models.ScheduledRun{
+		ScheduleId:   schedule.ScheduleId,
+		ScheduledFor: schedule.NextRunAt,
+		RanAt:        now,
+		Attempt:      schedule.Attempts + 1,
+		Outcome:      models.RunSucceeded,
+	}
+
+	if payErr != nil {
+		run.Outcome = models.RunFailed
+		run.Error = payErr.Error()
+
+		if isRetryable(payErr) && run.Attempt < maxScheduleAttempts {
+			run.Outcome = models.RunRetrying
+
+			_, err := r.scheduleRepo.CreateRun(ctx, run)
+			if err != nil {
+				return err
+			}
+
+			return r.scheduleRepo.Reschedule(ctx, schedule.ScheduleId, now.Add(scheduleRetryDelay), run.Attempt, now)
+		}
+	}
+
+	_, err := r.scheduleRepo.CreateRun(ctx, run)
+	if err != nil {
+		return err
+	}
+
+	next, recurs, err := nextRun(schedule, now)
+	if err != nil {
+		return err
+	}
+
+	if !recurs {
+		return r.scheduleRepo.SetStatus(ctx, schedule.ScheduleId, models.ScheduleCompleted, now)
+	}
+
+	return r.scheduleRepo.Reschedule(ctx, schedule.ScheduleId, next, 0, now)
+}
+
+func isRetryable(err error) bool {
+	return errors.Is(err, ErrInsufficentBalance) || errors.Is(err, repository.ErrAccountLocked)
+}
+
+// nextRun returns the first occurrence of schedule after now, or false if it does not
+// recur. Occurrences missed while the scheduler was down are skipped rather than paid late.
+func nextRun(schedule models.ScheduledPayment, now time.Time) (time.Time, bool, error) {
+	start := schedule.StartAt
+
+	switch schedule.Frequency {
+	case models.FrequencyDaily, models.FrequencyWeekly:
+		days := 1
+		if schedule.Frequency == models.FrequencyWeekly {
+			days = 7
+		}
+
+		k := 0
+		if now.After(start) {
+			k = int(now.Sub(start)/(24*time.Hour)) / days
+		}
+		for {
+			next := start.AddDate(0, 0, k*days)
+			if next.After(now) {
+				return next, true, nil
+			}
+			k++
+		}
+	case models.FrequencyMonthly:
+		k := 0
+		if now.After(start) {
+			k = (now.Year()-start.Year())*12 + int(now.Month()-start.Month()) - 1
+			if k < 0 {
+				k = 0
+			}
+		}
+		for {
+			next := addMonths(start, k)
+			if next.After(now) {
+				return next, true, nil
+			}
+			k++
+		}
+	case models.FrequencyCron:
+		cron, err := parseCron(schedule.Cron)
+		if err != nil {
+			return time.Time{}, false, err
+		}
+		next, found := cron.next(now)
+		return next, found, nil
+	}
+
+	return time.Time{}, false, nil
+}
+
+// addMonths moves t by months, keeping its day of the month or the last day of shorter
+// months: a schedule starting on January 31st runs on February 28th, then March 31st.
+func addMonths(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	last := first.AddDate(0, 1, -1).Day()
+
+	day := t.Day()
+	if day > last {
+		day = last
+	}
+
+	return first.AddDate(0, 0, day-1)
+}
+
+// Run pays due schedules right away and then every interval until ctx is done.
+func (r *scheduleServiceImpl) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		err := r.RunDue(ctx)
+		if err != nil {
+			log.Error().Err(err).Msg("ScheduleService::Run")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,337 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gopay/internal/models"
+	"github.com/gopay/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestScheduleService_Create(t *testing.T) {
+	now := time.Date(2024, 1, 31, 10, 30, 0, 0, time.UTC)
+	setupClock(now)
+	defer resetClock()
+
+	var (
+		ctx      = context.Background()
+		owner    = "0001"
+		receiver = "0002"
+	)
+
+	scenarios := map[string]struct {
+		given   models.ScheduledPaymentReq
+		doMocks func(deps scheduleServiceDependencies)
+		want    models.ScheduledPayment
+		wantErr error
+	}{
+		"monthly-starting-now": {
+			given: models.ScheduledPaymentReq{
+				Receiver:  receiver,
+				Amount:    5000,
+				Frequency: models.FrequencyMonthly,
+			},
+			doMocks: func(deps scheduleServiceDependencies) {
+				deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{AccountId: owner}, nil)
+				deps.accRepoMock.On("FindOne", ctx, receiver).Return(models.Account{AccountId: receiver}, nil)
+				deps.scheduleRepoMock.On("Create", ctx, models.ScheduledPayment{
+					Owner:     owner,
+					Receiver:  receiver,
+					Amount:    5000,
+					Frequency: models.FrequencyMonthly,
+					StartAt:   now,
+					NextRunAt: now,
+					CreatedAt: now,
+				}).Return("s-1", nil)
+			},
+			want: models.ScheduledPayment{
+				ScheduleId: "s-1",
+				Owner:      owner,
+				Receiver:   receiver,
+				Amount:     5000,
+				Frequency:  models.FrequencyMonthly,
+				StartAt:    now,
+				NextRunAt:  now,
+				Status:     models.ScheduleActive,
+				CreatedAt:  now,
+				UpdatedAt:  now,
+			},
+			wantErr: nil,
+		},
+		"cron-first-run-is-first-match": {
+			given: models.ScheduledPaymentReq{
+				Receiver:  receiver,
+				Amount:    5000,
+				Frequency: models.FrequencyCron,
+				Cron:      "0 9 * * *",
+			},
+			doMocks: func(deps scheduleServiceDependencies) {
+				deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{AccountId: owner}, nil)
+				deps.accRepoMock.On("FindOne", ctx, receiver).Return(models.Account{AccountId: receiver}, nil)
+				deps.scheduleRepoMock.On("Create", ctx, models.ScheduledPayment{
+					Owner:     owner,
+					Receiver:  receiver,
+					Amount:    5000,
+					Frequency: models.FrequencyCron,
+					Cron:      "0 9 * * *",
+					StartAt:   now,
+					NextRunAt: time.Date(2024, 2, 1, 9, 0, 0, 0, time.UTC),
+					CreatedAt: now,
+				}).Return("s-1", nil)
+			},
+			want: models.ScheduledPayment{
+				ScheduleId: "s-1",
+				Owner:      owner,
+				Receiver:   receiver,
+				Amount:     5000,
+				Frequency:  models.FrequencyCron,
+				Cron:       "0 9 * * *",
+				StartAt:    now,
+				NextRunAt:  time.Date(2024, 2, 1, 9, 0, 0, 0, time.UTC),
+				Status:     models.ScheduleActive,
+				CreatedAt:  now,
+				UpdatedAt:  now,
+			},
+			wantErr: nil,
+		},
+		"invalid-frequency": {
+			given: models.ScheduledPaymentReq{
+				Receiver:  receiver,
+				Amount:    5000,
+				Frequency: "hourly",
+			},
+			wantErr: ErrInvalidFrequency,
+		},
+		"invalid-cron": {
+			given: models.ScheduledPaymentReq{
+				Receiver:  receiver,
+				Amount:    5000,
+				Frequency: models.FrequencyCron,
+				Cron:      "every day",
+			},
+			wantErr: ErrInvalidCron,
+		},
+		"start-in-past": {
+			given: models.ScheduledPaymentReq{
+				Receiver:  receiver,
+				Amount:    5000,
+				Frequency: models.FrequencyDaily,
+				StartAt:   now.AddDate(0, 0, -1),
+			},
+			wantErr: ErrStartInPast,
+		},
+		"pay-self": {
+			given: models.ScheduledPaymentReq{
+				Receiver:  owner,
+				Amount:    5000,
+				Frequency: models.FrequencyDaily,
+			},
+			wantErr: ErrInvalidPaymentOp,
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			service, deps := setupScheduleService(t)
+			if tcase.doMocks != nil {
+				tcase.doMocks(deps)
+			}
+
+			schedule, err := service.Create(ctx, owner, tcase.given)
+
+			if tcase.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tcase.wantErr)
+			}
+
+			assert.Equal(t, tcase.want, schedule)
+		})
+	}
+}
+
+func TestScheduleService_RunDue(t *testing.T) {
+	now := time.Date(2024, 1, 31, 10, 30, 0, 0, time.UTC)
+	setupClock(now)
+	defer resetClock()
+
+	var (
+		ctx      = context.Background()
+		schedule = models.ScheduledPayment{
+			ScheduleId: "s-1",
+			Owner:      "0001",
+			Receiver:   "0002",
+			Amount:     5000,
+			Frequency:  models.FrequencyMonthly,
+			StartAt:    now.AddDate(0, -1, 0),
+			NextRunAt:  now,
+			Status:     models.ScheduleActive,
+		}
+		nextMonth = time.Date(2024, 2, 29, 10, 30, 0, 0, time.UTC)
+	)
+
+	scenarios := map[string]struct {
+		doMocks func(deps scheduleServiceDependencies)
+	}{
+		"pays-and-moves-to-next-month": {
+			doMocks: func(deps scheduleServiceDependencies) {
+				deps.scheduleRepoMock.On("FindDue", ctx, now).Return([]models.ScheduledPayment{schedule}, nil)
+				deps.scheduleRepoMock.On("Claim", ctx, "s-1", now).Return(schedule, nil)
+				deps.transactionSvcMock.On("Pay", ctx, "0001", "0002", models.Money(5000), models.Annotation{}).Return(models.Receipt{}, nil)
+				deps.scheduleRepoMock.On("CreateRun", ctx, models.ScheduledRun{
+					ScheduleId:   "s-1",
+					ScheduledFor: now,
+					RanAt:        now,
+					Attempt:      1,
+					Outcome:      models.RunSucceeded,
+				}).Return("run-1", nil)
+				deps.scheduleRepoMock.On("Reschedule", ctx, "s-1", nextMonth, 0, now).Return(nil)
+			},
+		},
+		"insufficient-balance-is-retried": {
+			doMocks: func(deps scheduleServiceDependencies) {
+				deps.scheduleRepoMock.On("FindDue", ctx, now).Return([]models.ScheduledPayment{schedule}, nil)
+				deps.scheduleRepoMock.On("Claim", ctx, "s-1", now).Return(schedule, nil).Twice()
+				deps.transactionSvcMock.On("Pay", ctx, "0001", "0002", models.Money(5000), models.Annotation{}).Return(models.Receipt{}, ErrInsufficentBalance)
+				deps.scheduleRepoMock.On("CreateRun", ctx, models.ScheduledRun{
+					ScheduleId:   "s-1",
+					ScheduledFor: now,
+					RanAt:        now,
+					Attempt:      1,
+					Outcome:      models.RunRetrying,
+					Error:        ErrInsufficentBalance.Error(),
+				}).Return("run-1", nil)
+				deps.scheduleRepoMock.On("Reschedule", ctx, "s-1", now.Add(scheduleRetryDelay), 1, now).Return(nil)
+			},
+		},
+		"gives-up-after-last-attempt": {
+			doMocks: func(deps scheduleServiceDependencies) {
+				retried := schedule
+				retried.Attempts = maxScheduleAttempts - 1
+
+				deps.scheduleRepoMock.On("FindDue", ctx, now).Return([]models.ScheduledPayment{retried}, nil)
+				deps.scheduleRepoMock.On("Claim", ctx, "s-1", now).Return(retried, nil).Twice()
+				deps.transactionSvcMock.On("Pay", ctx, "0001", "0002", models.Money(5000), models.Annotation{}).Return(models.Receipt{}, ErrInsufficentBalance)
+				deps.scheduleRepoMock.On("CreateRun", ctx, models.ScheduledRun{
+					ScheduleId:   "s-1",
+					ScheduledFor: now,
+					RanAt:        now,
+					Attempt:      maxScheduleAttempts,
+					Outcome:      models.RunFailed,
+					Error:        ErrInsufficentBalance.Error(),
+				}).Return("run-3", nil)
+				deps.scheduleRepoMock.On("Reschedule", ctx, "s-1", nextMonth, 0, now).Return(nil)
+			},
+		},
+		"one-off-completes": {
+			doMocks: func(deps scheduleServiceDependencies) {
+				once := schedule
+				once.Frequency = models.FrequencyOnce
+
+				deps.scheduleRepoMock.On("FindDue", ctx, now).Return([]models.ScheduledPayment{once}, nil)
+				deps.scheduleRepoMock.On("Claim", ctx, "s-1", now).Return(once, nil)
+				deps.transactionSvcMock.On("Pay", ctx, "0001", "0002", models.Money(5000), models.Annotation{}).Return(models.Receipt{}, nil)
+				deps.scheduleRepoMock.On("CreateRun", ctx, mock.Anything).Return("run-1", nil)
+				deps.scheduleRepoMock.On("SetStatus", ctx, "s-1", models.ScheduleCompleted, now).Return(nil)
+			},
+		},
+		"claimed-by-another-replica": {
+			doMocks: func(deps scheduleServiceDependencies) {
+				deps.scheduleRepoMock.On("FindDue", ctx, now).Return([]models.ScheduledPayment{schedule}, nil)
+				deps.scheduleRepoMock.On("Claim", ctx, "s-1", now).Return(models.ScheduledPayment{}, repository.ErrScheduledPaymentNotFound)
+			},
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			service, deps := setupScheduleService(t)
+			tcase.doMocks(deps)
+
+			err := service.RunDue(ctx)
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestScheduleService_NextRun(t *testing.T) {
+	start := time.Date(2024, 1, 31, 9, 0, 0, 0, time.UTC)
+
+	scenarios := map[string]struct {
+		frequency models.Frequency
+		now       time.Time
+		want      time.Time
+		recurs    bool
+	}{
+		"daily": {
+			frequency: models.FrequencyDaily,
+			now:       start,
+			want:      start.AddDate(0, 0, 1),
+			recurs:    true,
+		},
+		"weekly-skips-missed-runs": {
+			frequency: models.FrequencyWeekly,
+			now:       start.AddDate(0, 0, 20),
+			want:      start.AddDate(0, 0, 21),
+			recurs:    true,
+		},
+		"monthly-clamps-to-month-end": {
+			frequency: models.FrequencyMonthly,
+			now:       start,
+			want:      time.Date(2024, 2, 29, 9, 0, 0, 0, time.UTC),
+			recurs:    true,
+		},
+		"monthly-keeps-start-day": {
+			frequency: models.FrequencyMonthly,
+			now:       time.Date(2024, 2, 29, 9, 0, 0, 0, time.UTC),
+			want:      time.Date(2024, 3, 31, 9, 0, 0, 0, time.UTC),
+			recurs:    true,
+		},
+		"once": {
+			frequency: models.FrequencyOnce,
+			now:       start,
+			recurs:    false,
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			next, recurs, err := nextRun(models.ScheduledPayment{Frequency: tcase.frequency, StartAt: start}, tcase.now)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tcase.recurs, recurs)
+			assert.Equal(t, tcase.want, next)
+		})
+	}
+}
+
+type scheduleServiceDependencies struct {
+	scheduleRepoMock   *repository.MockScheduledPaymentRepo
+	accRepoMock        *repository.MockAccountRepo
+	transactionSvcMock *MockTransactionService
+	uowMock            *repository.MockUnitOfWork
+}
+
+func setupScheduleService(t *testing.T) (*scheduleServiceImpl, scheduleServiceDependencies) {
+	deps := scheduleServiceDependencies{
+		scheduleRepoMock:   repository.NewMockScheduledPaymentRepo(t),
+		accRepoMock:        repository.NewMockAccountRepo(t),
+		transactionSvcMock: NewMockTransactionService(t),
+		uowMock:            repository.NewMockUnitOfWork(t),
+	}
+
+	deps.uowMock.EXPECT().Do(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		}).
+		Maybe()
+
+	return NewScheduleService(deps.scheduleRepoMock, deps.accRepoMock, deps.transactionSvcMock, deps.uowMock), deps
+}
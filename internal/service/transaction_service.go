@@ -3,21 +3,44 @@ package service
 import (
 	"context"
 	"errors"
-	"math"
+	"fmt"
 	"time"
+	"unicode/utf8"
 
 	"github.com/gopay/internal/models"
 	"github.com/gopay/internal/repository"
-	"github.com/gopay/internal/utils"
 	"github.com/rs/zerolog/log"
 )
 
 var (
-	ErrInvalidAmount        = errors.New("amount cannot be less or equal to zero")
-	ErrInsufficentBalance   = errors.New("insufficient balance")
-	ErrFailedDebitOperation = errors.New("debit operation  unsuccessful ")
-	ErrFaileCreditOperation = errors.New("credit operation  unsuccessful ")
-	ErrInvalidPaymentOp     = errors.New("sender and receiver accounts must be different")
+	ErrInvalidAmount         = errors.New("amount cannot be less or equal to zero")
+	ErrInsufficentBalance    = errors.New("insufficient balance")
+	ErrFailedDebitOperation  = errors.New("debit operation  unsuccessful ")
+	ErrFaileCreditOperation  = errors.New("credit operation  unsuccessful ")
+	ErrInvalidPaymentOp      = errors.New("sender and receiver accounts must be different")
+	ErrNotRefundable         = errors.New("only received payments can be refunded")
+	ErrRefundForbidden       = errors.New("only the receiver of a payment can refund it")
+	ErrRefundExceeded        = errors.New("refund exceeds the amount left to refund")
+	ErrInvalidSplit          = errors.New("invalid split payment")
+	ErrSplitAmbiguous        = fmt.Errorf("give either shares or receivers and a total: %w", ErrInvalidSplit)
+	ErrSplitNoReceivers      = fmt.Errorf("split must have between 1 and %d receivers: %w", maxSplitReceivers, ErrInvalidSplit)
+	ErrSplitDuplicate        = fmt.Errorf("each receiver can appear only once: %w", ErrInvalidSplit)
+	ErrSplitTooSmall         = fmt.Errorf("total must give every receiver at least one cent: %w", ErrInvalidSplit)
+	ErrInvalidFilter         = errors.New("invalid transaction filter")
+	ErrInvalidLimit          = fmt.Errorf("limit must be between 1 and %d: %w", maxPageLimit, ErrInvalidFilter)
+	ErrInvalidDateRange      = fmt.Errorf("from must be before to: %w", ErrInvalidFilter)
+	ErrInvalidAmountRange    = fmt.Errorf("amounts must be positive and min must not exceed max: %w", ErrInvalidFilter)
+	ErrInvalidDirection      = fmt.Errorf("direction must be in or out: %w", ErrInvalidFilter)
+	ErrInvalidSortOrder      = fmt.Errorf("order must be asc or desc: %w", ErrInvalidFilter)
+	ErrInvalidKind           = fmt.Errorf("unknown transaction kind: %w", ErrInvalidFilter)
+	ErrInvalidMemoSearch     = fmt.Errorf("memo search must be at most %d characters: %w", maxMemoLength, ErrInvalidFilter)
+	ErrInvalidMetadataSearch = fmt.Errorf("metadata search keys must be valid metadata keys: %w", ErrInvalidFilter)
+)
+
+const (
+	defaultPageLimit  = 50
+	maxPageLimit      = 200
+	maxSplitReceivers = 50
 )
 
 var nowOriginal = func() time.Time {
@@ -36,11 +59,19 @@ func resetClock() {
 }
 
 type TransactionService interface {
-	Deposit(ctx context.Context, owner string, amount float32) error
-	Withdraw(ctx context.Context, owner string, amount float32) error
-	Pay(ctx context.Context, owner string, receiver string, amount float32) error
+	Deposit(ctx context.Context, owner string, amount models.Money, note models.Annotation) error
+	Withdraw(ctx context.Context, owner string, amount models.Money, note models.Annotation) (models.Receipt, error)
+	Pay(ctx context.Context, owner string, receiver string, amount models.Money, note models.Annotation) (models.Receipt, error)
+	Sweep(ctx context.Context, owner string, receiver string, amount models.Money) error
+	SplitPay(ctx context.Context, owner string, req models.SplitPayReq) ([]models.SplitShare, error)
+	ReservePayout(ctx context.Context, owner string, largest models.Money, amount models.Money) error
+	Payout(ctx context.Context, owner string, receiver string, amount models.Money) error
+	ReleasePayout(ctx context.Context, owner string, amount models.Money) error
+	PayInterest(ctx context.Context, owner string, amount models.Money, note models.Annotation) error
+	Refund(ctx context.Context, transactionId string, initiator string, amount models.Money) error
+	AdminRefund(ctx context.Context, transactionId string, amount models.Money) error
 	GetTransaction(ctx context.Context, id string) (models.Transaction, error)
-	GetAllTransactions(ctx context.Context, accId string) ([]models.Transaction, error)
+	GetAllTransactions(ctx context.Context, accId string, filter models.TransactionFilter) (models.TransactionPage, error)
 	GetBalance(ctx context.Context, accId string) (models.Balance, error)
 }
 
@@ -49,144 +80,765 @@ var _ TransactionService = (*transactionServiceImpl)(nil)
 type transactionServiceImpl struct {
 	transactionRepo repository.TransactionRepo
 	accountRepo     repository.AccountRepo
+	holdRepo        repository.HoldRepo
+	uow             repository.UnitOfWork
+	ledger          LedgerService
+	limits          LimitService
+	fees            FeeService
 }
 
-func NewTransactionService(transactionRepo repository.TransactionRepo, accountRepo repository.AccountRepo) *transactionServiceImpl {
+func NewTransactionService(transactionRepo repository.TransactionRepo, accountRepo repository.AccountRepo, holdRepo repository.HoldRepo, uow repository.UnitOfWork, ledger LedgerService, limits LimitService, fees FeeService) *transactionServiceImpl {
 	return &transactionServiceImpl{
 		transactionRepo: transactionRepo,
 		accountRepo:     accountRepo,
+		holdRepo:        holdRepo,
+		uow:             uow,
+		ledger:          ledger,
+		limits:          limits,
+		fees:            fees,
 	}
 }
 
+// GetBalance returns accId's balance along with how much of it active holds reserve.
 func (r *transactionServiceImpl) GetBalance(ctx context.Context, accId string) (models.Balance, error) {
 	_, err := r.accountRepo.FindOne(ctx, accId)
 	if err != nil {
 		return models.Balance{}, err
 	}
 
-	return r.transactionRepo.GetBalance(ctx, accId)
+	balance, err := r.transactionRepo.GetBalance(ctx, accId)
+	if err != nil {
+		return models.Balance{}, err
+	}
+
+	balance.Held, err = r.holdRepo.GetHeld(ctx, accId, clockNow())
+	if err != nil {
+		return models.Balance{}, err
+	}
+	balance.Available = balance.Amount - balance.Held
+
+	return balance, nil
 }
 
-func (r *transactionServiceImpl) GetAllTransactions(ctx context.Context, accId string) ([]models.Transaction, error) {
-	_, err := r.accountRepo.FindOne(ctx, accId)
+// GetAllTransactions returns one page of accId's history. A zero Limit means defaultPageLimit
+// and a zero Order means oldest first.
+func (r *transactionServiceImpl) GetAllTransactions(ctx context.Context, accId string, filter models.TransactionFilter) (models.TransactionPage, error) {
+	filter, err := validateFilter(filter)
 	if err != nil {
-		return []models.Transaction{}, err
+		return models.TransactionPage{}, err
 	}
 
-	return r.transactionRepo.FindAll(ctx, accId)
+	_, err = r.accountRepo.FindOne(ctx, accId)
+	if err != nil {
+		return models.TransactionPage{}, err
+	}
+
+	return r.transactionRepo.FindPage(ctx, accId, filter)
 }
 
 func (r *transactionServiceImpl) GetTransaction(ctx context.Context, id string) (models.Transaction, error) {
 	return r.transactionRepo.FindOne(ctx, id)
 }
 
-func (r *transactionServiceImpl) Deposit(ctx context.Context, owner string, amount float32) error {
+func (r *transactionServiceImpl) Deposit(ctx context.Context, owner string, amount models.Money, note models.Annotation) error {
 	if amount <= 0 {
 		return ErrInvalidAmount
 	}
 
-	_, err := r.accountRepo.FindOne(ctx, owner)
+	note, err := sanitizeAnnotation(note)
 	if err != nil {
 		return err
 	}
 
-	return r.credit(ctx, owner, owner, owner, amount)
+	err = r.limits.Check(ctx, owner, models.KindDeposit, amount, amount)
+	if err != nil {
+		return err
+	}
+
+	return r.uow.Do(ctx, func(ctx context.Context) error {
+		// Under the lock, a close either sees the deposit or the deposit sees the account closed.
+		err := r.transactionRepo.LockAccount(ctx, owner)
+		if err != nil {
+			return err
+		}
+
+		err = r.receiver(ctx, owner)
+		if err != nil {
+			return err
+		}
+
+		err = r.credit(ctx, models.KindDeposit, owner, owner, owner, amount, note)
+		if err != nil {
+			return err
+		}
+
+		return r.ledger.RecordDeposit(ctx, owner, amount)
+	})
 }
 
-func (r *transactionServiceImpl) Withdraw(ctx context.Context, owner string, amount float32) error {
-	_, err := r.accountRepo.FindOne(ctx, owner)
+// Withdraw takes amount, which is negative, out of owner's balance along with the
+// withdrawal fee.
+func (r *transactionServiceImpl) Withdraw(ctx context.Context, owner string, amount models.Money, note models.Annotation) (models.Receipt, error) {
+	if amount >= 0 {
+		return models.Receipt{}, ErrInvalidAmount
+	}
+
+	note, err := sanitizeAnnotation(note)
 	if err != nil {
-		return err
+		return models.Receipt{}, err
+	}
+
+	var receipt models.Receipt
+
+	err = r.uow.Do(ctx, func(ctx context.Context) error {
+		err := r.sender(ctx, owner)
+		if err != nil {
+			return err
+		}
+
+		err = r.checkLimits(ctx, owner, models.KindWithdrawal, -amount, -amount)
+		if err != nil {
+			return err
+		}
+
+		receipt, err = r.receipt(ctx, owner, models.FeeWithdrawal, -amount)
+		if err != nil {
+			return err
+		}
+
+		err = r.debit(ctx, owner, owner, -receipt.Total)
+		if err != nil {
+			return err
+		}
+
+		transaction := models.Transaction{
+			CreatedAt:  clockNow(),
+			IsConsumed: true,
+			Owner:      owner,
+			Sender:     owner,
+			Receiver:   owner,
+			Amount:     amount,
+			Kind:       models.KindWithdrawal,
+			Memo:       note.Memo,
+			Metadata:   note.Metadata,
+		}
+
+		err = r.transactionRepo.Create(ctx, transaction)
+		if err != nil {
+			log.Error().Err(err).Msg("TransactionService::Withdraw")
+			return ErrFailedDebitOperation
+		}
+
+		err = r.ledger.RecordWithdrawal(ctx, owner, -amount)
+		if err != nil {
+			return err
+		}
+
+		return r.chargeFee(ctx, owner, models.FeeWithdrawal, receipt.Fee)
+	})
+	if err != nil {
+		return models.Receipt{}, err
+	}
+
+	return receipt, nil
+}
+
+// Pay pays receiver, an account id or a handle, and charges the payment fee. A payment
+// that does not choose a privacy gets the sender's default one.
+func (r *transactionServiceImpl) Pay(ctx context.Context, owner string, receiver string, amount models.Money, note models.Annotation) (models.Receipt, error) {
+	if owner == receiver {
+		return models.Receipt{}, ErrInvalidPaymentOp
 	}
 
-	consumed, err := r.debit(ctx, owner, owner, amount)
+	note, err := sanitizeAnnotation(note)
 	if err != nil {
-		return err
+		return models.Receipt{}, err
 	}
 
-	transaction := models.Transaction{
-		CreatedAt:  clockNow(),
-		IsConsumed: true,
-		Owner:      owner,
-		Sender:     owner,
-		Receiver:   owner,
-		Amount:     amount,
+	from, err := r.accountRepo.FindOne(ctx, owner)
+	if err != nil {
+		return models.Receipt{}, err
+	}
+
+	if note.Privacy == "" {
+		note.Privacy = defaultPrivacy(from)
 	}
 
-	err = r.transactionRepo.Create(ctx, transaction)
+	acc, err := findAccountRef(ctx, r.accountRepo, receiver)
 	if err != nil {
-		go func() {
-			err := utils.Retry(func() error {
-				return r.transactionRepo.RollBackConsumed(ctx, consumed)
-			}, "rollback of MarkAsConsumed")
-			log.Error().Err(err)
-		}()
-		log.Error().Err(err)
-		return ErrFailedDebitOperation
+		return models.Receipt{}, err
 	}
 
-	return nil
+	if acc.AccountId == owner {
+		return models.Receipt{}, ErrInvalidPaymentOp
+	}
+
+	return r.pay(ctx, owner, acc.AccountId, amount.Abs(), note, fromBalance)
+}
+
+// Sweep pays amount from owner to receiver whatever the status of owner, to empty an
+// account that is being closed. The receiver must still be able to receive.
+func (r *transactionServiceImpl) Sweep(ctx context.Context, owner string, receiver string, amount models.Money) error {
+	if owner == receiver {
+		return ErrInvalidPaymentOp
+	}
+
+	if amount <= 0 {
+		return ErrInvalidAmount
+	}
+
+	_, err := r.pay(ctx, owner, receiver, amount, models.Annotation{Memo: "account closed"}, fromSweep)
+	return err
+}
+
+// funding is where pay takes the money it pays from.
+type funding int
+
+const (
+	// fromBalance debits the owner, who must fit the payment in its limits, along with
+	// the payment fee.
+	fromBalance funding = iota
+	// fromSweep debits the owner whatever its status and limits, and charges no fee.
+	fromSweep
+	// fromReservation pays out of what ReservePayout set aside, which was held to the
+	// owner's limits when it was reserved. Only the payment fee is debited.
+	fromReservation
+)
+
+// pay moves amount from owner to receiver, taking the money from source. Both accounts
+// are checked inside the unit of work, owner under its lock, so that the payment cannot
+// slip past a status change.
+func (r *transactionServiceImpl) pay(ctx context.Context, owner string, receiver string, amount models.Money, note models.Annotation, source funding) (models.Receipt, error) {
+	receipt := models.Receipt{Amount: amount, Total: amount}
+
+	err := r.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		if source != fromSweep {
+			err = r.sender(ctx, owner)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = r.receiver(ctx, receiver)
+		if err != nil {
+			return err
+		}
+
+		if source == fromBalance {
+			err = r.checkLimits(ctx, owner, models.KindPaymentOut, amount, amount)
+			if err != nil {
+				return err
+			}
+		}
+
+		if source != fromSweep {
+			receipt, err = r.receipt(ctx, owner, models.FeePayment, amount)
+			if err != nil {
+				return err
+			}
+		}
+
+		debited := receipt.Total
+		if source == fromReservation {
+			debited = receipt.Fee
+		}
+
+		if debited > 0 {
+			err = r.debit(ctx, owner, owner, -debited)
+			if err != nil {
+				return err
+			}
+		}
+
+		transaction := models.Transaction{
+			CreatedAt:  clockNow(),
+			IsConsumed: true,
+			Owner:      owner,
+			Sender:     owner,
+			Receiver:   receiver,
+			Amount:     -amount,
+			Kind:       models.KindPaymentOut,
+			Memo:       note.Memo,
+			Metadata:   note.Metadata,
+			Privacy:    note.Privacy,
+		}
+
+		err = r.transactionRepo.Create(ctx, transaction)
+		if err != nil {
+			log.Error().Err(err).Msg("TransactionService::Pay")
+			return ErrFailedDebitOperation
+		}
+
+		err = r.credit(ctx, models.KindPaymentIn, receiver, owner, receiver, amount, note)
+		if err != nil {
+			log.Error().Err(err).Msg("TransactionService::Pay")
+			return ErrFaileCreditOperation
+		}
+
+		if source == fromReservation {
+			err = r.ledger.RecordPayout(ctx, receiver, amount)
+		} else {
+			err = r.ledger.RecordPayment(ctx, owner, receiver, amount)
+		}
+		if err != nil {
+			return err
+		}
+
+		return r.chargeFee(ctx, owner, models.FeePayment, receipt.Fee)
+	})
+	if err != nil {
+		return models.Receipt{}, err
+	}
+
+	return receipt, nil
+}
+
+// SplitPay pays every share of req out of a single debit, so either all receivers are
+// paid or none is. Each share is charged the fee of a payment of its own amount. It
+// returns the shares that were paid along with their fees.
+func (r *transactionServiceImpl) SplitPay(ctx context.Context, owner string, req models.SplitPayReq) ([]models.SplitShare, error) {
+	shares, err := splitShares(owner, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var total, largest models.Money
+	for _, share := range shares {
+		total += share.Amount
+		largest = max(largest, share.Amount)
+	}
+
+	paid := make([]models.SplitShare, len(shares))
+
+	err = r.uow.Do(ctx, func(ctx context.Context) error {
+		err := r.sender(ctx, owner)
+		if err != nil {
+			return err
+		}
+
+		for _, share := range shares {
+			err = r.receiver(ctx, share.Receiver)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = r.checkLimits(ctx, owner, models.KindPaymentOut, largest, total)
+		if err != nil {
+			return err
+		}
+
+		receipts := make([]models.Receipt, len(shares))
+		var debited models.Money
+		for i, share := range shares {
+			receipts[i], err = r.receipt(ctx, owner, models.FeePayment, share.Amount)
+			if err != nil {
+				return err
+			}
+
+			if debited+receipts[i].Total < debited {
+				return ErrInvalidAmount
+			}
+			debited += receipts[i].Total
+		}
+
+		err = r.debit(ctx, owner, owner, -debited)
+		if err != nil {
+			return err
+		}
+
+		for i, share := range shares {
+			transaction := models.Transaction{
+				CreatedAt:  clockNow(),
+				IsConsumed: true,
+				Owner:      owner,
+				Sender:     owner,
+				Receiver:   share.Receiver,
+				Amount:     -share.Amount,
+				Kind:       models.KindPaymentOut,
+			}
+
+			err = r.transactionRepo.Create(ctx, transaction)
+			if err != nil {
+				log.Error().Err(err).Msg("TransactionService::SplitPay")
+				return ErrFailedDebitOperation
+			}
+
+			err = r.credit(ctx, models.KindPaymentIn, share.Receiver, owner, share.Receiver, share.Amount, models.Annotation{})
+			if err != nil {
+				log.Error().Err(err).Msg("TransactionService::SplitPay")
+				return ErrFaileCreditOperation
+			}
+
+			err = r.ledger.RecordPayment(ctx, owner, share.Receiver, share.Amount)
+			if err != nil {
+				return err
+			}
+
+			err = r.chargeFee(ctx, owner, models.FeePayment, receipts[i].Fee)
+			if err != nil {
+				return err
+			}
+
+			paid[i] = models.SplitShare{Receiver: share.Receiver, Amount: share.Amount, Fee: receipts[i].Fee}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return paid, nil
 }
 
-func (r *transactionServiceImpl) Pay(ctx context.Context, owner string, receiver string, amount float32) error {
+// splitShares validates req and resolves it into one share per receiver. An equal split
+// hands the cents left over to the first receivers, one each, in the order given.
+func splitShares(owner string, req models.SplitPayReq) ([]models.SplitShare, error) {
+	if len(req.Shares) > 0 && (len(req.Receivers) > 0 || req.Total != 0) {
+		return nil, ErrSplitAmbiguous
+	}
+
+	shares := req.Shares
+	if len(shares) == 0 {
+		n := models.Money(len(req.Receivers))
+		if n == 0 || n > maxSplitReceivers {
+			return nil, ErrSplitNoReceivers
+		}
+		if req.Total <= 0 {
+			return nil, ErrInvalidAmount
+		}
+		if req.Total < n {
+			return nil, ErrSplitTooSmall
+		}
+
+		base, remainder := req.Total/n, req.Total%n
+		shares = make([]models.SplitShare, 0, n)
+
+		for i, receiver := range req.Receivers {
+			amount := base
+			if models.Money(i) < remainder {
+				amount++
+			}
+
+			shares = append(shares, models.SplitShare{Receiver: receiver, Amount: amount})
+		}
+	}
+
+	if len(shares) > maxSplitReceivers {
+		return nil, ErrSplitNoReceivers
+	}
+
+	seen := make(map[string]bool, len(shares))
+	var total models.Money
+
+	for _, share := range shares {
+		if share.Receiver == owner {
+			return nil, ErrInvalidPaymentOp
+		}
+		if seen[share.Receiver] {
+			return nil, ErrSplitDuplicate
+		}
+		if share.Amount <= 0 || total+share.Amount < total {
+			return nil, ErrInvalidAmount
+		}
+
+		seen[share.Receiver] = true
+		total += share.Amount
+	}
+
+	return shares, nil
+}
+
+// ReservePayout takes amount out of the owner's balance and parks it in payout clearing,
+// from where Payout and ReleasePayout move it on. amount counts against the owner's
+// rolling limits and largest, the largest payout it is for, against the limit of a
+// single payment.
+func (r *transactionServiceImpl) ReservePayout(ctx context.Context, owner string, largest models.Money, amount models.Money) error {
+	return r.uow.Do(ctx, func(ctx context.Context) error {
+		err := r.sender(ctx, owner)
+		if err != nil {
+			return err
+		}
+
+		err = r.checkLimits(ctx, owner, models.KindPaymentOut, largest, amount)
+		if err != nil {
+			return err
+		}
+
+		err = r.debit(ctx, owner, owner, -amount)
+		if err != nil {
+			return err
+		}
+
+		return r.ledger.RecordPayoutReservation(ctx, owner, amount)
+	})
+}
+
+// Payout pays receiver out of what owner reserved with ReservePayout. It is a payment
+// like any other, owner's fee included, funded by the reservation.
+func (r *transactionServiceImpl) Payout(ctx context.Context, owner string, receiver string, amount models.Money) error {
 	if owner == receiver {
 		return ErrInvalidPaymentOp
 	}
 
-	_, err := r.accountRepo.FindOne(ctx, owner)
+	_, err := r.pay(ctx, owner, receiver, amount, models.Annotation{}, fromReservation)
+	return err
+}
+
+// ReleasePayout gives back to owner a reserved amount that could not be paid out.
+func (r *transactionServiceImpl) ReleasePayout(ctx context.Context, owner string, amount models.Money) error {
+	return r.uow.Do(ctx, func(ctx context.Context) error {
+		err := r.credit(ctx, models.KindAdjustment, owner, owner, owner, amount, models.Annotation{})
+		if err != nil {
+			log.Error().Err(err).Msg("TransactionService::ReleasePayout")
+			return ErrFaileCreditOperation
+		}
+
+		return r.ledger.RecordPayoutRelease(ctx, owner, amount)
+	})
+}
+
+// PayInterest credits owner with the interest it earned, out of the interest account.
+func (r *transactionServiceImpl) PayInterest(ctx context.Context, owner string, amount models.Money, note models.Annotation) error {
+	return r.uow.Do(ctx, func(ctx context.Context) error {
+		err := r.transactionRepo.LockAccount(ctx, owner)
+		if err != nil {
+			return err
+		}
+
+		err = r.receiver(ctx, owner)
+		if err != nil {
+			return err
+		}
+
+		err = r.credit(ctx, models.KindInterest, owner, owner, owner, amount, note)
+		if err != nil {
+			log.Error().Err(err).Msg("TransactionService::PayInterest")
+			return ErrFaileCreditOperation
+		}
+
+		return r.ledger.RecordInterest(ctx, owner, amount)
+	})
+}
+
+// Refund sends amount of the payment received in transactionId back to its sender. A zero
+// amount refunds whatever is left. Only the receiver of the payment may refund it.
+func (r *transactionServiceImpl) Refund(ctx context.Context, transactionId string, initiator string, amount models.Money) error {
+	original, err := r.refundable(ctx, transactionId)
+	if err != nil {
+		return err
+	}
+
+	if initiator != original.Owner {
+		return ErrRefundForbidden
+	}
+
+	return r.refund(ctx, original, amount, false)
+}
+
+// AdminRefund is Refund on behalf of the receiver. It also refunds out of frozen accounts.
+func (r *transactionServiceImpl) AdminRefund(ctx context.Context, transactionId string, amount models.Money) error {
+	original, err := r.refundable(ctx, transactionId)
+	if err != nil {
+		return err
+	}
+
+	return r.refund(ctx, original, amount, true)
+}
+
+func (r *transactionServiceImpl) refundable(ctx context.Context, transactionId string) (models.Transaction, error) {
+	original, err := r.transactionRepo.FindOne(ctx, transactionId)
+	if err != nil {
+		return models.Transaction{}, err
+	}
+
+	if original.Kind != models.KindPaymentIn {
+		return models.Transaction{}, ErrNotRefundable
+	}
+
+	return original, nil
+}
+
+// refund debits the receiver of original and credits its sender, both legs linked to
+// original. Unless the refund is made by an admin, the receiver must be able to send.
+func (r *transactionServiceImpl) refund(ctx context.Context, original models.Transaction, amount models.Money, admin bool) error {
+	if amount < 0 {
+		return ErrInvalidAmount
+	}
+
+	payer := original.Owner
+	payee := original.Sender
+
+	return r.uow.Do(ctx, func(ctx context.Context) error {
+		// The lock is taken before adding up past refunds so that concurrent refunds
+		// of the same payment cannot go over its amount.
+		err := r.transactionRepo.LockAccount(ctx, payer)
+		if err != nil {
+			return err
+		}
+
+		if !admin {
+			err = r.sender(ctx, payer)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = r.receiver(ctx, payee)
+		if err != nil {
+			return err
+		}
+
+		related, err := r.transactionRepo.FindRelated(ctx, original.TransactionId)
+		if err != nil {
+			return err
+		}
+
+		remaining := original.Amount
+		for _, t := range related {
+			if t.Kind == models.KindRefund && t.Owner == payer {
+				remaining += t.Amount
+			}
+		}
+
+		refund := amount
+		if refund == 0 {
+			refund = remaining
+		}
+		if refund <= 0 || refund > remaining {
+			return ErrRefundExceeded
+		}
+
+		err = r.debit(ctx, payer, payer, -refund)
+		if err != nil {
+			return err
+		}
+
+		out := models.Transaction{
+			CreatedAt:  clockNow(),
+			IsConsumed: true,
+			Owner:      payer,
+			Sender:     payer,
+			Receiver:   payee,
+			Amount:     -refund,
+			Kind:       models.KindRefund,
+			RelatedId:  original.TransactionId,
+		}
+
+		err = r.transactionRepo.Create(ctx, out)
+		if err != nil {
+			log.Error().Err(err).Msg("TransactionService::Refund")
+			return ErrFailedDebitOperation
+		}
+
+		in := models.Transaction{
+			CreatedAt:  clockNow(),
+			IsConsumed: false,
+			Owner:      payee,
+			Sender:     payer,
+			Receiver:   payee,
+			Amount:     refund,
+			Kind:       models.KindRefund,
+			RelatedId:  original.TransactionId,
+		}
+
+		err = r.transactionRepo.Create(ctx, in)
+		if err != nil {
+			log.Error().Err(err).Msg("TransactionService::Refund")
+			return ErrFaileCreditOperation
+		}
+
+		return r.ledger.RecordRefund(ctx, payer, payee, refund)
+	})
+}
+
+// sender takes id's account lock and fails unless id is an account that may send money.
+// The account is read under the lock, so a freeze or close that committed while waiting
+// for it is seen. It must run inside a unit of work.
+func (r *transactionServiceImpl) sender(ctx context.Context, id string) error {
+	err := r.transactionRepo.LockAccount(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	acc, err := r.accountRepo.FindOne(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	_, err = r.accountRepo.FindOne(ctx, receiver)
+	return canSend(acc)
+}
+
+// receiver fails unless id is an account that may receive money.
+func (r *transactionServiceImpl) receiver(ctx context.Context, id string) error {
+	acc, err := r.accountRepo.FindOne(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	amount = float32(math.Abs(float64(amount)))
+	return canReceive(acc)
+}
 
-	consumed, err := r.debit(ctx, owner, owner, -amount)
+// checkLimits checks total against owner's limits under owner's account lock, so that
+// debit, which takes the lock again, debits what was checked. Amounts debit rejects are
+// left to it.
+func (r *transactionServiceImpl) checkLimits(ctx context.Context, owner string, kind models.TransactionKind, largest models.Money, total models.Money) error {
+	if total <= 0 {
+		return nil
+	}
+
+	err := r.transactionRepo.LockAccount(ctx, owner)
 	if err != nil {
 		return err
 	}
 
+	return r.limits.Check(ctx, owner, kind, largest, total)
+}
+
+// receipt prices operation on amount for owner.
+func (r *transactionServiceImpl) receipt(ctx context.Context, owner string, operation models.FeeOperation, amount models.Money) (models.Receipt, error) {
+	fee, err := r.fees.Quote(ctx, owner, operation, amount)
+	if err != nil {
+		return models.Receipt{}, err
+	}
+
+	if amount+fee < amount {
+		return models.Receipt{}, ErrInvalidAmount
+	}
+
+	return models.Receipt{Amount: amount, Fee: fee, Total: amount + fee}, nil
+}
+
+// chargeFee records the fee owner paid on operation, out of money debit already took, as
+// a fee transaction in owner's history credited to the revenue account in the ledger.
+func (r *transactionServiceImpl) chargeFee(ctx context.Context, owner string, operation models.FeeOperation, fee models.Money) error {
+	if fee == 0 {
+		return nil
+	}
+
 	transaction := models.Transaction{
 		CreatedAt:  clockNow(),
 		IsConsumed: true,
 		Owner:      owner,
 		Sender:     owner,
-		Receiver:   receiver,
-		Amount:     -amount,
+		Receiver:   owner,
+		Amount:     -fee,
+		Kind:       models.KindFee,
+		Memo:       string(operation) + " fee",
 	}
 
-	err = r.transactionRepo.Create(ctx, transaction)
+	err := r.transactionRepo.Create(ctx, transaction)
 	if err != nil {
-		utils.Go(func() {
-			err := utils.Retry(func() error {
-				return r.transactionRepo.RollBackConsumed(ctx, consumed)
-			}, "rollback of MarkAsConsumed")
-			log.Error().Err(err)
-		})
-		log.Error().Err(err)
+		log.Error().Err(err).Msg("TransactionService::chargeFee")
 		return ErrFailedDebitOperation
 	}
 
-	err = r.credit(ctx, receiver, owner, receiver, amount)
-	if err != nil {
-		utils.Go(func() {
-			err := utils.Retry(func() error {
-				return r.transactionRepo.RollBackConsumed(ctx, consumed)
-			}, "rollback of MarkAsConsumed")
-			log.Error().Err(err)
-		})
-		log.Error().Err(err)
-		return ErrFaileCreditOperation
-	}
-
-	return nil
+	return r.ledger.RecordFee(ctx, owner, fee)
 }
 
-func (r *transactionServiceImpl) credit(ctx context.Context, owner string, sender string, receiver string, amount float32) error {
+func (r *transactionServiceImpl) credit(ctx context.Context, kind models.TransactionKind, owner string, sender string, receiver string, amount models.Money, note models.Annotation) error {
 	if amount <= 0 {
 		return ErrInvalidAmount
 	}
@@ -198,46 +850,58 @@ func (r *transactionServiceImpl) credit(ctx context.Context, owner string, sende
 		Sender:     sender,
 		Receiver:   receiver,
 		Amount:     amount,
+		Kind:       kind,
+		Memo:       note.Memo,
+		Metadata:   note.Metadata,
+		Privacy:    note.Privacy,
 	}
 
 	return r.transactionRepo.Create(ctx, transaction)
 }
 
-func (r *transactionServiceImpl) debit(ctx context.Context, owner string, receiver string, amount float32) ([]string, error) {
+// debit consumes the owner's oldest transactions until amount is covered, issuing
+// a change transaction for any leftover. It must run inside a unit of work so that
+// a failure halfway leaves every consumed transaction untouched, and it holds the
+// owner's account lock so concurrent debits cannot spend the same transactions.
+// Money reserved by active holds cannot be debited.
+func (r *transactionServiceImpl) debit(ctx context.Context, owner string, receiver string, amount models.Money) error {
 	if amount >= 0 {
-		return []string{}, ErrInvalidAmount
+		return ErrInvalidAmount
+	}
+
+	err := r.transactionRepo.LockAccount(ctx, owner)
+	if err != nil {
+		return err
 	}
 
 	balance, err := r.transactionRepo.GetBalance(ctx, owner)
 	if err != nil {
-		return []string{}, err
+		return err
 	}
 
-	if (balance.Amount + float64(amount)) < 0 {
-		return []string{}, ErrInsufficentBalance
+	held, err := r.holdRepo.GetHeld(ctx, owner, clockNow())
+	if err != nil {
+		return err
 	}
 
-	transactions, err := r.transactionRepo.FindAll(ctx, owner)
+	if (balance.Amount - held + amount) < 0 {
+		return ErrInsufficentBalance
+	}
+
+	transactions, err := r.transactionRepo.FindUnconsumed(ctx, owner)
 	if err != nil {
-		return []string{}, err
+		return err
 	}
 
 	debit := (-1) * amount
-	transConsumed := []string{}
+
 	for _, t := range transactions {
 		err = r.transactionRepo.MarkAsConsumed(ctx, t.TransactionId)
 		if err != nil {
-			utils.Go(func() {
-				err := utils.Retry(func() error {
-					return r.transactionRepo.RollBackConsumed(ctx, transConsumed)
-				}, "rollback of MarkAsConsumed")
-				log.Error().Err(err)
-			})
-			log.Error().Err(err)
-			return []string{}, ErrFailedDebitOperation
+			log.Error().Err(err).Msg("TransactionService::debit")
+			return ErrFailedDebitOperation
 		}
 
-		transConsumed = append(transConsumed, t.TransactionId)
 		remaining := t.Amount - debit
 
 		if remaining == 0 {
@@ -250,21 +914,61 @@ func (r *transactionServiceImpl) debit(ctx context.Context, owner string, receiv
 		}
 
 		if remaining > 0 {
-			err := r.credit(ctx, owner, owner, receiver, t.Amount-debit)
+			err := r.credit(ctx, models.KindChange, owner, owner, receiver, t.Amount-debit, models.Annotation{})
 			if err != nil {
-				utils.Go(func() {
-					err := utils.Retry(func() error {
-						return r.transactionRepo.RollBackConsumed(ctx, transConsumed)
-					}, "rollback of MarkAsConsumed")
-					log.Error().Err(err)
-				})
-				log.Error().Err(err)
-				return []string{}, ErrFailedDebitOperation
+				log.Error().Err(err).Msg("TransactionService::debit")
+				return ErrFailedDebitOperation
 			}
-
 			break
 		}
 	}
 
-	return transConsumed, nil
+	return nil
+}
+
+func validateFilter(filter models.TransactionFilter) (models.TransactionFilter, error) {
+	if filter.Limit == 0 {
+		filter.Limit = defaultPageLimit
+	}
+	if filter.Limit < 0 || filter.Limit > maxPageLimit {
+		return filter, ErrInvalidLimit
+	}
+
+	if filter.Order == "" {
+		filter.Order = models.SortAsc
+	}
+	if filter.Order != models.SortAsc && filter.Order != models.SortDesc {
+		return filter, ErrInvalidSortOrder
+	}
+
+	if filter.Kind != "" && !filter.Kind.IsValid() {
+		return filter, ErrInvalidKind
+	}
+
+	if filter.Direction != "" && filter.Direction != models.DirectionIn && filter.Direction != models.DirectionOut {
+		return filter, ErrInvalidDirection
+	}
+
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return filter, ErrInvalidDateRange
+	}
+
+	if filter.MinAmount != nil && *filter.MinAmount < 0 || filter.MaxAmount != nil && *filter.MaxAmount < 0 {
+		return filter, ErrInvalidAmountRange
+	}
+	if filter.MinAmount != nil && filter.MaxAmount != nil && *filter.MinAmount > *filter.MaxAmount {
+		return filter, ErrInvalidAmountRange
+	}
+
+	filter.Memo = sanitizeText(filter.Memo)
+	if utf8.RuneCountInString(filter.Memo) > maxMemoLength {
+		return filter, ErrInvalidMemoSearch
+	}
+	for key := range filter.Metadata {
+		if !validMetadataKey(key) {
+			return filter, ErrInvalidMetadataSearch
+		}
+	}
+
+	return filter, nil
 }
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/gopay/internal/models"
+	"github.com/gopay/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionService_ConcurrentPayments(t *testing.T) {
+	const (
+		accounts = 4
+		workers  = 16
+		payments = 50
+		deposit  = models.Money(10000)
+	)
+
+	ctx := context.Background()
+	transactionRepo := yieldingTransactionRepo{repository.NewTransactionRepo()}
+	accountRepo := repository.NewAccountRepo()
+	ledger := NewLedgerService(repository.NewLedgerRepo(), accountRepo)
+	limits := NewLimitService(models.Limits{}, repository.NewLimitRepo(), accountRepo, transactionRepo, repository.NewPayoutRepo())
+	fees := NewFeeService(models.FeeSchedule{}, repository.NewFeeWaiverRepo(), accountRepo)
+	service := NewTransactionService(transactionRepo, accountRepo, repository.NewHoldRepo(), repository.NewUnitOfWork(), ledger, limits, fees)
+
+	ids := make([]string, 0, accounts)
+	for i := 0; i < accounts; i++ {
+		id, err := accountRepo.Create(ctx, "Shankar", "Nakai")
+		require.NoError(t, err)
+		require.NoError(t, service.Deposit(ctx, id, deposit, models.Annotation{}))
+		ids = append(ids, id)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, workers*payments)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for p := 0; p < payments; p++ {
+				owner := ids[(w+p)%accounts]
+				receiver := ids[(w+p+1)%accounts]
+
+				var err error
+				if p%5 == 0 {
+					_, err = service.Withdraw(ctx, owner, -1500, models.Annotation{})
+				} else {
+					_, err = service.Pay(ctx, owner, receiver, models.Money(700+w*13), models.Annotation{})
+				}
+
+				if err != nil && !errors.Is(err, ErrInsufficentBalance) {
+					errs <- err
+				}
+			}
+		}(w)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+
+	var total models.Money
+	for _, id := range ids {
+		balance, err := service.GetBalance(ctx, id)
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, balance.Amount, models.Money(0))
+
+		withdrawn := models.Money(0)
+		transactions, err := transactionRepo.FindAll(ctx, id)
+		assert.NoError(t, err)
+		for _, tr := range transactions {
+			if tr.Sender == id && tr.Receiver == id && tr.Amount < 0 {
+				withdrawn += tr.Amount
+			}
+		}
+
+		total += balance.Amount - withdrawn
+
+		entries, err := ledger.GetEntries(ctx, id)
+		assert.NoError(t, err)
+		var ledgerBalance models.Money
+		for _, e := range entries {
+			for _, p := range e.Postings {
+				if p.AccountId == id {
+					ledgerBalance += p.Amount
+				}
+			}
+		}
+		assert.Equal(t, balance.Amount, ledgerBalance)
+	}
+
+	assert.Equal(t, deposit*accounts, total)
+
+	check, err := ledger.Check(ctx)
+	assert.NoError(t, err)
+	assert.True(t, check.Balanced)
+}
+
+// yieldingTransactionRepo gives up the processor between the reads and writes of a
+// debit so that unsynchronized debits would reliably interleave.
+type yieldingTransactionRepo struct {
+	repository.TransactionRepo
+}
+
+func (r yieldingTransactionRepo) GetBalance(ctx context.Context, id string) (models.Balance, error) {
+	runtime.Gosched()
+	return r.TransactionRepo.GetBalance(ctx, id)
+}
+
+func (r yieldingTransactionRepo) FindUnconsumed(ctx context.Context, accId string) ([]models.Transaction, error) {
+	runtime.Gosched()
+	return r.TransactionRepo.FindUnconsumed(ctx, accId)
+}
+
+func (r yieldingTransactionRepo) MarkAsConsumed(ctx context.Context, id string) error {
+	runtime.Gosched()
+	return r.TransactionRepo.MarkAsConsumed(ctx, id)
+}
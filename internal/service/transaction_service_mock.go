@@ -0,0 +1,778 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package service
+
+import (
+	context "context"
+
+	models "github.com/gopay/internal/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockTransactionService is an autogenerated mock type for the TransactionService type
+type MockTransactionService struct {
+	mock.Mock
+}
+
+type MockTransactionService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTransactionService) EXPECT() *MockTransactionService_Expecter {
+	return &MockTransactionService_Expecter{mock: &_m.Mock}
+}
+
+// AdminRefund provides a mock function with given fields: ctx, transactionId, amount
+func (_m *MockTransactionService) AdminRefund(ctx context.Context, transactionId string, amount models.Money) error {
+	ret := _m.Called(ctx, transactionId, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AdminRefund")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.Money) error); ok {
+		r0 = rf(ctx, transactionId, amount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTransactionService_AdminRefund_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AdminRefund'
+type MockTransactionService_AdminRefund_Call struct {
+	*mock.Call
+}
+
+// AdminRefund is a helper method to define mock.On call
+//   - ctx context.Context
+//   - transactionId string
+//   - amount models.Money
+func (_e *MockTransactionService_Expecter) AdminRefund(ctx interface{}, transactionId interface{}, amount interface{}) *MockTransactionService_AdminRefund_Call {
+	return &MockTransactionService_AdminRefund_Call{Call: _e.mock.On("AdminRefund", ctx, transactionId, amount)}
+}
+
+func (_c *MockTransactionService_AdminRefund_Call) Run(run func(ctx context.Context, transactionId string, amount models.Money)) *MockTransactionService_AdminRefund_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.Money))
+	})
+	return _c
+}
+
+func (_c *MockTransactionService_AdminRefund_Call) Return(_a0 error) *MockTransactionService_AdminRefund_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTransactionService_AdminRefund_Call) RunAndReturn(run func(context.Context, string, models.Money) error) *MockTransactionService_AdminRefund_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Deposit provides a mock function with given fields: ctx, owner, amount, note
+func (_m *MockTransactionService) Deposit(ctx context.Context, owner string, amount models.Money, note models.Annotation) error {
+	ret := _m.Called(ctx, owner, amount, note)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Deposit")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.Money, models.Annotation) error); ok {
+		r0 = rf(ctx, owner, amount, note)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTransactionService_Deposit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Deposit'
+type MockTransactionService_Deposit_Call struct {
+	*mock.Call
+}
+
+// Deposit is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - amount models.Money
+//   - note models.Annotation
+func (_e *MockTransactionService_Expecter) Deposit(ctx interface{}, owner interface{}, amount interface{}, note interface{}) *MockTransactionService_Deposit_Call {
+	return &MockTransactionService_Deposit_Call{Call: _e.mock.On("Deposit", ctx, owner, amount, note)}
+}
+
+func (_c *MockTransactionService_Deposit_Call) Run(run func(ctx context.Context, owner string, amount models.Money, note models.Annotation)) *MockTransactionService_Deposit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.Money), args[3].(models.Annotation))
+	})
+	return _c
+}
+
+func (_c *MockTransactionService_Deposit_Call) Return(_a0 error) *MockTransactionService_Deposit_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTransactionService_Deposit_Call) RunAndReturn(run func(context.Context, string, models.Money, models.Annotation) error) *MockTransactionService_Deposit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllTransactions provides a mock function with given fields: ctx, accId, filter
+func (_m *MockTransactionService) GetAllTransactions(ctx context.Context, accId string, filter models.TransactionFilter) (models.TransactionPage, error) {
+	ret := _m.Called(ctx, accId, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllTransactions")
+	}
+
+	var r0 models.TransactionPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.TransactionFilter) (models.TransactionPage, error)); ok {
+		return rf(ctx, accId, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.TransactionFilter) models.TransactionPage); ok {
+		r0 = rf(ctx, accId, filter)
+	} else {
+		r0 = ret.Get(0).(models.TransactionPage)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.TransactionFilter) error); ok {
+		r1 = rf(ctx, accId, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTransactionService_GetAllTransactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllTransactions'
+type MockTransactionService_GetAllTransactions_Call struct {
+	*mock.Call
+}
+
+// GetAllTransactions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accId string
+//   - filter models.TransactionFilter
+func (_e *MockTransactionService_Expecter) GetAllTransactions(ctx interface{}, accId interface{}, filter interface{}) *MockTransactionService_GetAllTransactions_Call {
+	return &MockTransactionService_GetAllTransactions_Call{Call: _e.mock.On("GetAllTransactions", ctx, accId, filter)}
+}
+
+func (_c *MockTransactionService_GetAllTransactions_Call) Run(run func(ctx context.Context, accId string, filter models.TransactionFilter)) *MockTransactionService_GetAllTransactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.TransactionFilter))
+	})
+	return _c
+}
+
+func (_c *MockTransactionService_GetAllTransactions_Call) Return(_a0 models.TransactionPage, _a1 error) *MockTransactionService_GetAllTransactions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTransactionService_GetAllTransactions_Call) RunAndReturn(run func(context.Context, string, models.TransactionFilter) (models.TransactionPage, error)) *MockTransactionService_GetAllTransactions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBalance provides a mock function with given fields: ctx, accId
+func (_m *MockTransactionService) GetBalance(ctx context.Context, accId string) (models.Balance, error) {
+	ret := _m.Called(ctx, accId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBalance")
+	}
+
+	var r0 models.Balance
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.Balance, error)); ok {
+		return rf(ctx, accId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.Balance); ok {
+		r0 = rf(ctx, accId)
+	} else {
+		r0 = ret.Get(0).(models.Balance)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, accId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTransactionService_GetBalance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBalance'
+type MockTransactionService_GetBalance_Call struct {
+	*mock.Call
+}
+
+// GetBalance is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accId string
+func (_e *MockTransactionService_Expecter) GetBalance(ctx interface{}, accId interface{}) *MockTransactionService_GetBalance_Call {
+	return &MockTransactionService_GetBalance_Call{Call: _e.mock.On("GetBalance", ctx, accId)}
+}
+
+func (_c *MockTransactionService_GetBalance_Call) Run(run func(ctx context.Context, accId string)) *MockTransactionService_GetBalance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockTransactionService_GetBalance_Call) Return(_a0 models.Balance, _a1 error) *MockTransactionService_GetBalance_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTransactionService_GetBalance_Call) RunAndReturn(run func(context.Context, string) (models.Balance, error)) *MockTransactionService_GetBalance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTransaction provides a mock function with given fields: ctx, id
+func (_m *MockTransactionService) GetTransaction(ctx context.Context, id string) (models.Transaction, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransaction")
+	}
+
+	var r0 models.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (models.Transaction, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) models.Transaction); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(models.Transaction)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTransactionService_GetTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransaction'
+type MockTransactionService_GetTransaction_Call struct {
+	*mock.Call
+}
+
+// GetTransaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockTransactionService_Expecter) GetTransaction(ctx interface{}, id interface{}) *MockTransactionService_GetTransaction_Call {
+	return &MockTransactionService_GetTransaction_Call{Call: _e.mock.On("GetTransaction", ctx, id)}
+}
+
+func (_c *MockTransactionService_GetTransaction_Call) Run(run func(ctx context.Context, id string)) *MockTransactionService_GetTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockTransactionService_GetTransaction_Call) Return(_a0 models.Transaction, _a1 error) *MockTransactionService_GetTransaction_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTransactionService_GetTransaction_Call) RunAndReturn(run func(context.Context, string) (models.Transaction, error)) *MockTransactionService_GetTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Pay provides a mock function with given fields: ctx, owner, receiver, amount, note
+func (_m *MockTransactionService) Pay(ctx context.Context, owner string, receiver string, amount models.Money, note models.Annotation) (models.Receipt, error) {
+	ret := _m.Called(ctx, owner, receiver, amount, note)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Pay")
+	}
+
+	var r0 models.Receipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.Money, models.Annotation) (models.Receipt, error)); ok {
+		return rf(ctx, owner, receiver, amount, note)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.Money, models.Annotation) models.Receipt); ok {
+		r0 = rf(ctx, owner, receiver, amount, note)
+	} else {
+		r0 = ret.Get(0).(models.Receipt)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, models.Money, models.Annotation) error); ok {
+		r1 = rf(ctx, owner, receiver, amount, note)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTransactionService_Pay_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Pay'
+type MockTransactionService_Pay_Call struct {
+	*mock.Call
+}
+
+// Pay is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - receiver string
+//   - amount models.Money
+//   - note models.Annotation
+func (_e *MockTransactionService_Expecter) Pay(ctx interface{}, owner interface{}, receiver interface{}, amount interface{}, note interface{}) *MockTransactionService_Pay_Call {
+	return &MockTransactionService_Pay_Call{Call: _e.mock.On("Pay", ctx, owner, receiver, amount, note)}
+}
+
+func (_c *MockTransactionService_Pay_Call) Run(run func(ctx context.Context, owner string, receiver string, amount models.Money, note models.Annotation)) *MockTransactionService_Pay_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(models.Money), args[4].(models.Annotation))
+	})
+	return _c
+}
+
+func (_c *MockTransactionService_Pay_Call) Return(_a0 models.Receipt, _a1 error) *MockTransactionService_Pay_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTransactionService_Pay_Call) RunAndReturn(run func(context.Context, string, string, models.Money, models.Annotation) (models.Receipt, error)) *MockTransactionService_Pay_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PayInterest provides a mock function with given fields: ctx, owner, amount, note
+func (_m *MockTransactionService) PayInterest(ctx context.Context, owner string, amount models.Money, note models.Annotation) error {
+	ret := _m.Called(ctx, owner, amount, note)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PayInterest")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.Money, models.Annotation) error); ok {
+		r0 = rf(ctx, owner, amount, note)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTransactionService_PayInterest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PayInterest'
+type MockTransactionService_PayInterest_Call struct {
+	*mock.Call
+}
+
+// PayInterest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - amount models.Money
+//   - note models.Annotation
+func (_e *MockTransactionService_Expecter) PayInterest(ctx interface{}, owner interface{}, amount interface{}, note interface{}) *MockTransactionService_PayInterest_Call {
+	return &MockTransactionService_PayInterest_Call{Call: _e.mock.On("PayInterest", ctx, owner, amount, note)}
+}
+
+func (_c *MockTransactionService_PayInterest_Call) Run(run func(ctx context.Context, owner string, amount models.Money, note models.Annotation)) *MockTransactionService_PayInterest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.Money), args[3].(models.Annotation))
+	})
+	return _c
+}
+
+func (_c *MockTransactionService_PayInterest_Call) Return(_a0 error) *MockTransactionService_PayInterest_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTransactionService_PayInterest_Call) RunAndReturn(run func(context.Context, string, models.Money, models.Annotation) error) *MockTransactionService_PayInterest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Payout provides a mock function with given fields: ctx, owner, receiver, amount
+func (_m *MockTransactionService) Payout(ctx context.Context, owner string, receiver string, amount models.Money) error {
+	ret := _m.Called(ctx, owner, receiver, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Payout")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.Money) error); ok {
+		r0 = rf(ctx, owner, receiver, amount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTransactionService_Payout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Payout'
+type MockTransactionService_Payout_Call struct {
+	*mock.Call
+}
+
+// Payout is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - receiver string
+//   - amount models.Money
+func (_e *MockTransactionService_Expecter) Payout(ctx interface{}, owner interface{}, receiver interface{}, amount interface{}) *MockTransactionService_Payout_Call {
+	return &MockTransactionService_Payout_Call{Call: _e.mock.On("Payout", ctx, owner, receiver, amount)}
+}
+
+func (_c *MockTransactionService_Payout_Call) Run(run func(ctx context.Context, owner string, receiver string, amount models.Money)) *MockTransactionService_Payout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(models.Money))
+	})
+	return _c
+}
+
+func (_c *MockTransactionService_Payout_Call) Return(_a0 error) *MockTransactionService_Payout_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTransactionService_Payout_Call) RunAndReturn(run func(context.Context, string, string, models.Money) error) *MockTransactionService_Payout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Refund provides a mock function with given fields: ctx, transactionId, initiator, amount
+func (_m *MockTransactionService) Refund(ctx context.Context, transactionId string, initiator string, amount models.Money) error {
+	ret := _m.Called(ctx, transactionId, initiator, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Refund")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.Money) error); ok {
+		r0 = rf(ctx, transactionId, initiator, amount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTransactionService_Refund_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Refund'
+type MockTransactionService_Refund_Call struct {
+	*mock.Call
+}
+
+// Refund is a helper method to define mock.On call
+//   - ctx context.Context
+//   - transactionId string
+//   - initiator string
+//   - amount models.Money
+func (_e *MockTransactionService_Expecter) Refund(ctx interface{}, transactionId interface{}, initiator interface{}, amount interface{}) *MockTransactionService_Refund_Call {
+	return &MockTransactionService_Refund_Call{Call: _e.mock.On("Refund", ctx, transactionId, initiator, amount)}
+}
+
+func (_c *MockTransactionService_Refund_Call) Run(run func(ctx context.Context, transactionId string, initiator string, amount models.Money)) *MockTransactionService_Refund_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(models.Money))
+	})
+	return _c
+}
+
+func (_c *MockTransactionService_Refund_Call) Return(_a0 error) *MockTransactionService_Refund_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTransactionService_Refund_Call) RunAndReturn(run func(context.Context, string, string, models.Money) error) *MockTransactionService_Refund_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleasePayout provides a mock function with given fields: ctx, owner, amount
+func (_m *MockTransactionService) ReleasePayout(ctx context.Context, owner string, amount models.Money) error {
+	ret := _m.Called(ctx, owner, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleasePayout")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.Money) error); ok {
+		r0 = rf(ctx, owner, amount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTransactionService_ReleasePayout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleasePayout'
+type MockTransactionService_ReleasePayout_Call struct {
+	*mock.Call
+}
+
+// ReleasePayout is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - amount models.Money
+func (_e *MockTransactionService_Expecter) ReleasePayout(ctx interface{}, owner interface{}, amount interface{}) *MockTransactionService_ReleasePayout_Call {
+	return &MockTransactionService_ReleasePayout_Call{Call: _e.mock.On("ReleasePayout", ctx, owner, amount)}
+}
+
+func (_c *MockTransactionService_ReleasePayout_Call) Run(run func(ctx context.Context, owner string, amount models.Money)) *MockTransactionService_ReleasePayout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.Money))
+	})
+	return _c
+}
+
+func (_c *MockTransactionService_ReleasePayout_Call) Return(_a0 error) *MockTransactionService_ReleasePayout_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTransactionService_ReleasePayout_Call) RunAndReturn(run func(context.Context, string, models.Money) error) *MockTransactionService_ReleasePayout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReservePayout provides a mock function with given fields: ctx, owner, largest, amount
+func (_m *MockTransactionService) ReservePayout(ctx context.Context, owner string, largest models.Money, amount models.Money) error {
+	ret := _m.Called(ctx, owner, largest, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReservePayout")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.Money, models.Money) error); ok {
+		r0 = rf(ctx, owner, largest, amount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTransactionService_ReservePayout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReservePayout'
+type MockTransactionService_ReservePayout_Call struct {
+	*mock.Call
+}
+
+// ReservePayout is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - largest models.Money
+//   - amount models.Money
+func (_e *MockTransactionService_Expecter) ReservePayout(ctx interface{}, owner interface{}, largest interface{}, amount interface{}) *MockTransactionService_ReservePayout_Call {
+	return &MockTransactionService_ReservePayout_Call{Call: _e.mock.On("ReservePayout", ctx, owner, largest, amount)}
+}
+
+func (_c *MockTransactionService_ReservePayout_Call) Run(run func(ctx context.Context, owner string, largest models.Money, amount models.Money)) *MockTransactionService_ReservePayout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.Money), args[3].(models.Money))
+	})
+	return _c
+}
+
+func (_c *MockTransactionService_ReservePayout_Call) Return(_a0 error) *MockTransactionService_ReservePayout_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTransactionService_ReservePayout_Call) RunAndReturn(run func(context.Context, string, models.Money, models.Money) error) *MockTransactionService_ReservePayout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SplitPay provides a mock function with given fields: ctx, owner, req
+func (_m *MockTransactionService) SplitPay(ctx context.Context, owner string, req models.SplitPayReq) ([]models.SplitShare, error) {
+	ret := _m.Called(ctx, owner, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SplitPay")
+	}
+
+	var r0 []models.SplitShare
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.SplitPayReq) ([]models.SplitShare, error)); ok {
+		return rf(ctx, owner, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.SplitPayReq) []models.SplitShare); ok {
+		r0 = rf(ctx, owner, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.SplitShare)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.SplitPayReq) error); ok {
+		r1 = rf(ctx, owner, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTransactionService_SplitPay_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SplitPay'
+type MockTransactionService_SplitPay_Call struct {
+	*mock.Call
+}
+
+// SplitPay is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - req models.SplitPayReq
+func (_e *MockTransactionService_Expecter) SplitPay(ctx interface{}, owner interface{}, req interface{}) *MockTransactionService_SplitPay_Call {
+	return &MockTransactionService_SplitPay_Call{Call: _e.mock.On("SplitPay", ctx, owner, req)}
+}
+
+func (_c *MockTransactionService_SplitPay_Call) Run(run func(ctx context.Context, owner string, req models.SplitPayReq)) *MockTransactionService_SplitPay_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.SplitPayReq))
+	})
+	return _c
+}
+
+func (_c *MockTransactionService_SplitPay_Call) Return(_a0 []models.SplitShare, _a1 error) *MockTransactionService_SplitPay_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTransactionService_SplitPay_Call) RunAndReturn(run func(context.Context, string, models.SplitPayReq) ([]models.SplitShare, error)) *MockTransactionService_SplitPay_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Sweep provides a mock function with given fields: ctx, owner, receiver, amount
+func (_m *MockTransactionService) Sweep(ctx context.Context, owner string, receiver string, amount models.Money) error {
+	ret := _m.Called(ctx, owner, receiver, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Sweep")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, models.Money) error); ok {
+		r0 = rf(ctx, owner, receiver, amount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTransactionService_Sweep_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Sweep'
+type MockTransactionService_Sweep_Call struct {
+	*mock.Call
+}
+
+// Sweep is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - receiver string
+//   - amount models.Money
+func (_e *MockTransactionService_Expecter) Sweep(ctx interface{}, owner interface{}, receiver interface{}, amount interface{}) *MockTransactionService_Sweep_Call {
+	return &MockTransactionService_Sweep_Call{Call: _e.mock.On("Sweep", ctx, owner, receiver, amount)}
+}
+
+func (_c *MockTransactionService_Sweep_Call) Run(run func(ctx context.Context, owner string, receiver string, amount models.Money)) *MockTransactionService_Sweep_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(models.Money))
+	})
+	return _c
+}
+
+func (_c *MockTransactionService_Sweep_Call) Return(_a0 error) *MockTransactionService_Sweep_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTransactionService_Sweep_Call) RunAndReturn(run func(context.Context, string, string, models.Money) error) *MockTransactionService_Sweep_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Withdraw provides a mock function with given fields: ctx, owner, amount, note
+func (_m *MockTransactionService) Withdraw(ctx context.Context, owner string, amount models.Money, note models.Annotation) (models.Receipt, error) {
+	ret := _m.Called(ctx, owner, amount, note)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Withdraw")
+	}
+
+	var r0 models.Receipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.Money, models.Annotation) (models.Receipt, error)); ok {
+		return rf(ctx, owner, amount, note)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.Money, models.Annotation) models.Receipt); ok {
+		r0 = rf(ctx, owner, amount, note)
+	} else {
+		r0 = ret.Get(0).(models.Receipt)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.Money, models.Annotation) error); ok {
+		r1 = rf(ctx, owner, amount, note)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTransactionService_Withdraw_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Withdraw'
+type MockTransactionService_Withdraw_Call struct {
+	*mock.Call
+}
+
+// Withdraw is a helper method to define mock.On call
+//   - ctx context.Context
+//   - owner string
+//   - amount models.Money
+//   - note models.Annotation
+func (_e *MockTransactionService_Expecter) Withdraw(ctx interface{}, owner interface{}, amount interface{}, note interface{}) *MockTransactionService_Withdraw_Call {
+	return &MockTransactionService_Withdraw_Call{Call: _e.mock.On("Withdraw", ctx, owner, amount, note)}
+}
+
+func (_c *MockTransactionService_Withdraw_Call) Run(run func(ctx context.Context, owner string, amount models.Money, note models.Annotation)) *MockTransactionService_Withdraw_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(models.Money), args[3].(models.Annotation))
+	})
+	return _c
+}
+
+func (_c *MockTransactionService_Withdraw_Call) Return(_a0 models.Receipt, _a1 error) *MockTransactionService_Withdraw_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTransactionService_Withdraw_Call) RunAndReturn(run func(context.Context, string, models.Money, models.Annotation) (models.Receipt, error)) *MockTransactionService_Withdraw_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockTransactionService creates a new instance of MockTransactionService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTransactionService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTransactionService {
+	mock := &MockTransactionService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/gopay/internal/repository"
 	"github.com/gopay/internal/utils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestTransactionService_GetBalance(t *testing.T) {
@@ -42,10 +44,13 @@ func TestTransactionService_GetBalance(t *testing.T) {
 					AccountId: owner,
 					Amount:    10000,
 				}, nil)
+				deps.holdRepoMock.On("GetHeld", ctx, owner, mock.Anything).Return(models.Money(2500), nil)
 			},
 			want: models.Balance{
 				AccountId: owner,
 				Amount:    10000,
+				Held:      2500,
+				Available: 7500,
 			},
 			wantErr: nil,
 		},
@@ -95,7 +100,7 @@ func TestTransactionService_GetAllTransactions(t *testing.T) {
 				Sender:        owner,
 				Receiver:      owner,
 				CreatedAt:     now,
-				Amount:        7000.00,
+				Amount:        7000,
 				IsConsumed:    false,
 			},
 			{
@@ -104,20 +109,24 @@ func TestTransactionService_GetAllTransactions(t *testing.T) {
 				Sender:        owner,
 				Receiver:      owner,
 				CreatedAt:     now,
-				Amount:        3000.00,
+				Amount:        3000,
 				IsConsumed:    false,
 			},
 		}
 	)
 
+	minAmount := models.Money(5000)
+	maxAmount := models.Money(1000)
+
 	type args struct {
-		owner string
+		owner  string
+		filter models.TransactionFilter
 	}
 
 	scenarios := map[string]struct {
 		given   args
 		doMocks func(deps transactionServiceDependencies)
-		want    []models.Transaction
+		want    models.TransactionPage
 		wantErr error
 	}{
 		"happy-path": {
@@ -131,9 +140,33 @@ func TestTransactionService_GetAllTransactions(t *testing.T) {
 					LastName:  "Nakai",
 				}, nil)
 
-				deps.transRepoMock.On("FindAll", ctx, owner).Return(data, nil)
+				deps.transRepoMock.On("FindPage", ctx, owner, models.TransactionFilter{
+					Limit: defaultPageLimit,
+					Order: models.SortAsc,
+				}).Return(models.TransactionPage{Transactions: data}, nil)
+			},
+			want:    models.TransactionPage{Transactions: data},
+			wantErr: nil,
+		},
+		"filtered-page": {
+			given: args{
+				owner: owner,
+				filter: models.TransactionFilter{
+					Limit:     1,
+					Order:     models.SortDesc,
+					Direction: models.DirectionIn,
+				},
+			},
+			doMocks: func(deps transactionServiceDependencies) {
+				deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{AccountId: owner}, nil)
+
+				deps.transRepoMock.On("FindPage", ctx, owner, models.TransactionFilter{
+					Limit:     1,
+					Order:     models.SortDesc,
+					Direction: models.DirectionIn,
+				}).Return(models.TransactionPage{Transactions: data[:1], NextCursor: "next"}, nil)
 			},
-			want:    data,
+			want:    models.TransactionPage{Transactions: data[:1], NextCursor: "next"},
 			wantErr: nil,
 		},
 		"invalid-account": {
@@ -143,9 +176,49 @@ func TestTransactionService_GetAllTransactions(t *testing.T) {
 			doMocks: func(deps transactionServiceDependencies) {
 				deps.accRepoMock.On("FindOne", ctx, "1234").Return(models.Account{}, repository.ErrAccountNotFound)
 			},
-			want:    []models.Transaction{},
+			want:    models.TransactionPage{},
 			wantErr: repository.ErrAccountNotFound,
 		},
+		"limit-too-large": {
+			given: args{
+				owner:  owner,
+				filter: models.TransactionFilter{Limit: maxPageLimit + 1},
+			},
+			want:    models.TransactionPage{},
+			wantErr: ErrInvalidLimit,
+		},
+		"invalid-direction": {
+			given: args{
+				owner:  owner,
+				filter: models.TransactionFilter{Direction: "sideways"},
+			},
+			want:    models.TransactionPage{},
+			wantErr: ErrInvalidFilter,
+		},
+		"invalid-kind": {
+			given: args{
+				owner:  owner,
+				filter: models.TransactionFilter{Kind: "gift"},
+			},
+			want:    models.TransactionPage{},
+			wantErr: ErrInvalidKind,
+		},
+		"invalid-date-range": {
+			given: args{
+				owner:  owner,
+				filter: models.TransactionFilter{From: now, To: now.Add(-time.Hour)},
+			},
+			want:    models.TransactionPage{},
+			wantErr: ErrInvalidDateRange,
+		},
+		"invalid-amount-range": {
+			given: args{
+				owner:  owner,
+				filter: models.TransactionFilter{MinAmount: &minAmount, MaxAmount: &maxAmount},
+			},
+			want:    models.TransactionPage{},
+			wantErr: ErrInvalidAmountRange,
+		},
 	}
 
 	for name, tcase := range scenarios {
@@ -156,7 +229,7 @@ func TestTransactionService_GetAllTransactions(t *testing.T) {
 				tcase.doMocks(deps)
 			}
 
-			transactions, err := service.GetAllTransactions(ctx, tcase.given.owner)
+			page, err := service.GetAllTransactions(ctx, tcase.given.owner, tcase.given.filter)
 
 			if tcase.wantErr == nil {
 				assert.NoError(t, err)
@@ -164,7 +237,7 @@ func TestTransactionService_GetAllTransactions(t *testing.T) {
 				assert.ErrorIs(t, err, tcase.wantErr)
 			}
 
-			assert.ElementsMatch(t, tcase.want, transactions)
+			assert.Equal(t, tcase.want, page)
 		})
 	}
 }
@@ -175,14 +248,15 @@ func TestTransactionService_Deposit(t *testing.T) {
 	defer resetClock()
 
 	var (
-		ctx            = context.Background()
-		owner          = "0001"
-		amount float32 = 50.0
+		ctx                 = context.Background()
+		owner               = "0001"
+		amount models.Money = 50
 	)
 
 	type args struct {
 		owner  string
-		amount float32
+		amount models.Money
+		note   models.Annotation
 	}
 
 	scenarios := map[string]struct {
@@ -204,28 +278,68 @@ func TestTransactionService_Deposit(t *testing.T) {
 					Sender:     owner,
 					Receiver:   owner,
 					Amount:     amount,
+					Kind:       models.KindDeposit,
 				}
 
+				deps.transRepoMock.On("LockAccount", ctx, owner).Return(nil)
 				deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{
 					AccountId: owner,
 					Name:      "Shankar",
 					LastName:  "Nakai",
 				}, nil)
 				deps.transRepoMock.On("Create", ctx, transaction).Return(nil)
+				deps.ledgerMock.On("RecordDeposit", ctx, owner, amount).Return(nil)
+			},
+			wantErr: nil,
+		},
+		"with-memo-and-metadata": {
+			given: args{
+				owner:  owner,
+				amount: amount,
+				note: models.Annotation{
+					Memo:     "  salary\n for  May\u202e ",
+					Metadata: models.Metadata{"payroll.id": "2024-05"},
+				},
+			},
+			doMocks: func(deps transactionServiceDependencies) {
+				transaction := models.Transaction{
+					CreatedAt:  now,
+					IsConsumed: false,
+					Owner:      owner,
+					Sender:     owner,
+					Receiver:   owner,
+					Amount:     amount,
+					Kind:       models.KindDeposit,
+					Memo:       "salary for May",
+					Metadata:   models.Metadata{"payroll.id": "2024-05"},
+				}
+
+				deps.transRepoMock.On("LockAccount", ctx, owner).Return(nil)
+				deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{AccountId: owner}, nil)
+				deps.transRepoMock.On("Create", ctx, transaction).Return(nil)
+				deps.ledgerMock.On("RecordDeposit", ctx, owner, amount).Return(nil)
 			},
 			wantErr: nil,
 		},
+		"memo-too-long": {
+			given: args{
+				owner:  owner,
+				amount: amount,
+				note:   models.Annotation{Memo: strings.Repeat("a", maxMemoLength+1)},
+			},
+			wantErr: ErrMemoTooLong,
+		},
 		"zero-amount": {
 			given: args{
 				owner:  owner,
-				amount: 0.00,
+				amount: 0,
 			},
 			wantErr: ErrInvalidAmount,
 		},
 		"negative-amount": {
 			given: args{
 				owner:  owner,
-				amount: -1.00,
+				amount: -1,
 			},
 			wantErr: ErrInvalidAmount,
 		},
@@ -235,10 +349,42 @@ func TestTransactionService_Deposit(t *testing.T) {
 				amount: amount,
 			},
 			doMocks: func(deps transactionServiceDependencies) {
+				deps.transRepoMock.On("LockAccount", ctx, owner).Return(nil)
 				deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{}, repository.ErrAccountNotFound)
 			},
 			wantErr: repository.ErrAccountNotFound,
 		},
+		"frozen-account-can-receive": {
+			given: args{
+				owner:  owner,
+				amount: amount,
+			},
+			doMocks: func(deps transactionServiceDependencies) {
+				deps.transRepoMock.On("LockAccount", ctx, owner).Return(nil)
+				deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{AccountId: owner, Status: models.AccountFrozen}, nil)
+				deps.transRepoMock.On("Create", ctx, models.Transaction{
+					CreatedAt: now,
+					Owner:     owner,
+					Sender:    owner,
+					Receiver:  owner,
+					Amount:    amount,
+					Kind:      models.KindDeposit,
+				}).Return(nil)
+				deps.ledgerMock.On("RecordDeposit", ctx, owner, amount).Return(nil)
+			},
+			wantErr: nil,
+		},
+		"closed-account": {
+			given: args{
+				owner:  owner,
+				amount: amount,
+			},
+			doMocks: func(deps transactionServiceDependencies) {
+				deps.transRepoMock.On("LockAccount", ctx, owner).Return(nil)
+				deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{AccountId: owner, Status: models.AccountClosed}, nil)
+			},
+			wantErr: ErrAccountClosed,
+		},
 	}
 
 	for name, tcase := range scenarios {
@@ -249,7 +395,7 @@ func TestTransactionService_Deposit(t *testing.T) {
 				tcase.doMocks(deps)
 			}
 
-			err := service.Deposit(ctx, tcase.given.owner, tcase.given.amount)
+			err := service.Deposit(ctx, tcase.given.owner, tcase.given.amount, tcase.given.note)
 
 			if tcase.wantErr == nil {
 				assert.NoError(t, err)
@@ -268,14 +414,14 @@ func TestTransactionService_Withdraw(t *testing.T) {
 	defer resetClock()
 
 	var (
-		ctx            = context.Background()
-		owner          = "0001"
-		amount float32 = -1000.0
+		ctx                 = context.Background()
+		owner               = "0001"
+		amount models.Money = -1000
 	)
 
 	type args struct {
 		owner  string
-		amount float32
+		amount models.Money
 	}
 
 	scenarios := map[string]struct {
@@ -297,7 +443,7 @@ func TestTransactionService_Withdraw(t *testing.T) {
 						Owner:         owner,
 						Sender:        owner,
 						Receiver:      owner,
-						Amount:        7000.0,
+						Amount:        7000,
 					},
 				}
 
@@ -308,6 +454,7 @@ func TestTransactionService_Withdraw(t *testing.T) {
 					Sender:     owner,
 					Receiver:   owner,
 					Amount:     amount,
+					Kind:       models.KindWithdrawal,
 				}
 
 				transaction := models.Transaction{
@@ -317,6 +464,7 @@ func TestTransactionService_Withdraw(t *testing.T) {
 					Sender:     owner,
 					Receiver:   owner,
 					Amount:     7000 + amount,
+					Kind:       models.KindChange,
 				}
 
 				deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{
@@ -325,14 +473,17 @@ func TestTransactionService_Withdraw(t *testing.T) {
 					LastName:  "Nakai",
 				}, nil)
 
+				deps.transRepoMock.On("LockAccount", ctx, owner).Return(nil)
 				deps.transRepoMock.On("GetBalance", ctx, owner).Return(models.Balance{
 					AccountId: owner,
 					Amount:    7000,
 				}, nil)
-				deps.transRepoMock.On("FindAll", ctx, owner).Return(transactions, nil)
+				deps.holdRepoMock.On("GetHeld", ctx, owner, mock.Anything).Return(models.Money(0), nil)
+				deps.transRepoMock.On("FindUnconsumed", ctx, owner).Return(transactions, nil)
 				deps.transRepoMock.On("MarkAsConsumed", ctx, transactions[0].TransactionId).Return(nil)
 				deps.transRepoMock.On("Create", ctx, transaction).Return(nil)
 				deps.transRepoMock.On("Create", ctx, debitTransaction).Return(nil)
+				deps.ledgerMock.On("RecordWithdrawal", ctx, owner, -debitTransaction.Amount).Return(nil)
 			},
 			wantErr: nil,
 		},
@@ -342,21 +493,26 @@ func TestTransactionService_Withdraw(t *testing.T) {
 				amount: amount,
 			},
 			doMocks: func(deps transactionServiceDependencies) {
+				deps.transRepoMock.On("LockAccount", ctx, owner).Return(nil)
 				deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{}, repository.ErrAccountNotFound)
 			},
 			wantErr: repository.ErrAccountNotFound,
 		},
-		"invalid-amount": {
+		"frozen-account": {
 			given: args{
 				owner:  owner,
-				amount: amount * -1,
+				amount: amount,
 			},
 			doMocks: func(deps transactionServiceDependencies) {
-				deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{
-					AccountId: owner,
-					Name:      "Shankar",
-					LastName:  "Nakai",
-				}, nil)
+				deps.transRepoMock.On("LockAccount", ctx, owner).Return(nil)
+				deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{AccountId: owner, Status: models.AccountFrozen}, nil)
+			},
+			wantErr: ErrAccountFrozen,
+		},
+		"invalid-amount": {
+			given: args{
+				owner:  owner,
+				amount: amount * -1,
 			},
 			wantErr: ErrInvalidAmount,
 		},
@@ -372,17 +528,35 @@ func TestTransactionService_Withdraw(t *testing.T) {
 					LastName:  "Nakai",
 				}, nil)
 
+				deps.transRepoMock.On("LockAccount", ctx, owner).Return(nil)
 				deps.transRepoMock.On("GetBalance", ctx, owner).Return(models.Balance{
 					AccountId: owner,
 					Amount:    500,
 				}, nil)
+				deps.holdRepoMock.On("GetHeld", ctx, owner, mock.Anything).Return(models.Money(0), nil)
+			},
+			wantErr: ErrInsufficentBalance,
+		},
+		"held-balance-cannot-be-withdrawn": {
+			given: args{
+				owner:  owner,
+				amount: amount,
+			},
+			doMocks: func(deps transactionServiceDependencies) {
+				deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{AccountId: owner}, nil)
+				deps.transRepoMock.On("LockAccount", ctx, owner).Return(nil)
+				deps.transRepoMock.On("GetBalance", ctx, owner).Return(models.Balance{
+					AccountId: owner,
+					Amount:    1500,
+				}, nil)
+				deps.holdRepoMock.On("GetHeld", ctx, owner, now).Return(models.Money(501), nil)
 			},
 			wantErr: ErrInsufficentBalance,
 		},
 		"multi-transaction-consumption-remaining": {
 			given: args{
 				owner:  owner,
-				amount: -400.0,
+				amount: -400,
 			},
 			doMocks: func(deps transactionServiceDependencies) {
 				transactions := []models.Transaction{
@@ -393,7 +567,7 @@ func TestTransactionService_Withdraw(t *testing.T) {
 						Owner:         owner,
 						Sender:        owner,
 						Receiver:      owner,
-						Amount:        200.0,
+						Amount:        200,
 					},
 					{
 						TransactionId: "2000000",
@@ -402,7 +576,7 @@ func TestTransactionService_Withdraw(t *testing.T) {
 						Owner:         owner,
 						Sender:        owner,
 						Receiver:      owner,
-						Amount:        100.0,
+						Amount:        100,
 					},
 					{
 						TransactionId: "3000000",
@@ -411,7 +585,7 @@ func TestTransactionService_Withdraw(t *testing.T) {
 						Owner:         owner,
 						Sender:        owner,
 						Receiver:      owner,
-						Amount:        300.0,
+						Amount:        300,
 					},
 				}
 
@@ -422,6 +596,7 @@ func TestTransactionService_Withdraw(t *testing.T) {
 					Sender:     owner,
 					Receiver:   owner,
 					Amount:     -400,
+					Kind:       models.KindWithdrawal,
 				}
 
 				transaction := models.Transaction{
@@ -431,6 +606,7 @@ func TestTransactionService_Withdraw(t *testing.T) {
 					Sender:     owner,
 					Receiver:   owner,
 					Amount:     200,
+					Kind:       models.KindChange,
 				}
 
 				deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{
@@ -439,23 +615,26 @@ func TestTransactionService_Withdraw(t *testing.T) {
 					LastName:  "Nakai",
 				}, nil)
 
+				deps.transRepoMock.On("LockAccount", ctx, owner).Return(nil)
 				deps.transRepoMock.On("GetBalance", ctx, owner).Return(models.Balance{
 					AccountId: owner,
 					Amount:    600,
 				}, nil)
-				deps.transRepoMock.On("FindAll", ctx, owner).Return(transactions, nil)
+				deps.holdRepoMock.On("GetHeld", ctx, owner, mock.Anything).Return(models.Money(0), nil)
+				deps.transRepoMock.On("FindUnconsumed", ctx, owner).Return(transactions, nil)
 				deps.transRepoMock.On("MarkAsConsumed", ctx, transactions[0].TransactionId).Return(nil)
 				deps.transRepoMock.On("MarkAsConsumed", ctx, transactions[1].TransactionId).Return(nil)
 				deps.transRepoMock.On("MarkAsConsumed", ctx, transactions[2].TransactionId).Return(nil)
 				deps.transRepoMock.On("Create", ctx, transaction).Return(nil)
 				deps.transRepoMock.On("Create", ctx, debitTransaction).Return(nil)
+				deps.ledgerMock.On("RecordWithdrawal", ctx, owner, -debitTransaction.Amount).Return(nil)
 			},
 			wantErr: nil,
 		},
 		"multi-transaction-consumption-exact": {
 			given: args{
 				owner:  owner,
-				amount: -400.0,
+				amount: -400,
 			},
 			doMocks: func(deps transactionServiceDependencies) {
 				transactions := []models.Transaction{
@@ -466,7 +645,7 @@ func TestTransactionService_Withdraw(t *testing.T) {
 						Owner:         owner,
 						Sender:        owner,
 						Receiver:      owner,
-						Amount:        200.0,
+						Amount:        200,
 					},
 					{
 						TransactionId: "2000000",
@@ -475,7 +654,7 @@ func TestTransactionService_Withdraw(t *testing.T) {
 						Owner:         owner,
 						Sender:        owner,
 						Receiver:      owner,
-						Amount:        200.0,
+						Amount:        200,
 					},
 					{
 						TransactionId: "3000000",
@@ -484,7 +663,7 @@ func TestTransactionService_Withdraw(t *testing.T) {
 						Owner:         owner,
 						Sender:        owner,
 						Receiver:      owner,
-						Amount:        200.0,
+						Amount:        200,
 					},
 				}
 
@@ -495,6 +674,7 @@ func TestTransactionService_Withdraw(t *testing.T) {
 					Sender:     owner,
 					Receiver:   owner,
 					Amount:     -400,
+					Kind:       models.KindWithdrawal,
 				}
 
 				deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{
@@ -503,21 +683,24 @@ func TestTransactionService_Withdraw(t *testing.T) {
 					LastName:  "Nakai",
 				}, nil)
 
+				deps.transRepoMock.On("LockAccount", ctx, owner).Return(nil)
 				deps.transRepoMock.On("GetBalance", ctx, owner).Return(models.Balance{
 					AccountId: owner,
 					Amount:    600,
 				}, nil)
-				deps.transRepoMock.On("FindAll", ctx, owner).Return(transactions, nil)
+				deps.holdRepoMock.On("GetHeld", ctx, owner, mock.Anything).Return(models.Money(0), nil)
+				deps.transRepoMock.On("FindUnconsumed", ctx, owner).Return(transactions, nil)
 				deps.transRepoMock.On("MarkAsConsumed", ctx, transactions[0].TransactionId).Return(nil)
 				deps.transRepoMock.On("MarkAsConsumed", ctx, transactions[1].TransactionId).Return(nil)
 				deps.transRepoMock.On("Create", ctx, debitTransaction).Return(nil)
+				deps.ledgerMock.On("RecordWithdrawal", ctx, owner, -debitTransaction.Amount).Return(nil)
 			},
 			wantErr: nil,
 		},
 		"multi-transaction-consumption-rollback": {
 			given: args{
 				owner:  owner,
-				amount: -400.0,
+				amount: -400,
 			},
 			doMocks: func(deps transactionServiceDependencies) {
 				transactions := []models.Transaction{
@@ -528,7 +711,7 @@ func TestTransactionService_Withdraw(t *testing.T) {
 						Owner:         owner,
 						Sender:        owner,
 						Receiver:      owner,
-						Amount:        200.0,
+						Amount:        200,
 					},
 					{
 						TransactionId: "2000000",
@@ -537,7 +720,7 @@ func TestTransactionService_Withdraw(t *testing.T) {
 						Owner:         owner,
 						Sender:        owner,
 						Receiver:      owner,
-						Amount:        100.0,
+						Amount:        100,
 					},
 					{
 						TransactionId: "3000000",
@@ -546,7 +729,7 @@ func TestTransactionService_Withdraw(t *testing.T) {
 						Owner:         owner,
 						Sender:        owner,
 						Receiver:      owner,
-						Amount:        300.0,
+						Amount:        300,
 					},
 				}
 
@@ -556,15 +739,16 @@ func TestTransactionService_Withdraw(t *testing.T) {
 					LastName:  "Nakai",
 				}, nil)
 
+				deps.transRepoMock.On("LockAccount", ctx, owner).Return(nil)
 				deps.transRepoMock.On("GetBalance", ctx, owner).Return(models.Balance{
 					AccountId: owner,
 					Amount:    600,
 				}, nil)
-				deps.transRepoMock.On("FindAll", ctx, owner).Return(transactions, nil)
+				deps.holdRepoMock.On("GetHeld", ctx, owner, mock.Anything).Return(models.Money(0), nil)
+				deps.transRepoMock.On("FindUnconsumed", ctx, owner).Return(transactions, nil)
 				deps.transRepoMock.On("MarkAsConsumed", ctx, transactions[0].TransactionId).Return(nil)
 				deps.transRepoMock.On("MarkAsConsumed", ctx, transactions[1].TransactionId).Return(nil)
 				deps.transRepoMock.On("MarkAsConsumed", ctx, transactions[2].TransactionId).Return(repository.ErrTransactionNotFound)
-				deps.transRepoMock.On("RollBackConsumed", ctx, []string{"1000000", "2000000"}).Return(nil)
 			},
 			wantErr: ErrFailedDebitOperation,
 		},
@@ -578,7 +762,7 @@ func TestTransactionService_Withdraw(t *testing.T) {
 				tcase.doMocks(deps)
 			}
 
-			err := service.Withdraw(ctx, tcase.given.owner, tcase.given.amount)
+			_, err := service.Withdraw(ctx, tcase.given.owner, tcase.given.amount, models.Annotation{})
 
 			if tcase.wantErr == nil {
 				assert.NoError(t, err)
@@ -589,6 +773,51 @@ func TestTransactionService_Withdraw(t *testing.T) {
 	}
 }
 
+func TestTransactionService_WithdrawFee(t *testing.T) {
+	now := time.Now()
+	setupClock(now)
+	utils.SetSyncGoroutine()
+	defer utils.ResetGoroutine()
+	defer resetClock()
+
+	var (
+		ctx                 = context.Background()
+		owner               = "0001"
+		amount models.Money = -1000
+		fee    models.Money = 150
+	)
+
+	service, deps := setupTransactionService(t)
+	deps.feeMock.ExpectedCalls = nil
+	deps.feeMock.On("Quote", ctx, owner, models.FeeWithdrawal, -amount).Return(fee, nil)
+
+	transactions := []models.Transaction{
+		{TransactionId: "1000000", CreatedAt: now, Owner: owner, Sender: owner, Receiver: owner, Amount: 7000},
+	}
+
+	deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{AccountId: owner}, nil)
+	deps.transRepoMock.On("LockAccount", ctx, owner).Return(nil)
+	deps.transRepoMock.On("GetBalance", ctx, owner).Return(models.Balance{AccountId: owner, Amount: 7000}, nil)
+	deps.holdRepoMock.On("GetHeld", ctx, owner, mock.Anything).Return(models.Money(0), nil)
+	deps.transRepoMock.On("FindUnconsumed", ctx, owner).Return(transactions, nil)
+	deps.transRepoMock.On("MarkAsConsumed", ctx, transactions[0].TransactionId).Return(nil)
+	deps.transRepoMock.On("Create", ctx, models.Transaction{
+		CreatedAt: now, Owner: owner, Sender: owner, Receiver: owner, Amount: 7000 + amount - fee, Kind: models.KindChange,
+	}).Return(nil)
+	deps.transRepoMock.On("Create", ctx, models.Transaction{
+		CreatedAt: now, IsConsumed: true, Owner: owner, Sender: owner, Receiver: owner, Amount: amount, Kind: models.KindWithdrawal,
+	}).Return(nil)
+	deps.transRepoMock.On("Create", ctx, models.Transaction{
+		CreatedAt: now, IsConsumed: true, Owner: owner, Sender: owner, Receiver: owner, Amount: -fee, Kind: models.KindFee, Memo: "withdrawal fee",
+	}).Return(nil)
+	deps.ledgerMock.On("RecordWithdrawal", ctx, owner, -amount).Return(nil)
+	deps.ledgerMock.On("RecordFee", ctx, owner, fee).Return(nil)
+
+	receipt, err := service.Withdraw(ctx, owner, amount, models.Annotation{})
+	assert.NoError(t, err)
+	assert.Equal(t, models.Receipt{Amount: -amount, Fee: fee, Total: -amount + fee}, receipt)
+}
+
 func TestTransactionService_Pay(t *testing.T) {
 	now := time.Now()
 	setupClock(now)
@@ -597,16 +826,17 @@ func TestTransactionService_Pay(t *testing.T) {
 	defer resetClock()
 
 	var (
-		ctx              = context.Background()
-		owner            = "0001"
-		receiver         = "0002"
-		amount   float32 = 1000.0
+		ctx                   = context.Background()
+		owner                 = "0001"
+		receiver              = "0002"
+		amount   models.Money = 1000
 	)
 
 	type args struct {
 		owner    string
 		receiver string
-		amount   float32
+		amount   models.Money
+		note     models.Annotation
 	}
 
 	scenarios := map[string]struct {
@@ -619,6 +849,7 @@ func TestTransactionService_Pay(t *testing.T) {
 				owner:    owner,
 				receiver: receiver,
 				amount:   amount,
+				note:     models.Annotation{Memo: "dinner", Metadata: models.Metadata{"split": "3"}},
 			},
 			doMocks: func(deps transactionServiceDependencies) {
 				transactions := []models.Transaction{
@@ -629,7 +860,7 @@ func TestTransactionService_Pay(t *testing.T) {
 						Owner:         owner,
 						Sender:        owner,
 						Receiver:      owner,
-						Amount:        7000.0,
+						Amount:        7000,
 					},
 				}
 
@@ -640,6 +871,10 @@ func TestTransactionService_Pay(t *testing.T) {
 					Sender:     owner,
 					Receiver:   receiver,
 					Amount:     -amount,
+					Kind:       models.KindPaymentOut,
+					Memo:       "dinner",
+					Metadata:   models.Metadata{"split": "3"},
+					Privacy:    models.PrivacyFriends,
 				}
 
 				senderAdjTransaction := models.Transaction{
@@ -649,6 +884,7 @@ func TestTransactionService_Pay(t *testing.T) {
 					Sender:     owner,
 					Receiver:   owner,
 					Amount:     7000 - amount,
+					Kind:       models.KindChange,
 				}
 
 				receiverTransaction := models.Transaction{
@@ -658,30 +894,38 @@ func TestTransactionService_Pay(t *testing.T) {
 					Sender:     owner,
 					Receiver:   receiver,
 					Amount:     amount,
+					Kind:       models.KindPaymentIn,
+					Memo:       "dinner",
+					Metadata:   models.Metadata{"split": "3"},
+					Privacy:    models.PrivacyFriends,
 				}
 
 				deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{
-					AccountId: owner,
-					Name:      "Shankar",
-					LastName:  "Nakai",
+					AccountId:      owner,
+					Name:           "Shankar",
+					LastName:       "Nakai",
+					DefaultPrivacy: models.PrivacyFriends,
 				}, nil)
 
 				deps.accRepoMock.On("FindOne", ctx, receiver).Return(models.Account{
-					AccountId: owner,
+					AccountId: receiver,
 					Name:      "Jessica",
 					LastName:  "Lourenco",
 				}, nil)
 
+				deps.transRepoMock.On("LockAccount", ctx, owner).Return(nil)
 				deps.transRepoMock.On("GetBalance", ctx, owner).Return(models.Balance{
 					AccountId: owner,
 					Amount:    7000,
 				}, nil)
+				deps.holdRepoMock.On("GetHeld", ctx, owner, mock.Anything).Return(models.Money(0), nil)
 
-				deps.transRepoMock.On("FindAll", ctx, owner).Return(transactions, nil)
+				deps.transRepoMock.On("FindUnconsumed", ctx, owner).Return(transactions, nil)
 				deps.transRepoMock.On("MarkAsConsumed", ctx, transactions[0].TransactionId).Return(nil)
 				deps.transRepoMock.On("Create", ctx, senderAdjTransaction).Return(nil)
 				deps.transRepoMock.On("Create", ctx, debitTransaction).Return(nil)
 				deps.transRepoMock.On("Create", ctx, receiverTransaction).Return(nil)
+				deps.ledgerMock.On("RecordPayment", ctx, owner, receiver, receiverTransaction.Amount).Return(nil)
 			},
 			wantErr: nil,
 		},
@@ -720,6 +964,65 @@ func TestTransactionService_Pay(t *testing.T) {
 			},
 			wantErr: repository.ErrAccountNotFound,
 		},
+		"frozen-sender": {
+			given: args{
+				owner:    owner,
+				receiver: receiver,
+				amount:   amount,
+			},
+			doMocks: func(deps transactionServiceDependencies) {
+				deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{AccountId: owner, Status: models.AccountFrozen}, nil)
+				deps.accRepoMock.On("FindOne", ctx, receiver).Return(models.Account{AccountId: receiver}, nil)
+				deps.transRepoMock.On("LockAccount", ctx, owner).Return(nil)
+			},
+			wantErr: ErrAccountFrozen,
+		},
+		"closed-receiver": {
+			given: args{
+				owner:    owner,
+				receiver: receiver,
+				amount:   amount,
+			},
+			doMocks: func(deps transactionServiceDependencies) {
+				deps.transRepoMock.On("LockAccount", ctx, owner).Return(nil)
+				deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{AccountId: owner, Status: models.AccountActive}, nil)
+				deps.accRepoMock.On("FindOne", ctx, receiver).Return(models.Account{AccountId: receiver, Status: models.AccountClosed}, nil)
+			},
+			wantErr: ErrAccountClosed,
+		},
+		"invalid-privacy": {
+			given: args{
+				owner:    owner,
+				receiver: receiver,
+				amount:   amount,
+				note:     models.Annotation{Privacy: "everyone"},
+			},
+			wantErr: ErrInvalidPrivacy,
+		},
+		"unknown-handle": {
+			given: args{
+				owner:    owner,
+				receiver: "@nobody",
+				amount:   amount,
+			},
+			doMocks: func(deps transactionServiceDependencies) {
+				deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{AccountId: owner, Status: models.AccountActive}, nil)
+				deps.accRepoMock.On("FindByHandle", ctx, "nobody").Return(models.Account{}, repository.ErrAccountNotFound)
+			},
+			wantErr: repository.ErrAccountNotFound,
+		},
+		"own-handle": {
+			given: args{
+				owner:    owner,
+				receiver: "Shankar",
+				amount:   amount,
+			},
+			doMocks: func(deps transactionServiceDependencies) {
+				deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{AccountId: owner, Handle: "shankar", Status: models.AccountActive}, nil)
+				deps.accRepoMock.On("FindByHandle", ctx, "Shankar").Return(models.Account{AccountId: owner, Handle: "shankar", Status: models.AccountActive}, nil)
+			},
+			wantErr: ErrInvalidPaymentOp,
+		},
 		"insufficient-balance": {
 			given: args{
 				owner:    owner,
@@ -734,23 +1037,48 @@ func TestTransactionService_Pay(t *testing.T) {
 				}, nil)
 
 				deps.accRepoMock.On("FindOne", ctx, receiver).Return(models.Account{
-					AccountId: owner,
+					AccountId: receiver,
 					Name:      "Jessica",
 					LastName:  "Lourenco",
 				}, nil)
 
+				deps.transRepoMock.On("LockAccount", ctx, owner).Return(nil)
 				deps.transRepoMock.On("GetBalance", ctx, owner).Return(models.Balance{
 					AccountId: owner,
 					Amount:    500,
 				}, nil)
+				deps.holdRepoMock.On("GetHeld", ctx, owner, mock.Anything).Return(models.Money(0), nil)
 			},
 			wantErr: ErrInsufficentBalance,
 		},
+		"account-locked": {
+			given: args{
+				owner:    owner,
+				receiver: receiver,
+				amount:   amount,
+			},
+			doMocks: func(deps transactionServiceDependencies) {
+				deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{
+					AccountId: owner,
+					Name:      "Shankar",
+					LastName:  "Nakai",
+				}, nil)
+
+				deps.accRepoMock.On("FindOne", ctx, receiver).Return(models.Account{
+					AccountId: receiver,
+					Name:      "Jessica",
+					LastName:  "Lourenco",
+				}, nil)
+
+				deps.transRepoMock.On("LockAccount", ctx, owner).Return(repository.ErrAccountLocked)
+			},
+			wantErr: repository.ErrAccountLocked,
+		},
 		"multi-transaction-consumption": {
 			given: args{
 				owner:    owner,
 				receiver: receiver,
-				amount:   400.0,
+				amount:   400,
 			},
 			doMocks: func(deps transactionServiceDependencies) {
 				transactions := []models.Transaction{
@@ -761,7 +1089,7 @@ func TestTransactionService_Pay(t *testing.T) {
 						Owner:         owner,
 						Sender:        owner,
 						Receiver:      owner,
-						Amount:        200.0,
+						Amount:        200,
 					},
 					{
 						TransactionId: "2000000",
@@ -770,7 +1098,7 @@ func TestTransactionService_Pay(t *testing.T) {
 						Owner:         owner,
 						Sender:        owner,
 						Receiver:      owner,
-						Amount:        200.0,
+						Amount:        200,
 					},
 					{
 						TransactionId: "3000000",
@@ -779,7 +1107,7 @@ func TestTransactionService_Pay(t *testing.T) {
 						Owner:         owner,
 						Sender:        owner,
 						Receiver:      owner,
-						Amount:        200.0,
+						Amount:        200,
 					},
 				}
 
@@ -789,7 +1117,9 @@ func TestTransactionService_Pay(t *testing.T) {
 					Owner:      owner,
 					Sender:     owner,
 					Receiver:   receiver,
-					Amount:     -400.0,
+					Amount:     -400,
+					Kind:       models.KindPaymentOut,
+					Privacy:    models.PrivacyPrivate,
 				}
 
 				receiverTransaction := models.Transaction{
@@ -798,7 +1128,9 @@ func TestTransactionService_Pay(t *testing.T) {
 					Owner:      receiver,
 					Sender:     owner,
 					Receiver:   receiver,
-					Amount:     400.0,
+					Amount:     400,
+					Kind:       models.KindPaymentIn,
+					Privacy:    models.PrivacyPrivate,
 				}
 
 				deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{
@@ -808,21 +1140,24 @@ func TestTransactionService_Pay(t *testing.T) {
 				}, nil)
 
 				deps.accRepoMock.On("FindOne", ctx, receiver).Return(models.Account{
-					AccountId: owner,
+					AccountId: receiver,
 					Name:      "Jessica",
 					LastName:  "Lourenco",
 				}, nil)
 
+				deps.transRepoMock.On("LockAccount", ctx, owner).Return(nil)
 				deps.transRepoMock.On("GetBalance", ctx, owner).Return(models.Balance{
 					AccountId: owner,
 					Amount:    600,
 				}, nil)
+				deps.holdRepoMock.On("GetHeld", ctx, owner, mock.Anything).Return(models.Money(0), nil)
 
-				deps.transRepoMock.On("FindAll", ctx, owner).Return(transactions, nil)
+				deps.transRepoMock.On("FindUnconsumed", ctx, owner).Return(transactions, nil)
 				deps.transRepoMock.On("MarkAsConsumed", ctx, transactions[0].TransactionId).Return(nil)
 				deps.transRepoMock.On("MarkAsConsumed", ctx, transactions[1].TransactionId).Return(nil)
 				deps.transRepoMock.On("Create", ctx, debitTransaction).Return(nil)
 				deps.transRepoMock.On("Create", ctx, receiverTransaction).Return(nil)
+				deps.ledgerMock.On("RecordPayment", ctx, owner, receiver, receiverTransaction.Amount).Return(nil)
 			},
 			wantErr: nil,
 		},
@@ -836,7 +1171,563 @@ func TestTransactionService_Pay(t *testing.T) {
 				tcase.doMocks(deps)
 			}
 
-			err := service.Pay(ctx, tcase.given.owner, tcase.given.receiver, tcase.given.amount)
+			_, err := service.Pay(ctx, tcase.given.owner, tcase.given.receiver, tcase.given.amount, tcase.given.note)
+
+			if tcase.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tcase.wantErr)
+			}
+		})
+	}
+}
+
+func TestTransactionService_SplitPay(t *testing.T) {
+	now := time.Now()
+	setupClock(now)
+	defer resetClock()
+
+	var (
+		ctx    = context.Background()
+		owner  = "0001"
+		alice  = "0002"
+		bob    = "0003"
+		shares = []models.SplitShare{
+			{Receiver: alice, Amount: 3000},
+			{Receiver: bob, Amount: 2000},
+		}
+	)
+
+	scenarios := map[string]struct {
+		given   models.SplitPayReq
+		doMocks func(deps transactionServiceDependencies)
+		want    []models.SplitShare
+		wantErr error
+	}{
+		"happy-path": {
+			given: models.SplitPayReq{Shares: shares},
+			doMocks: func(deps transactionServiceDependencies) {
+				unconsumed := []models.Transaction{
+					{TransactionId: "1000000", Owner: owner, Sender: owner, Receiver: owner, Amount: 7000},
+				}
+
+				deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{AccountId: owner}, nil)
+				deps.accRepoMock.On("FindOne", ctx, alice).Return(models.Account{AccountId: alice}, nil)
+				deps.accRepoMock.On("FindOne", ctx, bob).Return(models.Account{AccountId: bob}, nil)
+
+				deps.transRepoMock.On("LockAccount", ctx, owner).Return(nil).Times(3)
+				deps.transRepoMock.On("GetBalance", ctx, owner).Return(models.Balance{AccountId: owner, Amount: 7000}, nil).Once()
+				deps.holdRepoMock.On("GetHeld", ctx, owner, mock.Anything).Return(models.Money(0), nil).Once()
+				deps.transRepoMock.On("FindUnconsumed", ctx, owner).Return(unconsumed, nil).Once()
+				deps.transRepoMock.On("MarkAsConsumed", ctx, "1000000").Return(nil).Once()
+				deps.transRepoMock.On("Create", ctx, models.Transaction{
+					CreatedAt: now, Owner: owner, Sender: owner, Receiver: owner, Amount: 2000, Kind: models.KindChange,
+				}).Return(nil)
+
+				for _, share := range shares {
+					deps.transRepoMock.On("Create", ctx, models.Transaction{
+						CreatedAt: now, IsConsumed: true, Owner: owner, Sender: owner, Receiver: share.Receiver, Amount: -share.Amount, Kind: models.KindPaymentOut,
+					}).Return(nil)
+					deps.transRepoMock.On("Create", ctx, models.Transaction{
+						CreatedAt: now, Owner: share.Receiver, Sender: owner, Receiver: share.Receiver, Amount: share.Amount, Kind: models.KindPaymentIn,
+					}).Return(nil)
+					deps.ledgerMock.On("RecordPayment", ctx, owner, share.Receiver, share.Amount).Return(nil)
+				}
+			},
+			want:    shares,
+			wantErr: nil,
+		},
+		"fee-per-share": {
+			given: models.SplitPayReq{Shares: shares},
+			doMocks: func(deps transactionServiceDependencies) {
+				unconsumed := []models.Transaction{
+					{TransactionId: "1000000", Owner: owner, Sender: owner, Receiver: owner, Amount: 7000},
+				}
+
+				deps.feeMock.ExpectedCalls = nil
+				deps.feeMock.On("Quote", ctx, owner, models.FeePayment, models.Money(3000)).Return(models.Money(30), nil)
+				deps.feeMock.On("Quote", ctx, owner, models.FeePayment, models.Money(2000)).Return(models.Money(25), nil)
+
+				deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{AccountId: owner}, nil)
+				deps.accRepoMock.On("FindOne", ctx, alice).Return(models.Account{AccountId: alice}, nil)
+				deps.accRepoMock.On("FindOne", ctx, bob).Return(models.Account{AccountId: bob}, nil)
+
+				deps.transRepoMock.On("LockAccount", ctx, owner).Return(nil)
+				deps.transRepoMock.On("GetBalance", ctx, owner).Return(models.Balance{AccountId: owner, Amount: 7000}, nil).Once()
+				deps.holdRepoMock.On("GetHeld", ctx, owner, mock.Anything).Return(models.Money(0), nil).Once()
+				deps.transRepoMock.On("FindUnconsumed", ctx, owner).Return(unconsumed, nil).Once()
+				deps.transRepoMock.On("MarkAsConsumed", ctx, "1000000").Return(nil).Once()
+				deps.transRepoMock.On("Create", ctx, models.Transaction{
+					CreatedAt: now, Owner: owner, Sender: owner, Receiver: owner, Amount: 1945, Kind: models.KindChange,
+				}).Return(nil)
+
+				for _, share := range shares {
+					deps.transRepoMock.On("Create", ctx, models.Transaction{
+						CreatedAt: now, IsConsumed: true, Owner: owner, Sender: owner, Receiver: share.Receiver, Amount: -share.Amount, Kind: models.KindPaymentOut,
+					}).Return(nil)
+					deps.transRepoMock.On("Create", ctx, models.Transaction{
+						CreatedAt: now, Owner: share.Receiver, Sender: owner, Receiver: share.Receiver, Amount: share.Amount, Kind: models.KindPaymentIn,
+					}).Return(nil)
+					deps.ledgerMock.On("RecordPayment", ctx, owner, share.Receiver, share.Amount).Return(nil)
+				}
+
+				for _, fee := range []models.Money{30, 25} {
+					deps.transRepoMock.On("Create", ctx, models.Transaction{
+						CreatedAt: now, IsConsumed: true, Owner: owner, Sender: owner, Receiver: owner, Amount: -fee, Kind: models.KindFee, Memo: "payment fee",
+					}).Return(nil).Once()
+					deps.ledgerMock.On("RecordFee", ctx, owner, fee).Return(nil).Once()
+				}
+			},
+			want: []models.SplitShare{
+				{Receiver: alice, Amount: 3000, Fee: 30},
+				{Receiver: bob, Amount: 2000, Fee: 25},
+			},
+			wantErr: nil,
+		},
+		"unknown-receiver": {
+			given: models.SplitPayReq{Shares: shares},
+			doMocks: func(deps transactionServiceDependencies) {
+				deps.transRepoMock.On("LockAccount", ctx, owner).Return(nil)
+				deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{AccountId: owner}, nil)
+				deps.accRepoMock.On("FindOne", ctx, alice).Return(models.Account{AccountId: alice}, nil)
+				deps.accRepoMock.On("FindOne", ctx, bob).Return(models.Account{}, repository.ErrAccountNotFound)
+			},
+			wantErr: repository.ErrAccountNotFound,
+		},
+		"total-over-balance-pays-no-one": {
+			given: models.SplitPayReq{Shares: shares},
+			doMocks: func(deps transactionServiceDependencies) {
+				deps.accRepoMock.On("FindOne", ctx, mock.Anything).Return(models.Account{}, nil)
+				deps.transRepoMock.On("LockAccount", ctx, owner).Return(nil)
+				deps.transRepoMock.On("GetBalance", ctx, owner).Return(models.Balance{AccountId: owner, Amount: 4999}, nil)
+				deps.holdRepoMock.On("GetHeld", ctx, owner, mock.Anything).Return(models.Money(0), nil)
+			},
+			wantErr: ErrInsufficentBalance,
+		},
+		"invalid-split": {
+			given:   models.SplitPayReq{Shares: shares, Total: 5000},
+			wantErr: ErrSplitAmbiguous,
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			service, deps := setupTransactionService(t)
+			if tcase.doMocks != nil {
+				tcase.doMocks(deps)
+			}
+
+			paid, err := service.SplitPay(ctx, owner, tcase.given)
+
+			if tcase.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tcase.wantErr)
+			}
+
+			assert.Equal(t, tcase.want, paid)
+		})
+	}
+}
+
+func TestSplitShares(t *testing.T) {
+	owner := "0001"
+
+	scenarios := map[string]struct {
+		given   models.SplitPayReq
+		want    []models.SplitShare
+		wantErr error
+	}{
+		"explicit-shares": {
+			given: models.SplitPayReq{Shares: []models.SplitShare{{Receiver: "0002", Amount: 10}, {Receiver: "0003", Amount: 20}}},
+			want:  []models.SplitShare{{Receiver: "0002", Amount: 10}, {Receiver: "0003", Amount: 20}},
+		},
+		"equal-split": {
+			given: models.SplitPayReq{Receivers: []string{"0002", "0003"}, Total: 1000},
+			want:  []models.SplitShare{{Receiver: "0002", Amount: 500}, {Receiver: "0003", Amount: 500}},
+		},
+		"remainder-goes-to-first-receivers": {
+			given: models.SplitPayReq{Receivers: []string{"0002", "0003", "0004"}, Total: 1000},
+			want:  []models.SplitShare{{Receiver: "0002", Amount: 334}, {Receiver: "0003", Amount: 333}, {Receiver: "0004", Amount: 333}},
+		},
+		"total-below-one-cent-each": {
+			given:   models.SplitPayReq{Receivers: []string{"0002", "0003", "0004"}, Total: 2},
+			wantErr: ErrSplitTooSmall,
+		},
+		"no-receivers": {
+			given:   models.SplitPayReq{Total: 1000},
+			wantErr: ErrSplitNoReceivers,
+		},
+		"both-forms": {
+			given:   models.SplitPayReq{Shares: []models.SplitShare{{Receiver: "0002", Amount: 10}}, Receivers: []string{"0003"}},
+			wantErr: ErrSplitAmbiguous,
+		},
+		"duplicate-receiver": {
+			given:   models.SplitPayReq{Receivers: []string{"0002", "0002"}, Total: 1000},
+			wantErr: ErrSplitDuplicate,
+		},
+		"pay-self": {
+			given:   models.SplitPayReq{Receivers: []string{"0002", owner}, Total: 1000},
+			wantErr: ErrInvalidPaymentOp,
+		},
+		"non-positive-share": {
+			given:   models.SplitPayReq{Shares: []models.SplitShare{{Receiver: "0002", Amount: 0}}},
+			wantErr: ErrInvalidAmount,
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			shares, err := splitShares(owner, tcase.given)
+
+			if tcase.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tcase.wantErr)
+			}
+
+			assert.Equal(t, tcase.want, shares)
+		})
+	}
+}
+
+func TestTransactionService_Payout(t *testing.T) {
+	now := time.Now()
+	setupClock(now)
+	defer resetClock()
+
+	var (
+		ctx      = context.Background()
+		owner    = "0001"
+		receiver = "0002"
+	)
+
+	t.Run("reserve", func(t *testing.T) {
+		service, deps := setupTransactionService(t)
+		deps.limitMock.ExpectedCalls = nil
+		deps.limitMock.On("Check", ctx, owner, models.KindPaymentOut, models.Money(1500), models.Money(2000)).Return(nil)
+
+		deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{AccountId: owner}, nil)
+		deps.transRepoMock.On("LockAccount", ctx, owner).Return(nil)
+		deps.transRepoMock.On("GetBalance", ctx, owner).Return(models.Balance{AccountId: owner, Amount: 3000}, nil)
+		deps.holdRepoMock.On("GetHeld", ctx, owner, mock.Anything).Return(models.Money(0), nil)
+		deps.transRepoMock.On("FindUnconsumed", ctx, owner).Return([]models.Transaction{
+			{TransactionId: "1000000", Owner: owner, Sender: owner, Receiver: owner, Amount: 3000},
+		}, nil)
+		deps.transRepoMock.On("MarkAsConsumed", ctx, "1000000").Return(nil)
+		deps.transRepoMock.On("Create", ctx, models.Transaction{
+			CreatedAt: now, Owner: owner, Sender: owner, Receiver: owner, Amount: 1000, Kind: models.KindChange,
+		}).Return(nil)
+		deps.ledgerMock.On("RecordPayoutReservation", ctx, owner, models.Money(2000)).Return(nil)
+
+		assert.NoError(t, service.ReservePayout(ctx, owner, 1500, 2000))
+	})
+
+	t.Run("pay", func(t *testing.T) {
+		service, deps := setupTransactionService(t)
+
+		deps.transRepoMock.On("LockAccount", ctx, owner).Return(nil)
+		deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{AccountId: owner}, nil)
+		deps.accRepoMock.On("FindOne", ctx, receiver).Return(models.Account{AccountId: receiver}, nil)
+		deps.transRepoMock.On("Create", ctx, models.Transaction{
+			CreatedAt: now, IsConsumed: true, Owner: owner, Sender: owner, Receiver: receiver, Amount: -2000, Kind: models.KindPaymentOut,
+		}).Return(nil)
+		deps.transRepoMock.On("Create", ctx, models.Transaction{
+			CreatedAt: now, Owner: receiver, Sender: owner, Receiver: receiver, Amount: 2000, Kind: models.KindPaymentIn,
+		}).Return(nil)
+		deps.ledgerMock.On("RecordPayout", ctx, receiver, models.Money(2000)).Return(nil)
+
+		assert.NoError(t, service.Payout(ctx, owner, receiver, 2000))
+	})
+
+	t.Run("pay-with-fee", func(t *testing.T) {
+		service, deps := setupTransactionService(t)
+		deps.feeMock.ExpectedCalls = nil
+		deps.feeMock.On("Quote", ctx, owner, models.FeePayment, models.Money(2000)).Return(models.Money(30), nil)
+
+		deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{AccountId: owner}, nil)
+		deps.accRepoMock.On("FindOne", ctx, receiver).Return(models.Account{AccountId: receiver}, nil)
+		deps.transRepoMock.On("LockAccount", ctx, owner).Return(nil)
+		deps.transRepoMock.On("GetBalance", ctx, owner).Return(models.Balance{AccountId: owner, Amount: 100}, nil)
+		deps.holdRepoMock.On("GetHeld", ctx, owner, mock.Anything).Return(models.Money(0), nil)
+		deps.transRepoMock.On("FindUnconsumed", ctx, owner).Return([]models.Transaction{
+			{TransactionId: "1000000", Owner: owner, Sender: owner, Receiver: owner, Amount: 100},
+		}, nil)
+		deps.transRepoMock.On("MarkAsConsumed", ctx, "1000000").Return(nil)
+		deps.transRepoMock.On("Create", ctx, models.Transaction{
+			CreatedAt: now, Owner: owner, Sender: owner, Receiver: owner, Amount: 70, Kind: models.KindChange,
+		}).Return(nil)
+		deps.transRepoMock.On("Create", ctx, models.Transaction{
+			CreatedAt: now, IsConsumed: true, Owner: owner, Sender: owner, Receiver: receiver, Amount: -2000, Kind: models.KindPaymentOut,
+		}).Return(nil)
+		deps.transRepoMock.On("Create", ctx, models.Transaction{
+			CreatedAt: now, Owner: receiver, Sender: owner, Receiver: receiver, Amount: 2000, Kind: models.KindPaymentIn,
+		}).Return(nil)
+		deps.transRepoMock.On("Create", ctx, models.Transaction{
+			CreatedAt: now, IsConsumed: true, Owner: owner, Sender: owner, Receiver: owner, Amount: -30, Kind: models.KindFee, Memo: "payment fee",
+		}).Return(nil)
+		deps.ledgerMock.On("RecordPayout", ctx, receiver, models.Money(2000)).Return(nil)
+		deps.ledgerMock.On("RecordFee", ctx, owner, models.Money(30)).Return(nil)
+
+		assert.NoError(t, service.Payout(ctx, owner, receiver, 2000))
+	})
+
+	t.Run("frozen-owner", func(t *testing.T) {
+		service, deps := setupTransactionService(t)
+
+		deps.transRepoMock.On("LockAccount", ctx, owner).Return(nil)
+		deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{AccountId: owner, Status: models.AccountFrozen}, nil)
+
+		assert.ErrorIs(t, service.Payout(ctx, owner, receiver, 2000), ErrAccountFrozen)
+	})
+
+	t.Run("unknown-receiver", func(t *testing.T) {
+		service, deps := setupTransactionService(t)
+
+		deps.transRepoMock.On("LockAccount", ctx, owner).Return(nil)
+		deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{AccountId: owner}, nil)
+		deps.accRepoMock.On("FindOne", ctx, receiver).Return(models.Account{}, repository.ErrAccountNotFound)
+
+		assert.ErrorIs(t, service.Payout(ctx, owner, receiver, 2000), repository.ErrAccountNotFound)
+	})
+
+	t.Run("release", func(t *testing.T) {
+		service, deps := setupTransactionService(t)
+
+		deps.transRepoMock.On("Create", ctx, models.Transaction{
+			CreatedAt: now, Owner: owner, Sender: owner, Receiver: owner, Amount: 2000, Kind: models.KindAdjustment,
+		}).Return(nil)
+		deps.ledgerMock.On("RecordPayoutRelease", ctx, owner, models.Money(2000)).Return(nil)
+
+		assert.NoError(t, service.ReleasePayout(ctx, owner, 2000))
+	})
+}
+
+func TestTransactionService_PayInterest(t *testing.T) {
+	now := time.Now()
+	setupClock(now)
+	defer resetClock()
+
+	var (
+		ctx   = context.Background()
+		owner = "0001"
+		note  = models.Annotation{Memo: "interest 2026-03"}
+	)
+
+	t.Run("credits-interest", func(t *testing.T) {
+		service, deps := setupTransactionService(t)
+
+		deps.transRepoMock.On("LockAccount", ctx, owner).Return(nil)
+		deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{AccountId: owner, Status: models.AccountFrozen}, nil)
+		deps.transRepoMock.On("Create", ctx, models.Transaction{
+			CreatedAt: now, Owner: owner, Sender: owner, Receiver: owner, Amount: 41, Kind: models.KindInterest, Memo: note.Memo,
+		}).Return(nil)
+		deps.ledgerMock.On("RecordInterest", ctx, owner, models.Money(41)).Return(nil)
+
+		assert.NoError(t, service.PayInterest(ctx, owner, 41, note))
+	})
+
+	t.Run("closed-account", func(t *testing.T) {
+		service, deps := setupTransactionService(t)
+
+		deps.transRepoMock.On("LockAccount", ctx, owner).Return(nil)
+		deps.accRepoMock.On("FindOne", ctx, owner).Return(models.Account{AccountId: owner, Status: models.AccountClosed}, nil)
+
+		assert.ErrorIs(t, service.PayInterest(ctx, owner, 41, note), ErrAccountClosed)
+	})
+}
+
+func TestTransactionService_Refund(t *testing.T) {
+	now := time.Now()
+	setupClock(now)
+	defer resetClock()
+
+	var (
+		ctx                    = context.Background()
+		payer                  = "0002"
+		payee                  = "0001"
+		paymentId              = "3000000"
+		amount    models.Money = 1000
+	)
+
+	payment := models.Transaction{
+		TransactionId: paymentId,
+		CreatedAt:     now,
+		Owner:         payer,
+		Sender:        payee,
+		Receiver:      payer,
+		Amount:        amount,
+		Kind:          models.KindPaymentIn,
+	}
+
+	previousRefund := models.Transaction{
+		TransactionId: "4000000",
+		CreatedAt:     now,
+		IsConsumed:    true,
+		Owner:         payer,
+		Sender:        payer,
+		Receiver:      payee,
+		Amount:        -600,
+		Kind:          models.KindRefund,
+		RelatedId:     paymentId,
+	}
+
+	refundMocks := func(deps transactionServiceDependencies, related []models.Transaction, refund models.Money) {
+		deps.accRepoMock.On("FindOne", ctx, payer).Return(models.Account{AccountId: payer, Status: models.AccountActive}, nil)
+		deps.accRepoMock.On("FindOne", ctx, payee).Return(models.Account{AccountId: payee, Status: models.AccountActive}, nil)
+		deps.transRepoMock.On("LockAccount", ctx, payer).Return(nil)
+		deps.transRepoMock.On("FindRelated", ctx, paymentId).Return(related, nil)
+		deps.transRepoMock.On("GetBalance", ctx, payer).Return(models.Balance{AccountId: payer, Amount: amount}, nil)
+		deps.holdRepoMock.On("GetHeld", ctx, payer, mock.Anything).Return(models.Money(0), nil)
+		deps.transRepoMock.On("FindUnconsumed", ctx, payer).Return([]models.Transaction{payment}, nil)
+		deps.transRepoMock.On("MarkAsConsumed", ctx, paymentId).Return(nil)
+		if refund < amount {
+			deps.transRepoMock.On("Create", ctx, models.Transaction{
+				CreatedAt: now,
+				Owner:     payer,
+				Sender:    payer,
+				Receiver:  payer,
+				Amount:    amount - refund,
+				Kind:      models.KindChange,
+			}).Return(nil)
+		}
+		deps.transRepoMock.On("Create", ctx, models.Transaction{
+			CreatedAt:  now,
+			IsConsumed: true,
+			Owner:      payer,
+			Sender:     payer,
+			Receiver:   payee,
+			Amount:     -refund,
+			Kind:       models.KindRefund,
+			RelatedId:  paymentId,
+		}).Return(nil)
+		deps.transRepoMock.On("Create", ctx, models.Transaction{
+			CreatedAt: now,
+			Owner:     payee,
+			Sender:    payer,
+			Receiver:  payee,
+			Amount:    refund,
+			Kind:      models.KindRefund,
+			RelatedId: paymentId,
+		}).Return(nil)
+		deps.ledgerMock.On("RecordRefund", ctx, payer, payee, refund).Return(nil)
+	}
+
+	type args struct {
+		transactionId string
+		initiator     string
+		amount        models.Money
+	}
+
+	scenarios := map[string]struct {
+		given   args
+		doMocks func(deps transactionServiceDependencies)
+		wantErr error
+	}{
+		"full-refund": {
+			given: args{
+				transactionId: paymentId,
+				initiator:     payer,
+			},
+			doMocks: func(deps transactionServiceDependencies) {
+				deps.transRepoMock.On("FindOne", ctx, paymentId).Return(payment, nil)
+				refundMocks(deps, []models.Transaction{}, amount)
+			},
+			wantErr: nil,
+		},
+		"partial-refund": {
+			given: args{
+				transactionId: paymentId,
+				initiator:     payer,
+				amount:        300,
+			},
+			doMocks: func(deps transactionServiceDependencies) {
+				deps.transRepoMock.On("FindOne", ctx, paymentId).Return(payment, nil)
+				refundMocks(deps, []models.Transaction{previousRefund}, 300)
+			},
+			wantErr: nil,
+		},
+		"exceeds-remaining": {
+			given: args{
+				transactionId: paymentId,
+				initiator:     payer,
+				amount:        500,
+			},
+			doMocks: func(deps transactionServiceDependencies) {
+				deps.transRepoMock.On("FindOne", ctx, paymentId).Return(payment, nil)
+				deps.accRepoMock.On("FindOne", ctx, payer).Return(models.Account{AccountId: payer}, nil)
+				deps.accRepoMock.On("FindOne", ctx, payee).Return(models.Account{AccountId: payee}, nil)
+				deps.transRepoMock.On("LockAccount", ctx, payer).Return(nil)
+				deps.transRepoMock.On("FindRelated", ctx, paymentId).Return([]models.Transaction{previousRefund}, nil)
+			},
+			wantErr: ErrRefundExceeded,
+		},
+		"frozen-receiver-cannot-refund": {
+			given: args{
+				transactionId: paymentId,
+				initiator:     payer,
+			},
+			doMocks: func(deps transactionServiceDependencies) {
+				deps.transRepoMock.On("FindOne", ctx, paymentId).Return(payment, nil)
+				deps.transRepoMock.On("LockAccount", ctx, payer).Return(nil)
+				deps.accRepoMock.On("FindOne", ctx, payer).Return(models.Account{AccountId: payer, Status: models.AccountFrozen}, nil)
+			},
+			wantErr: ErrAccountFrozen,
+		},
+		"not-the-receiver": {
+			given: args{
+				transactionId: paymentId,
+				initiator:     payee,
+			},
+			doMocks: func(deps transactionServiceDependencies) {
+				deps.transRepoMock.On("FindOne", ctx, paymentId).Return(payment, nil)
+			},
+			wantErr: ErrRefundForbidden,
+		},
+		"not-a-payment": {
+			given: args{
+				transactionId: "4000000",
+				initiator:     payer,
+			},
+			doMocks: func(deps transactionServiceDependencies) {
+				deps.transRepoMock.On("FindOne", ctx, "4000000").Return(previousRefund, nil)
+			},
+			wantErr: ErrNotRefundable,
+		},
+		"negative-amount": {
+			given: args{
+				transactionId: paymentId,
+				initiator:     payer,
+				amount:        -100,
+			},
+			doMocks: func(deps transactionServiceDependencies) {
+				deps.transRepoMock.On("FindOne", ctx, paymentId).Return(payment, nil)
+			},
+			wantErr: ErrInvalidAmount,
+		},
+		"transaction-not-found": {
+			given: args{
+				transactionId: "9999999",
+				initiator:     payer,
+			},
+			doMocks: func(deps transactionServiceDependencies) {
+				deps.transRepoMock.On("FindOne", ctx, "9999999").Return(models.Transaction{}, repository.ErrTransactionNotFound)
+			},
+			wantErr: repository.ErrTransactionNotFound,
+		},
+	}
+
+	for name, tcase := range scenarios {
+		tcase := tcase
+		t.Run(name, func(t *testing.T) {
+			service, deps := setupTransactionService(t)
+			if tcase.doMocks != nil {
+				tcase.doMocks(deps)
+			}
+
+			err := service.Refund(ctx, tcase.given.transactionId, tcase.given.initiator, tcase.given.amount)
 
 			if tcase.wantErr == nil {
 				assert.NoError(t, err)
@@ -850,13 +1741,31 @@ func TestTransactionService_Pay(t *testing.T) {
 type transactionServiceDependencies struct {
 	transRepoMock *repository.MockTransactionRepo
 	accRepoMock   *repository.MockAccountRepo
+	holdRepoMock  *repository.MockHoldRepo
+	uowMock       *repository.MockUnitOfWork
+	ledgerMock    *MockLedgerService
+	limitMock     *MockLimitService
+	feeMock       *MockFeeService
 }
 
 func setupTransactionService(t *testing.T) (*transactionServiceImpl, transactionServiceDependencies) {
 	deps := transactionServiceDependencies{
 		transRepoMock: repository.NewMockTransactionRepo(t),
 		accRepoMock:   repository.NewMockAccountRepo(t),
+		holdRepoMock:  repository.NewMockHoldRepo(t),
+		uowMock:       repository.NewMockUnitOfWork(t),
+		ledgerMock:    NewMockLedgerService(t),
+		limitMock:     NewMockLimitService(t),
+		feeMock:       NewMockFeeService(t),
 	}
 
-	return NewTransactionService(deps.transRepoMock, deps.accRepoMock), deps
+	deps.uowMock.EXPECT().Do(mock.Anything, mock.Anything).
+		RunAndReturn(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		}).
+		Maybe()
+	deps.limitMock.EXPECT().Check(mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	deps.feeMock.EXPECT().Quote(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(0, nil).Maybe()
+
+	return NewTransactionService(deps.transRepoMock, deps.accRepoMock, deps.holdRepoMock, deps.uowMock, deps.ledgerMock, deps.limitMock, deps.feeMock), deps
 }
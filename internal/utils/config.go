@@ -1,6 +1,14 @@
 package utils
 
-import "github.com/spf13/viper"
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/gopay/internal/models"
+	"github.com/spf13/viper"
+)
 
 type Config struct {
 	DbDriver         string `mapstructure:"DB_DRIVER"`
@@ -9,6 +17,27 @@ type Config struct {
 	PostgresPassword string `mapstructure:"POSTGRES_PASSWORD"`
 	PostgresDb       string `mapstructure:"DB_NAME"`
 	ServerAddress    string `mapstructure:"SERVER_ADDRESS"`
+
+	// Default transaction limits, as amounts like "500.00". Empty means no limit.
+	LimitMaxDeposit    string `mapstructure:"LIMIT_MAX_DEPOSIT"`
+	LimitMaxWithdrawal string `mapstructure:"LIMIT_MAX_WITHDRAWAL"`
+	LimitMaxPayment    string `mapstructure:"LIMIT_MAX_PAYMENT"`
+	LimitDaily         string `mapstructure:"LIMIT_DAILY"`
+	LimitWeekly        string `mapstructure:"LIMIT_WEEKLY"`
+	LimitMonthly       string `mapstructure:"LIMIT_MONTHLY"`
+
+	// FeeScheduleFile is a JSON models.FeeSchedule. Without one, nothing is charged.
+	FeeScheduleFile string `mapstructure:"FEE_SCHEDULE_FILE"`
+
+	// Interest paid on balances: a yearly rate in basis points, 0 for none, and a day
+	// count, ACT/365 when empty.
+	InterestAprBps   int64  `mapstructure:"INTEREST_APR_BPS"`
+	InterestDayCount string `mapstructure:"INTEREST_DAY_COUNT"`
+
+	// AuthAdminToken is the bearer token operators call the /admin routes with; they are
+	// closed when it is empty. AuthSessionTTL is how long a login lasts, a day when zero.
+	AuthAdminToken string        `mapstructure:"AUTH_ADMIN_TOKEN"`
+	AuthSessionTTL time.Duration `mapstructure:"AUTH_SESSION_TTL"`
 }
 
 func LoadConfig(path string) (config Config, err error) {
@@ -24,3 +53,74 @@ func LoadConfig(path string) (config Config, err error) {
 	err = viper.Unmarshal(&config)
 	return
 }
+
+// Limits parses the default transaction limits.
+func (c Config) Limits() (models.Limits, error) {
+	var limits models.Limits
+
+	for _, l := range []struct {
+		key   string
+		value string
+		limit *models.Money
+	}{
+		{"LIMIT_MAX_DEPOSIT", c.LimitMaxDeposit, &limits.MaxDeposit},
+		{"LIMIT_MAX_WITHDRAWAL", c.LimitMaxWithdrawal, &limits.MaxWithdrawal},
+		{"LIMIT_MAX_PAYMENT", c.LimitMaxPayment, &limits.MaxPayment},
+		{"LIMIT_DAILY", c.LimitDaily, &limits.Daily},
+		{"LIMIT_WEEKLY", c.LimitWeekly, &limits.Weekly},
+		{"LIMIT_MONTHLY", c.LimitMonthly, &limits.Monthly},
+	} {
+		if l.value == "" {
+			continue
+		}
+
+		limit, err := models.ParseMoney(l.value)
+		if err != nil || limit < 0 {
+			return models.Limits{}, fmt.Errorf("invalid %s %q", l.key, l.value)
+		}
+		*l.limit = limit
+	}
+
+	return limits, nil
+}
+
+// FeeSchedule reads and validates the fee schedule file.
+func (c Config) FeeSchedule() (models.FeeSchedule, error) {
+	schedule := models.FeeSchedule{}
+	if c.FeeScheduleFile == "" {
+		return schedule, nil
+	}
+
+	data, err := os.ReadFile(c.FeeScheduleFile)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(data, &schedule)
+	if err != nil {
+		return nil, fmt.Errorf("invalid FEE_SCHEDULE_FILE %q: %w", c.FeeScheduleFile, err)
+	}
+
+	return schedule, schedule.Validate()
+}
+
+// InterestRate parses the interest paid on balances.
+func (c Config) InterestRate() (models.InterestRate, error) {
+	rate := models.InterestRate{
+		AprBps:   c.InterestAprBps,
+		DayCount: models.DayCount(c.InterestDayCount),
+	}
+	if rate.DayCount == "" {
+		rate.DayCount = models.DayCountActual365
+	}
+
+	return rate, rate.Validate()
+}
+
+// SessionTTL is how long a login lasts.
+func (c Config) SessionTTL() time.Duration {
+	if c.AuthSessionTTL <= 0 {
+		return 24 * time.Hour
+	}
+	return c.AuthSessionTTL
+}
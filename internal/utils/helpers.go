@@ -3,7 +3,6 @@ package utils
 import (
 	"encoding/json"
 	"errors"
-	"math"
 	"net/http"
 	"time"
 
@@ -18,9 +17,15 @@ var (
 )
 
 func ErrorWithMessage(w http.ResponseWriter, status int, message string) {
+	ErrorWithDetails(w, status, message, nil)
+}
+
+// ErrorWithDetails is ErrorWithMessage with details telling the client more about the error.
+func ErrorWithDetails(w http.ResponseWriter, status int, message string, details any) {
 	resp := ErrorResponse{
 		Status:  status,
 		Message: message,
+		Details: details,
 	}
 
 	payload, err := json.Marshal(resp)
@@ -84,19 +89,19 @@ func Pay(transaction *models.Transaction) bool {
 }
 
 func Debit(transaction *models.Transaction) bool {
-	var balance float64
+	var balance models.Money
 	var oldest *models.Transaction
 
 	for _, t := range models.Transactions {
 		if t.Owner == transaction.Owner && !t.IsConsumed {
-			balance += float64(t.Amount)
+			balance += t.Amount
 			if oldest == nil || t.CreatedAt.Before(oldest.CreatedAt) {
 				oldest = t
 			}
 		}
 	}
 
-	if (balance + float64(transaction.Amount)) < 0 {
+	if (balance + transaction.Amount) < 0 {
 		return false
 	}
 
@@ -128,7 +133,7 @@ func Credit(transaction *models.Transaction) {
 		Sender:        transaction.Owner,
 		Receiver:      transaction.Receiver,
 		CreatedAt:     time.Now(),
-		Amount:        float32(math.Abs(float64(transaction.Amount))),
+		Amount:        transaction.Amount.Abs(),
 		IsConsumed:    false,
 	}
 	models.Transactions[t.TransactionId] = t
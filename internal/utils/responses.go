@@ -3,4 +3,5 @@ package utils
 type ErrorResponse struct {
 	Status  int    `json:"status"`
 	Message string `json:"message"`
+	Details any    `json:"details,omitempty"`
 }